@@ -9,6 +9,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/relaying"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
@@ -226,6 +227,17 @@ func (blockchain *BlockChain) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return blockchain.GetConfig().BTCChain
 }
 
-func (blockchain *BlockChain) GetPortalFeederAddress() string {
-	return blockchain.GetConfig().ChainParams.PortalFeederAddress
+// GetRelayingChain returns external chain of public token tokenID, it returns nil if portal does not support the token
+func (blockchain *BlockChain) GetRelayingChain(tokenID string) relaying.RelayingChain {
+	return blockchain.relayingChains[tokenID]
+}
+
+// GetPortalFeederAddresses returns feeders of exchange rates oracle at beaconHeight,
+// PortalFeederAddress is the only feeder when the oracle is not enabled
+func (blockchain *BlockChain) GetPortalFeederAddresses(beaconHeight uint64) []string {
+	feederAddresses := blockchain.GetPortalParams(beaconHeight).FeederAddresses
+	if len(feederAddresses) > 0 {
+		return feederAddresses
+	}
+	return []string{blockchain.GetConfig().ChainParams.PortalFeederAddress}
 }
@@ -409,7 +409,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(shardBlock *Shar
 			return err
 		}
 
-		err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *privacyCustomTokenSubView, shardBlock.Header.ShardID)
+		err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *privacyCustomTokenSubView, shardBlock.Header.ShardID, shardBlock.Header.BeaconHeight)
 		if err != nil {
 			return err
 		}
@@ -428,7 +428,7 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(shardBlock *Shar
 		return err
 	}
 
-	err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *view, shardBlock.Header.ShardID)
+	err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *view, shardBlock.Header.ShardID, shardBlock.Header.BeaconHeight)
 	if err != nil {
 		return err
 	}
@@ -497,7 +497,8 @@ func (blockchain *BlockChain) StoreTxByPublicKey(db incdb.Database, view *TxView
 	return nil
 }
 
-func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(stateDB *statedb.StateDB, view TxViewPoint, shardID byte) error {
+func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(stateDB *statedb.StateDB, view TxViewPoint, shardID byte, beaconHeight uint64) error {
+	isPrivacyV2Enabled := beaconHeight >= blockchain.GetBeaconHeightBreakPointPrivacyV2()
 	// commitment and output are the same key in map
 	keys := make([]string, 0, len(view.mapCommitments))
 	for k := range view.mapCommitments {
@@ -514,13 +515,48 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(stateDB *statedb.S
 		publicKeyShardID := common.GetShardIDFromLastByte(lastByte)
 		if publicKeyShardID == shardID {
 			// commitment
-			commitmentsArray := view.mapCommitments[k]
-			err = statedb.StoreCommitments(stateDB, *view.tokenID, publicKeyBytes, commitmentsArray, view.shardID)
-			if err != nil {
-				return err
+			// coins of privacy v2 are only stored in the list of v2 coins, so they are never used in rings of v1 proofs
+			outputCoinArray := view.mapOutputCoins[k]
+			commitmentsV2 := make(map[string]bool)
+			coinV2BytesArray := make([][]byte, 0)
+			// confidential coins of all tokens are stored in one list, so they are picked as ring members of each other
+			confidentialCoinBytesArray := make([][]byte, 0)
+			for i := range outputCoinArray {
+				commitment := outputCoinArray[i].CoinDetails.GetCoinCommitment().ToBytesS()
+				if isPrivacyV2Enabled && view.mapCommitmentsV2[string(commitment)] {
+					commitmentsV2[string(commitment)] = true
+					if privacy.GetAssetTag(outputCoinArray[i].CoinDetails) != nil {
+						confidentialCoinBytesArray = append(confidentialCoinBytesArray, privacy.CoinV2Bytes(outputCoinArray[i].CoinDetails))
+					} else {
+						coinV2BytesArray = append(coinV2BytesArray, privacy.CoinV2Bytes(outputCoinArray[i].CoinDetails))
+					}
+				}
+			}
+			commitmentsArray := make([][]byte, 0, len(view.mapCommitments[k]))
+			for _, commitment := range view.mapCommitments[k] {
+				if !commitmentsV2[string(commitment)] {
+					commitmentsArray = append(commitmentsArray, commitment)
+				}
+			}
+			if len(commitmentsArray) > 0 {
+				err = statedb.StoreCommitments(stateDB, *view.tokenID, publicKeyBytes, commitmentsArray, view.shardID)
+				if err != nil {
+					return err
+				}
+			}
+			if len(coinV2BytesArray) > 0 {
+				err = statedb.StoreCommitments(stateDB, privacy.GetCoinV2TokenID(*view.tokenID), nil, coinV2BytesArray, view.shardID)
+				if err != nil {
+					return err
+				}
+			}
+			if len(confidentialCoinBytesArray) > 0 {
+				err = statedb.StoreCommitments(stateDB, privacy.GetCoinV2TokenID(common.ConfidentialAssetID), nil, confidentialCoinBytesArray, view.shardID)
+				if err != nil {
+					return err
+				}
 			}
 			// outputs
-			outputCoinArray := view.mapOutputCoins[k]
 			outputCoinBytesArray := make([][]byte, 0)
 			for _, outputCoin := range outputCoinArray {
 				outputCoinBytesArray = append(outputCoinBytesArray, outputCoin.Bytes())
@@ -578,7 +614,7 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionViewPointFromBlock(sh
 			}
 		}
 		// Store both commitment and outcoin
-		err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *privacyCustomTokenSubView, shardBlock.Header.ShardID)
+		err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *privacyCustomTokenSubView, shardBlock.Header.ShardID, shardBlock.Header.BeaconHeight)
 		if err != nil {
 			return err
 		}
@@ -589,7 +625,7 @@ func (blockchain *BlockChain) CreateAndSaveCrossTransactionViewPointFromBlock(sh
 		}
 	}
 	// store commitment
-	err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *view, shardBlock.Header.ShardID)
+	err = blockchain.StoreCommitmentsFromTxViewPoint(transactionStateRoot, *view, shardBlock.Header.ShardID, shardBlock.Header.BeaconHeight)
 	if err != nil {
 		return err
 	}
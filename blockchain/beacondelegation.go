@@ -0,0 +1,445 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// buildDelegationInstructions builds instructions for delegation actions of shard blocks
+// and returns delegations unlocked by the swap out of their validators in the previous beacon block
+func (blockchain *BlockChain) buildDelegationInstructions(
+	beaconBestState *BeaconBestState,
+	statefulActionsByShardID map[byte][][]string,
+) [][]string {
+	instructions := [][]string{}
+	if !blockchain.isDelegationEnabled(beaconBestState.BeaconHeight + 1) {
+		return instructions
+	}
+	stateDB := beaconBestState.consensusStateDB
+	// delegations are unlocked only when their validator is swapped out, so validators in shard committees are skipped
+	shardCommitteeKeys := getShardCommitteeKeySet(beaconBestState)
+	for _, committeePublicKey := range statedb.GetAllValidatorsWithDelegations(stateDB) {
+		if shardCommitteeKeys[committeePublicKey] {
+			continue
+		}
+		delegations, err := statedb.GetDelegationsByCommitteePublicKey(stateDB, committeePublicKey)
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		for _, delegation := range delegations {
+			if !delegation.Unlocked() {
+				continue
+			}
+			inst, err := buildDelegationReturnInst(delegation)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			instructions = append(instructions, inst)
+		}
+	}
+
+	epoch := beaconBestState.Epoch
+	if (beaconBestState.BeaconHeight+1)%blockchain.config.ChainParams.Epoch == 1 {
+		epoch = beaconBestState.Epoch + 1
+	}
+
+	unDelegatedTxIDs := map[common.Hash]bool{}
+	var keys []int
+	for k := range statefulActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		for _, action := range statefulActionsByShardID[shardID] {
+			metaType, err := strconv.Atoi(action[0])
+			if err != nil {
+				continue
+			}
+			var newInst []string
+			switch metaType {
+			case metadata.DelegationMeta:
+				newInst, err = buildInstructionForDelegation(beaconBestState, action[1], shardID)
+			case metadata.UnDelegationMeta:
+				newInst, err = buildInstructionForUnDelegation(beaconBestState, action[1], shardID, unDelegatedTxIDs)
+			case metadata.SetCommissionRateMeta:
+				newInst, err = buildInstructionForSetCommissionRate(beaconBestState, action[1], shardID, epoch)
+			default:
+				continue
+			}
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst)
+			}
+		}
+	}
+	return instructions
+}
+
+// isDelegationEnabled returns true if delegation instructions are built and processed in the beacon block at beaconHeight
+func (blockchain *BlockChain) isDelegationEnabled(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointDelegation
+}
+
+// isShardValidatorAcceptingDelegation checks the committee public key is staked to a shard with auto re-staking on
+func isShardValidatorAcceptingDelegation(beaconBestState *BeaconBestState, committeePublicKey string) bool {
+	isAutoStaking, ok := beaconBestState.AutoStaking.Get(committeePublicKey)
+	if !ok || !isAutoStaking {
+		return false
+	}
+	beaconKeys := []incognitokey.CommitteePublicKey{}
+	beaconKeys = append(beaconKeys, beaconBestState.BeaconCommittee...)
+	beaconKeys = append(beaconKeys, beaconBestState.BeaconPendingValidator...)
+	beaconKeys = append(beaconKeys, beaconBestState.CandidateBeaconWaitingForCurrentRandom...)
+	beaconKeys = append(beaconKeys, beaconBestState.CandidateBeaconWaitingForNextRandom...)
+	beaconKeyStrs, err := incognitokey.CommitteeKeyListToString(beaconKeys)
+	if err != nil {
+		return false
+	}
+	return common.IndexOfStr(committeePublicKey, beaconKeyStrs) == -1
+}
+
+func getShardCommitteeKeySet(beaconBestState *BeaconBestState) map[string]bool {
+	keySet := map[string]bool{}
+	for _, committee := range beaconBestState.ShardCommittee {
+		committeeStrs, err := incognitokey.CommitteeKeyListToString(committee)
+		if err != nil {
+			continue
+		}
+		for _, committeeStr := range committeeStrs {
+			keySet[committeeStr] = true
+		}
+	}
+	return keySet
+}
+
+func isInShardCommittee(beaconBestState *BeaconBestState, committeePublicKey string) bool {
+	for _, committee := range beaconBestState.ShardCommittee {
+		committeeStrs, err := incognitokey.CommitteeKeyListToString(committee)
+		if err != nil {
+			continue
+		}
+		if common.IndexOfStr(committeePublicKey, committeeStrs) > -1 {
+			return true
+		}
+	}
+	return false
+}
+
+func buildDelegationReturnInst(delegation *statedb.DelegationState) ([]string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(delegation.DelegatorAddress())
+	if err != nil {
+		return []string{}, err
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	shardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+	returnContent := metadata.DelegationReturnContent{
+		TxDelegationID:     delegation.TxDelegationID(),
+		CommitteePublicKey: delegation.CommitteePublicKey(),
+		DelegatorAddress:   delegation.DelegatorAddress(),
+		Amount:             delegation.Amount(),
+		ShardID:            shardID,
+	}
+	returnContentBytes, err := json.Marshal(returnContent)
+	if err != nil {
+		return []string{}, err
+	}
+	return []string{
+		strconv.Itoa(metadata.UnDelegationMeta),
+		strconv.Itoa(int(shardID)),
+		common.DelegationReturnedChainStatus,
+		base64.StdEncoding.EncodeToString(returnContentBytes),
+	}, nil
+}
+
+func buildInstructionForDelegation(
+	beaconBestState *BeaconBestState,
+	contentStr string,
+	shardID byte,
+) ([]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return []string{}, err
+	}
+	var delegationAction metadata.DelegationAction
+	err = json.Unmarshal(contentBytes, &delegationAction)
+	if err != nil {
+		return []string{}, err
+	}
+	status := common.DelegationAcceptedChainStatus
+	if !isShardValidatorAcceptingDelegation(beaconBestState, delegationAction.Meta.CommitteePublicKey) {
+		status = common.DelegationRejectedChainStatus
+	}
+	return []string{
+		strconv.Itoa(metadata.DelegationMeta),
+		strconv.Itoa(int(shardID)),
+		status,
+		contentStr,
+	}, nil
+}
+
+// buildInstructionForUnDelegation marks a delegation as unbonding, the delegation is returned right away
+// when its validator is not in a shard committee
+func buildInstructionForUnDelegation(
+	beaconBestState *BeaconBestState,
+	contentStr string,
+	shardID byte,
+	unDelegatedTxIDs map[common.Hash]bool,
+) ([]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return []string{}, err
+	}
+	var unDelegationAction metadata.UnDelegationAction
+	err = json.Unmarshal(contentBytes, &unDelegationAction)
+	if err != nil {
+		return []string{}, err
+	}
+	rejectedInst := []string{
+		strconv.Itoa(metadata.UnDelegationMeta),
+		strconv.Itoa(int(shardID)),
+		common.DelegationRejectedChainStatus,
+		contentStr,
+	}
+	delegationTxID := unDelegationAction.Meta.DelegationTxID
+	if unDelegatedTxIDs[delegationTxID] {
+		return rejectedInst, nil
+	}
+	delegation, has, err := statedb.GetDelegation(beaconBestState.consensusStateDB, delegationTxID)
+	if err != nil {
+		return []string{}, err
+	}
+	if !has || delegation.Unbonding() || delegation.Unlocked() ||
+		delegation.DelegatorAddress() != unDelegationAction.Meta.DelegatorPaymentAddress {
+		return rejectedInst, nil
+	}
+	unDelegatedTxIDs[delegationTxID] = true
+	if !isInShardCommittee(beaconBestState, delegation.CommitteePublicKey()) {
+		return buildDelegationReturnInst(delegation)
+	}
+	return []string{
+		strconv.Itoa(metadata.UnDelegationMeta),
+		strconv.Itoa(int(shardID)),
+		common.DelegationAcceptedChainStatus,
+		contentStr,
+	}, nil
+}
+
+// buildInstructionForSetCommissionRate accepts a new commission rate of a staked validator
+// which differs by at most MaxCommissionRateChangePerEpoch from the rate applied in the epoch of the new beacon block
+func buildInstructionForSetCommissionRate(
+	beaconBestState *BeaconBestState,
+	contentStr string,
+	shardID byte,
+	epoch uint64,
+) ([]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return []string{}, err
+	}
+	var setCommissionRateAction metadata.SetCommissionRateAction
+	err = json.Unmarshal(contentBytes, &setCommissionRateAction)
+	if err != nil {
+		return []string{}, err
+	}
+	committeePublicKey := setCommissionRateAction.Meta.CommitteePublicKey
+	commissionRate := setCommissionRateAction.Meta.CommissionRate
+	currentCommissionRate, _, err := statedb.GetValidatorCommission(beaconBestState.consensusStateDB, committeePublicKey, epoch)
+	if err != nil {
+		return []string{}, err
+	}
+	commissionRateChange := commissionRate - currentCommissionRate
+	if commissionRate < currentCommissionRate {
+		commissionRateChange = currentCommissionRate - commissionRate
+	}
+	status := common.SetCommissionRateAcceptedChainStatus
+	if _, ok := beaconBestState.AutoStaking.Get(committeePublicKey); !ok ||
+		commissionRate > metadata.MaxCommissionRate ||
+		commissionRateChange > metadata.MaxCommissionRateChangePerEpoch {
+		status = common.SetCommissionRateRejectedChainStatus
+	}
+	return []string{
+		strconv.Itoa(metadata.SetCommissionRateMeta),
+		strconv.Itoa(int(shardID)),
+		status,
+		contentStr,
+	}, nil
+}
+
+// processDelegationInstructions stores delegation instructions of a beacon block into the consensus state,
+// then unlocks delegations of swapped out validators which are unbonding or whose validator stopped auto staking.
+// New commission rates apply from the epoch following the epoch of the beacon block
+func (blockchain *BlockChain) processDelegationInstructions(
+	newBestState *BeaconBestState,
+	beaconBlock *BeaconBlock,
+	committeeChange *committeeChange,
+) error {
+	if !blockchain.isDelegationEnabled(beaconBlock.Header.Height) {
+		return nil
+	}
+	stateDB := newBestState.consensusStateDB
+	// delegations read or changed by the beacon block
+	delegations := map[common.Hash]*statedb.DelegationState{}
+	getDelegation := func(txDelegationID common.Hash) (*statedb.DelegationState, bool, error) {
+		if delegation, ok := delegations[txDelegationID]; ok {
+			return delegation, true, nil
+		}
+		delegation, has, err := statedb.GetDelegation(stateDB, txDelegationID)
+		if err != nil || !has {
+			return nil, has, err
+		}
+		delegations[txDelegationID] = delegation
+		return delegation, true, nil
+	}
+	updatedDelegations := map[common.Hash]bool{}
+	deletedDelegations := map[common.Hash]bool{}
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) < 4 {
+			continue
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.DelegationMeta):
+			if inst[2] != common.DelegationAcceptedChainStatus {
+				continue
+			}
+			var delegationAction metadata.DelegationAction
+			if err := decodeDelegationInstContent(inst[3], &delegationAction); err != nil {
+				return err
+			}
+			delegations[delegationAction.TxReqID] = statedb.NewDelegationStateWithValue(
+				delegationAction.Meta.CommitteePublicKey,
+				delegationAction.Meta.DelegatorPaymentAddress,
+				delegationAction.TxReqID,
+				delegationAction.Meta.DelegationAmount,
+				false,
+				false,
+			)
+			updatedDelegations[delegationAction.TxReqID] = true
+		case strconv.Itoa(metadata.UnDelegationMeta):
+			switch inst[2] {
+			case common.DelegationAcceptedChainStatus:
+				var unDelegationAction metadata.UnDelegationAction
+				if err := decodeDelegationInstContent(inst[3], &unDelegationAction); err != nil {
+					return err
+				}
+				delegation, has, err := getDelegation(unDelegationAction.Meta.DelegationTxID)
+				if err != nil {
+					return NewBlockChainError(ProcessDelegationInstructionError, err)
+				}
+				if has && !deletedDelegations[unDelegationAction.Meta.DelegationTxID] {
+					delegation.SetUnbonding(true)
+					updatedDelegations[unDelegationAction.Meta.DelegationTxID] = true
+				}
+			case common.DelegationReturnedChainStatus:
+				var returnContent metadata.DelegationReturnContent
+				if err := decodeDelegationInstContent(inst[3], &returnContent); err != nil {
+					return err
+				}
+				delete(delegations, returnContent.TxDelegationID)
+				delete(updatedDelegations, returnContent.TxDelegationID)
+				deletedDelegations[returnContent.TxDelegationID] = true
+			}
+		case strconv.Itoa(metadata.SetCommissionRateMeta):
+			if inst[2] != common.SetCommissionRateAcceptedChainStatus {
+				continue
+			}
+			var setCommissionRateAction metadata.SetCommissionRateAction
+			if err := decodeDelegationInstContent(inst[3], &setCommissionRateAction); err != nil {
+				return err
+			}
+			err := statedb.StoreValidatorCommission(stateDB, setCommissionRateAction.Meta.CommitteePublicKey, setCommissionRateAction.Meta.CommissionRate, beaconBlock.Header.Epoch+1)
+			if err != nil {
+				return NewBlockChainError(ProcessDelegationInstructionError, err)
+			}
+		}
+	}
+
+	swappedOutKeys := map[string]bool{}
+	for _, committees := range committeeChange.shardCommitteeRemoved {
+		committeeStrs, err := incognitokey.CommitteeKeyListToString(committees)
+		if err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+		for _, committeeStr := range committeeStrs {
+			swappedOutKeys[committeeStr] = true
+		}
+	}
+	// delegations accepted by the beacon block are not indexed yet, their validators are in shard committees anyway
+	for committeePublicKey := range swappedOutKeys {
+		isAutoStaking, ok := newBestState.AutoStaking.Get(committeePublicKey)
+		validatorDelegations, err := statedb.GetDelegationsByCommitteePublicKey(stateDB, committeePublicKey)
+		if err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+		for _, validatorDelegation := range validatorDelegations {
+			txDelegationID := validatorDelegation.TxDelegationID()
+			if deletedDelegations[txDelegationID] {
+				continue
+			}
+			delegation, _, err := getDelegation(txDelegationID)
+			if err != nil {
+				return NewBlockChainError(ProcessDelegationInstructionError, err)
+			}
+			if delegation.Unlocked() {
+				continue
+			}
+			if delegation.Unbonding() || !ok || !isAutoStaking {
+				delegation.SetUnlocked(true)
+				updatedDelegations[txDelegationID] = true
+			}
+		}
+	}
+
+	updatedTxDelegationIDs := []common.Hash{}
+	for txDelegationID := range updatedDelegations {
+		updatedTxDelegationIDs = append(updatedTxDelegationIDs, txDelegationID)
+	}
+	sort.Slice(updatedTxDelegationIDs, func(i, j int) bool {
+		return updatedTxDelegationIDs[i].String() < updatedTxDelegationIDs[j].String()
+	})
+	for _, txDelegationID := range updatedTxDelegationIDs {
+		err := statedb.StoreDelegation(stateDB, delegations[txDelegationID])
+		if err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+	}
+	deletedTxDelegationIDs := []common.Hash{}
+	for txDelegationID := range deletedDelegations {
+		deletedTxDelegationIDs = append(deletedTxDelegationIDs, txDelegationID)
+	}
+	sort.Slice(deletedTxDelegationIDs, func(i, j int) bool {
+		return deletedTxDelegationIDs[i].String() < deletedTxDelegationIDs[j].String()
+	})
+	for _, txDelegationID := range deletedTxDelegationIDs {
+		err := statedb.DeleteDelegation(stateDB, txDelegationID)
+		if err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+	}
+	return nil
+}
+
+func decodeDelegationInstContent(contentStr string, content interface{}) error {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return NewBlockChainError(ProcessDelegationInstructionError, err)
+	}
+	err = json.Unmarshal(contentBytes, content)
+	if err != nil {
+		return NewBlockChainError(ProcessDelegationInstructionError, err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommitteeRewardWithDelegations(t *testing.T) {
+	cpk := "committee-public-key"
+	tests := []struct {
+		name                    string
+		reward                  uint64
+		stakingAmount           uint64
+		delegationInfo          *statedb.CommitteeDelegationInfo
+		wantValidatorReward     uint64
+		wantDelegatorRewards    map[string]uint64
+		wantNoDelegatorRewarded bool
+	}{
+		{
+			name:                    "no delegation",
+			reward:                  1000,
+			stakingAmount:           1750,
+			delegationInfo:          nil,
+			wantValidatorReward:     1000,
+			wantNoDelegatorRewarded: true,
+		},
+		{
+			name:          "half delegated without commission",
+			reward:        1000,
+			stakingAmount: 1000,
+			delegationInfo: &statedb.CommitteeDelegationInfo{
+				CommitteePublicKey: cpk,
+				CommissionRate:     0,
+				Delegations: []*statedb.DelegationState{
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{1}), 600, false, false),
+					statedb.NewDelegationStateWithValue(cpk, "delegator-2", common.HashH([]byte{2}), 400, false, false),
+				},
+			},
+			wantValidatorReward:  500,
+			wantDelegatorRewards: map[string]uint64{"delegator-1": 300, "delegator-2": 200},
+		},
+		{
+			name:          "half delegated with 10% commission",
+			reward:        1000,
+			stakingAmount: 1000,
+			delegationInfo: &statedb.CommitteeDelegationInfo{
+				CommitteePublicKey: cpk,
+				CommissionRate:     1000,
+				Delegations: []*statedb.DelegationState{
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{1}), 600, false, false),
+					statedb.NewDelegationStateWithValue(cpk, "delegator-2", common.HashH([]byte{2}), 400, false, false),
+				},
+			},
+			wantValidatorReward:  550,
+			wantDelegatorRewards: map[string]uint64{"delegator-1": 270, "delegator-2": 180},
+		},
+		{
+			name:          "half delegated after the stake of validator is slashed",
+			reward:        1000,
+			stakingAmount: 1100,
+			delegationInfo: &statedb.CommitteeDelegationInfo{
+				CommitteePublicKey: cpk,
+				CommissionRate:     0,
+				Delegations: []*statedb.DelegationState{
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{1}), 600, false, false),
+					statedb.NewDelegationStateWithValue(cpk, "delegator-2", common.HashH([]byte{2}), 400, false, false),
+				},
+				SlashedAmount: 100,
+			},
+			wantValidatorReward:  500,
+			wantDelegatorRewards: map[string]uint64{"delegator-1": 300, "delegator-2": 200},
+		},
+		{
+			name:          "dust goes to validator",
+			reward:        10,
+			stakingAmount: 1,
+			delegationInfo: &statedb.CommitteeDelegationInfo{
+				CommitteePublicKey: cpk,
+				CommissionRate:     0,
+				Delegations: []*statedb.DelegationState{
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{1}), 1, false, false),
+					statedb.NewDelegationStateWithValue(cpk, "delegator-2", common.HashH([]byte{2}), 1, false, false),
+				},
+			},
+			wantValidatorReward:  4,
+			wantDelegatorRewards: map[string]uint64{"delegator-1": 3, "delegator-2": 3},
+		},
+		{
+			name:          "same delegator with many delegations",
+			reward:        900,
+			stakingAmount: 1000,
+			delegationInfo: &statedb.CommitteeDelegationInfo{
+				CommitteePublicKey: cpk,
+				CommissionRate:     10000,
+				Delegations: []*statedb.DelegationState{
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{1}), 1000, false, false),
+					statedb.NewDelegationStateWithValue(cpk, "delegator-1", common.HashH([]byte{2}), 1000, false, false),
+				},
+			},
+			wantValidatorReward:     900,
+			wantNoDelegatorRewarded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validatorReward, delegatorRewards := splitCommitteeRewardWithDelegations(tt.reward, tt.stakingAmount, tt.delegationInfo)
+			assert.Equal(t, tt.wantValidatorReward, validatorReward)
+			if tt.wantNoDelegatorRewarded {
+				assert.Empty(t, delegatorRewards)
+				return
+			}
+			assert.Equal(t, tt.wantDelegatorRewards, delegatorRewards)
+			total := validatorReward
+			for _, amount := range delegatorRewards {
+				total += amount
+			}
+			assert.Equal(t, tt.reward, total)
+		})
+	}
+}
+
+func TestBuildInstructionForSetCommissionRate(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_setcommissionrate_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, _ := incdb.Open("leveldb", dbPath)
+	stateDB, _ := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	cpk := "committee-public-key"
+	beaconBestState := &BeaconBestState{AutoStaking: NewMapStringBool(), consensusStateDB: stateDB}
+	beaconBestState.AutoStaking.Set(cpk, true)
+	buildInstStatus := func(commissionRate uint64, epoch uint64) string {
+		contentBytes, _ := json.Marshal(metadata.SetCommissionRateAction{
+			Meta: metadata.SetCommissionRateMetadata{CommitteePublicKey: cpk, CommissionRate: commissionRate},
+		})
+		inst, err := buildInstructionForSetCommissionRate(beaconBestState, base64.StdEncoding.EncodeToString(contentBytes), 0, epoch)
+		assert.Nil(t, err)
+		return inst[2]
+	}
+
+	// the change from the rate of the epoch is capped
+	assert.Equal(t, common.SetCommissionRateRejectedChainStatus, buildInstStatus(metadata.MaxCommissionRateChangePerEpoch+1, 1))
+	assert.Equal(t, common.SetCommissionRateAcceptedChainStatus, buildInstStatus(metadata.MaxCommissionRateChangePerEpoch, 1))
+	assert.Nil(t, statedb.StoreValidatorCommission(stateDB, cpk, metadata.MaxCommissionRateChangePerEpoch, 2))
+
+	// the new rate applies from the next epoch only
+	assert.Equal(t, common.SetCommissionRateRejectedChainStatus, buildInstStatus(2*metadata.MaxCommissionRateChangePerEpoch, 1))
+	assert.Equal(t, common.SetCommissionRateAcceptedChainStatus, buildInstStatus(2*metadata.MaxCommissionRateChangePerEpoch, 2))
+	assert.Equal(t, common.SetCommissionRateAcceptedChainStatus, buildInstStatus(0, 2))
+
+	assert.Equal(t, common.SetCommissionRateRejectedChainStatus, buildInstStatus(metadata.MaxCommissionRate+1, 100))
+	beaconBestState.AutoStaking.Remove(cpk)
+	assert.Equal(t, common.SetCommissionRateRejectedChainStatus, buildInstStatus(0, 2))
+}
@@ -0,0 +1,345 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/pkg/errors"
+)
+
+// shardCommitteeGetter returns the committee of a shard stored in the beacon consensus state at a beacon height
+type shardCommitteeGetter func(shardID byte, beaconHeight uint64) ([]string, error)
+
+// getShardCommitteeByBeaconHeight returns the committee of a shard stored in the beacon consensus state at beaconHeight
+func (blockchain *BlockChain) getShardCommitteeByBeaconHeight(beaconBestState *BeaconBestState, shardID byte, beaconHeight uint64) ([]string, error) {
+	if beaconHeight > beaconBestState.BeaconHeight {
+		return nil, errors.Errorf("beacon height %+v is greater than height of beacon view %+v", beaconHeight, beaconBestState.BeaconHeight)
+	}
+	consensusStateDBRootHash, err := blockchain.GetBeaconConsensusRootHash(beaconBestState, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	consensusStateDB, err := statedb.NewWithPrefixTrie(consensusStateDBRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, err
+	}
+	return incognitokey.CommitteeKeyListToString(statedb.GetOneShardCommittee(consensusStateDB, shardID))
+}
+
+// verifyDoubleSignEvidence checks the two messages of the evidence are signed by the offender
+// for two different shard blocks of the same shard and height, which no honest node signs:
+// two proposals in the same propose timeslot, or two votes for blocks with the same produce and propose timeslots
+// (voting again at the same height is allowed for a block produced earlier, or re-proposed later, see blsbftv2).
+// The offender and the committee root of each block are checked against the shard committee stored by beacon
+// at the beacon height of the block, never against a committee given by the evidence
+func verifyDoubleSignEvidence(evidence *metadata.DoubleSignEvidenceMetadata, getShardCommittee shardCommitteeGetter) error {
+	committeePublicKey := incognitokey.CommitteePublicKey{}
+	if err := committeePublicKey.FromString(evidence.CommitteePublicKey); err != nil {
+		return err
+	}
+	briPublicKey, ok := committeePublicKey.MiningPubKey[common.BridgeConsensus]
+	if !ok {
+		return errors.Errorf("bridge public key of %+v not found", evidence.CommitteePublicKey)
+	}
+	headers := [2]ShardHeader{}
+	hashes := [2]common.Hash{}
+	for i, message := range []metadata.DoubleSignMessage{evidence.FirstMessage, evidence.SecondMessage} {
+		if err := json.Unmarshal(message.Header, &headers[i]); err != nil {
+			return err
+		}
+		header := headers[i]
+		if header.Version < 2 || header.ShardID != evidence.ShardID {
+			return errors.Errorf("header %+v is not a version 2 header of shard %+v", i, evidence.ShardID)
+		}
+		committee, err := getShardCommittee(evidence.ShardID, header.BeaconHeight)
+		if err != nil {
+			return err
+		}
+		if common.IndexOfStr(evidence.CommitteePublicKey, committee) == -1 {
+			return errors.Errorf("offender %+v not in the committee of shard %+v at beacon height %+v", evidence.CommitteePublicKey, evidence.ShardID, header.BeaconHeight)
+		}
+		if _, ok := verifyHashFromStringArray(committee, header.CommitteeRoot); !ok {
+			return errors.Errorf("committee of shard %+v at beacon height %+v not match committee root of header %+v", evidence.ShardID, header.BeaconHeight, i)
+		}
+		hashes[i] = header.Hash()
+		var data []byte
+		switch evidence.EvidenceType {
+		case metadata.DoubleSignEvidenceProposeType:
+			if header.Proposer != evidence.CommitteePublicKey {
+				return errors.Errorf("offender is not the proposer of header %+v", i)
+			}
+			data = hashes[i].GetBytes()
+		case metadata.DoubleSignEvidenceVoteType:
+			data = append(data, []byte(hashes[i].String())...)
+			data = append(data, message.BLS...)
+			data = append(data, message.BRI...)
+			data = common.HashB(data)
+		default:
+			return errors.Errorf("evidence type %+v not supported", evidence.EvidenceType)
+		}
+		isValid, err := bridgesig.Verify(briPublicKey, data, message.Signature)
+		if err != nil {
+			return err
+		}
+		if !isValid {
+			return errors.Errorf("invalid signature of message %+v", i)
+		}
+	}
+	if hashes[0].IsEqual(&hashes[1]) {
+		return errors.New("evidence should contain two different blocks")
+	}
+	if headers[0].Height != headers[1].Height {
+		return errors.Errorf("blocks of the evidence have different heights %+v and %+v", headers[0].Height, headers[1].Height)
+	}
+	if common.CalculateTimeSlot(headers[0].ProposeTime) != common.CalculateTimeSlot(headers[1].ProposeTime) {
+		return errors.New("blocks of the evidence are proposed in different timeslots")
+	}
+	if evidence.EvidenceType == metadata.DoubleSignEvidenceVoteType && common.CalculateTimeSlot(headers[0].Timestamp) != common.CalculateTimeSlot(headers[1].Timestamp) {
+		return errors.New("voted blocks of the evidence are produced in different timeslots")
+	}
+	return nil
+}
+
+// isDoubleSignSlashingEnabled returns true if double sign evidences are accepted and slashed at beaconHeight
+func (blockchain *BlockChain) isDoubleSignSlashingEnabled(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointSlashing
+}
+
+// parseDoubleSignSlashingInst parses an accepted double sign slashing instruction of the beacon block at beaconHeight,
+// instructions before BeaconHeightBreakPointSlashing are ignored
+func (blockchain *BlockChain) parseDoubleSignSlashingInst(inst []string, beaconHeight uint64) (*metadata.DoubleSignSlashingContent, bool) {
+	if !blockchain.isDoubleSignSlashingEnabled(beaconHeight) {
+		return nil, false
+	}
+	return metadata.ParseDoubleSignSlashingInst(inst)
+}
+
+// buildDoubleSignSlashingInstructions builds instructions for double sign evidences of shard blocks,
+// the offender of an accepted evidence loses DoubleSignSlashingPercent of its stake
+// and is ejected from its shard committee unless the committee would fall under MinShardCommitteeSize
+func (blockchain *BlockChain) buildDoubleSignSlashingInstructions(
+	beaconBestState *BeaconBestState,
+	beaconHeight uint64,
+	statefulActionsByShardID map[byte][][]string,
+) [][]string {
+	instructions := [][]string{}
+	if !blockchain.isDoubleSignSlashingEnabled(beaconHeight) {
+		return instructions
+	}
+	slashedKeys := map[string]bool{}
+	ejectedByShardID := map[byte]int{}
+	var keys []int
+	for k := range statefulActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		for _, action := range statefulActionsByShardID[shardID] {
+			metaType, err := strconv.Atoi(action[0])
+			if err != nil || metaType != metadata.DoubleSignEvidenceMeta {
+				continue
+			}
+			newInst, err := blockchain.buildInstructionForDoubleSignEvidence(beaconBestState, action[1], slashedKeys, ejectedByShardID)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			instructions = append(instructions, newInst)
+		}
+	}
+	return instructions
+}
+
+func (blockchain *BlockChain) buildInstructionForDoubleSignEvidence(
+	beaconBestState *BeaconBestState,
+	contentStr string,
+	slashedKeys map[string]bool,
+	ejectedByShardID map[byte]int,
+) ([]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return []string{}, err
+	}
+	var evidenceAction metadata.DoubleSignEvidenceAction
+	err = json.Unmarshal(contentBytes, &evidenceAction)
+	if err != nil {
+		return []string{}, err
+	}
+	evidence := evidenceAction.Meta
+	rejectedInst := []string{
+		strconv.Itoa(metadata.DoubleSignEvidenceMeta),
+		strconv.Itoa(int(evidence.ShardID)),
+		common.DoubleSignEvidenceRejectedChainStatus,
+		contentStr,
+	}
+	getShardCommittee := func(shardID byte, beaconHeight uint64) ([]string, error) {
+		return blockchain.getShardCommitteeByBeaconHeight(beaconBestState, shardID, beaconHeight)
+	}
+	if err := verifyDoubleSignEvidence(&evidence, getShardCommittee); err != nil {
+		Logger.log.Infof("Reject double sign evidence %+v, error %+v", evidenceAction.TxReqID, err)
+		return rejectedInst, nil
+	}
+	shardCommittee, err := incognitokey.CommitteeKeyListToString(beaconBestState.ShardCommittee[evidence.ShardID])
+	if err != nil {
+		return []string{}, err
+	}
+	// fixed block validators are run by the network itself and never slashed
+	index := common.IndexOfStr(evidence.CommitteePublicKey, shardCommittee)
+	if index < NumberOfFixedBlockValidators || slashedKeys[evidence.CommitteePublicKey] {
+		return rejectedInst, nil
+	}
+	stakerInfo, has, err := statedb.GetStakerInfo(beaconBestState.consensusStateDB, evidence.CommitteePublicKey)
+	if err != nil {
+		return []string{}, err
+	}
+	if !has || stakerInfo.TxStakingID() == common.HashH([]byte{0}) {
+		return rejectedInst, nil
+	}
+	slashedStake, has, err := statedb.GetSlashedStake(beaconBestState.consensusStateDB, evidence.CommitteePublicKey)
+	if err != nil {
+		return []string{}, err
+	}
+	if has && slashedStake.TxStakingID() == stakerInfo.TxStakingID() {
+		return rejectedInst, nil
+	}
+
+	slashedKeys[evidence.CommitteePublicKey] = true
+	ejected := len(shardCommittee)-ejectedByShardID[evidence.ShardID] > beaconBestState.MinShardCommitteeSize
+	if ejected {
+		ejectedByShardID[evidence.ShardID]++
+	}
+	slashingContent := metadata.DoubleSignSlashingContent{
+		CommitteePublicKey: evidence.CommitteePublicKey,
+		ShardID:            evidence.ShardID,
+		TxStakingID:        stakerInfo.TxStakingID(),
+		TxEvidenceID:       evidenceAction.TxReqID,
+		SlashedAmount:      blockchain.config.ChainParams.StakingAmountShard * blockchain.config.ChainParams.DoubleSignSlashingPercent / 100,
+		Ejected:            ejected,
+	}
+	slashingContentBytes, err := json.Marshal(slashingContent)
+	if err != nil {
+		return []string{}, err
+	}
+	return []string{
+		strconv.Itoa(metadata.DoubleSignEvidenceMeta),
+		strconv.Itoa(int(evidence.ShardID)),
+		common.DoubleSignEvidenceAcceptedChainStatus,
+		base64.StdEncoding.EncodeToString(slashingContentBytes),
+	}, nil
+}
+
+// processDoubleSignSlashingInstruction turns off auto staking of the offender so its stake (minus the slashed amount)
+// is returned when it leaves the committee, an ejected offender leaves the shard committee right away
+func (beaconBestState *BeaconBestState) processDoubleSignSlashingInstruction(blockchain *BlockChain, instruction []string, committeeChange *committeeChange) error {
+	slashingContent, ok := blockchain.parseDoubleSignSlashingInst(instruction, beaconBestState.BeaconHeight)
+	if !ok {
+		return nil
+	}
+	committeePublicKey := slashingContent.CommitteePublicKey
+	if _, ok := beaconBestState.AutoStaking.Get(committeePublicKey); ok {
+		beaconBestState.AutoStaking.Set(committeePublicKey, false)
+		committeeChange.stopAutoStaking = append(committeeChange.stopAutoStaking, committeePublicKey)
+	}
+	if !slashingContent.Ejected {
+		return nil
+	}
+	shardID := slashingContent.ShardID
+	shardCommitteeStr, err := incognitokey.CommitteeKeyListToString(beaconBestState.ShardCommittee[shardID])
+	if err != nil {
+		return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+	}
+	if common.IndexOfStr(committeePublicKey, shardCommitteeStr) == -1 {
+		return nil
+	}
+	tempShardCommittee, err := RemoveValidator(shardCommitteeStr, []string{committeePublicKey})
+	if err != nil {
+		return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+	}
+	beaconBestState.ShardCommittee[shardID], err = incognitokey.CommitteeBase58KeyListToStruct(tempShardCommittee)
+	if err != nil {
+		return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+	}
+	ejectedKeys, err := incognitokey.CommitteeBase58KeyListToStruct([]string{committeePublicKey})
+	if err != nil {
+		return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+	}
+	committeeChange.shardCommitteeRemoved[shardID] = append(committeeChange.shardCommitteeRemoved[shardID], ejectedKeys...)
+	return nil
+}
+
+// processDoubleSignSlashingInstructions stores slashed stakes of a beacon block into the consensus state
+func (blockchain *BlockChain) processDoubleSignSlashingInstructions(
+	newBestState *BeaconBestState,
+	beaconBlock *BeaconBlock,
+) error {
+	for _, inst := range beaconBlock.Body.Instructions {
+		slashingContent, ok := blockchain.parseDoubleSignSlashingInst(inst, beaconBlock.Header.Height)
+		if !ok {
+			continue
+		}
+		Logger.log.Infof("Slash %+v of validator %+v for double sign evidence %+v", slashingContent.SlashedAmount, slashingContent.CommitteePublicKey, slashingContent.TxEvidenceID)
+		err := statedb.StoreSlashedStake(newBestState.consensusStateDB, statedb.NewSlashedStakeStateWithValue(
+			slashingContent.CommitteePublicKey,
+			slashingContent.TxStakingID,
+			slashingContent.TxEvidenceID,
+			slashingContent.SlashedAmount,
+		))
+		if err != nil {
+			return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, fmt.Errorf("store slashed stake of %+v error %+v", slashingContent.CommitteePublicKey, err))
+		}
+	}
+	return nil
+}
+
+// processDoubleSignSlashingForShard removes ejected offenders from the committee of the shard
+// (after the swap of the shard block, which is built upon the committee before ejection)
+// and deletes their staking tx once it is returned
+func (shardBestState *ShardBestState) processDoubleSignSlashingForShard(blockchain *BlockChain, beaconBlocks []*BeaconBlock, shardBlock *ShardBlock, committeeChange *committeeChange) error {
+	shardID := shardBlock.Header.ShardID
+	for _, beaconBlock := range beaconBlocks {
+		for _, inst := range beaconBlock.Body.Instructions {
+			slashingContent, ok := blockchain.parseDoubleSignSlashingInst(inst, beaconBlock.Header.Height)
+			if !ok || !slashingContent.Ejected {
+				continue
+			}
+			if txID, ok := shardBestState.StakingTx.Get(slashingContent.CommitteePublicKey); ok {
+				if checkReturnStakingTxExistence(txID, shardBlock) {
+					shardBestState.StakingTx.Remove(slashingContent.CommitteePublicKey)
+				}
+			}
+			if slashingContent.ShardID != shardID {
+				continue
+			}
+			shardCommitteeStr, err := incognitokey.CommitteeKeyListToString(shardBestState.ShardCommittee)
+			if err != nil {
+				return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+			}
+			if common.IndexOfStr(slashingContent.CommitteePublicKey, shardCommitteeStr) == -1 {
+				continue
+			}
+			tempShardCommittee, err := RemoveValidator(shardCommitteeStr, []string{slashingContent.CommitteePublicKey})
+			if err != nil {
+				return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+			}
+			shardBestState.ShardCommittee, err = incognitokey.CommitteeBase58KeyListToStruct(tempShardCommittee)
+			if err != nil {
+				return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+			}
+			ejectedKeys, err := incognitokey.CommitteeBase58KeyListToStruct([]string{slashingContent.CommitteePublicKey})
+			if err != nil {
+				return NewBlockChainError(ProcessDoubleSignSlashingInstructionError, err)
+			}
+			committeeChange.shardCommitteeRemoved[shardID] = append(committeeChange.shardCommitteeRemoved[shardID], ejectedKeys...)
+		}
+	}
+	return nil
+}
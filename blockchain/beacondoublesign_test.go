@@ -0,0 +1,227 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type doubleSignTestKey struct {
+	committeePublicKey string
+	briPrivateKey      []byte
+}
+
+func newDoubleSignTestKey(t *testing.T, seed byte) doubleSignTestKey {
+	seedBytes := common.HashB([]byte{seed})
+	committeePublicKey, err := incognitokey.NewCommitteeKeyFromSeed(seedBytes, seedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committeePublicKeyStr, err := committeePublicKey.ToBase58()
+	if err != nil {
+		t.Fatal(err)
+	}
+	briPrivateKey, _ := bridgesig.KeyGen(seedBytes)
+	return doubleSignTestKey{
+		committeePublicKey: committeePublicKeyStr,
+		briPrivateKey:      bridgesig.SKBytes(&briPrivateKey),
+	}
+}
+
+func signDoubleSignTestMessage(t *testing.T, evidenceType string, key doubleSignTestKey, header ShardHeader) metadata.DoubleSignMessage {
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := metadata.DoubleSignMessage{Header: headerBytes}
+	hash := header.Hash()
+	var data []byte
+	if evidenceType == metadata.DoubleSignEvidenceProposeType {
+		data = hash.GetBytes()
+	} else {
+		message.BLS = []byte{1, 2, 3}
+		data = append(data, []byte(hash.String())...)
+		data = append(data, message.BLS...)
+		data = common.HashB(data)
+	}
+	message.Signature, err = bridgesig.Sign(key.briPrivateKey, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func TestVerifyDoubleSignEvidence(t *testing.T) {
+	offender := newDoubleSignTestKey(t, 1)
+	other := newDoubleSignTestKey(t, 2)
+	committee := []string{other.committeePublicKey, offender.committeePublicKey}
+	committeeRoot, err := generateHashFromStringArray(committee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// committee of shard 1 stored by beacon at beacon height 5
+	getShardCommittee := func(shardID byte, beaconHeight uint64) ([]string, error) {
+		if shardID != 1 || beaconHeight != 5 {
+			return nil, fmt.Errorf("committee of shard %+v at beacon height %+v not found", shardID, beaconHeight)
+		}
+		return committee, nil
+	}
+	newHeader := func(height uint64, proposeTime int64, txRoot byte) ShardHeader {
+		return ShardHeader{
+			ShardID:       1,
+			Version:       2,
+			Height:        height,
+			BeaconHeight:  5,
+			CommitteeRoot: committeeRoot,
+			TxRoot:        common.HashH([]byte{txRoot}),
+			Proposer:      offender.committeePublicKey,
+			ProposeTime:   proposeTime,
+		}
+	}
+	producedAt := func(header ShardHeader, produceTime int64) ShardHeader {
+		header.Timestamp = produceTime
+		return header
+	}
+	tests := []struct {
+		name         string
+		evidenceType string
+		signer       doubleSignTestKey
+		first        ShardHeader
+		second       ShardHeader
+		wantErr      bool
+	}{
+		{
+			name:         "conflicting proposes",
+			evidenceType: metadata.DoubleSignEvidenceProposeType,
+			signer:       offender,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(10, 101, 2),
+		},
+		{
+			name:         "conflicting votes",
+			evidenceType: metadata.DoubleSignEvidenceVoteType,
+			signer:       offender,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(10, 100, 2),
+		},
+		{
+			name:         "same block",
+			evidenceType: metadata.DoubleSignEvidenceProposeType,
+			signer:       offender,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(10, 100, 1),
+			wantErr:      true,
+		},
+		{
+			name:         "different heights",
+			evidenceType: metadata.DoubleSignEvidenceProposeType,
+			signer:       offender,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(11, 100, 2),
+			wantErr:      true,
+		},
+		{
+			name:         "different rounds",
+			evidenceType: metadata.DoubleSignEvidenceVoteType,
+			signer:       offender,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(10, 100+common.TIMESLOT, 2),
+			wantErr:      true,
+		},
+		{
+			name:         "proposes of blocks produced in different timeslots",
+			evidenceType: metadata.DoubleSignEvidenceProposeType,
+			signer:       offender,
+			first:        producedAt(newHeader(10, 100, 1), 100-common.TIMESLOT),
+			second:       producedAt(newHeader(10, 100, 2), 100),
+		},
+		{
+			name:         "votes for blocks produced in different timeslots",
+			evidenceType: metadata.DoubleSignEvidenceVoteType,
+			signer:       offender,
+			first:        producedAt(newHeader(10, 100, 1), 100-common.TIMESLOT),
+			second:       producedAt(newHeader(10, 100, 2), 100),
+			wantErr:      true,
+		},
+		{
+			name:         "signed by another key",
+			evidenceType: metadata.DoubleSignEvidenceVoteType,
+			signer:       other,
+			first:        newHeader(10, 100, 1),
+			second:       newHeader(10, 100, 2),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evidence, _ := metadata.NewDoubleSignEvidenceMetadata(
+				tt.evidenceType,
+				1,
+				offender.committeePublicKey,
+				signDoubleSignTestMessage(t, tt.evidenceType, tt.signer, tt.first),
+				signDoubleSignTestMessage(t, tt.evidenceType, tt.signer, tt.second),
+				metadata.DoubleSignEvidenceMeta,
+			)
+			err := verifyDoubleSignEvidence(evidence, getShardCommittee)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	evidence, _ := metadata.NewDoubleSignEvidenceMetadata(
+		metadata.DoubleSignEvidenceProposeType,
+		1,
+		offender.committeePublicKey,
+		signDoubleSignTestMessage(t, metadata.DoubleSignEvidenceProposeType, offender, newHeader(10, 100, 1)),
+		signDoubleSignTestMessage(t, metadata.DoubleSignEvidenceProposeType, offender, newHeader(10, 100, 2)),
+		metadata.DoubleSignEvidenceMeta,
+	)
+	t.Run("stored committee not match committee root", func(t *testing.T) {
+		assert.Error(t, verifyDoubleSignEvidence(evidence, func(shardID byte, beaconHeight uint64) ([]string, error) {
+			return []string{other.committeePublicKey, offender.committeePublicKey, newDoubleSignTestKey(t, 3).committeePublicKey}, nil
+		}))
+	})
+	t.Run("offender not in stored committee", func(t *testing.T) {
+		assert.Error(t, verifyDoubleSignEvidence(evidence, func(shardID byte, beaconHeight uint64) ([]string, error) {
+			return []string{other.committeePublicKey}, nil
+		}))
+	})
+	t.Run("committee of another beacon height", func(t *testing.T) {
+		assert.Error(t, verifyDoubleSignEvidence(evidence, func(shardID byte, beaconHeight uint64) ([]string, error) {
+			return nil, fmt.Errorf("committee of shard %+v at beacon height %+v not found", shardID, beaconHeight)
+		}))
+	})
+}
+
+func TestDoubleSignSlashingBreakPoint(t *testing.T) {
+	blockchain := &BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointSlashing: 100}}}
+	contentBytes, err := json.Marshal(metadata.DoubleSignSlashingContent{CommitteePublicKey: "offender", SlashedAmount: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst := []string{
+		strconv.Itoa(metadata.DoubleSignEvidenceMeta),
+		"1",
+		common.DoubleSignEvidenceAcceptedChainStatus,
+		base64.StdEncoding.EncodeToString(contentBytes),
+	}
+	_, ok := blockchain.parseDoubleSignSlashingInst(inst, 99)
+	assert.False(t, ok)
+	content, ok := blockchain.parseDoubleSignSlashingInst(inst, 100)
+	assert.True(t, ok)
+	assert.Equal(t, "offender", content.CommitteePublicKey)
+
+	statefulActions := map[byte][][]string{1: {{strconv.Itoa(metadata.DoubleSignEvidenceMeta), "evidence"}}}
+	assert.Empty(t, blockchain.buildDoubleSignSlashingInstructions(nil, 99, statefulActions))
+}
@@ -45,6 +45,10 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 			err = blockchain.processPDETrade(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
 			err = blockchain.processPDECrossPoolTrade(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			err = blockchain.processPDELimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			err = blockchain.processPDECancelLimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
 			err = blockchain.processPDEWithdrawal(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDEFeeWithdrawalRequestMeta):
@@ -60,6 +64,11 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 	if reflect.DeepEqual(backUpCurrentPDEState, currentPDEState) {
 		return nil
 	}
+	err = updatePDEPriceCumulatives(pdexStateDB, backUpCurrentPDEState.PDEPoolPairs, currentPDEState.PDEPoolPairs, beaconHeight+1)
+	if err != nil {
+		Logger.log.Error(err)
+		return err
+	}
 	// store updated currentPDEState to leveldb with new beacon height
 	err = storePDEStateToDB(pdexStateDB, beaconHeight+1, currentPDEState)
 	if err != nil {
@@ -96,6 +105,12 @@ func hasPDEInstruction(instructions [][]string) bool {
 		case strconv.Itoa(metadata.PDETradingFeesDistributionMeta):
 			hasPDEXInstruction = true
 			break
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
 		}
 	}
 	return hasPDEXInstruction
@@ -402,12 +417,33 @@ func (blockchain *BlockChain) processPDECrossPoolTrade(pdexStateDB *statedb.Stat
 		return nil
 	}
 
+	if !applyPDECrossPoolTradeAcceptedContents(beaconHeight, pdeTradeAcceptedContents, currentPDEState) {
+		return nil
+	}
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDETradeStatusPrefix,
+		pdeTradeAcceptedContents[0].RequestedTxID[:],
+		byte(common.PDECrossPoolTradeAcceptedStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted trade status: %+v", err)
+	}
+	return nil
+}
+
+// applyPDECrossPoolTradeAcceptedContents updates pools by operations of accepted contents, returns false if a pool is not found
+func applyPDECrossPoolTradeAcceptedContents(
+	beaconHeight uint64,
+	pdeTradeAcceptedContents []metadata.PDECrossPoolTradeAcceptedContent,
+	currentPDEState *CurrentPDEState,
+) bool {
 	for _, pdeTradeAcceptedContent := range pdeTradeAcceptedContents {
 		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr))
 		pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
 		if !found || pdePoolForPair == nil {
 			Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr)
-			return nil
+			return false
 		}
 
 		if pdeTradeAcceptedContent.Token1PoolValueOperation.Operator == "+" {
@@ -418,14 +454,125 @@ func (blockchain *BlockChain) processPDECrossPoolTrade(pdexStateDB *statedb.Stat
 			pdePoolForPair.Token2PoolValue += pdeTradeAcceptedContent.Token2PoolValueOperation.Value
 		}
 	}
+	return true
+}
+
+func (blockchain *BlockChain) processPDELimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	switch instruction[2] {
+	case common.PDELimitOrderWaitingChainStatus:
+		var limitOrderAction metadata.PDELimitOrderRequestAction
+		err := json.Unmarshal([]byte(instruction[3]), &limitOrderAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde waiting limit order instruction: %+v", err)
+			return nil
+		}
+		orderMeta := limitOrderAction.Meta
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, limitOrderAction.TxReqID.String()))
+		if currentPDEState.PDELimitOrders == nil {
+			currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+		}
+		currentPDEState.PDELimitOrders[orderKey] = rawdbv2.NewPDELimitOrder(
+			limitOrderAction.TxReqID,
+			orderMeta.TraderAddressStr,
+			orderMeta.TokenIDToBuyStr,
+			orderMeta.TokenIDToSellStr,
+			orderMeta.SellAmount,
+			orderMeta.MinAcceptableAmount,
+			orderMeta.TradingFee,
+			orderMeta.ExpiryBeaconHeight,
+			limitOrderAction.ShardID,
+		)
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			limitOrderAction.TxReqID[:],
+			byte(common.PDELimitOrderWaitingStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde waiting limit order status: %+v", err)
+		}
+
+	case common.PDELimitOrderFeeRefundChainStatus, common.PDELimitOrderSellingTokenRefundChainStatus:
+		var refundContent metadata.PDELimitOrderRefundContent
+		err := json.Unmarshal([]byte(instruction[3]), &refundContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order refund instruction: %+v", err)
+			return nil
+		}
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, refundContent.OrderID.String()))
+		removePDELimitOrder(currentPDEState, orderKey)
+		status := common.PDELimitOrderRejectedStatus
+		if refundContent.Reason == common.PDELimitOrderExpiredChainStatus {
+			status = common.PDELimitOrderExpiredStatus
+		} else if refundContent.Reason == common.PDELimitOrderCancelledChainStatus {
+			status = common.PDELimitOrderCancelledStatus
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			refundContent.OrderID[:],
+			byte(status),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde refunded limit order status: %+v", err)
+		}
+
+	case common.PDELimitOrderMatchedChainStatus:
+		var matchedContents []metadata.PDECrossPoolTradeAcceptedContent
+		err := json.Unmarshal([]byte(instruction[3]), &matchedContents)
+		if err != nil {
+			Logger.log.Errorf("WARNING: an error occured while unmarshaling pde limit order matched contents: %+v", err)
+			return nil
+		}
+		if len(matchedContents) == 0 {
+			Logger.log.Error("WARNING: There is no pde limit order matched content.")
+			return nil
+		}
+		if !applyPDECrossPoolTradeAcceptedContents(beaconHeight, matchedContents, currentPDEState) {
+			return nil
+		}
+		orderID := matchedContents[0].RequestedTxID
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, orderID.String()))
+		removePDELimitOrder(currentPDEState, orderKey)
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			orderID[:],
+			byte(common.PDELimitOrderMatchedStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde matched limit order status: %+v", err)
+		}
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDECancelLimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	var cancelAction metadata.PDECancelLimitOrderRequestAction
+	err := json.Unmarshal([]byte(instruction[3]), &cancelAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order instruction: %+v", err)
+		return nil
+	}
+	// the order is removed by its refund instructions
+	status := common.PDECancelLimitOrderRejectedStatus
+	if instruction[2] == common.PDECancelLimitOrderAcceptedChainStatus {
+		status = common.PDECancelLimitOrderAcceptedStatus
+	}
 	err = statedb.TrackPDEStatus(
 		pdexStateDB,
-		rawdbv2.PDETradeStatusPrefix,
-		pdeTradeAcceptedContents[0].RequestedTxID[:],
-		byte(common.PDECrossPoolTradeAcceptedStatus),
+		rawdbv2.PDECancelOrderStatusPrefix,
+		cancelAction.TxReqID[:],
+		byte(status),
 	)
 	if err != nil {
-		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted trade status: %+v", err)
+		Logger.log.Errorf("ERROR: an error occured while tracking pde cancel limit order status: %+v", err)
 	}
 	return nil
 }
@@ -416,38 +416,32 @@ type tradeInfo struct {
 	receiveAmount           uint64
 }
 
+// isPDEBestRouteEnabled returns true if best route trades are routed through any pools in the beacon block following beaconHeight,
+// before that they are routed via PRV pools the same way of nodes not knowing UseBestRoute
+func (blockchain *BlockChain) isPDEBestRouteEnabled(beaconHeight uint64) bool {
+	return beaconHeight+1 >= blockchain.config.ChainParams.BeaconHeightBreakPointBestRoute
+}
+
 func (blockchain *BlockChain) buildInstsForSortedTradableActions(
 	currentPDEState *CurrentPDEState,
 	beaconHeight uint64,
 	sortedTradableActions []metadata.PDECrossPoolTradeRequestAction,
 ) ([][]string, map[string]uint64) {
-	prvIDStr := common.PRVCoinID.String()
 	tradableInsts := [][]string{}
 	tradingFeeByPair := make(map[string]uint64)
 	for _, tradeAction := range sortedTradableActions {
 		tradeMeta := tradeAction.Meta
 		var sequentialTrades []*tradeInfo
-		if isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) { // direct trade
-			sequentialTrades = []*tradeInfo{
-				&tradeInfo{
-					tokenIDToBuyStr:  tradeMeta.TokenIDToBuyStr,
-					tokenIDToSellStr: tradeMeta.TokenIDToSellStr,
-					sellAmount:       tradeMeta.SellAmount,
-				},
-			}
-		} else { // cross pool trade
-			sequentialTrades = []*tradeInfo{
-				&tradeInfo{
-					tokenIDToBuyStr:  prvIDStr,
-					tokenIDToSellStr: tradeMeta.TokenIDToSellStr,
-					sellAmount:       tradeMeta.SellAmount,
-				},
-				&tradeInfo{
-					tokenIDToBuyStr:  tradeMeta.TokenIDToBuyStr,
-					tokenIDToSellStr: prvIDStr,
-					sellAmount:       uint64(0),
-				},
+		if tradeMeta.UseBestRoute && blockchain.isPDEBestRouteEnabled(beaconHeight) {
+			// the route is found on pools updated by trades before
+			sequentialTrades = buildBestRouteSequentialTrades(currentPDEState, beaconHeight, tradeMeta.TokenIDToBuyStr, tradeMeta.TokenIDToSellStr, tradeMeta.SellAmount)
+			if len(sequentialTrades) == 0 {
+				untradableInsts := blockchain.buildInstsForUntradableActions([]metadata.PDECrossPoolTradeRequestAction{tradeAction})
+				tradableInsts = append(tradableInsts, untradableInsts...)
+				continue
 			}
+		} else {
+			sequentialTrades = buildSequentialTrades(tradeMeta.TokenIDToBuyStr, tradeMeta.TokenIDToSellStr, tradeMeta.SellAmount)
 		}
 		newInsts, err := blockchain.buildInstructionsForPDECrossPoolTrade(
 			sequentialTrades,
@@ -469,9 +463,130 @@ func (blockchain *BlockChain) buildInstsForSortedTradableActions(
 			tradableInsts = append(tradableInsts, newInsts...)
 		}
 	}
+
+	// resting limit orders are matched against pools updated by the trades above
+	limitOrderInsts := blockchain.buildInstsForMatchedPDELimitOrders(currentPDEState, beaconHeight, tradingFeeByPair)
+	tradableInsts = append(tradableInsts, limitOrderInsts...)
 	return tradableInsts, tradingFeeByPair
 }
 
+// buildSequentialTrades returns a direct trade if the pair contains PRV, otherwise two trades via PRV pools
+func buildSequentialTrades(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+) []*tradeInfo {
+	prvIDStr := common.PRVCoinID.String()
+	if isTradingFairContainsPRV(tokenIDToSellStr, tokenIDToBuyStr) { // direct trade
+		return []*tradeInfo{
+			&tradeInfo{
+				tokenIDToBuyStr:  tokenIDToBuyStr,
+				tokenIDToSellStr: tokenIDToSellStr,
+				sellAmount:       sellAmount,
+			},
+		}
+	}
+	// cross pool trade
+	return []*tradeInfo{
+		&tradeInfo{
+			tokenIDToBuyStr:  prvIDStr,
+			tokenIDToSellStr: tokenIDToSellStr,
+			sellAmount:       sellAmount,
+		},
+		&tradeInfo{
+			tokenIDToBuyStr:  tokenIDToBuyStr,
+			tokenIDToSellStr: prvIDStr,
+			sellAmount:       uint64(0),
+		},
+	}
+}
+
+// simulateSequentialTrades calculates new pool values of each trade without updating pools, returns the final receiving amount
+func simulateSequentialTrades(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	sequentialTrades []*tradeInfo,
+) uint64 {
+	amt := sequentialTrades[0].sellAmount
+	for _, tradeInf := range sequentialTrades {
+		tradeInf.sellAmount = amt
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
+		pdePoolPair, _ := currentPDEState.PDEPoolPairs[pairKey]
+		newAmt, newTokenPoolValueToBuy, newTokenPoolValueToSell := calcTradeValue(pdePoolPair, tradeInf.tokenIDToSellStr, amt)
+		amt = newAmt
+		tradeInf.newTokenPoolValueToBuy = newTokenPoolValueToBuy
+		tradeInf.newTokenPoolValueToSell = newTokenPoolValueToSell
+		tradeInf.receiveAmount = amt
+	}
+	return amt
+}
+
+// applySequentialTrades updates pools on mem by simulated trades and builds trade accepted contents
+func applySequentialTrades(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	sequentialTrades []*tradeInfo,
+	tradingFee uint64,
+	shardID byte,
+	traderAddressStr string,
+	txReqID common.Hash,
+	tradingFeeByPair map[string]uint64,
+) []metadata.PDECrossPoolTradeAcceptedContent {
+	tradeAcceptedContents := []metadata.PDECrossPoolTradeAcceptedContent{}
+	proportionalFee := tradingFee / uint64(len(sequentialTrades))
+	for idx, tradeInf := range sequentialTrades {
+		// update current pde state on mem
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
+		pdePoolPair, _ := currentPDEState.PDEPoolPairs[pairKey]
+
+		pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToBuy
+		pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToSell
+		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToSell
+			pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToBuy
+		}
+
+		// build trade accepted contents
+		pdeTradeAcceptedContent := metadata.PDECrossPoolTradeAcceptedContent{
+			TraderAddressStr: traderAddressStr,
+			TokenIDToBuyStr:  tradeInf.tokenIDToBuyStr,
+			ReceiveAmount:    tradeInf.receiveAmount,
+			Token1IDStr:      pdePoolPair.Token1IDStr,
+			Token2IDStr:      pdePoolPair.Token2IDStr,
+			ShardID:          shardID,
+			RequestedTxID:    txReqID,
+		}
+		pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "-",
+			Value:    tradeInf.receiveAmount,
+		}
+		pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "+",
+			Value:    tradeInf.sellAmount,
+		}
+		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+				Operator: "+",
+				Value:    tradeInf.sellAmount,
+			}
+			pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+				Operator: "-",
+				Value:    tradeInf.receiveAmount,
+			}
+		}
+
+		addingFee := proportionalFee
+		if idx == len(sequentialTrades)-1 {
+			addingFee = tradingFee - uint64(len(sequentialTrades)-1)*proportionalFee
+		}
+		pdeTradeAcceptedContent.AddingFee = addingFee
+		sKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr, ""))
+		tradingFeeByPair[sKey] += addingFee
+		tradeAcceptedContents = append(tradeAcceptedContents, pdeTradeAcceptedContent)
+	}
+	return tradeAcceptedContents
+}
+
 func (blockchain *BlockChain) buildInstsForUntradableActions(
 	untradableActions []metadata.PDECrossPoolTradeRequestAction,
 ) [][]string {
@@ -561,18 +676,7 @@ func (blockchain *BlockChain) buildInstructionsForPDECrossPoolTrade(
 		return [][]string{refundTradingFeeInst, refundSellingTokenInst}, nil
 	}
 
-	amt := sequentialTrades[0].sellAmount
-	for _, tradeInf := range sequentialTrades {
-		tradeInf.sellAmount = amt
-		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
-		pdePoolPair, _ := currentPDEState.PDEPoolPairs[pairKey]
-		newAmt, newTokenPoolValueToBuy, newTokenPoolValueToSell := calcTradeValue(pdePoolPair, tradeInf.tokenIDToSellStr, amt)
-		amt = newAmt
-		tradeInf.newTokenPoolValueToBuy = newTokenPoolValueToBuy
-		tradeInf.newTokenPoolValueToSell = newTokenPoolValueToSell
-		tradeInf.receiveAmount = amt
-	}
-
+	amt := simulateSequentialTrades(currentPDEState, beaconHeight, sequentialTrades)
 	if minAcceptableAmount > amt {
 		refundTradingFeeInst := buildCrossPoolTradeRefundInst(
 			traderAddressStr,
@@ -595,59 +699,16 @@ func (blockchain *BlockChain) buildInstructionsForPDECrossPoolTrade(
 		return [][]string{refundTradingFeeInst, refundSellingTokenInst}, nil
 	}
 
-	tradeAcceptedContents := []metadata.PDECrossPoolTradeAcceptedContent{}
-	proportionalFee := tradingFee / uint64(len(sequentialTrades))
-	for idx, tradeInf := range sequentialTrades {
-		// update current pde state on mem
-		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
-		pdePoolPair, _ := currentPDEState.PDEPoolPairs[pairKey]
-
-		pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToBuy
-		pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToSell
-		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
-			pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToSell
-			pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToBuy
-		}
-
-		// build trade accepted contents
-		pdeTradeAcceptedContent := metadata.PDECrossPoolTradeAcceptedContent{
-			TraderAddressStr: traderAddressStr,
-			TokenIDToBuyStr:  tradeInf.tokenIDToBuyStr,
-			ReceiveAmount:    tradeInf.receiveAmount,
-			Token1IDStr:      pdePoolPair.Token1IDStr,
-			Token2IDStr:      pdePoolPair.Token2IDStr,
-			ShardID:          shardID,
-			RequestedTxID:    txReqID,
-		}
-		pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
-			Operator: "-",
-			Value:    tradeInf.receiveAmount,
-		}
-		pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
-			Operator: "+",
-			Value:    tradeInf.sellAmount,
-		}
-		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
-			pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
-				Operator: "+",
-				Value:    tradeInf.sellAmount,
-			}
-			pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
-				Operator: "-",
-				Value:    tradeInf.receiveAmount,
-			}
-		}
-
-		addingFee := proportionalFee
-		if idx == len(sequentialTrades)-1 {
-			addingFee = tradingFee - uint64(len(sequentialTrades)-1)*proportionalFee
-		}
-		pdeTradeAcceptedContent.AddingFee = addingFee
-		sKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr, ""))
-		tradingFeeByPair[sKey] += addingFee
-		tradeAcceptedContents = append(tradeAcceptedContents, pdeTradeAcceptedContent)
-	}
-
+	tradeAcceptedContents := applySequentialTrades(
+		currentPDEState,
+		beaconHeight,
+		sequentialTrades,
+		tradingFee,
+		shardID,
+		traderAddressStr,
+		txReqID,
+		tradingFeeByPair,
+	)
 	pdeTradeAcceptedContentsBytes, err := json.Marshal(tradeAcceptedContents)
 	if err != nil {
 		Logger.log.Errorf("ERROR: an error occured while marshaling pdeTradeAcceptedContents: %+v", err)
@@ -1106,3 +1167,339 @@ func (blockchain *BlockChain) buildInstructionsForPDEFeeWithdrawal(
 	}
 	return [][]string{acceptedInst}, nil
 }
+
+func buildLimitOrderRefundInst(
+	order *rawdbv2.PDELimitOrder,
+	tokenIDStr string,
+	amount uint64,
+	status string,
+	reason string,
+) []string {
+	refundContent := metadata.PDELimitOrderRefundContent{
+		TraderAddressStr: order.TraderAddressStr,
+		TokenIDStr:       tokenIDStr,
+		Amount:           amount,
+		ShardID:          order.ShardID,
+		OrderID:          order.OrderID,
+		Reason:           reason,
+	}
+	refundContentBytes, _ := json.Marshal(refundContent)
+	return []string{
+		strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+		strconv.Itoa(int(order.ShardID)),
+		status,
+		string(refundContentBytes),
+	}
+}
+
+// buildLimitOrderRefundInsts refunds trading fee (if any) and selling token of an order
+func buildLimitOrderRefundInsts(
+	order *rawdbv2.PDELimitOrder,
+	reason string,
+) [][]string {
+	insts := [][]string{}
+	if order.TradingFee > 0 {
+		insts = append(insts, buildLimitOrderRefundInst(
+			order,
+			common.PRVCoinID.String(),
+			order.TradingFee,
+			common.PDELimitOrderFeeRefundChainStatus,
+			reason,
+		))
+	}
+	insts = append(insts, buildLimitOrderRefundInst(
+		order,
+		order.TokenIDToSellStr,
+		order.SellAmount,
+		common.PDELimitOrderSellingTokenRefundChainStatus,
+		reason,
+	))
+	return insts
+}
+
+func isPDETradablePair(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+) bool {
+	prvIDStr := common.PRVCoinID.String()
+	if isTradingFairContainsPRV(tokenIDToSellStr, tokenIDToBuyStr) {
+		return isPoolPairExisting(beaconHeight, currentPDEState, tokenIDToSellStr, tokenIDToBuyStr)
+	}
+	return isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tokenIDToSellStr) &&
+		isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tokenIDToBuyStr)
+}
+
+func isPDELimitOrderExpired(order *rawdbv2.PDELimitOrder, newBeaconHeight uint64) bool {
+	return order.ExpiryBeaconHeight < newBeaconHeight
+}
+
+// isPDELimitOrderEnabled returns true if limit orders are accepted in the beacon block following beaconHeight
+func (blockchain *BlockChain) isPDELimitOrderEnabled(beaconHeight uint64) bool {
+	return beaconHeight+1 >= blockchain.config.ChainParams.BeaconHeightBreakPointLimitOrder
+}
+
+func removePDELimitOrder(currentPDEState *CurrentPDEState, orderKey string) {
+	order, found := currentPDEState.PDELimitOrders[orderKey]
+	if !found {
+		return
+	}
+	if currentPDEState.DeletedPDELimitOrders == nil {
+		currentPDEState.DeletedPDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	currentPDEState.DeletedPDELimitOrders[orderKey] = order
+	delete(currentPDEState.PDELimitOrders, orderKey)
+}
+
+func (blockchain *BlockChain) buildInstsForPDECancelLimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
+) [][]string {
+	insts := [][]string{}
+	var keys []int
+	for k := range pdeCancelLimitOrderActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		for _, action := range pdeCancelLimitOrderActionsByShardID[shardID] {
+			contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cancel limit order action: %+v", err)
+				continue
+			}
+			var cancelAction metadata.PDECancelLimitOrderRequestAction
+			err = json.Unmarshal(contentBytes, &cancelAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order action: %+v", err)
+				continue
+			}
+			cancelActionBytes, _ := json.Marshal(cancelAction)
+			orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, cancelAction.Meta.OrderID.String()))
+			order, found := currentPDEState.PDELimitOrders[orderKey]
+			if !found || order == nil || order.TraderAddressStr != cancelAction.Meta.TraderAddressStr {
+				insts = append(insts, []string{
+					strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta),
+					strconv.Itoa(int(shardID)),
+					common.PDECancelLimitOrderRejectedChainStatus,
+					string(cancelActionBytes),
+				})
+				continue
+			}
+			insts = append(insts, []string{
+				strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta),
+				strconv.Itoa(int(shardID)),
+				common.PDECancelLimitOrderAcceptedChainStatus,
+				string(cancelActionBytes),
+			})
+			insts = append(insts, buildLimitOrderRefundInsts(order, common.PDELimitOrderCancelledChainStatus)...)
+			removePDELimitOrder(currentPDEState, orderKey)
+		}
+	}
+	return insts
+}
+
+// buildInstsForPDELimitOrders puts new orders into the order book,
+// orders before activation, of untradable pairs, under the min sell amount, with an invalid expiry or exceeding the book size are refunded
+func (blockchain *BlockChain) buildInstsForPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+) [][]string {
+	insts := [][]string{}
+	var keys []int
+	for k := range pdeLimitOrderActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		for _, action := range pdeLimitOrderActionsByShardID[shardID] {
+			contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order action: %+v", err)
+				continue
+			}
+			var limitOrderAction metadata.PDELimitOrderRequestAction
+			err = json.Unmarshal(contentBytes, &limitOrderAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order action: %+v", err)
+				continue
+			}
+			orderMeta := limitOrderAction.Meta
+			order := rawdbv2.NewPDELimitOrder(
+				limitOrderAction.TxReqID,
+				orderMeta.TraderAddressStr,
+				orderMeta.TokenIDToBuyStr,
+				orderMeta.TokenIDToSellStr,
+				orderMeta.SellAmount,
+				orderMeta.MinAcceptableAmount,
+				orderMeta.TradingFee,
+				orderMeta.ExpiryBeaconHeight,
+				limitOrderAction.ShardID,
+			)
+			if !blockchain.isPDELimitOrderEnabled(beaconHeight) ||
+				order.SellAmount < metadata.MinPDELimitOrderSellAmount ||
+				isPDELimitOrderExpired(order, beaconHeight+1) ||
+				order.ExpiryBeaconHeight > beaconHeight+1+metadata.MaxPDELimitOrderLifetime ||
+				len(currentPDEState.PDELimitOrders) >= MaxPDELimitOrdersInBook ||
+				!isPDETradablePair(beaconHeight, currentPDEState, orderMeta.TokenIDToSellStr, orderMeta.TokenIDToBuyStr) {
+				insts = append(insts, buildLimitOrderRefundInsts(order, common.PDELimitOrderRejectedChainStatus)...)
+				continue
+			}
+			limitOrderActionBytes, _ := json.Marshal(limitOrderAction)
+			insts = append(insts, []string{
+				strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+				strconv.Itoa(int(shardID)),
+				common.PDELimitOrderWaitingChainStatus,
+				string(limitOrderActionBytes),
+			})
+			if currentPDEState.PDELimitOrders == nil {
+				currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+			}
+			orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, order.OrderID.String()))
+			currentPDEState.PDELimitOrders[orderKey] = order
+		}
+	}
+	return insts
+}
+
+func (blockchain *BlockChain) buildInstsForExpiredPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) [][]string {
+	insts := [][]string{}
+	if !blockchain.isPDELimitOrderEnabled(beaconHeight) {
+		return insts
+	}
+	orderKeys := []string{}
+	for orderKey := range currentPDEState.PDELimitOrders {
+		orderKeys = append(orderKeys, orderKey)
+	}
+	sort.Strings(orderKeys)
+	for _, orderKey := range orderKeys {
+		order := currentPDEState.PDELimitOrders[orderKey]
+		if !isPDELimitOrderExpired(order, beaconHeight+1) {
+			continue
+		}
+		insts = append(insts, buildLimitOrderRefundInsts(order, common.PDELimitOrderExpiredChainStatus)...)
+		removePDELimitOrder(currentPDEState, orderKey)
+	}
+	return insts
+}
+
+// buildInstsForMatchedPDELimitOrders matches resting orders by trading fee (the same way of sorting cross pool trades),
+// an order is matched when the pool price lets it receive at least its min acceptable amount, others keep resting.
+// At most MaxPDELimitOrdersScannedPerBlock orders are priced per block, taken from a window of the book (sorted by order key)
+// rotating with beacon height, and at most MaxPDELimitOrdersMatchedPerBlock of them are matched.
+// Orders which would receive nothing at the current price are refunded
+func (blockchain *BlockChain) buildInstsForMatchedPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tradingFeeByPair map[string]uint64,
+) [][]string {
+	insts := [][]string{}
+	if !blockchain.isPDELimitOrderEnabled(beaconHeight) || len(currentPDEState.PDELimitOrders) == 0 {
+		return insts
+	}
+	orderKeys := []string{}
+	for orderKey := range currentPDEState.PDELimitOrders {
+		orderKeys = append(orderKeys, orderKey)
+	}
+	sort.Strings(orderKeys)
+	if len(orderKeys) > MaxPDELimitOrdersScannedPerBlock {
+		start := int((beaconHeight * MaxPDELimitOrdersScannedPerBlock) % uint64(len(orderKeys)))
+		scannedKeys := make([]string, 0, MaxPDELimitOrdersScannedPerBlock)
+		for i := 0; i < MaxPDELimitOrdersScannedPerBlock; i++ {
+			scannedKeys = append(scannedKeys, orderKeys[(start+i)%len(orderKeys)])
+		}
+		orderKeys = scannedKeys
+	}
+
+	prvIDStr := common.PRVCoinID.String()
+	type sortingInfo struct {
+		orderKey        string
+		order           *rawdbv2.PDELimitOrder
+		sellAmountInPRV uint64
+	}
+	tradableOrders := []sortingInfo{}
+	for _, orderKey := range orderKeys {
+		order := currentPDEState.PDELimitOrders[orderKey]
+		if !isPDETradablePair(beaconHeight, currentPDEState, order.TokenIDToSellStr, order.TokenIDToBuyStr) {
+			continue
+		}
+		sellAmountInPRV := order.SellAmount
+		if order.TokenIDToSellStr != prvIDStr {
+			poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, order.TokenIDToSellStr))
+			sellAmountInPRV, _, _ = calcTradeValue(currentPDEState.PDEPoolPairs[poolPairKey], order.TokenIDToSellStr, order.SellAmount)
+		}
+		tradableOrders = append(tradableOrders, sortingInfo{orderKey: orderKey, order: order, sellAmountInPRV: sellAmountInPRV})
+	}
+	sort.Slice(tradableOrders, func(i, j int) bool {
+		// comparing a/b to c/d is equivalent with comparing a*d to c*b
+		firstItemProportion := big.NewInt(0)
+		firstItemProportion.Mul(
+			new(big.Int).SetUint64(tradableOrders[i].order.TradingFee),
+			new(big.Int).SetUint64(tradableOrders[j].sellAmountInPRV),
+		)
+		secondItemProportion := big.NewInt(0)
+		secondItemProportion.Mul(
+			new(big.Int).SetUint64(tradableOrders[j].order.TradingFee),
+			new(big.Int).SetUint64(tradableOrders[i].sellAmountInPRV),
+		)
+		cmp := firstItemProportion.Cmp(secondItemProportion)
+		if cmp == 0 {
+			return tradableOrders[i].orderKey < tradableOrders[j].orderKey
+		}
+		return cmp == 1
+	})
+
+	matchedOrders := 0
+	for _, info := range tradableOrders {
+		if matchedOrders >= MaxPDELimitOrdersMatchedPerBlock {
+			break
+		}
+		order := info.order
+		// pools could be drained by orders matched before
+		if !isPDETradablePair(beaconHeight, currentPDEState, order.TokenIDToSellStr, order.TokenIDToBuyStr) {
+			continue
+		}
+		sequentialTrades := buildSequentialTrades(order.TokenIDToBuyStr, order.TokenIDToSellStr, order.SellAmount)
+		receiveAmount := simulateSequentialTrades(currentPDEState, beaconHeight, sequentialTrades)
+		if receiveAmount == 0 {
+			insts = append(insts, buildLimitOrderRefundInsts(order, common.PDELimitOrderDustChainStatus)...)
+			removePDELimitOrder(currentPDEState, info.orderKey)
+			continue
+		}
+		if receiveAmount < order.MinAcceptableAmount {
+			continue
+		}
+		matchedContents := applySequentialTrades(
+			currentPDEState,
+			beaconHeight,
+			sequentialTrades,
+			order.TradingFee,
+			order.ShardID,
+			order.TraderAddressStr,
+			order.OrderID,
+			tradingFeeByPair,
+		)
+		matchedContentsBytes, err := json.Marshal(matchedContents)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while marshaling pde limit order matched contents: %+v", err)
+			continue
+		}
+		insts = append(insts, []string{
+			strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+			strconv.Itoa(int(order.ShardID)),
+			common.PDELimitOrderMatchedChainStatus,
+			string(matchedContentsBytes),
+		})
+		removePDELimitOrder(currentPDEState, info.orderKey)
+		matchedOrders++
+	}
+	return insts
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"math/big"
 	"sort"
 	"strconv"
 )
@@ -94,7 +95,7 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 	}
 
 	//save final exchangeRates
-	blockchain.pickExchangesRatesFinal(currentPortalState)
+	blockchain.pickExchangesRatesFinal(portalStateDB, block.GetHeight(), beaconHeight, currentPortalState, portalParams)
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
@@ -606,106 +607,225 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 	return nil
 }
 
-func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
+func (blockchain *BlockChain) pickExchangesRatesFinal(
+	portalStateDB *statedb.StateDB,
+	blockHeight uint64,
+	beaconHeight uint64,
+	currentPortalState *CurrentPortalState,
+	portalParams PortalParams,
+) {
+	if len(portalParams.FeederAddresses) > 0 {
+		history := aggregateFeederExchangeRates(currentPortalState, beaconHeight, portalParams)
+		if len(history.Submissions) == 0 {
+			return
+		}
+		// track submitted and final rates of the block
+		history.BeaconHeight = blockHeight
+		historyBytes, _ := json.Marshal(history)
+		err := statedb.TrackPortalStateStatusMultiple(
+			portalStateDB,
+			statedb.PortalExchangeRatesHistoryStatusPrefix(),
+			[]byte(strconv.FormatUint(blockHeight, 10)),
+			historyBytes,
+			beaconHeight,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: Save exchange rates history error: %+v", err)
+		}
+		return
+	}
+
+	tokenIDs := append([]string{common.PRVIDStr}, common.PortalSupportedIncTokenIDs...)
+
 	//convert to slice
-	var btcExchangeRatesSlice []uint64
-	var bnbExchangeRatesSlice []uint64
-	var prvExchangeRatesSlice []uint64
+	exchangeRatesSlices := make(map[string][]uint64, len(tokenIDs))
 	for _, v := range currentPortalState.ExchangeRatesRequests {
 		for _, rate := range v.Rates {
-			switch rate.PTokenID {
-			case common.PortalBTCIDStr:
-				btcExchangeRatesSlice = append(btcExchangeRatesSlice, rate.Rate)
-				break
-			case common.PortalBNBIDStr:
-				bnbExchangeRatesSlice = append(bnbExchangeRatesSlice, rate.Rate)
-				break
-			case common.PRVIDStr:
-				prvExchangeRatesSlice = append(prvExchangeRatesSlice, rate.Rate)
-				break
+			if common.IndexOfStr(rate.PTokenID, tokenIDs) == -1 {
+				continue
 			}
+			exchangeRatesSlices[rate.PTokenID] = append(exchangeRatesSlices[rate.PTokenID], rate.Rate)
 		}
 	}
 
-	//sort
-	sort.SliceStable(btcExchangeRatesSlice, func(i, j int) bool {
-		return btcExchangeRatesSlice[i] < btcExchangeRatesSlice[j]
-	})
+	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
+	for _, tokenID := range tokenIDs {
+		ratesSlice := exchangeRatesSlices[tokenID]
 
-	sort.SliceStable(bnbExchangeRatesSlice, func(i, j int) bool {
-		return bnbExchangeRatesSlice[i] < bnbExchangeRatesSlice[j]
-	})
+		//sort
+		sort.SliceStable(ratesSlice, func(i, j int) bool {
+			return ratesSlice[i] < ratesSlice[j]
+		})
 
-	sort.SliceStable(prvExchangeRatesSlice, func(i, j int) bool {
-		return prvExchangeRatesSlice[i] < prvExchangeRatesSlice[j]
-	})
+		//get current value
+		var amount uint64
+		if len(ratesSlice) > 0 {
+			amount = calcMedian(ratesSlice)
+		}
 
-	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
+		//update value when has exchange
+		if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
+			var amountPreState uint64
+			if value, ok := exchangeRatesState.Rates()[tokenID]; ok {
+				amountPreState = value.Amount
+			}
 
-	var btcAmount uint64
-	var bnbAmount uint64
-	var prvAmount uint64
+			//pick current value and pre value state
+			amount = choicePrice(amount, amountPreState)
+		}
 
-	//get current value
-	if len(btcExchangeRatesSlice) > 0 {
-		btcAmount = calcMedian(btcExchangeRatesSlice)
+		//select
+		if amount > 0 {
+			exchangeRatesList[tokenID] = statedb.FinalExchangeRatesDetail{
+				Amount: amount,
+			}
+		}
 	}
 
-	if len(bnbExchangeRatesSlice) > 0 {
-		bnbAmount = calcMedian(bnbExchangeRatesSlice)
-
+	if len(exchangeRatesList) > 0 {
+		currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(exchangeRatesList)
 	}
+}
 
-	if len(prvExchangeRatesSlice) > 0 {
-		prvAmount = calcMedian(prvExchangeRatesSlice)
+// aggregateFeederExchangeRates updates final exchange rates with rates submitted by feeders of exchange rates oracle.
+// Final rate of a token is median of latest rates of feeders in ExchangeRatesWindowBlocks,
+// rates deviating more than MaxPercentExchangeRateDeviation from median are rejected.
+// Final rate and its beacon height are only updated when a rate submitted at beaconHeight is not rejected.
+func aggregateFeederExchangeRates(
+	currentPortalState *CurrentPortalState,
+	beaconHeight uint64,
+	portalParams PortalParams,
+) *metadata.ExchangeRatesHistory {
+	tokenIDs := append([]string{common.PRVIDStr}, common.PortalSupportedIncTokenIDs...)
+	preRates := map[string]statedb.FinalExchangeRatesDetail{}
+	if currentPortalState.FinalExchangeRatesState != nil && currentPortalState.FinalExchangeRatesState.Rates() != nil {
+		preRates = currentPortalState.FinalExchangeRatesState.Rates()
+	}
+
+	// tokenID -> feeder address -> latest rate of feeder, rates out of window and rates of removed feeders are dropped
+	feederRates := make(map[string]map[string]statedb.FeederRate, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		feederRates[tokenID] = make(map[string]statedb.FeederRate)
+		for feederAddress, feederRate := range preRates[tokenID].FeederRates {
+			if feederRate.BeaconHeight+portalParams.ExchangeRatesWindowBlocks <= beaconHeight {
+				continue
+			}
+			if common.IndexOfStr(feederAddress, portalParams.FeederAddresses) == -1 {
+				continue
+			}
+			feederRates[tokenID][feederAddress] = feederRate
+		}
 	}
 
-	//todo: need refactor code, not need write this code
-	//update value when has exchange
-	if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
-		var btcAmountPreState uint64
-		var bnbAmountPreState uint64
-		var prvAmountPreState uint64
-		if value, ok := exchangeRatesState.Rates()[common.PortalBTCIDStr]; ok {
-			btcAmountPreState = value.Amount
-		}
+	// add rates submitted in this block in order of tx id
+	txReqIDs := make([]string, 0, len(currentPortalState.ExchangeRatesRequests))
+	for txReqID := range currentPortalState.ExchangeRatesRequests {
+		txReqIDs = append(txReqIDs, txReqID)
+	}
+	sort.Strings(txReqIDs)
 
-		if value, ok := exchangeRatesState.Rates()[common.PortalBNBIDStr]; ok {
-			bnbAmountPreState = value.Amount
+	submissions := make([]*metadata.ExchangeRatesSubmission, 0, len(txReqIDs))
+	// tokenID -> feeder address -> submission in this block
+	submittedBy := make(map[string]map[string]*metadata.ExchangeRatesSubmission, len(tokenIDs))
+	for _, txReqID := range txReqIDs {
+		req := currentPortalState.ExchangeRatesRequests[txReqID]
+		submission := &metadata.ExchangeRatesSubmission{
+			TxReqID:          txReqID,
+			SenderAddress:    req.SenderAddress,
+			Rates:            req.Rates,
+			RejectedTokenIDs: []string{},
 		}
-
-		if value, ok := exchangeRatesState.Rates()[common.PRVIDStr]; ok {
-			prvAmountPreState = value.Amount
+		submissions = append(submissions, submission)
+		for _, rate := range req.Rates {
+			if _, ok := feederRates[rate.PTokenID]; !ok {
+				continue
+			}
+			feederRates[rate.PTokenID][req.SenderAddress] = statedb.FeederRate{
+				Rate:         rate.Rate,
+				BeaconHeight: beaconHeight,
+			}
+			if submittedBy[rate.PTokenID] == nil {
+				submittedBy[rate.PTokenID] = make(map[string]*metadata.ExchangeRatesSubmission)
+			}
+			submittedBy[rate.PTokenID][req.SenderAddress] = submission
 		}
-
-		//pick current value and pre value state
-		btcAmount = choicePrice(btcAmount, btcAmountPreState)
-		bnbAmount = choicePrice(bnbAmount, bnbAmountPreState)
-		prvAmount = choicePrice(prvAmount, prvAmountPreState)
 	}
 
-	//select
-	if btcAmount > 0 {
-		exchangeRatesList[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: btcAmount,
+	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
+	finalRates := make(map[string]uint64)
+	for _, tokenID := range tokenIDs {
+		detail := statedb.FinalExchangeRatesDetail{
+			Amount:       preRates[tokenID].Amount,
+			BeaconHeight: preRates[tokenID].BeaconHeight,
+			FeederRates:  feederRates[tokenID],
+		}
+
+		feederAddresses := make([]string, 0, len(detail.FeederRates))
+		ratesSlice := make([]uint64, 0, len(detail.FeederRates))
+		for feederAddress, feederRate := range detail.FeederRates {
+			feederAddresses = append(feederAddresses, feederAddress)
+			ratesSlice = append(ratesSlice, feederRate.Rate)
+		}
+		sort.Strings(feederAddresses)
+		sort.Slice(ratesSlice, func(i, j int) bool {
+			return ratesSlice[i] < ratesSlice[j]
+		})
+
+		if len(ratesSlice) > 0 {
+			median := calcMedian(ratesSlice)
+			acceptedRates := make([]uint64, 0, len(ratesSlice))
+			isUpdated := false
+			for _, feederAddress := range feederAddresses {
+				feederRate := detail.FeederRates[feederAddress]
+				if isDeviatedRate(feederRate.Rate, median, portalParams.MaxPercentExchangeRateDeviation) {
+					if submission, ok := submittedBy[tokenID][feederAddress]; ok {
+						submission.RejectedTokenIDs = append(submission.RejectedTokenIDs, tokenID)
+					}
+					continue
+				}
+				acceptedRates = append(acceptedRates, feederRate.Rate)
+				if feederRate.BeaconHeight == beaconHeight {
+					isUpdated = true
+				}
+			}
+			if isUpdated {
+				sort.Slice(acceptedRates, func(i, j int) bool {
+					return acceptedRates[i] < acceptedRates[j]
+				})
+				detail.Amount = calcMedian(acceptedRates)
+				detail.BeaconHeight = beaconHeight
+			}
 		}
-	}
 
-	if bnbAmount > 0 {
-		exchangeRatesList[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: bnbAmount,
+		if detail.Amount > 0 || len(detail.FeederRates) > 0 {
+			exchangeRatesList[tokenID] = detail
 		}
-	}
-
-	if prvAmount > 0 {
-		exchangeRatesList[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: prvAmount,
+		if detail.Amount > 0 {
+			finalRates[tokenID] = detail.Amount
 		}
 	}
 
 	if len(exchangeRatesList) > 0 {
 		currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(exchangeRatesList)
 	}
+
+	return &metadata.ExchangeRatesHistory{
+		Submissions: submissions,
+		FinalRates:  finalRates,
+	}
+}
+
+// isDeviatedRate returns whether rate deviates more than maxPercent from median, maxPercent 0 is unlimited
+func isDeviatedRate(rate uint64, median uint64, maxPercent uint64) bool {
+	if maxPercent == 0 || median == 0 {
+		return false
+	}
+	diff := rate - median
+	if rate < median {
+		diff = median - rate
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(diff), big.NewInt(100)).Cmp(
+		new(big.Int).Mul(new(big.Int).SetUint64(median), new(big.Int).SetUint64(maxPercent))) > 0
 }
 
 func calcMedian(ratesList []uint64) uint64 {
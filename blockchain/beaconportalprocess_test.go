@@ -0,0 +1,63 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateFeederExchangeRates(t *testing.T) {
+	portalParams := PortalParams{
+		FeederAddresses:                 []string{"feederA", "feederB", "feederC"},
+		ExchangeRatesWindowBlocks:       10,
+		MaxPercentExchangeRateDeviation: 10,
+		MaxExchangeRatesAgeBlocks:       20,
+	}
+	portalState := &CurrentPortalState{
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PortalBTCIDStr: {
+				Amount:       100,
+				BeaconHeight: 95,
+				FeederRates: map[string]statedb.FeederRate{
+					"feederA": {Rate: 100, BeaconHeight: 95},
+					"feederB": {Rate: 90, BeaconHeight: 85},  // out of window
+					"feederD": {Rate: 100, BeaconHeight: 99}, // removed feeder
+				},
+			},
+		}),
+		ExchangeRatesRequests: map[string]*metadata.ExchangeRatesRequestStatus{
+			"tx1": metadata.NewExchangeRatesRequestStatus(common.PortalExchangeRatesAcceptedStatus, "feederB",
+				[]*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: 104}, {PTokenID: common.PRVIDStr, Rate: 1}}),
+			"tx2": metadata.NewExchangeRatesRequestStatus(common.PortalExchangeRatesAcceptedStatus, "feederC",
+				[]*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: 200}}),
+		},
+	}
+
+	history := aggregateFeederExchangeRates(portalState, 100, portalParams)
+	assert.Equal(t, 2, len(history.Submissions))
+	assert.Equal(t, []string{}, history.Submissions[0].RejectedTokenIDs)
+	// rate of feeder C deviates too much from median 104
+	assert.Equal(t, []string{common.PortalBTCIDStr}, history.Submissions[1].RejectedTokenIDs)
+	assert.Equal(t, map[string]uint64{common.PortalBTCIDStr: 102, common.PRVIDStr: 1}, history.FinalRates)
+
+	btcRate := portalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr]
+	assert.Equal(t, uint64(102), btcRate.Amount)
+	assert.Equal(t, uint64(100), btcRate.BeaconHeight)
+	assert.Equal(t, 3, len(btcRate.FeederRates))
+	assert.False(t, isExchangeRatesStale(portalState.FinalExchangeRatesState, common.PortalBTCIDStr, 120, portalParams))
+	assert.True(t, isExchangeRatesStale(portalState.FinalExchangeRatesState, common.PortalBTCIDStr, 121, portalParams))
+	assert.True(t, isExchangeRatesStale(portalState.FinalExchangeRatesState, common.PortalBNBIDStr, 100, portalParams))
+
+	// final rate is kept when there are no new rates
+	portalState.ExchangeRatesRequests = map[string]*metadata.ExchangeRatesRequestStatus{}
+	history = aggregateFeederExchangeRates(portalState, 106, portalParams)
+	assert.Equal(t, 0, len(history.Submissions))
+	btcRate = portalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr]
+	assert.Equal(t, uint64(102), btcRate.Amount)
+	assert.Equal(t, uint64(100), btcRate.BeaconHeight)
+	// rate of feeder A is out of window
+	assert.Equal(t, 2, len(btcRate.FeederRates))
+}
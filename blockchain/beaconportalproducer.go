@@ -3,9 +3,6 @@ package blockchain
 import (
 	"encoding/base64"
 	"encoding/json"
-	"github.com/binance-chain/go-sdk/types/msg"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
-	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 
 	//"github.com/binance-chain/go-sdk/types/msg"
 	//"github.com/incognitochain/incognito-chain/relaying/bnb"
@@ -311,8 +308,8 @@ func (blockchain *BlockChain) buildInstructionsForPortingRequest(
 
 	//get exchange rates
 	exchangeRatesState := currentPortalState.FinalExchangeRatesState
-	if exchangeRatesState == nil {
-		Logger.log.Errorf("Porting request, exchange rates not found")
+	if exchangeRatesState == nil || isExchangeRatesStale(exchangeRatesState, actionData.Meta.PTokenId, beaconHeight, portalParams) {
+		Logger.log.Errorf("Porting request, exchange rates not found or stale")
 		inst := buildRequestPortingInst(
 			actionData.Meta.Type,
 			shardID,
@@ -622,281 +619,70 @@ func (blockchain *BlockChain) buildInstructionsForReqPTokens(
 		return [][]string{inst}, nil
 	}
 
-	if meta.TokenID == common.PortalBTCIDStr {
-		btcChain := blockchain.config.BTCChain
-		if btcChain == nil {
-			Logger.log.Error("BTC relaying chain should not be null")
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-		// parse PortingProof in meta
-		btcTxProof, err := btcrelaying.ParseBTCProofFromB64EncodeStr(meta.PortingProof)
-		if err != nil {
-			Logger.log.Errorf("PortingProof is invalid %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		isValid, err := btcChain.VerifyTxWithMerkleProofs(btcTxProof)
-		if !isValid || err != nil {
-			Logger.log.Errorf("Verify btcTxProof failed %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// extract attached message from txOut's OP_RETURN
-		btcAttachedMsg, err := btcrelaying.ExtractAttachedMsgFromTx(btcTxProof.BTCTx)
-		if err != nil {
-			Logger.log.Errorf("Could not extract attached message from BTC tx proof with err: %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		encodedMsg := btcrelaying.HashAndEncodeBase58(meta.UniquePortingID)
-		if btcAttachedMsg != encodedMsg {
-			Logger.log.Errorf("PortingId in the btc attached message is not matched with portingID in metadata")
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check whether amount transfer in txBNB is equal porting amount or not
-		// check receiver and amount in tx
-		// get list matching custodians in waitingPortingRequest
-		custodians := waitingPortingRequest.Custodians()
-		outputs := btcTxProof.BTCTx.TxOut
-		for _, cusDetail := range custodians {
-			remoteAddressNeedToBeTransfer := cusDetail.RemoteAddress
-			amountNeedToBeTransfer := cusDetail.Amount
-			amountNeedToBeTransferInBTC := btcrelaying.ConvertIncPBTCAmountToExternalBTCAmount(int64(amountNeedToBeTransfer))
-
-			isChecked := false
-			for _, out := range outputs {
-				addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
-				if err != nil {
-					Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
-					continue
-				}
-				if addrStr != remoteAddressNeedToBeTransfer {
-					continue
-				}
-				if out.Value < amountNeedToBeTransferInBTC {
-					Logger.log.Errorf("BTC-TxProof is invalid - the transferred amount to %s must be equal to or greater than %d, but got %d", addrStr, amountNeedToBeTransferInBTC, out.Value)
-					inst := buildReqPTokensInst(
-						meta.UniquePortingID,
-						meta.TokenID,
-						meta.IncogAddressStr,
-						meta.PortingAmount,
-						meta.PortingProof,
-						meta.Type,
-						shardID,
-						actionData.TxReqID,
-						common.PortalReqPTokensRejectedChainStatus,
-					)
-					return [][]string{inst}, nil
-				} else {
-					isChecked = true
-					break
-				}
-			}
-			if !isChecked {
-				Logger.log.Error("BTC-TxProof is invalid")
-				inst := buildReqPTokensInst(
-					meta.UniquePortingID,
-					meta.TokenID,
-					meta.IncogAddressStr,
-					meta.PortingAmount,
-					meta.PortingProof,
-					meta.Type,
-					shardID,
-					actionData.TxReqID,
-					common.PortalReqPTokensRejectedChainStatus,
-				)
-				return [][]string{inst}, nil
-			}
-		}
-		// update holding public token for custodians
-		for _, cusDetail := range custodians {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
-			UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
-		}
-
+	relayingChain := blockchain.GetRelayingChain(meta.TokenID)
+	if relayingChain == nil {
+		Logger.log.Errorf("TokenID is not supported currently on Portal")
 		inst := buildReqPTokensInst(
-			actionData.Meta.UniquePortingID,
-			actionData.Meta.TokenID,
-			actionData.Meta.IncogAddressStr,
-			actionData.Meta.PortingAmount,
-			actionData.Meta.PortingProof,
-			actionData.Meta.Type,
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
 			shardID,
 			actionData.TxReqID,
-			common.PortalReqPTokensAcceptedChainStatus,
+			common.PortalReqPTokensRejectedChainStatus,
 		)
-
-		// remove waiting porting request from currentPortalState
-		deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
 		return [][]string{inst}, nil
+	}
 
-	} else if meta.TokenID == common.PortalBNBIDStr {
-		// parse PortingProof in meta
-		txProofBNB, err := bnb.ParseBNBProofFromB64EncodeStr(meta.PortingProof)
-		if err != nil {
-			Logger.log.Errorf("PortingProof is invalid %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check minimum confirmations block of bnb proof
-		latestBNBBlockHeight, err2 := blockchain.GetLatestBNBBlkHeight()
-		if err2 != nil {
-			Logger.log.Errorf("Can not get latest relaying bnb block height %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		if latestBNBBlockHeight < txProofBNB.BlockHeight+bnb.MinConfirmationsBlock {
-			Logger.log.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-				bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-		dataHash, err2 := blockchain.GetBNBDataHash(txProofBNB.BlockHeight)
-		if err2 != nil {
-			Logger.log.Errorf("Error when get data hash in blockHeight %v - %v\n",
-				txProofBNB.BlockHeight, err2)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// parse PortingProof in meta and verify it with relaying headers
+	relayingTx, err := relayingChain.ParseAndVerifyTxProof(meta.PortingProof)
+	if err != nil {
+		Logger.log.Errorf("PortingProof is invalid %v\n", err)
+		inst := buildReqPTokensInst(
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
+			shardID,
+			actionData.TxReqID,
+			common.PortalReqPTokensRejectedChainStatus,
+		)
+		return [][]string{inst}, nil
+	}
 
-		isValid, err := txProofBNB.Verify(dataHash)
-		if !isValid || err != nil {
-			Logger.log.Errorf("Verify txProofBNB failed %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// check memo attaches portingID req
+	if !relayingTx.IsPortingMemo(meta.UniquePortingID) {
+		Logger.log.Errorf("PortingId in memo of tx proof is not matched with portingID in metadata")
+		inst := buildReqPTokensInst(
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
+			shardID,
+			actionData.TxReqID,
+			common.PortalReqPTokensRejectedChainStatus,
+		)
+		return [][]string{inst}, nil
+	}
 
-		// parse Tx from Data in txProofBNB
-		txBNB, err := bnb.ParseTxFromData(txProofBNB.Proof.Data)
-		if err != nil {
-			Logger.log.Errorf("Data in PortingProof is invalid %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// check whether amount transfer in tx is equal porting amount or not
+	// check receiver and amount in tx
+	// get list matching custodians in waitingPortingRequest
+	custodians := waitingPortingRequest.Custodians()
+	for _, cusDetail := range custodians {
+		remoteAddressNeedToBeTransfer := cusDetail.RemoteAddress
+		amountNeedToBeTransfer := relayingChain.ConvertIncAmountToExternalAmount(cusDetail.Amount)
 
-		// check memo attach portingID req:
-		memo := txBNB.Memo
-		memoBytes, err2 := base64.StdEncoding.DecodeString(memo)
-		if err2 != nil {
-			Logger.log.Errorf("Can not decode memo in tx bnb proof", err2)
+		amountTransfer, isTransferred := relayingTx.GetAmountTo(remoteAddressNeedToBeTransfer)
+		if !isTransferred {
+			Logger.log.Errorf("TxProof is invalid - Receiver address is invalid, expected %v",
+				remoteAddressNeedToBeTransfer)
 			inst := buildReqPTokensInst(
 				meta.UniquePortingID,
 				meta.TokenID,
@@ -910,11 +696,9 @@ func (blockchain *BlockChain) buildInstructionsForReqPTokens(
 			)
 			return [][]string{inst}, nil
 		}
-
-		var portingMemo PortingMemoBNB
-		err2 = json.Unmarshal(memoBytes, &portingMemo)
-		if err2 != nil {
-			Logger.log.Errorf("Can not unmarshal memo in tx bnb proof", err2)
+		if amountTransfer < amountNeedToBeTransfer {
+			Logger.log.Errorf("TxProof is invalid - Amount transfer to %s must be equal to or greater than %d, but got %d",
+				remoteAddressNeedToBeTransfer, amountNeedToBeTransfer, amountTransfer)
 			inst := buildReqPTokensInst(
 				meta.UniquePortingID,
 				meta.TokenID,
@@ -928,121 +712,52 @@ func (blockchain *BlockChain) buildInstructionsForReqPTokens(
 			)
 			return [][]string{inst}, nil
 		}
+	}
 
-		if portingMemo.PortingID != meta.UniquePortingID {
-			Logger.log.Errorf("PortingId in memoTx is not matched with portingID in metadata", err2)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// update holding public token for custodians
+	for _, cusDetail := range custodians {
+		custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
+		UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
+	}
 
-		// check whether amount transfer in txBNB is equal porting amount or not
-		// check receiver and amount in tx
-		// get list matching custodians in waitingPortingRequest
-		custodians := waitingPortingRequest.Custodians()
-		outputs := txBNB.Msgs[0].(msg.SendMsg).Outputs
-		for _, cusDetail := range custodians {
-			remoteAddressNeedToBeTransfer := cusDetail.RemoteAddress
-			amountNeedToBeTransfer := cusDetail.Amount
-			amountNeedToBeTransferInBNB := convertIncPBNBAmountToExternalBNBAmount(int64(amountNeedToBeTransfer))
-
-			isChecked := false
-			for _, out := range outputs {
-				addr, _ := bnb.GetAccAddressString(&out.Address, blockchain.config.ChainParams.BNBRelayingHeaderChainID)
-				if addr != remoteAddressNeedToBeTransfer {
-					Logger.log.Warnf("[portal] remoteAddressNeedToBeTransfer: %v - addr: %v\n", remoteAddressNeedToBeTransfer, addr)
-					continue
-				}
+	inst := buildReqPTokensInst(
+		actionData.Meta.UniquePortingID,
+		actionData.Meta.TokenID,
+		actionData.Meta.IncogAddressStr,
+		actionData.Meta.PortingAmount,
+		actionData.Meta.PortingProof,
+		actionData.Meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalReqPTokensAcceptedChainStatus,
+	)
 
-				// calculate amount that was transferred to custodian's remote address
-				amountTransfer := int64(0)
-				for _, coin := range out.Coins {
-					if coin.Denom == bnb.DenomBNB {
-						amountTransfer += coin.Amount
-					}
-				}
-				if amountTransfer < amountNeedToBeTransferInBNB {
-					Logger.log.Errorf("TxProof-BNB is invalid - Amount transfer to %s must be equal to or greater than %d, but got %d",
-						addr, amountNeedToBeTransferInBNB, amountTransfer)
-					inst := buildReqPTokensInst(
-						meta.UniquePortingID,
-						meta.TokenID,
-						meta.IncogAddressStr,
-						meta.PortingAmount,
-						meta.PortingProof,
-						meta.Type,
-						shardID,
-						actionData.TxReqID,
-						common.PortalReqPTokensRejectedChainStatus,
-					)
-					return [][]string{inst}, nil
-				} else {
-					isChecked = true
-					break
-				}
-			}
-			if !isChecked {
-				Logger.log.Errorf("TxProof-BNB is invalid - Receiver address is invalid, expected %v",
-					remoteAddressNeedToBeTransfer)
-				inst := buildReqPTokensInst(
-					meta.UniquePortingID,
-					meta.TokenID,
-					meta.IncogAddressStr,
-					meta.PortingAmount,
-					meta.PortingProof,
-					meta.Type,
-					shardID,
-					actionData.TxReqID,
-					common.PortalReqPTokensRejectedChainStatus,
-				)
-				return [][]string{inst}, nil
-			}
-		}
+	// remove waiting porting request from currentPortalState
+	deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
+	return [][]string{inst}, nil
+}
 
-		// update holding public token for custodians
-		for _, cusDetail := range custodians {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
-			UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
-		}
+// buildExchangeRatesInst builds a new instruction from exchange rates action received from ShardToBeaconBlock
+func buildExchangeRatesInst(
+	actionData metadata.PortalExchangeRatesAction,
+	metaType int,
+	shardID byte,
+	status string,
+) []string {
+	portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
+		SenderAddress: actionData.Meta.SenderAddress,
+		Rates:         actionData.Meta.Rates,
+		TxReqID:       actionData.TxReqID,
+		LockTime:      actionData.LockTime,
+	}
 
-		inst := buildReqPTokensInst(
-			actionData.Meta.UniquePortingID,
-			actionData.Meta.TokenID,
-			actionData.Meta.IncogAddressStr,
-			actionData.Meta.PortingAmount,
-			actionData.Meta.PortingProof,
-			actionData.Meta.Type,
-			shardID,
-			actionData.TxReqID,
-			common.PortalReqPTokensAcceptedChainStatus,
-		)
+	portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
 
-		// remove waiting porting request from currentPortalState
-		deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
-		return [][]string{inst}, nil
-	} else {
-		Logger.log.Errorf("TokenID is not supported currently on Portal")
-		inst := buildReqPTokensInst(
-			meta.UniquePortingID,
-			meta.TokenID,
-			meta.IncogAddressStr,
-			meta.PortingAmount,
-			meta.PortingProof,
-			meta.Type,
-			shardID,
-			actionData.TxReqID,
-			common.PortalReqPTokensRejectedChainStatus,
-		)
-		return [][]string{inst}, nil
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(portalExchangeRatesContentBytes),
 	}
 }
 
@@ -1072,43 +787,20 @@ func (blockchain *BlockChain) buildInstructionsForExchangeRates(
 		_, ok := currentPortalState.ExchangeRatesRequests[actionData.TxReqID.String()]
 		if ok {
 			Logger.log.Errorf("ERROR: exchange rates key is duplicated")
-
-			portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
-				SenderAddress: actionData.Meta.SenderAddress,
-				Rates:         actionData.Meta.Rates,
-				TxReqID:       actionData.TxReqID,
-				LockTime:      actionData.LockTime,
-			}
-
-			portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
-
-			inst := []string{
-				strconv.Itoa(metaType),
-				strconv.Itoa(int(shardID)),
-				common.PortalExchangeRatesRejectedChainStatus,
-				string(portalExchangeRatesContentBytes),
-			}
-
+			inst := buildExchangeRatesInst(actionData, metaType, shardID, common.PortalExchangeRatesRejectedChainStatus)
 			return [][]string{inst}, nil
 		}
 	}
 
-	//success
-	portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
-		SenderAddress: actionData.Meta.SenderAddress,
-		Rates:         actionData.Meta.Rates,
-		TxReqID:       actionData.TxReqID,
-		LockTime:      actionData.LockTime,
+	// feeders of exchange rates oracle may be changed after the request was validated in shard
+	if len(portalParams.FeederAddresses) > 0 && common.IndexOfStr(actionData.Meta.SenderAddress, portalParams.FeederAddresses) == -1 {
+		Logger.log.Errorf("ERROR: sender %v is not a feeder of exchange rates", actionData.Meta.SenderAddress)
+		inst := buildExchangeRatesInst(actionData, metaType, shardID, common.PortalExchangeRatesRejectedChainStatus)
+		return [][]string{inst}, nil
 	}
 
-	portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
-
-	inst := []string{
-		strconv.Itoa(metaType),
-		strconv.Itoa(int(shardID)),
-		common.PortalExchangeRatesAcceptedChainStatus,
-		string(portalExchangeRatesContentBytes),
-	}
+	//success
+	inst := buildExchangeRatesInst(actionData, metaType, shardID, common.PortalExchangeRatesAcceptedChainStatus)
 
 	//update E-R request
 	if currentPortalState.ExchangeRatesRequests != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/pkg/errors"
 )
@@ -463,6 +464,10 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(curView *
 	// build stateful instructions
 	statefulInsts := blockchain.buildStatefulInstructions(curView.featureStateDB, statefulActionsByShardID, beaconBlock.Header.Height, rewardForCustodianByEpoch, portalParams)
 	bridgeInstructions = append(bridgeInstructions, statefulInsts...)
+	delegationInsts := blockchain.buildDelegationInstructions(curView, statefulActionsByShardID)
+	bridgeInstructions = append(bridgeInstructions, delegationInsts...)
+	doubleSignSlashingInsts := blockchain.buildDoubleSignSlashingInstructions(curView, beaconBlock.Header.Height, statefulActionsByShardID)
+	bridgeInstructions = append(bridgeInstructions, doubleSignSlashingInsts...)
 
 	tempInstruction, err := curView.GenerateInstruction(beaconBlock.Header.Height,
 		stakeInstructions, swapInstructions, stopAutoStakingInstructions,
@@ -944,6 +949,12 @@ func (beaconBestState *BeaconBestState) processInstruction(instruction []string,
 			}
 		}
 	}
+	if instruction[0] == strconv.Itoa(metadata.DoubleSignEvidenceMeta) {
+		if err := beaconBestState.processDoubleSignSlashingInstruction(blockchain, instruction, committeeChange); err != nil {
+			return err, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+		}
+		return nil, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+	}
 	if instruction[0] == SwapAction {
 		if common.IndexOfUint64(beaconBestState.BeaconHeight/blockchain.config.ChainParams.Epoch, blockchain.config.ChainParams.EpochBreakPointSwapNewKey) > -1 || len(instruction) == 7 {
 			err := beaconBestState.processSwapInstructionForKeyListV2(instruction, blockchain, committeeChange)
@@ -1456,6 +1467,16 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		return err
 	}
 
+	// execute, store delegation instructions
+	err = blockchain.processDelegationInstructions(newBestState, beaconBlock, committeeChange)
+	if err != nil {
+		return err
+	}
+	err = blockchain.processDoubleSignSlashingInstructions(newBestState, beaconBlock)
+	if err != nil {
+		return err
+	}
+
 	blockchain.processForSlashing(newBestState.slashStateDB, beaconBlock)
 
 	// Remove shard reward request of old epoch
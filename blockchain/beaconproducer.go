@@ -336,6 +336,10 @@ func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, re
 	// build stateful instructions
 	statefulInsts := blockchain.buildStatefulInstructions(beaconBestState.featureStateDB, statefulActionsByShardID, beaconBestState.BeaconHeight+1, rewardForCustodianByEpoch, portalParams)
 	bridgeInstructions = append(bridgeInstructions, statefulInsts...)
+	delegationInsts := blockchain.buildDelegationInstructions(beaconBestState, statefulActionsByShardID)
+	bridgeInstructions = append(bridgeInstructions, delegationInsts...)
+	doubleSignSlashingInsts := blockchain.buildDoubleSignSlashingInstructions(beaconBestState, beaconBestState.BeaconHeight+1, statefulActionsByShardID)
+	bridgeInstructions = append(bridgeInstructions, doubleSignSlashingInsts...)
 	return shardStates, validStakeInstructions, validSwapInstructions, bridgeInstructions, acceptedRewardInstructions, validStopAutoStakingInstructions
 }
 
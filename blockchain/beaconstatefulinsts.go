@@ -41,6 +41,12 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PDEFeeWithdrawalRequestMeta,
 			metadata.PDEPRVRequiredContributionRequestMeta,
 			metadata.PDECrossPoolTradeRequestMeta,
+			metadata.PDELimitOrderRequestMeta,
+			metadata.PDECancelLimitOrderRequestMeta,
+			metadata.DelegationMeta,
+			metadata.UnDelegationMeta,
+			metadata.SetCommissionRateMeta,
+			metadata.DoubleSignEvidenceMeta,
 			metadata.PortalCustodianDepositMeta,
 			metadata.PortalUserRegisterMeta,
 			metadata.PortalUserRequestPTokenMeta,
@@ -116,6 +122,8 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	pdeCrossPoolTradeActionsByShardID := map[byte][][]string{}
 	pdeWithdrawalActionsByShardID := map[byte][][]string{}
 	pdeFeeWithdrawalActionsByShardID := map[byte][][]string{}
+	pdeLimitOrderActionsByShardID := map[byte][][]string{}
+	pdeCancelLimitOrderActionsByShardID := map[byte][][]string{}
 
 	// portal instructions
 	portalCustodianDepositActionsByShardID := map[byte][][]string{}
@@ -177,6 +185,18 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.PDELimitOrderRequestMeta:
+				pdeLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDECancelLimitOrderRequestMeta:
+				pdeCancelLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeCancelLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PDEWithdrawalRequestMeta:
 				pdeWithdrawalActionsByShardID = groupPDEActionsByShardID(
 					pdeWithdrawalActionsByShardID,
@@ -290,6 +310,8 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		pdeCrossPoolTradeActionsByShardID,
 		pdeWithdrawalActionsByShardID,
 		pdeFeeWithdrawalActionsByShardID,
+		pdeLimitOrderActionsByShardID,
+		pdeCancelLimitOrderActionsByShardID,
 	)
 
 	if err != nil {
@@ -399,15 +421,22 @@ func prepareInfoForSorting(
 		return tradingFee, sellAmount
 	}
 	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, tradeMeta.TokenIDToSellStr))
-	poolPair, _ := currentPDEState.PDEPoolPairs[poolPairKey]
+	poolPair, found := currentPDEState.PDEPoolPairs[poolPairKey]
+	if !found || poolPair == nil {
+		// best route trades could sell a token that has no PRV pool
+		return tradingFee, sellAmount
+	}
 	sellAmount, _, _ = calcTradeValue(poolPair, tradeMeta.TokenIDToSellStr, sellAmount)
 	return tradingFee, sellAmount
 }
 
+// categorizeNSortPDECrossPoolTradeInstsByFee splits trades by their pools existing,
+// best route trades are checked against any route only if best route is enabled, otherwise they are routed via PRV pools
 func categorizeNSortPDECrossPoolTradeInstsByFee(
 	beaconHeight uint64,
 	currentPDEState *CurrentPDEState,
 	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
+	isBestRouteEnabled bool,
 ) ([]metadata.PDECrossPoolTradeRequestAction, []metadata.PDECrossPoolTradeRequestAction) {
 	prvIDStr := common.PRVCoinID.String()
 	tradableActions := []metadata.PDECrossPoolTradeRequestAction{}
@@ -434,6 +463,14 @@ func categorizeNSortPDECrossPoolTradeInstsByFee(
 				continue
 			}
 			tradeMeta := crossPoolTradeRequestAction.Meta
+			if tradeMeta.UseBestRoute && isBestRouteEnabled {
+				if !isPDETradeRouteExisting(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr, tradeMeta.SellAmount) {
+					untradableActions = append(untradableActions, crossPoolTradeRequestAction)
+					continue
+				}
+				tradableActions = append(tradableActions, crossPoolTradeRequestAction)
+				continue
+			}
 			if (isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) && !isPoolPairExisting(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr)) ||
 			(!isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) && (!isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tradeMeta.TokenIDToSellStr) || !isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tradeMeta.TokenIDToBuyStr))) {
 				untradableActions = append(untradableActions, crossPoolTradeRequestAction)
@@ -559,6 +596,8 @@ func (blockchain *BlockChain) handlePDEInsts(
 	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
 	pdeWithdrawalActionsByShardID map[byte][][]string,
 	pdeFeeWithdrawalActionsByShardID map[byte][][]string,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
 ) ([][]string, error) {
 	instructions := [][]string{}
 
@@ -603,11 +642,20 @@ func (blockchain *BlockChain) handlePDEInsts(
 		}
 	}
 
+	// handle limit order book: cancellation, new orders then expiry, resting orders are matched along with cross pool trades
+	cancelLimitOrderInsts := blockchain.buildInstsForPDECancelLimitOrders(currentPDEState, beaconHeight, pdeCancelLimitOrderActionsByShardID)
+	instructions = append(instructions, cancelLimitOrderInsts...)
+	limitOrderInsts := blockchain.buildInstsForPDELimitOrders(currentPDEState, beaconHeight, pdeLimitOrderActionsByShardID)
+	instructions = append(instructions, limitOrderInsts...)
+	expiredLimitOrderInsts := blockchain.buildInstsForExpiredPDELimitOrders(currentPDEState, beaconHeight)
+	instructions = append(instructions, expiredLimitOrderInsts...)
+
 	// handle cross pool trade
 	sortedTradableActions, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(
 		beaconHeight,
 		currentPDEState,
 		pdeCrossPoolTradeActionsByShardID,
+		blockchain.isPDEBestRouteEnabled(beaconHeight),
 	)
 	tradableInsts, tradingFeeByPair := blockchain.buildInstsForSortedTradableActions(currentPDEState, beaconHeight, sortedTradableActions)
 	untradableInsts := blockchain.buildInstsForUntradableActions(untradableActions)
@@ -41,6 +41,13 @@ const (
 	burningAddress2 = "12RxahVABnAVCGP3LGwCn8jkQxgw7z1x14wztHzn455TTVpi1wBq9YGwkRMQg3J4e657AbAnCvYCJSdA9czBUNuCKwGSRQt55Xwz8WA"
 )
 
+// limits of the pdex limit order book, bounding the work of beacon on every block
+const (
+	MaxPDELimitOrdersInBook          = 10000 // new orders are rejected when the book is full
+	MaxPDELimitOrdersScannedPerBlock = 200   // resting orders priced per beacon block, in a rotating window of the book
+	MaxPDELimitOrdersMatchedPerBlock = 50
+)
+
 // CONSTANT for network MAINNET
 const (
 	// ------------- Mainnet ---------------------------------------------
@@ -202,6 +209,24 @@ var TestnetReplaceCommitteeEpoch = []uint64{}
 var IsTestNet = true
 var IsTestNet2 = false
 
+// AccountKey, KeyList and KeyListV2 are formats of keylist.json and keylist-v2.json
+type AccountKey struct {
+	PrivateKey     string
+	PaymentAddress string
+	// PubKey     string
+	CommitteePublicKey string
+}
+
+type KeyList struct {
+	Shard  map[int][]AccountKey
+	Beacon []AccountKey
+}
+type KeyListV2 struct {
+	Epoch  uint64
+	Shard  map[int][]AccountKey
+	Beacon []AccountKey
+}
+
 func init() {
 	if len(os.Args) > 0 && (strings.Contains(os.Args[0], "test") || strings.Contains(os.Args[0], "Test")) {
 		return
@@ -220,23 +245,6 @@ func init() {
 		panic(err)
 	}
 
-	type AccountKey struct {
-		PrivateKey     string
-		PaymentAddress string
-		// PubKey     string
-		CommitteePublicKey string
-	}
-
-	type KeyList struct {
-		Shard  map[int][]AccountKey
-		Beacon []AccountKey
-	}
-	type KeyListV2 struct {
-		Epoch  uint64
-		Shard  map[int][]AccountKey
-		Beacon []AccountKey
-	}
-
 	keylist := KeyList{}
 	keylistV2 := []KeyListV2{}
 
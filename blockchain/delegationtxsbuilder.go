@@ -0,0 +1,44 @@
+package blockchain
+
+import (
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// buildReturnDelegationTx pays back the amount of a rejected delegation or of a delegation returned after unbonding
+func (blockGenerator *BlockGenerator) buildReturnDelegationTx(
+	inst []string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+) (metadata.Transaction, error) {
+	delegationTxID, delegatorAddressStr, amount, instShardID, ok := metadata.ParseDelegationReturnInst(inst)
+	if !ok || instShardID != shardID {
+		return nil, nil
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(delegatorAddressStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while deserializing delegator address string: %+v", err)
+		return nil, nil
+	}
+	receiverAddr := keyWallet.KeySet.PaymentAddress
+	meta := metadata.NewReturnDelegationMetadata(
+		delegationTxID,
+		metadata.ReturnDelegationMeta,
+	)
+	resTx := new(transaction.Tx)
+	err = resTx.InitTxSalary(
+		amount,
+		&receiverAddr,
+		producerPrivateKey,
+		shardView.GetCopiedTransactionStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing return delegation (normal) tx: %+v", err)
+		return nil, nil
+	}
+	return resTx, nil
+}
@@ -182,6 +182,8 @@ const (
 	GetShardBlockHeightByHashError
 	GetShardBlockByHashError
 	ResponsedTransactionFromBeaconInstructionsError
+	ProcessDelegationInstructionError
+	ProcessDoubleSignSlashingInstructionError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -346,6 +348,8 @@ var ErrCodeMessage = map[int]struct {
 	GetShardBlockHeightByHashError:                    {-1155, "Get Shard Block Height By Hash Error"},
 	GetShardBlockByHashError:                          {-1156, "Get Shard Block By Hash Error"},
 	ShardStakingTxRootHashError:                       {-1157, "Build Shard StakingTX error"},
+	ProcessDelegationInstructionError:                 {-1158, "Process Delegation Instruction Error"},
+	ProcessDoubleSignSlashingInstructionError:         {-1159, "Process Double Sign Slashing Instruction Error"},
 	GetListOutputCoinsByKeysetError:                   {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                     {-3000, "Get Total Locked Collateral Error"},
 	ResponsedTransactionFromBeaconInstructionsError:   {-3100, "Build Transaction Response From Beacon Instructions Error"},
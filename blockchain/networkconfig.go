@@ -0,0 +1,324 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigDuration is a time.Duration which is written as a string (e.g. "10s") in network config files
+type ConfigDuration time.Duration
+
+func (d ConfigDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *ConfigDuration) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+	value, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = ConfigDuration(value)
+	return nil
+}
+
+// NetworkGenesisConfig defines the genesis blocks of a custom network
+type NetworkGenesisConfig struct {
+	BlockTime        string   // genesis block time, e.g. "2020-01-01T00:00:00.000Z"
+	KeyListFile      string   // keylist.json formatted file of initial committees, relative to the network config file
+	InitialIncognito []string // serialized salary txs of initial PRV allocations
+	FeePerTxKb       uint64
+}
+
+/*
+NetworkConfig defines a custom network (e.g. a local devnet) in a json or yaml file.
+Params of the Base network (mainnet, testnet or testnet-2) are used for fields not set in the file.
+Durations of PortalParams are in nanoseconds.
+*/
+type NetworkConfig struct {
+	Base                             string
+	Name                             string
+	Net                              uint32
+	DefaultPort                      string
+	RPCPort                          string
+	WSPort                           string
+	MaxShardCommitteeSize            int
+	MinShardCommitteeSize            int
+	MaxBeaconCommitteeSize           int
+	MinBeaconCommitteeSize           int
+	MinShardBlockInterval            ConfigDuration
+	MaxShardBlockCreation            ConfigDuration
+	MinBeaconBlockInterval           ConfigDuration
+	MaxBeaconBlockCreation           ConfigDuration
+	StakingAmountShard               uint64
+	ActiveShards                     int
+	BasicReward                      uint64
+	Epoch                            uint64
+	RandomTime                       uint64
+	SlashLevels                      []SlashLevel
+	EthContractAddressStr            string
+	Offset                           int
+	SwapOffset                       int
+	AssignOffset                     int
+	IncognitoDAOAddress              string
+	CentralizedWebsitePaymentAddress string
+	ConsensusV2Epoch                 uint64
+	BeaconHeightBreakPointBurnAddr   uint64
+	PortalParams                     map[uint64]PortalParams
+	PortalFeederAddress              string
+	ReplaceStakingTxHeight           uint64
+	BCHeightBreakPointFixRandShardCM uint64
+	BeaconHeightBreakPointPrivacyV2  uint64
+	DoubleSignSlashingPercent        uint64
+	BeaconHeightBreakPointSlashing   uint64
+	BeaconHeightBreakPointLimitOrder uint64
+	BeaconHeightBreakPointBestRoute  uint64
+	BeaconHeightBreakPointDelegation uint64
+	Genesis                          NetworkGenesisConfig
+
+	path string
+}
+
+func getBaseNetworkParams(base string) (*Params, error) {
+	switch base {
+	case MainetName:
+		return &ChainMainParam, nil
+	case TestnetName, "":
+		return &ChainTestParam, nil
+	case Testnet2Name:
+		return &ChainTest2Param, nil
+	}
+	return nil, fmt.Errorf("base network %v is not supported", base)
+}
+
+// NewNetworkConfig returns the network config of base network (mainnet, testnet or testnet-2)
+func NewNetworkConfig(base string) (*NetworkConfig, error) {
+	params, err := getBaseNetworkParams(base)
+	if err != nil {
+		return nil, err
+	}
+	portalParams := make(map[uint64]PortalParams, len(params.PortalParams))
+	for beaconHeight, p := range params.PortalParams {
+		portalParams[beaconHeight] = p
+	}
+	return &NetworkConfig{
+		Base:                             base,
+		Name:                             params.Name,
+		Net:                              params.Net,
+		DefaultPort:                      params.DefaultPort,
+		MaxShardCommitteeSize:            params.MaxShardCommitteeSize,
+		MinShardCommitteeSize:            params.MinShardCommitteeSize,
+		MaxBeaconCommitteeSize:           params.MaxBeaconCommitteeSize,
+		MinBeaconCommitteeSize:           params.MinBeaconCommitteeSize,
+		MinShardBlockInterval:            ConfigDuration(params.MinShardBlockInterval),
+		MaxShardBlockCreation:            ConfigDuration(params.MaxShardBlockCreation),
+		MinBeaconBlockInterval:           ConfigDuration(params.MinBeaconBlockInterval),
+		MaxBeaconBlockCreation:           ConfigDuration(params.MaxBeaconBlockCreation),
+		StakingAmountShard:               params.StakingAmountShard,
+		ActiveShards:                     params.ActiveShards,
+		BasicReward:                      params.BasicReward,
+		Epoch:                            params.Epoch,
+		RandomTime:                       params.RandomTime,
+		SlashLevels:                      append([]SlashLevel{}, params.SlashLevels...),
+		EthContractAddressStr:            params.EthContractAddressStr,
+		Offset:                           params.Offset,
+		SwapOffset:                       params.SwapOffset,
+		AssignOffset:                     params.AssignOffset,
+		IncognitoDAOAddress:              params.IncognitoDAOAddress,
+		CentralizedWebsitePaymentAddress: params.CentralizedWebsitePaymentAddress,
+		ConsensusV2Epoch:                 params.ConsensusV2Epoch,
+		BeaconHeightBreakPointBurnAddr:   params.BeaconHeightBreakPointBurnAddr,
+		PortalParams:                     portalParams,
+		PortalFeederAddress:              params.PortalFeederAddress,
+		ReplaceStakingTxHeight:           params.ReplaceStakingTxHeight,
+		BCHeightBreakPointFixRandShardCM: params.BCHeightBreakPointFixRandShardCM,
+		BeaconHeightBreakPointPrivacyV2:  params.BeaconHeightBreakPointPrivacyV2,
+		DoubleSignSlashingPercent:        params.DoubleSignSlashingPercent,
+		BeaconHeightBreakPointSlashing:   params.BeaconHeightBreakPointSlashing,
+		BeaconHeightBreakPointLimitOrder: params.BeaconHeightBreakPointLimitOrder,
+		BeaconHeightBreakPointBestRoute:  params.BeaconHeightBreakPointBestRoute,
+		BeaconHeightBreakPointDelegation: params.BeaconHeightBreakPointDelegation,
+		Genesis: NetworkGenesisConfig{
+			FeePerTxKb: params.GenesisParams.FeePerTxKb,
+		},
+	}, nil
+}
+
+// convertYAMLToJSONValue converts maps decoded by yaml to the ones json can encode
+func convertYAMLToJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[fmt.Sprintf("%v", key)] = convertYAMLToJSONValue(item)
+		}
+		return res
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertYAMLToJSONValue(item)
+		}
+	}
+	return value
+}
+
+// LoadNetworkConfig reads a network config file, yaml files (.yaml, .yml) have the same keys as json ones
+func LoadNetworkConfig(path string) (*NetworkConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		var value interface{}
+		err = yaml.Unmarshal(data, &value)
+		if err != nil {
+			return nil, err
+		}
+		data, err = json.Marshal(convertYAMLToJSONValue(value))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var base struct{ Base string }
+	err = json.Unmarshal(data, &base)
+	if err != nil {
+		return nil, err
+	}
+	netConfig, err := NewNetworkConfig(base.Base)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, netConfig)
+	if err != nil {
+		return nil, err
+	}
+	netConfig.path = path
+	return netConfig, nil
+}
+
+// ChainParams builds params of the custom network, including its genesis blocks
+func (netConfig *NetworkConfig) ChainParams() (*Params, error) {
+	baseParams, err := getBaseNetworkParams(netConfig.Base)
+	if err != nil {
+		return nil, err
+	}
+	if netConfig.Name == baseParams.Name {
+		return nil, fmt.Errorf("name of custom network must be different from base network %v", baseParams.Name)
+	}
+	if netConfig.ActiveShards <= 0 || netConfig.ActiveShards > common.MaxShardNumber {
+		return nil, fmt.Errorf("number of active shards %v is invalid", netConfig.ActiveShards)
+	}
+	if netConfig.Genesis.KeyListFile == "" {
+		return nil, errors.New("key list file of genesis committees is required")
+	}
+	if _, err := time.Parse("2006-01-02T15:04:05.000Z", netConfig.Genesis.BlockTime); err != nil {
+		return nil, fmt.Errorf("genesis block time %v is invalid: %v", netConfig.Genesis.BlockTime, err)
+	}
+
+	genesisParams, err := netConfig.genesisParams()
+	if err != nil {
+		return nil, err
+	}
+	params := *baseParams
+	params.Name = netConfig.Name
+	params.Net = netConfig.Net
+	params.DefaultPort = netConfig.DefaultPort
+	params.GenesisParams = genesisParams
+	params.MaxShardCommitteeSize = netConfig.MaxShardCommitteeSize
+	params.MinShardCommitteeSize = netConfig.MinShardCommitteeSize
+	params.MaxBeaconCommitteeSize = netConfig.MaxBeaconCommitteeSize
+	params.MinBeaconCommitteeSize = netConfig.MinBeaconCommitteeSize
+	params.MinShardBlockInterval = time.Duration(netConfig.MinShardBlockInterval)
+	params.MaxShardBlockCreation = time.Duration(netConfig.MaxShardBlockCreation)
+	params.MinBeaconBlockInterval = time.Duration(netConfig.MinBeaconBlockInterval)
+	params.MaxBeaconBlockCreation = time.Duration(netConfig.MaxBeaconBlockCreation)
+	params.StakingAmountShard = netConfig.StakingAmountShard
+	params.ActiveShards = netConfig.ActiveShards
+	params.GenesisBeaconBlock = CreateBeaconGenesisBlock(1, uint16(netConfig.Net), netConfig.Genesis.BlockTime, genesisParams)
+	params.GenesisShardBlock = CreateShardGenesisBlock(1, uint16(netConfig.Net), netConfig.Genesis.BlockTime, genesisParams)
+	params.BasicReward = netConfig.BasicReward
+	params.Epoch = netConfig.Epoch
+	params.RandomTime = netConfig.RandomTime
+	params.SlashLevels = netConfig.SlashLevels
+	params.EthContractAddressStr = netConfig.EthContractAddressStr
+	params.Offset = netConfig.Offset
+	params.SwapOffset = netConfig.SwapOffset
+	params.AssignOffset = netConfig.AssignOffset
+	params.IncognitoDAOAddress = netConfig.IncognitoDAOAddress
+	params.CentralizedWebsitePaymentAddress = netConfig.CentralizedWebsitePaymentAddress
+	params.CheckForce = false
+	params.ConsensusV2Epoch = netConfig.ConsensusV2Epoch
+	params.BeaconHeightBreakPointBurnAddr = netConfig.BeaconHeightBreakPointBurnAddr
+	params.PortalParams = netConfig.PortalParams
+	params.PortalFeederAddress = netConfig.PortalFeederAddress
+	params.EpochBreakPointSwapNewKey = []uint64{}
+	params.IsBackup = false
+	params.PreloadAddress = ""
+	params.ReplaceStakingTxHeight = netConfig.ReplaceStakingTxHeight
+	params.BCHeightBreakPointFixRandShardCM = netConfig.BCHeightBreakPointFixRandShardCM
+	params.BeaconHeightBreakPointPrivacyV2 = netConfig.BeaconHeightBreakPointPrivacyV2
+	params.DoubleSignSlashingPercent = netConfig.DoubleSignSlashingPercent
+	params.BeaconHeightBreakPointSlashing = netConfig.BeaconHeightBreakPointSlashing
+	params.BeaconHeightBreakPointLimitOrder = netConfig.BeaconHeightBreakPointLimitOrder
+	params.BeaconHeightBreakPointBestRoute = netConfig.BeaconHeightBreakPointBestRoute
+	params.BeaconHeightBreakPointDelegation = netConfig.BeaconHeightBreakPointDelegation
+	return &params, nil
+}
+
+// genesisParams picks the first MinBeaconCommitteeSize beacon keys and MinShardCommitteeSize keys of each shard
+// in the key list file as genesis committees
+func (netConfig *NetworkConfig) genesisParams() (*GenesisParams, error) {
+	keyListFile := netConfig.Genesis.KeyListFile
+	if !filepath.IsAbs(keyListFile) && netConfig.path != "" {
+		keyListFile = filepath.Join(filepath.Dir(netConfig.path), keyListFile)
+	}
+	keyData, err := ioutil.ReadFile(keyListFile)
+	if err != nil {
+		return nil, err
+	}
+	keyList := KeyList{}
+	err = json.Unmarshal(keyData, &keyList)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisParams := &GenesisParams{
+		InitialIncognito:                           netConfig.Genesis.InitialIncognito,
+		FeePerTxKb:                                 netConfig.Genesis.FeePerTxKb,
+		SelectBeaconNodeSerializedPubkeyV2:         make(map[uint64][]string),
+		SelectBeaconNodeSerializedPaymentAddressV2: make(map[uint64][]string),
+		SelectShardNodeSerializedPubkeyV2:          make(map[uint64][]string),
+		SelectShardNodeSerializedPaymentAddressV2:  make(map[uint64][]string),
+		ConsensusAlgorithm:                         ChainTestParam.GenesisParams.ConsensusAlgorithm,
+	}
+	if len(keyList.Beacon) < netConfig.MinBeaconCommitteeSize {
+		return nil, fmt.Errorf("key list has %v beacon keys, expect at least %v", len(keyList.Beacon), netConfig.MinBeaconCommitteeSize)
+	}
+	for i := 0; i < netConfig.MinBeaconCommitteeSize; i++ {
+		genesisParams.PreSelectBeaconNodeSerializedPubkey = append(genesisParams.PreSelectBeaconNodeSerializedPubkey, keyList.Beacon[i].CommitteePublicKey)
+		genesisParams.PreSelectBeaconNodeSerializedPaymentAddress = append(genesisParams.PreSelectBeaconNodeSerializedPaymentAddress, keyList.Beacon[i].PaymentAddress)
+	}
+	for i := 0; i < netConfig.ActiveShards; i++ {
+		if len(keyList.Shard[i]) < netConfig.MinShardCommitteeSize {
+			return nil, fmt.Errorf("key list has %v keys of shard %v, expect at least %v", len(keyList.Shard[i]), i, netConfig.MinShardCommitteeSize)
+		}
+		for j := 0; j < netConfig.MinShardCommitteeSize; j++ {
+			genesisParams.PreSelectShardNodeSerializedPubkey = append(genesisParams.PreSelectShardNodeSerializedPubkey, keyList.Shard[i][j].CommitteePublicKey)
+			genesisParams.PreSelectShardNodeSerializedPaymentAddress = append(genesisParams.PreSelectShardNodeSerializedPaymentAddress, keyList.Shard[i][j].PaymentAddress)
+		}
+	}
+	return genesisParams, nil
+}
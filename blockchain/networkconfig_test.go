@@ -0,0 +1,70 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadNetworkConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "networkconfig")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keyList := `{
+	"Beacon": [{"PaymentAddress": "beacon0", "CommitteePublicKey": "beaconKey0"}, {"PaymentAddress": "beacon1", "CommitteePublicKey": "beaconKey1"}],
+	"Shard": {
+		"0": [{"PaymentAddress": "shard00", "CommitteePublicKey": "shardKey00"}],
+		"1": [{"PaymentAddress": "shard10", "CommitteePublicKey": "shardKey10"}]
+	}
+}`
+	netConfigYAML := `
+Name: devnet
+Net: 100
+ActiveShards: 2
+MinShardCommitteeSize: 1
+MinBeaconCommitteeSize: 2
+MinShardBlockInterval: 5s
+PortalParams:
+  10:
+    MaxPercentCustodianRewards: 5
+Genesis:
+  BlockTime: "2020-01-01T00:00:00.000Z"
+  KeyListFile: keylist.json
+`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "keylist.json"), []byte(keyList), 0644))
+	netConfigFile := filepath.Join(dir, "network.yaml")
+	assert.Nil(t, ioutil.WriteFile(netConfigFile, []byte(netConfigYAML), 0644))
+
+	netConfig, err := LoadNetworkConfig(netConfigFile)
+	assert.Nil(t, err)
+	params, err := netConfig.ChainParams()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "devnet", params.Name)
+	assert.Equal(t, uint32(100), params.Net)
+	assert.Equal(t, 2, params.ActiveShards)
+	assert.Equal(t, 5*time.Second, params.MinShardBlockInterval)
+	// fields not set in the file are the ones of base network
+	assert.Equal(t, ChainTestParam.MaxShardBlockCreation, params.MaxShardBlockCreation)
+	assert.Equal(t, ChainTestParam.StakingAmountShard, params.StakingAmountShard)
+	assert.Equal(t, 2, len(params.PortalParams))
+	assert.Equal(t, uint64(5), params.PortalParams[10].MaxPercentCustodianRewards)
+	assert.Equal(t, 1, len(ChainTestParam.PortalParams))
+
+	assert.Equal(t, []string{"beaconKey0", "beaconKey1"}, params.GenesisParams.PreSelectBeaconNodeSerializedPubkey)
+	assert.Equal(t, []string{"shardKey00", "shardKey10"}, params.GenesisParams.PreSelectShardNodeSerializedPubkey)
+	assert.Equal(t, []string{"shard00", "shard10"}, params.GenesisParams.PreSelectShardNodeSerializedPaymentAddress)
+	assert.NotEqual(t, ChainTestParam.GenesisBeaconBlock.Hash(), params.GenesisBeaconBlock.Hash())
+
+	netConfig.MinBeaconCommitteeSize = 3
+	_, err = netConfig.ChainParams()
+	assert.NotNil(t, err)
+	netConfig.Name = ChainTestParam.Name
+	_, err = netConfig.ChainParams()
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"math"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -23,6 +24,12 @@ type PortalParams struct {
 	TP130                                uint64
 	MinPercentPortingFee                 float64
 	MinPercentRedeemFee                  float64
+
+	// exchange rates oracle, it is enabled when FeederAddresses is not empty
+	FeederAddresses                 []string // feeders who can submit exchange rates
+	ExchangeRatesWindowBlocks       uint64   // latest rate of a feeder is aggregated in this number of beacon blocks
+	MaxPercentExchangeRateDeviation uint64   // rates deviating more than this percentage from median of feeders are rejected, 0 is unlimited
+	MaxExchangeRatesAgeBlocks       uint64   // porting and redeem are paused when final rates are older than this number of beacon blocks, 0 is unlimited
 }
 
 /*
@@ -74,6 +81,12 @@ type Params struct {
 	PreloadAddress                   string
 	ReplaceStakingTxHeight           uint64
 	BCHeightBreakPointFixRandShardCM uint64
+	BeaconHeightBreakPointPrivacyV2  uint64 // privacy v2 txs are accepted from this beacon height
+	DoubleSignSlashingPercent        uint64 // percent of the shard staking amount slashed from a validator signing two conflicting blocks
+	BeaconHeightBreakPointSlashing   uint64 // double sign evidences are accepted and slashed from this beacon height
+	BeaconHeightBreakPointLimitOrder uint64 // pdex limit orders are accepted from this beacon height
+	BeaconHeightBreakPointBestRoute  uint64 // pdex cross pool trades could use the best route from this beacon height
+	BeaconHeightBreakPointDelegation uint64 // delegations to validators are accepted and rewarded from this beacon height
 }
 
 type GenesisParams struct {
@@ -181,6 +194,12 @@ func init() {
 		IsBackup:                         false,
 		PreloadAddress:                   "",
 		BCHeightBreakPointFixRandShardCM: 2070000,
+		BeaconHeightBreakPointPrivacyV2:  2500000,
+		DoubleSignSlashingPercent:        10,
+		BeaconHeightBreakPointSlashing:   2500000,
+		BeaconHeightBreakPointLimitOrder: 2500000,
+		BeaconHeightBreakPointBestRoute:  2500000,
+		BeaconHeightBreakPointDelegation: 2500000,
 	}
 	// END TESTNET
 
@@ -263,6 +282,12 @@ func init() {
 		IsBackup:                         false,
 		PreloadAddress:                   "",
 		BCHeightBreakPointFixRandShardCM: 120000,
+		BeaconHeightBreakPointPrivacyV2:  200000,
+		DoubleSignSlashingPercent:        10,
+		BeaconHeightBreakPointSlashing:   200000,
+		BeaconHeightBreakPointLimitOrder: 200000,
+		BeaconHeightBreakPointBestRoute:  200000,
+		BeaconHeightBreakPointDelegation: 200000,
 	}
 	// END TESTNET-2
 
@@ -344,6 +369,12 @@ func init() {
 		IsBackup:                         false,
 		PreloadAddress:                   "",
 		BCHeightBreakPointFixRandShardCM: 644000,
+		BeaconHeightBreakPointPrivacyV2:  math.MaxUint64,
+		DoubleSignSlashingPercent:        10,
+		BeaconHeightBreakPointSlashing:   math.MaxUint64,
+		BeaconHeightBreakPointLimitOrder: math.MaxUint64,
+		BeaconHeightBreakPointBestRoute:  math.MaxUint64,
+		BeaconHeightBreakPointDelegation: math.MaxUint64,
 	}
 	if IsTestNet {
 		if !IsTestNet2 {
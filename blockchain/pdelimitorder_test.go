@@ -0,0 +1,239 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	limitOrderTestTokenIDStr = "0000000000000000000000000000000000000000000000000000000000000100"
+	limitOrderTestTrader     = "12RuEdPjq4yxivzm8xPxRVHmkL74t4eAdUKPdKKhMEnpxPH3k8GEyULbwq4hjwHWmHQr7MmGBJsMpdCHsYAqNE18jipWQwciBf9yqvQ"
+)
+
+func buildPDELimitOrderReqAction(
+	orderID common.Hash,
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	expiryBeaconHeight uint64,
+) []string {
+	meta, _ := metadata.NewPDELimitOrderRequest(
+		tokenIDToBuyStr,
+		tokenIDToSellStr,
+		sellAmount,
+		minAcceptableAmount,
+		tradingFee,
+		limitOrderTestTrader,
+		expiryBeaconHeight,
+		metadata.PDELimitOrderRequestMeta,
+	)
+	actionContent := metadata.PDELimitOrderRequestAction{
+		Meta:    *meta,
+		TxReqID: orderID,
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDELimitOrderRequestMeta), actionContentBase64Str}
+}
+
+func buildPDECancelLimitOrderReqAction(orderID common.Hash, traderAddressStr string) []string {
+	meta, _ := metadata.NewPDECancelLimitOrderRequest(orderID, traderAddressStr, metadata.PDECancelLimitOrderRequestMeta)
+	actionContent := metadata.PDECancelLimitOrderRequestAction{
+		Meta:    *meta,
+		TxReqID: common.HashH([]byte("cancel" + orderID.String())),
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta), actionContentBase64Str}
+}
+
+func newLimitOrderTestBlockChain(breakPoint uint64) *BlockChain {
+	return &BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointLimitOrder: breakPoint}}}
+}
+
+func newLimitOrderTestPDEState(beaconHeight uint64) *CurrentPDEState {
+	return newLimitOrderTestPDEStateWithPool(beaconHeight, 1e9, 1e9)
+}
+
+func newLimitOrderTestPDEStateWithPool(beaconHeight uint64, prvPoolValue uint64, tokenPoolValue uint64) *CurrentPDEState {
+	prvIDStr := common.PRVCoinID.String()
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, limitOrderTestTokenIDStr))
+	return &CurrentPDEState{
+		WaitingPDEContributions:        make(map[string]*rawdbv2.PDEContribution),
+		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		PDEPoolPairs: map[string]*rawdbv2.PDEPoolForPair{
+			poolPairKey: rawdbv2.NewPDEPoolForPair(prvIDStr, prvPoolValue, limitOrderTestTokenIDStr, tokenPoolValue),
+		},
+		PDEShares:             make(map[string]uint64),
+		PDETradingFees:        make(map[string]uint64),
+		PDELimitOrders:        make(map[string]*rawdbv2.PDELimitOrder),
+		DeletedPDELimitOrders: make(map[string]*rawdbv2.PDELimitOrder),
+	}
+}
+
+func TestBuildInstsForPDELimitOrders(t *testing.T) {
+	bc := newLimitOrderTestBlockChain(0)
+	beaconHeight := uint64(100)
+	pdeState := newLimitOrderTestPDEState(beaconHeight)
+	prvIDStr := common.PRVCoinID.String()
+
+	restingOrderID := common.HashH([]byte("resting"))
+	expiredOrderID := common.HashH([]byte("expired"))
+	untradableOrderID := common.HashH([]byte("untradable"))
+	dustOrderID := common.HashH([]byte("dust"))
+	noExpiryOrderID := common.HashH([]byte("no expiry"))
+	farExpiryOrderID := common.HashH([]byte("far expiry"))
+	actions := map[byte][][]string{
+		1: {
+			buildPDELimitOrderReqAction(restingOrderID, limitOrderTestTokenIDStr, prvIDStr, 1e6, 2e6, 10, 200),
+			buildPDELimitOrderReqAction(expiredOrderID, limitOrderTestTokenIDStr, prvIDStr, 1e6, 9e5, 10, 100),
+			buildPDELimitOrderReqAction(untradableOrderID, common.HashH([]byte("unknown")).String(), prvIDStr, 1e6, 9e5, 0, 200),
+			buildPDELimitOrderReqAction(dustOrderID, limitOrderTestTokenIDStr, prvIDStr, 1e3, 1, 0, 200),
+			buildPDELimitOrderReqAction(noExpiryOrderID, limitOrderTestTokenIDStr, prvIDStr, 1e6, 9e5, 0, 0),
+			buildPDELimitOrderReqAction(farExpiryOrderID, limitOrderTestTokenIDStr, prvIDStr, 1e6, 9e5, 0, beaconHeight+2+metadata.MaxPDELimitOrderLifetime),
+		},
+	}
+	insts := bc.buildInstsForPDELimitOrders(pdeState, beaconHeight, actions)
+	// waiting + (fee refund + selling token refund) + 4 selling token refunds
+	assert.Equal(t, 7, len(insts))
+	assert.Equal(t, common.PDELimitOrderWaitingChainStatus, insts[0][2])
+	assert.Equal(t, common.PDELimitOrderFeeRefundChainStatus, insts[1][2])
+	for i, orderID := range []common.Hash{untradableOrderID, dustOrderID, noExpiryOrderID, farExpiryOrderID} {
+		assert.Equal(t, common.PDELimitOrderSellingTokenRefundChainStatus, insts[i+3][2])
+		var refundContent metadata.PDELimitOrderRefundContent
+		assert.Nil(t, json.Unmarshal([]byte(insts[i+3][3]), &refundContent))
+		assert.Equal(t, orderID, refundContent.OrderID)
+		assert.Equal(t, common.PDELimitOrderRejectedChainStatus, refundContent.Reason)
+	}
+
+	assert.Equal(t, 1, len(pdeState.PDELimitOrders))
+	orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, restingOrderID.String()))
+	assert.NotNil(t, pdeState.PDELimitOrders[orderKey])
+
+	// min acceptable amount is higher than the pool could pay, the order keeps resting
+	tradingFeeByPair := map[string]uint64{}
+	matchedInsts := bc.buildInstsForMatchedPDELimitOrders(pdeState, beaconHeight, tradingFeeByPair)
+	assert.Equal(t, 0, len(matchedInsts))
+	assert.Equal(t, 1, len(pdeState.PDELimitOrders))
+}
+
+func TestBuildInstsForMatchedPDELimitOrders(t *testing.T) {
+	bc := newLimitOrderTestBlockChain(0)
+	beaconHeight := uint64(100)
+	pdeState := newLimitOrderTestPDEState(beaconHeight)
+	prvIDStr := common.PRVCoinID.String()
+
+	lowFeeOrderID := common.HashH([]byte("low fee"))
+	highFeeOrderID := common.HashH([]byte("high fee"))
+	pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, lowFeeOrderID.String()))] =
+		rawdbv2.NewPDELimitOrder(lowFeeOrderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e7, 9e6, 1, 200, 1)
+	pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, highFeeOrderID.String()))] =
+		rawdbv2.NewPDELimitOrder(highFeeOrderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e7, 98e5, 100, 200, 1)
+
+	tradingFeeByPair := map[string]uint64{}
+	insts := bc.buildInstsForMatchedPDELimitOrders(pdeState, beaconHeight, tradingFeeByPair)
+	// the high fee order goes first and moves the price, the low fee order still accepts the worse price
+	assert.Equal(t, 2, len(insts))
+	var matchedContents []metadata.PDECrossPoolTradeAcceptedContent
+	assert.Nil(t, json.Unmarshal([]byte(insts[0][3]), &matchedContents))
+	assert.Equal(t, highFeeOrderID, matchedContents[len(matchedContents)-1].RequestedTxID)
+	assert.Equal(t, common.PDELimitOrderMatchedChainStatus, insts[1][2])
+	assert.Equal(t, 0, len(pdeState.PDELimitOrders))
+	assert.Equal(t, 2, len(pdeState.DeletedPDELimitOrders))
+
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, limitOrderTestTokenIDStr))
+	pool := pdeState.PDEPoolPairs[poolPairKey]
+	assert.Equal(t, uint64(102e7), pool.Token1PoolValue)
+}
+
+func TestBuildInstsForCancelledAndExpiredPDELimitOrders(t *testing.T) {
+	bc := newLimitOrderTestBlockChain(0)
+	beaconHeight := uint64(100)
+	pdeState := newLimitOrderTestPDEState(beaconHeight)
+	prvIDStr := common.PRVCoinID.String()
+
+	cancelledOrderID := common.HashH([]byte("cancelled"))
+	expiringOrderID := common.HashH([]byte("expiring"))
+	pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, cancelledOrderID.String()))] =
+		rawdbv2.NewPDELimitOrder(cancelledOrderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e6, 5e6, 0, 200, 1)
+	pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, expiringOrderID.String()))] =
+		rawdbv2.NewPDELimitOrder(expiringOrderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e6, 5e6, 0, 100, 1)
+
+	cancelActions := map[byte][][]string{
+		1: {
+			buildPDECancelLimitOrderReqAction(cancelledOrderID, "someone else"),
+			buildPDECancelLimitOrderReqAction(cancelledOrderID, limitOrderTestTrader),
+			buildPDECancelLimitOrderReqAction(common.HashH([]byte("unknown")), limitOrderTestTrader),
+		},
+	}
+	insts := bc.buildInstsForPDECancelLimitOrders(pdeState, beaconHeight, cancelActions)
+	assert.Equal(t, 4, len(insts))
+	assert.Equal(t, common.PDECancelLimitOrderRejectedChainStatus, insts[0][2])
+	assert.Equal(t, common.PDECancelLimitOrderAcceptedChainStatus, insts[1][2])
+	assert.Equal(t, common.PDELimitOrderSellingTokenRefundChainStatus, insts[2][2])
+	assert.Equal(t, common.PDECancelLimitOrderRejectedChainStatus, insts[3][2])
+
+	expiredInsts := bc.buildInstsForExpiredPDELimitOrders(pdeState, beaconHeight)
+	assert.Equal(t, 1, len(expiredInsts))
+	var refundContent metadata.PDELimitOrderRefundContent
+	assert.Nil(t, json.Unmarshal([]byte(expiredInsts[0][3]), &refundContent))
+	assert.Equal(t, expiringOrderID, refundContent.OrderID)
+	assert.Equal(t, common.PDELimitOrderExpiredChainStatus, refundContent.Reason)
+	assert.Equal(t, 0, len(pdeState.PDELimitOrders))
+}
+
+func TestPDELimitOrdersBreakPointAndLimits(t *testing.T) {
+	beaconHeight := uint64(100)
+	prvIDStr := common.PRVCoinID.String()
+
+	// limit orders are refunded before activation
+	bc := newLimitOrderTestBlockChain(beaconHeight + 2)
+	pdeState := newLimitOrderTestPDEState(beaconHeight)
+	actions := map[byte][][]string{
+		1: {buildPDELimitOrderReqAction(common.HashH([]byte("early")), limitOrderTestTokenIDStr, prvIDStr, 1e6, 9e5, 0, 200)},
+	}
+	insts := bc.buildInstsForPDELimitOrders(pdeState, beaconHeight, actions)
+	assert.Equal(t, 1, len(insts))
+	assert.Equal(t, common.PDELimitOrderSellingTokenRefundChainStatus, insts[0][2])
+	assert.Equal(t, 0, len(pdeState.PDELimitOrders))
+
+	// orders receiving nothing at the current price are refunded, at most MaxPDELimitOrdersScannedPerBlock per block
+	bc = newLimitOrderTestBlockChain(0)
+	pdeState = newLimitOrderTestPDEStateWithPool(beaconHeight, 1e15, 1)
+	numOrders := MaxPDELimitOrdersScannedPerBlock + 50
+	for i := 0; i < numOrders; i++ {
+		orderID := common.HashH([]byte(strconv.Itoa(i)))
+		pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, orderID.String()))] =
+			rawdbv2.NewPDELimitOrder(orderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e6, 1, 0, 200, 1)
+	}
+	insts = bc.buildInstsForMatchedPDELimitOrders(pdeState, beaconHeight, map[string]uint64{})
+	assert.Equal(t, MaxPDELimitOrdersScannedPerBlock, len(insts))
+	var refundContent metadata.PDELimitOrderRefundContent
+	assert.Nil(t, json.Unmarshal([]byte(insts[0][3]), &refundContent))
+	assert.Equal(t, common.PDELimitOrderDustChainStatus, refundContent.Reason)
+	assert.Equal(t, numOrders-MaxPDELimitOrdersScannedPerBlock, len(pdeState.PDELimitOrders))
+
+	// at most MaxPDELimitOrdersMatchedPerBlock orders are matched per block
+	pdeState = newLimitOrderTestPDEStateWithPool(beaconHeight, 1e15, 1e15)
+	numOrders = MaxPDELimitOrdersMatchedPerBlock + 10
+	for i := 0; i < numOrders; i++ {
+		orderID := common.HashH([]byte(strconv.Itoa(i)))
+		pdeState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, orderID.String()))] =
+			rawdbv2.NewPDELimitOrder(orderID, limitOrderTestTrader, limitOrderTestTokenIDStr, prvIDStr, 1e6, 1, 0, 200, 1)
+	}
+	insts = bc.buildInstsForMatchedPDELimitOrders(pdeState, beaconHeight, map[string]uint64{})
+	assert.Equal(t, MaxPDELimitOrdersMatchedPerBlock, len(insts))
+	assert.Equal(t, common.PDELimitOrderMatchedChainStatus, insts[0][2])
+	assert.Equal(t, numOrders-MaxPDELimitOrdersMatchedPerBlock, len(pdeState.PDELimitOrders))
+}
@@ -0,0 +1,150 @@
+package blockchain
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+const (
+	PDELiquidityProviderDepositEvent    = "deposit"
+	PDELiquidityProviderWithdrawalEvent = "withdrawal"
+)
+
+// PDELiquidityProviderEvent is a deposit or a withdrawal of a contributor on a pool pair,
+// Token1Price is the amount of token2 for one token1 in the pool right after the event
+type PDELiquidityProviderEvent struct {
+	Type         string
+	RequestID    string
+	BeaconHeight uint64
+	Token1Amount uint64
+	Token2Amount uint64
+	Token1Price  float64
+}
+
+// PDELiquidityProviderStats is the position of a contributor on a pool pair at a beacon height,
+// values are in token2 at the current pool price and the impermanent loss is the relative difference
+// between the position value and the value of holding the net deposited amounts instead
+type PDELiquidityProviderStats struct {
+	ContributorAddressStr    string
+	Token1IDStr              string
+	Token2IDStr              string
+	BeaconHeight             uint64
+	Shares                   uint64
+	TotalShares              uint64
+	SharePercentage          float64
+	Token1Amount             uint64
+	Token2Amount             uint64
+	WithdrawableTradingFee   uint64
+	Token1Price              float64
+	AverageEntryToken1Price  float64
+	NetDepositedToken1Amount int64
+	NetDepositedToken2Amount int64
+	HoldValue                float64
+	PositionValue            float64
+	ImpermanentLoss          float64
+	Events                   []*PDELiquidityProviderEvent
+}
+
+// GetPDEPoolPairToken1Price returns the amount of token2 for one token1 in the pool pair, 0 if the pool is empty
+func GetPDEPoolPairToken1Price(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	token1IDStr string,
+	token2IDStr string,
+) float64 {
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))
+	poolPair, found := currentPDEState.PDEPoolPairs[poolPairKey]
+	if !found || poolPair == nil || poolPair.Token1PoolValue == 0 || poolPair.Token2PoolValue == 0 {
+		return 0
+	}
+	if poolPair.Token1IDStr == token1IDStr {
+		return float64(poolPair.Token2PoolValue) / float64(poolPair.Token1PoolValue)
+	}
+	return float64(poolPair.Token1PoolValue) / float64(poolPair.Token2PoolValue)
+}
+
+// BuildPDELiquidityProviderStats computes the position of a contributor from the pde state at a beacon height,
+// amounts of events are in order of token1IDStr and token2IDStr
+func BuildPDELiquidityProviderStats(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+	events []*PDELiquidityProviderEvent,
+) (*PDELiquidityProviderStats, error) {
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))
+	poolPair, found := currentPDEState.PDEPoolPairs[poolPairKey]
+	if !found || poolPair == nil {
+		return nil, fmt.Errorf("pool pair %s-%s is not found at beacon height %d", token1IDStr, token2IDStr, beaconHeight)
+	}
+	token1PoolValue, token2PoolValue := poolPair.Token1PoolValue, poolPair.Token2PoolValue
+	if poolPair.Token1IDStr != token1IDStr {
+		token1PoolValue, token2PoolValue = token2PoolValue, token1PoolValue
+	}
+
+	stats := &PDELiquidityProviderStats{
+		ContributorAddressStr: contributorAddressStr,
+		Token1IDStr:           token1IDStr,
+		Token2IDStr:           token2IDStr,
+		BeaconHeight:          beaconHeight,
+		Token1Price:           GetPDEPoolPairToken1Price(currentPDEState, beaconHeight, token1IDStr, token2IDStr),
+		Events:                events,
+	}
+	shareKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, token1IDStr, token2IDStr, contributorAddressStr))
+	stats.Shares = currentPDEState.PDEShares[shareKey]
+	totalSharesForPairPrefix := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, token1IDStr, token2IDStr, ""))
+	totalSharesForPair := big.NewInt(0)
+	for key, shareAmt := range currentPDEState.PDEShares {
+		if strings.HasPrefix(key, totalSharesForPairPrefix) {
+			totalSharesForPair.Add(totalSharesForPair, new(big.Int).SetUint64(shareAmt))
+		}
+	}
+	stats.TotalShares = totalSharesForPair.Uint64()
+	if totalSharesForPair.Sign() > 0 {
+		shares := new(big.Int).SetUint64(stats.Shares)
+		stats.SharePercentage, _ = new(big.Rat).SetFrac(new(big.Int).Mul(shares, big.NewInt(100)), totalSharesForPair).Float64()
+		token1Amount := new(big.Int).Mul(new(big.Int).SetUint64(token1PoolValue), shares)
+		stats.Token1Amount = token1Amount.Div(token1Amount, totalSharesForPair).Uint64()
+		token2Amount := new(big.Int).Mul(new(big.Int).SetUint64(token2PoolValue), shares)
+		stats.Token2Amount = token2Amount.Div(token2Amount, totalSharesForPair).Uint64()
+	}
+	tradingFeeKey := string(rawdbv2.BuildPDETradingFeeKey(beaconHeight, token1IDStr, token2IDStr, contributorAddressStr))
+	stats.WithdrawableTradingFee = currentPDEState.PDETradingFees[tradingFeeKey]
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].BeaconHeight < events[j].BeaconHeight
+	})
+	depositedToken1Amount := float64(0)
+	depositedValue := float64(0)
+	for _, event := range events {
+		switch event.Type {
+		case PDELiquidityProviderDepositEvent:
+			stats.NetDepositedToken1Amount += int64(event.Token1Amount)
+			stats.NetDepositedToken2Amount += int64(event.Token2Amount)
+			depositedToken1Amount += float64(event.Token1Amount)
+			depositedValue += float64(event.Token1Amount) * event.Token1Price
+		case PDELiquidityProviderWithdrawalEvent:
+			stats.NetDepositedToken1Amount -= int64(event.Token1Amount)
+			stats.NetDepositedToken2Amount -= int64(event.Token2Amount)
+		}
+	}
+	if depositedToken1Amount > 0 {
+		stats.AverageEntryToken1Price = depositedValue / depositedToken1Amount
+	}
+	stats.HoldValue = float64(stats.NetDepositedToken1Amount)*stats.Token1Price + float64(stats.NetDepositedToken2Amount)
+	stats.PositionValue = float64(stats.Token1Amount)*stats.Token1Price + float64(stats.Token2Amount)
+	if stats.HoldValue > 0 {
+		stats.ImpermanentLoss = stats.PositionValue/stats.HoldValue - 1
+	} else if stats.AverageEntryToken1Price > 0 && stats.Token1Price > 0 {
+		// deposits were fully withdrawn or partly returned as profit, fall back on the price ratio since entry
+		priceRatio := stats.Token1Price / stats.AverageEntryToken1Price
+		stats.ImpermanentLoss = 2*math.Sqrt(priceRatio)/(1+priceRatio) - 1
+	}
+	return stats, nil
+}
@@ -0,0 +1,49 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPDELiquidityProviderStats(t *testing.T) {
+	beaconHeight := uint64(100)
+	prvIDStr := common.PRVCoinID.String()
+	tokenIDStr := routerTestTokenAIDStr
+	contributor := limitOrderTestTrader
+	pdeState := newRouterTestPDEState(beaconHeight, rawdbv2.NewPDEPoolForPair(prvIDStr, 4000, tokenIDStr, 1000))
+	pdeState.PDEShares[string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, prvIDStr, tokenIDStr, contributor))] = 250
+	pdeState.PDEShares[string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, prvIDStr, tokenIDStr, "someone else"))] = 750
+	pdeState.PDETradingFees[string(rawdbv2.BuildPDETradingFeeKey(beaconHeight, prvIDStr, tokenIDStr, contributor))] = 7
+
+	// deposited 500 token and 500 PRV at price 1, the price of token moves to 4 PRV
+	events := []*PDELiquidityProviderEvent{
+		{Type: PDELiquidityProviderDepositEvent, BeaconHeight: 10, Token1Amount: 500, Token2Amount: 500, Token1Price: 1},
+	}
+	stats, err := BuildPDELiquidityProviderStats(pdeState, beaconHeight, contributor, tokenIDStr, prvIDStr, events)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1000), stats.TotalShares)
+	assert.InDelta(t, 25.0, stats.SharePercentage, 1e-9)
+	assert.Equal(t, uint64(250), stats.Token1Amount)
+	assert.Equal(t, uint64(1000), stats.Token2Amount)
+	assert.Equal(t, uint64(7), stats.WithdrawableTradingFee)
+	assert.InDelta(t, 4.0, stats.Token1Price, 1e-9)
+	assert.InDelta(t, 2500.0, stats.HoldValue, 1e-9)
+	assert.InDelta(t, 2000.0, stats.PositionValue, 1e-9)
+	assert.InDelta(t, -0.2, stats.ImpermanentLoss, 1e-9)
+
+	// withdrawals reduce the amounts held instead
+	events = append(events, &PDELiquidityProviderEvent{
+		Type: PDELiquidityProviderWithdrawalEvent, BeaconHeight: 20, Token1Amount: 250, Token2Amount: 250, Token1Price: 1,
+	})
+	stats, err = BuildPDELiquidityProviderStats(pdeState, beaconHeight, contributor, tokenIDStr, prvIDStr, events)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(250), stats.NetDepositedToken1Amount)
+	assert.InDelta(t, 1.0, stats.AverageEntryToken1Price, 1e-9)
+	assert.InDelta(t, 1250.0, stats.HoldValue, 1e-9)
+
+	_, err = BuildPDELiquidityProviderStats(pdeState, beaconHeight, contributor, routerTestTokenBIDStr, prvIDStr, nil)
+	assert.NotNil(t, err)
+}
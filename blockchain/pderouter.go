@@ -0,0 +1,235 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+// MaxPDETradeRouteHops is the max number of pools a best route trade goes through
+const MaxPDETradeRouteHops = 3
+
+// PDETradeRouteHop is a trade through a single pool of a route
+type PDETradeRouteHop struct {
+	TokenIDToSellStr string
+	TokenIDToBuyStr  string
+	SellAmount       uint64
+	ReceiveAmount    uint64
+	TradingFee       uint64
+	PriceImpact      float64
+}
+
+// PDETradeRoute is a quote of trading SellAmount of TokenIDToSellStr through Path,
+// PriceImpact is the relative difference between the receiving amount and the one at current pool prices
+type PDETradeRoute struct {
+	TokenIDToSellStr string
+	TokenIDToBuyStr  string
+	SellAmount       uint64
+	ReceiveAmount    uint64
+	TradingFee       uint64
+	PriceImpact      float64
+	Path             []string
+	Hops             []*PDETradeRouteHop
+}
+
+// buildPDEPoolGraph returns tradable neighbors of each token, neighbors are sorted to keep routing deterministic
+func buildPDEPoolGraph(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) map[string][]string {
+	graph := make(map[string][]string)
+	poolPairPrefix := string(rawdbv2.PDEPoolPrefix) + fmt.Sprintf("%d-", beaconHeight)
+	for poolPairKey, poolPair := range currentPDEState.PDEPoolPairs {
+		if !strings.HasPrefix(poolPairKey, poolPairPrefix) ||
+			poolPair == nil || poolPair.Token1PoolValue == 0 || poolPair.Token2PoolValue == 0 {
+			continue
+		}
+		graph[poolPair.Token1IDStr] = append(graph[poolPair.Token1IDStr], poolPair.Token2IDStr)
+		graph[poolPair.Token2IDStr] = append(graph[poolPair.Token2IDStr], poolPair.Token1IDStr)
+	}
+	for tokenIDStr := range graph {
+		sort.Strings(graph[tokenIDStr])
+	}
+	return graph
+}
+
+// findBestPDETradePath searches all simple paths of at most maxHops pools from the selling token to the buying token
+// and returns the one with the highest receiving amount (the shorter one wins a tie)
+func findBestPDETradePath(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+	maxHops int,
+) ([]string, uint64) {
+	if currentPDEState == nil || sellAmount == 0 || tokenIDToSellStr == tokenIDToBuyStr {
+		return nil, 0
+	}
+	graph := buildPDEPoolGraph(currentPDEState, beaconHeight)
+	var bestPath []string
+	bestReceiveAmount := uint64(0)
+	path := []string{tokenIDToSellStr}
+	visited := map[string]bool{tokenIDToSellStr: true}
+
+	var search func(tokenIDStr string, amount uint64)
+	search = func(tokenIDStr string, amount uint64) {
+		if tokenIDStr == tokenIDToBuyStr {
+			if amount > bestReceiveAmount || (amount == bestReceiveAmount && len(path) < len(bestPath)) {
+				bestReceiveAmount = amount
+				bestPath = append([]string{}, path...)
+			}
+			return
+		}
+		if len(path)-1 >= maxHops {
+			return
+		}
+		for _, nextTokenIDStr := range graph[tokenIDStr] {
+			if visited[nextTokenIDStr] {
+				continue
+			}
+			poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tokenIDStr, nextTokenIDStr))
+			receiveAmount, _, _ := calcTradeValue(currentPDEState.PDEPoolPairs[poolPairKey], tokenIDStr, amount)
+			if receiveAmount == 0 {
+				continue
+			}
+			visited[nextTokenIDStr] = true
+			path = append(path, nextTokenIDStr)
+			search(nextTokenIDStr, receiveAmount)
+			path = path[:len(path)-1]
+			visited[nextTokenIDStr] = false
+		}
+	}
+	search(tokenIDToSellStr, sellAmount)
+	return bestPath, bestReceiveAmount
+}
+
+func buildSequentialTradesForPath(path []string, sellAmount uint64) []*tradeInfo {
+	sequentialTrades := []*tradeInfo{}
+	for i := 0; i < len(path)-1; i++ {
+		sequentialTrades = append(sequentialTrades, &tradeInfo{
+			tokenIDToBuyStr:  path[i+1],
+			tokenIDToSellStr: path[i],
+		})
+	}
+	if len(sequentialTrades) > 0 {
+		sequentialTrades[0].sellAmount = sellAmount
+	}
+	return sequentialTrades
+}
+
+// buildBestRouteSequentialTrades returns trades of the best route, nil if there is no route
+func buildBestRouteSequentialTrades(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+) []*tradeInfo {
+	path, _ := findBestPDETradePath(currentPDEState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, sellAmount, MaxPDETradeRouteHops)
+	if len(path) < 2 {
+		return nil
+	}
+	return buildSequentialTradesForPath(path, sellAmount)
+}
+
+func isPDETradeRouteExisting(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+) bool {
+	path, _ := findBestPDETradePath(currentPDEState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, sellAmount, MaxPDETradeRouteHops)
+	return len(path) >= 2
+}
+
+func calcPriceImpact(receiveAmount uint64, spotReceiveAmount float64) float64 {
+	if spotReceiveAmount <= 0 {
+		return 0
+	}
+	return 1 - float64(receiveAmount)/spotReceiveAmount
+}
+
+// GetPDETradeRouteQuote finds the best route of at most maxHops pools for a trade on the pde state,
+// the trading fee is split over hops the same way beacon does when executing the trade
+func GetPDETradeRouteQuote(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+	tradingFee uint64,
+	maxHops int,
+) (*PDETradeRoute, error) {
+	if maxHops <= 0 || maxHops > MaxPDETradeRouteHops {
+		return nil, fmt.Errorf("max hops should be in range [1, %d]", MaxPDETradeRouteHops)
+	}
+	if sellAmount == 0 {
+		return nil, errors.New("sell amount should be greater than 0")
+	}
+	path, receiveAmount := findBestPDETradePath(currentPDEState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, sellAmount, maxHops)
+	if len(path) < 2 {
+		return nil, fmt.Errorf("could not find any route from %s to %s within %d hops", tokenIDToSellStr, tokenIDToBuyStr, maxHops)
+	}
+	sequentialTrades := buildSequentialTradesForPath(path, sellAmount)
+	simulateSequentialTrades(currentPDEState, beaconHeight, sequentialTrades)
+
+	route := &PDETradeRoute{
+		TokenIDToSellStr: tokenIDToSellStr,
+		TokenIDToBuyStr:  tokenIDToBuyStr,
+		SellAmount:       sellAmount,
+		ReceiveAmount:    receiveAmount,
+		TradingFee:       tradingFee,
+		Path:             path,
+		Hops:             []*PDETradeRouteHop{},
+	}
+	proportionalFee := tradingFee / uint64(len(sequentialTrades))
+	spotReceiveAmount := float64(sellAmount)
+	for idx, tradeInf := range sequentialTrades {
+		poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
+		poolPair := currentPDEState.PDEPoolPairs[poolPairKey]
+		tokenPoolValueToSell, tokenPoolValueToBuy := poolPair.Token2PoolValue, poolPair.Token1PoolValue
+		if poolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			tokenPoolValueToSell, tokenPoolValueToBuy = poolPair.Token1PoolValue, poolPair.Token2PoolValue
+		}
+		price := float64(tokenPoolValueToBuy) / float64(tokenPoolValueToSell)
+		spotReceiveAmount *= price
+
+		hopFee := proportionalFee
+		if idx == len(sequentialTrades)-1 {
+			hopFee = tradingFee - uint64(len(sequentialTrades)-1)*proportionalFee
+		}
+		route.Hops = append(route.Hops, &PDETradeRouteHop{
+			TokenIDToSellStr: tradeInf.tokenIDToSellStr,
+			TokenIDToBuyStr:  tradeInf.tokenIDToBuyStr,
+			SellAmount:       tradeInf.sellAmount,
+			ReceiveAmount:    tradeInf.receiveAmount,
+			TradingFee:       hopFee,
+			PriceImpact:      calcPriceImpact(tradeInf.receiveAmount, float64(tradeInf.sellAmount)*price),
+		})
+	}
+	route.PriceImpact = calcPriceImpact(receiveAmount, spotReceiveAmount)
+	return route, nil
+}
+
+// GetPDEDefaultTradeReceiveAmount returns the receiving amount of the default route via PRV pools, 0 if it is not tradable
+func GetPDEDefaultTradeReceiveAmount(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+) uint64 {
+	prvIDStr := common.PRVCoinID.String()
+	if (isTradingFairContainsPRV(tokenIDToSellStr, tokenIDToBuyStr) && !isPoolPairExisting(beaconHeight, currentPDEState, tokenIDToSellStr, tokenIDToBuyStr)) ||
+		(!isTradingFairContainsPRV(tokenIDToSellStr, tokenIDToBuyStr) && (!isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tokenIDToSellStr) || !isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tokenIDToBuyStr))) {
+		return 0
+	}
+	sequentialTrades := buildSequentialTrades(tokenIDToBuyStr, tokenIDToSellStr, sellAmount)
+	return simulateSequentialTrades(currentPDEState, beaconHeight, sequentialTrades)
+}
@@ -0,0 +1,153 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	routerTestTokenAIDStr = "00000000000000000000000000000000000000000000000000000000000000aa"
+	routerTestTokenBIDStr = "00000000000000000000000000000000000000000000000000000000000000bb"
+	routerTestTokenCIDStr = "00000000000000000000000000000000000000000000000000000000000000cc"
+)
+
+func newRouterTestPDEState(beaconHeight uint64, pools ...*rawdbv2.PDEPoolForPair) *CurrentPDEState {
+	pdeState := &CurrentPDEState{
+		PDEPoolPairs:   make(map[string]*rawdbv2.PDEPoolForPair),
+		PDEShares:      make(map[string]uint64),
+		PDETradingFees: make(map[string]uint64),
+	}
+	for _, pool := range pools {
+		poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, pool.Token1IDStr, pool.Token2IDStr))
+		pdeState.PDEPoolPairs[poolPairKey] = pool
+	}
+	return pdeState
+}
+
+func TestFindBestPDETradePath(t *testing.T) {
+	beaconHeight := uint64(10)
+	prvIDStr := common.PRVCoinID.String()
+	pdeState := newRouterTestPDEState(
+		beaconHeight,
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 10000, routerTestTokenAIDStr, 10000),
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 10000, routerTestTokenBIDStr, 10000),
+		rawdbv2.NewPDEPoolForPair(routerTestTokenAIDStr, 1000000, routerTestTokenBIDStr, 1000000),
+		rawdbv2.NewPDEPoolForPair(routerTestTokenBIDStr, 1000000, routerTestTokenCIDStr, 1000000),
+	)
+
+	// the deep A-B pool beats the default route via PRV
+	path, receiveAmount := findBestPDETradePath(pdeState, beaconHeight, routerTestTokenAIDStr, routerTestTokenBIDStr, 1000, MaxPDETradeRouteHops)
+	assert.Equal(t, []string{routerTestTokenAIDStr, routerTestTokenBIDStr}, path)
+	assert.Equal(t, uint64(999), receiveAmount)
+	assert.Equal(t, uint64(833), GetPDEDefaultTradeReceiveAmount(pdeState, beaconHeight, routerTestTokenAIDStr, routerTestTokenBIDStr, 1000))
+
+	// C has no PRV pool, it is reachable via B only
+	path, _ = findBestPDETradePath(pdeState, beaconHeight, prvIDStr, routerTestTokenCIDStr, 1000, MaxPDETradeRouteHops)
+	assert.Equal(t, []string{prvIDStr, routerTestTokenBIDStr, routerTestTokenCIDStr}, path)
+	path, _ = findBestPDETradePath(pdeState, beaconHeight, prvIDStr, routerTestTokenCIDStr, 1000, 1)
+	assert.Nil(t, path)
+
+	// pools are not updated by searching
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, routerTestTokenAIDStr, routerTestTokenBIDStr))
+	assert.Equal(t, uint64(1000000), pdeState.PDEPoolPairs[poolPairKey].Token1PoolValue)
+}
+
+func TestGetPDETradeRouteQuote(t *testing.T) {
+	beaconHeight := uint64(10)
+	prvIDStr := common.PRVCoinID.String()
+	pdeState := newRouterTestPDEState(
+		beaconHeight,
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 1000000, routerTestTokenBIDStr, 1000000),
+		rawdbv2.NewPDEPoolForPair(routerTestTokenBIDStr, 1000000, routerTestTokenCIDStr, 1000000),
+	)
+
+	_, err := GetPDETradeRouteQuote(pdeState, beaconHeight, prvIDStr, routerTestTokenCIDStr, 1000, 3, MaxPDETradeRouteHops+1)
+	assert.NotNil(t, err)
+	_, err = GetPDETradeRouteQuote(pdeState, beaconHeight, prvIDStr, routerTestTokenAIDStr, 1000, 3, MaxPDETradeRouteHops)
+	assert.NotNil(t, err)
+
+	route, err := GetPDETradeRouteQuote(pdeState, beaconHeight, prvIDStr, routerTestTokenCIDStr, 1000, 3, MaxPDETradeRouteHops)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(route.Hops))
+	assert.Equal(t, route.Hops[0].ReceiveAmount, route.Hops[1].SellAmount)
+	assert.Equal(t, route.Hops[1].ReceiveAmount, route.ReceiveAmount)
+	assert.Equal(t, uint64(1), route.Hops[0].TradingFee)
+	assert.Equal(t, uint64(2), route.Hops[1].TradingFee)
+	assert.True(t, route.PriceImpact > 0 && route.PriceImpact < 0.01)
+}
+
+func TestBuildInstsForBestRoutePDECrossPoolTrades(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{}}}
+	beaconHeight := uint64(10)
+	prvIDStr := common.PRVCoinID.String()
+	pdeState := newRouterTestPDEState(
+		beaconHeight,
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 1000000, routerTestTokenBIDStr, 1000000),
+		rawdbv2.NewPDEPoolForPair(routerTestTokenBIDStr, 1000000, routerTestTokenCIDStr, 1000000),
+	)
+	tradeAction := metadata.PDECrossPoolTradeRequestAction{
+		Meta: metadata.PDECrossPoolTradeRequest{
+			TokenIDToBuyStr:     routerTestTokenCIDStr,
+			TokenIDToSellStr:    prvIDStr,
+			SellAmount:          1000,
+			MinAcceptableAmount: 900,
+			TradingFee:          2,
+			UseBestRoute:        true,
+		},
+		ShardID: 1,
+	}
+	insts, tradingFeeByPair := bc.buildInstsForSortedTradableActions(pdeState, beaconHeight, []metadata.PDECrossPoolTradeRequestAction{tradeAction})
+	assert.Equal(t, 1, len(insts))
+	assert.Equal(t, common.PDECrossPoolTradeAcceptedChainStatus, insts[0][2])
+	assert.Equal(t, 2, len(tradingFeeByPair))
+
+	// the same trade routed via PRV pools only is not tradable
+	crossPoolTradeActions := map[byte][][]string{
+		1: {buildPDECrossPoolTradeReqAction(routerTestTokenCIDStr, prvIDStr, 1000, 900, 2, "")},
+	}
+	_, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(beaconHeight, pdeState, crossPoolTradeActions, true)
+	assert.Equal(t, 1, len(untradableActions))
+}
+
+func TestPDEBestRouteBreakPoint(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointBestRoute: 20}}}
+	prvIDStr := common.PRVCoinID.String()
+	pdeState := newRouterTestPDEState(
+		10,
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 1000000, routerTestTokenBIDStr, 1000000),
+		rawdbv2.NewPDEPoolForPair(routerTestTokenBIDStr, 1000000, routerTestTokenCIDStr, 1000000),
+	)
+	tradeAction := metadata.PDECrossPoolTradeRequestAction{
+		Meta: metadata.PDECrossPoolTradeRequest{
+			MetadataBase:        metadata.MetadataBase{Type: metadata.PDECrossPoolTradeRequestMeta},
+			TokenIDToBuyStr:     routerTestTokenCIDStr,
+			TokenIDToSellStr:    prvIDStr,
+			SellAmount:          1000,
+			MinAcceptableAmount: 900,
+			TradingFee:          2,
+			UseBestRoute:        true,
+		},
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(tradeAction)
+	crossPoolTradeActions := map[byte][][]string{
+		1: {{strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta), base64.StdEncoding.EncodeToString(actionContentBytes)}},
+	}
+
+	// before the breakpoint best route trades are routed via PRV pools
+	assert.False(t, bc.isPDEBestRouteEnabled(18))
+	_, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(10, pdeState, crossPoolTradeActions, bc.isPDEBestRouteEnabled(18))
+	assert.Equal(t, 1, len(untradableActions))
+
+	assert.True(t, bc.isPDEBestRouteEnabled(19))
+	sortedTradableActions, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(10, pdeState, crossPoolTradeActions, bc.isPDEBestRouteEnabled(19))
+	assert.Equal(t, 1, len(sortedTradableActions))
+	assert.Equal(t, 0, len(untradableActions))
+}
@@ -0,0 +1,179 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// PDEPriceScale is the fixed point scale of prices in pde price accumulators
+var PDEPriceScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// PDETWAP is the time (beacon block) weighted average price of a pool pair over (FromBeaconHeight, ToBeaconHeight],
+// Token1Price is the amount of token2 for one token1 and vice versa
+type PDETWAP struct {
+	Token1IDStr      string
+	Token2IDStr      string
+	FromBeaconHeight uint64
+	ToBeaconHeight   uint64
+	Token1Price      float64
+	Token2Price      float64
+}
+
+// calcPDEPoolPrices returns scaled prices of the sorted tokens of a pool, nil if the pool is empty
+func calcPDEPoolPrices(poolPair *rawdbv2.PDEPoolForPair) (string, string, *big.Int, *big.Int) {
+	token1IDStr, token1PoolValue := poolPair.Token1IDStr, poolPair.Token1PoolValue
+	token2IDStr, token2PoolValue := poolPair.Token2IDStr, poolPair.Token2PoolValue
+	if token1IDStr > token2IDStr {
+		token1IDStr, token2IDStr = token2IDStr, token1IDStr
+		token1PoolValue, token2PoolValue = token2PoolValue, token1PoolValue
+	}
+	if token1PoolValue == 0 || token2PoolValue == 0 {
+		return token1IDStr, token2IDStr, nil, nil
+	}
+	token1Price := new(big.Int).Mul(new(big.Int).SetUint64(token2PoolValue), PDEPriceScale)
+	token1Price.Div(token1Price, new(big.Int).SetUint64(token1PoolValue))
+	token2Price := new(big.Int).Mul(new(big.Int).SetUint64(token1PoolValue), PDEPriceScale)
+	token2Price.Div(token2Price, new(big.Int).SetUint64(token2PoolValue))
+	return token1IDStr, token2IDStr, token1Price, token2Price
+}
+
+// accumulatePDEPrices adds the last prices of the pool held since the last update of the accumulators to newBeaconHeight,
+// the last prices are carried forward while the pool is empty or missing
+func accumulatePDEPrices(
+	priceCumulative *statedb.PDEPriceCumulativeState,
+	newBeaconHeight uint64,
+) {
+	if newBeaconHeight <= priceCumulative.LastUpdatedBeaconHeight() {
+		return
+	}
+	elapsedBlocks := new(big.Int).SetUint64(newBeaconHeight - priceCumulative.LastUpdatedBeaconHeight())
+	token1PriceCumulative := priceCumulative.Token1PriceCumulative()
+	token1PriceCumulative.Add(token1PriceCumulative, new(big.Int).Mul(priceCumulative.Token1LastPrice(), elapsedBlocks))
+	priceCumulative.SetToken1PriceCumulative(token1PriceCumulative)
+	token2PriceCumulative := priceCumulative.Token2PriceCumulative()
+	token2PriceCumulative.Add(token2PriceCumulative, new(big.Int).Mul(priceCumulative.Token2LastPrice(), elapsedBlocks))
+	priceCumulative.SetToken2PriceCumulative(token2PriceCumulative)
+	priceCumulative.SetLastUpdatedBeaconHeight(newBeaconHeight)
+}
+
+// updatePDEPriceCumulatives updates accumulators of pools changed by a beacon block,
+// prices before the block are accumulated so trades of the block could not move the average of the block itself,
+// then prices after the block become the last prices unless the pool is empty.
+// Accumulators are created once the pool has prices, so the last prices are always known
+func updatePDEPriceCumulatives(
+	stateDB *statedb.StateDB,
+	prevPoolPairs map[string]*rawdbv2.PDEPoolForPair,
+	poolPairs map[string]*rawdbv2.PDEPoolForPair,
+	newBeaconHeight uint64,
+) error {
+	poolPairKeys := []string{}
+	for poolPairKey := range poolPairs {
+		poolPairKeys = append(poolPairKeys, poolPairKey)
+	}
+	sort.Strings(poolPairKeys)
+	for _, poolPairKey := range poolPairKeys {
+		poolPair := poolPairs[poolPairKey]
+		if poolPair == nil {
+			continue
+		}
+		prevPoolPair, found := prevPoolPairs[poolPairKey]
+		if found && prevPoolPair != nil &&
+			prevPoolPair.Token1PoolValue == poolPair.Token1PoolValue &&
+			prevPoolPair.Token2PoolValue == poolPair.Token2PoolValue {
+			continue
+		}
+		token1IDStr, token2IDStr, token1Price, token2Price := calcPDEPoolPrices(poolPair)
+		priceCumulative, has, err := statedb.GetPDEPriceCumulative(stateDB, token1IDStr, token2IDStr)
+		if err != nil {
+			return err
+		}
+		if !has {
+			if token1Price == nil || token2Price == nil {
+				continue
+			}
+			priceCumulative = statedb.NewPDEPriceCumulativeState()
+			priceCumulative.SetToken1ID(token1IDStr)
+			priceCumulative.SetToken2ID(token2IDStr)
+			priceCumulative.SetLastUpdatedBeaconHeight(newBeaconHeight)
+		} else {
+			accumulatePDEPrices(priceCumulative, newBeaconHeight)
+		}
+		if token1Price != nil && token2Price != nil {
+			priceCumulative.SetToken1LastPrice(token1Price)
+			priceCumulative.SetToken2LastPrice(token2Price)
+		}
+		err = statedb.StorePDEPriceCumulative(stateDB, priceCumulative)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPDEPriceCumulativesAt returns accumulators of a pool pair at a beacon height,
+// the last prices are accumulated from the last update of accumulators to the beacon height
+func getPDEPriceCumulativesAt(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	token1IDStr string,
+	token2IDStr string,
+) (*statedb.PDEPriceCumulativeState, error) {
+	priceCumulative, has, err := statedb.GetPDEPriceCumulative(stateDB, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, fmt.Errorf("price accumulators of pool %s-%s are not available at beacon height %d", token1IDStr, token2IDStr, beaconHeight)
+	}
+	accumulatePDEPrices(priceCumulative, beaconHeight)
+	return priceCumulative, nil
+}
+
+// CalcPDETWAP calculates the average price of a pool pair over (fromBeaconHeight, toBeaconHeight]
+// from the pde states at both beacon heights
+func CalcPDETWAP(
+	fromStateDB *statedb.StateDB,
+	fromBeaconHeight uint64,
+	toStateDB *statedb.StateDB,
+	toBeaconHeight uint64,
+	token1IDStr string,
+	token2IDStr string,
+) (*PDETWAP, error) {
+	if fromBeaconHeight >= toBeaconHeight {
+		return nil, errors.New("from beacon height should be less than to beacon height")
+	}
+	fromPriceCumulative, err := getPDEPriceCumulativesAt(fromStateDB, fromBeaconHeight, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, err
+	}
+	toPriceCumulative, err := getPDEPriceCumulativesAt(toStateDB, toBeaconHeight, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, err
+	}
+	scaledWindow := new(big.Int).Mul(new(big.Int).SetUint64(toBeaconHeight-fromBeaconHeight), PDEPriceScale)
+	sortedToken1Price, _ := new(big.Rat).SetFrac(
+		new(big.Int).Sub(toPriceCumulative.Token1PriceCumulative(), fromPriceCumulative.Token1PriceCumulative()),
+		scaledWindow,
+	).Float64()
+	sortedToken2Price, _ := new(big.Rat).SetFrac(
+		new(big.Int).Sub(toPriceCumulative.Token2PriceCumulative(), fromPriceCumulative.Token2PriceCumulative()),
+		scaledWindow,
+	).Float64()
+	twap := &PDETWAP{
+		Token1IDStr:      token1IDStr,
+		Token2IDStr:      token2IDStr,
+		FromBeaconHeight: fromBeaconHeight,
+		ToBeaconHeight:   toBeaconHeight,
+		Token1Price:      sortedToken1Price,
+		Token2Price:      sortedToken2Price,
+	}
+	if token1IDStr != toPriceCumulative.Token1ID() {
+		twap.Token1Price, twap.Token2Price = sortedToken2Price, sortedToken1Price
+	}
+	return twap, nil
+}
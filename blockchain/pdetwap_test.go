@@ -0,0 +1,126 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitAndReopenStateDB(t *testing.T, stateDB *statedb.StateDB, warper statedb.DatabaseAccessWarper) *statedb.StateDB {
+	rootHash, err := stateDB.Commit(true)
+	assert.Nil(t, err)
+	assert.Nil(t, stateDB.Database().TrieDB().Commit(rootHash, false))
+	reopenedStateDB, err := statedb.NewWithPrefixTrie(rootHash, warper)
+	assert.Nil(t, err)
+	return reopenedStateDB
+}
+
+// applyTWAPTestBlock changes the pool in a beacon block the same way processPDEInstructions does
+func applyTWAPTestBlock(
+	t *testing.T,
+	stateDB *statedb.StateDB,
+	newBeaconHeight uint64,
+	prevPoolPair *rawdbv2.PDEPoolForPair,
+	poolPair *rawdbv2.PDEPoolForPair,
+) {
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(newBeaconHeight-1, poolPair.Token1IDStr, poolPair.Token2IDStr))
+	prevPoolPairs := map[string]*rawdbv2.PDEPoolForPair{}
+	if prevPoolPair != nil {
+		prevPoolPairs[poolPairKey] = prevPoolPair
+	}
+	poolPairs := map[string]*rawdbv2.PDEPoolForPair{poolPairKey: poolPair}
+	assert.Nil(t, updatePDEPriceCumulatives(stateDB, prevPoolPairs, poolPairs, newBeaconHeight))
+	assert.Nil(t, statedb.StorePDEPoolPairs(stateDB, newBeaconHeight, poolPairs))
+}
+
+func TestCalcPDETWAP(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_pdetwap_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, _ := incdb.Open("leveldb", dbPath)
+	warper := statedb.NewDatabaseAccessWarper(diskDB)
+	stateDB, _ := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), warper)
+
+	prvIDStr := common.PRVCoinID.String()
+	tokenIDStr := "00000000000000000000000000000000000000000000000000000000000000ff"
+
+	// the pool is created at beacon height 10 with price 2 token per PRV
+	poolAt10 := rawdbv2.NewPDEPoolForPair(prvIDStr, 1000, tokenIDStr, 2000)
+	applyTWAPTestBlock(t, stateDB, 10, nil, poolAt10)
+	stateDBAt10 := commitAndReopenStateDB(t, stateDB, warper)
+
+	// price moves to 4 token per PRV at beacon height 20
+	poolAt20 := rawdbv2.NewPDEPoolForPair(prvIDStr, 1000, tokenIDStr, 4000)
+	applyTWAPTestBlock(t, stateDB, 20, poolAt10, poolAt20)
+	stateDBAt20 := commitAndReopenStateDB(t, stateDB, warper)
+
+	// prices entering blocks 11..20 are 2
+	twap, err := CalcPDETWAP(stateDBAt10, 10, stateDBAt20, 20, prvIDStr, tokenIDStr)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0, twap.Token1Price, 1e-9)
+	assert.InDelta(t, 0.5, twap.Token2Price, 1e-9)
+
+	// nothing changes after beacon height 20, current price is accumulated up to the requested height
+	twap, err = CalcPDETWAP(stateDBAt10, 10, stateDBAt20, 30, tokenIDStr, prvIDStr)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.375, twap.Token1Price, 1e-9)
+	assert.InDelta(t, 3.0, twap.Token2Price, 1e-9)
+
+	_, err = CalcPDETWAP(stateDBAt10, 10, stateDBAt20, 10, prvIDStr, tokenIDStr)
+	assert.NotNil(t, err)
+	_, err = CalcPDETWAP(stateDBAt10, 10, stateDBAt20, 20, prvIDStr, common.Hash{}.String())
+	assert.NotNil(t, err)
+}
+
+func TestCalcPDETWAPWithEmptyPool(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_pdetwap_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, _ := incdb.Open("leveldb", dbPath)
+	warper := statedb.NewDatabaseAccessWarper(diskDB)
+	stateDB, _ := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), warper)
+
+	prvIDStr := common.PRVCoinID.String()
+	tokenIDStr := "00000000000000000000000000000000000000000000000000000000000000ff"
+
+	// an empty pool has no prices so accumulators are not created
+	applyTWAPTestBlock(t, stateDB, 5, nil, rawdbv2.NewPDEPoolForPair(prvIDStr, 0, tokenIDStr, 0))
+	_, has, err := statedb.GetPDEPriceCumulative(stateDB, prvIDStr, tokenIDStr)
+	assert.Nil(t, err)
+	assert.False(t, has)
+
+	poolAt10 := rawdbv2.NewPDEPoolForPair(prvIDStr, 1000, tokenIDStr, 2000)
+	applyTWAPTestBlock(t, stateDB, 10, nil, poolAt10)
+	stateDBAt10 := commitAndReopenStateDB(t, stateDB, warper)
+
+	// the pool is drained at beacon height 20 and refilled with price 4 at beacon height 30
+	poolAt20 := rawdbv2.NewPDEPoolForPair(prvIDStr, 0, tokenIDStr, 0)
+	applyTWAPTestBlock(t, stateDB, 20, poolAt10, poolAt20)
+	stateDBAt20 := commitAndReopenStateDB(t, stateDB, warper)
+	poolAt30 := rawdbv2.NewPDEPoolForPair(prvIDStr, 1000, tokenIDStr, 4000)
+	applyTWAPTestBlock(t, stateDB, 30, poolAt20, poolAt30)
+	stateDBAt30 := commitAndReopenStateDB(t, stateDB, warper)
+
+	// the last price 2 is carried forward while the pool is empty
+	twap, err := CalcPDETWAP(stateDBAt10, 10, stateDBAt20, 25, prvIDStr, tokenIDStr)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0, twap.Token1Price, 1e-9)
+	twap, err = CalcPDETWAP(stateDBAt10, 10, stateDBAt30, 30, prvIDStr, tokenIDStr)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0, twap.Token1Price, 1e-9)
+	assert.InDelta(t, 0.5, twap.Token2Price, 1e-9)
+	twap, err = CalcPDETWAP(stateDBAt10, 10, stateDBAt30, 40, prvIDStr, tokenIDStr)
+	assert.Nil(t, err)
+	assert.InDelta(t, 80.0/30, twap.Token1Price, 1e-9)
+}
@@ -362,6 +362,121 @@ func (blockGenerator *BlockGenerator) buildPDECrossPoolTradeIssuanceTx(
 	)
 }
 
+func (blockGenerator *BlockGenerator) buildPDELimitOrderRefundTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	var refundContent metadata.PDELimitOrderRefundContent
+	err := json.Unmarshal([]byte(contentStr), &refundContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order refund content: %+v", err)
+		return nil, nil
+	}
+	if shardID != refundContent.ShardID {
+		return nil, nil
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		instStatus,
+		refundContent.OrderID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		refundContent.TraderAddressStr,
+		refundContent.Amount,
+		refundContent.TokenIDStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing refunded limit order response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Info("[PDE Limit Order] Create refunded tx ok.")
+	return resTx, nil
+}
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderMatchedTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	matchedContents, err := parseCrossPoolTradeAcceptedContent(contentStr)
+	if err != nil {
+		return nil, nil
+	}
+	if len(matchedContents) == 0 {
+		Logger.log.Warn("WARNING: limit order matched contents is empty.")
+		return nil, nil
+	}
+	finalMatchedContent := matchedContents[len(matchedContents)-1]
+	if shardID != finalMatchedContent.ShardID {
+		return nil, nil
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		instStatus,
+		finalMatchedContent.RequestedTxID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		finalMatchedContent.TraderAddressStr,
+		finalMatchedContent.ReceiveAmount,
+		finalMatchedContent.TokenIDToBuyStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing matched limit order response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Info("[PDE Limit Order] Create matched tx ok.")
+	return resTx, nil
+}
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderIssuanceTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	Logger.log.Info("[PDE Limit Order] Starting...")
+	if instStatus == common.PDELimitOrderFeeRefundChainStatus || instStatus == common.PDELimitOrderSellingTokenRefundChainStatus {
+		return blockGenerator.buildPDELimitOrderRefundTx(
+			instStatus,
+			contentStr,
+			producerPrivateKey,
+			shardID,
+			shardView,
+			beaconView,
+		)
+	}
+	if instStatus == common.PDELimitOrderMatchedChainStatus {
+		return blockGenerator.buildPDELimitOrderMatchedTx(
+			instStatus,
+			contentStr,
+			producerPrivateKey,
+			shardID,
+			shardView,
+			beaconView,
+		)
+	}
+	return nil, nil
+}
+
 func (blockGenerator *BlockGenerator) buildPDEWithdrawalTx(
 	contentStr string,
 	producerPrivateKey *privacy.PrivateKey,
@@ -18,6 +18,8 @@ type CurrentPDEState struct {
 	PDEPoolPairs                   map[string]*rawdbv2.PDEPoolForPair
 	PDEShares                      map[string]uint64
 	PDETradingFees                 map[string]uint64
+	PDELimitOrders                 map[string]*rawdbv2.PDELimitOrder
+	DeletedPDELimitOrders          map[string]*rawdbv2.PDELimitOrder
 }
 
 func (s *CurrentPDEState) Copy() *CurrentPDEState {
@@ -72,12 +74,18 @@ func InitCurrentPDEStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	pdeLimitOrders, err := statedb.GetPDELimitOrders(stateDB, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
 	return &CurrentPDEState{
 		WaitingPDEContributions:        waitingPDEContributions,
 		PDEPoolPairs:                   pdePoolPairs,
 		PDEShares:                      pdeShares,
 		PDETradingFees:                 pdeTradingFees,
 		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		PDELimitOrders:                 pdeLimitOrders,
+		DeletedPDELimitOrders:          make(map[string]*rawdbv2.PDELimitOrder),
 	}, nil
 }
 
@@ -104,6 +112,11 @@ func storePDEStateToDB(
 	if err != nil {
 		return err
 	}
+	statedb.DeletePDELimitOrders(stateDB, currentPDEState.DeletedPDELimitOrders)
+	err = statedb.StorePDELimitOrders(stateDB, beaconHeight, currentPDEState.PDELimitOrders)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -237,7 +237,7 @@ func processAllNewInsts(
 // test contribution
 func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1001() {
 	fmt.Println("Running testcase: TestSimulatedBeaconBlock1001")
-	bc := &BlockChain{}
+	bc := &BlockChain{config: Config{ChainParams: &Params{}}}
 	shardID := byte(1)
 	beaconHeight := uint64(1001)
 
@@ -404,7 +404,7 @@ func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1001() {
 // test trading and withdrawing contribution
 func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1002() {
 	fmt.Println("Running testcase: TestSimulatedBeaconBlock1002")
-	bc := &BlockChain{}
+	bc := &BlockChain{config: Config{ChainParams: &Params{}}}
 	shardID := byte(1)
 	beaconHeight := uint64(1002)
 
@@ -590,6 +590,7 @@ func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1002() {
 		beaconHeight-1,
 		&s.currentPDEStateForProducer,
 		pdeTradeActionsByShardID,
+		true,
 	)
 
 	s.Equal(5, len(sortedTradableActions))
@@ -937,7 +938,7 @@ func (s *PDETestSuiteV2) SetupTestWithdraw(beaconHeight uint64) {
 
 func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1003() {
 	fmt.Println("Running testcase: TestSimulatedBeaconBlock1003 - Test withdraw")
-	bc := &BlockChain{}
+	bc := &BlockChain{config: Config{ChainParams: &Params{}}}
 	shardID := byte(1)
 	beaconHeight := uint64(1003)
 
@@ -7,6 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/relaying/bnb"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/types"
@@ -33,14 +34,9 @@ type CustodianStateSlice struct {
 	Value *statedb.CustodianState
 }
 
-type RedeemMemoBNB struct {
-	RedeemID                  string `json:"RedeemID"`
-	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
-}
+type RedeemMemoBNB = bnb.RedeemMemo
 
-type PortingMemoBNB struct {
-	PortingID string `json:"PortingID"`
-}
+type PortingMemoBNB = bnb.PortingMemo
 
 func InitCurrentPortalStateFromDB(
 	stateDB *statedb.StateDB,
@@ -375,6 +371,48 @@ func CalAmountNeededDepositLiquidate(currentPortalState *CurrentPortalState, cus
 	return totalAmountNeeded, nil
 }
 
+// CustodianTPRatio is the collateral health of a custodian for a ptoken, it is checked as calAndCheckTPRatio does
+type CustodianTPRatio struct {
+	LockedCollateral   uint64 // locked PRV, collaterals of waiting porting requests are excluded
+	HoldPubTokenAmount uint64
+	HoldPubTokenInPRV  uint64
+	TPRatio            uint64 // LockedCollateral * 100 / HoldPubTokenInPRV
+	IsTP120            bool   // TPRatio <= TP120
+	IsTP130            bool   // TPRatio <= TP130, custodian is liquidated
+}
+
+// CalCustodianTPRatio returns TP ratio of custodian for pTokenId at exchange rates,
+// TP ratio is zero if custodian does not hold any public token
+func CalCustodianTPRatio(portalState *CurrentPortalState, custodian *statedb.CustodianState, exchangeRates *statedb.FinalExchangeRatesState, pTokenId string, portalParams PortalParams) (*CustodianTPRatio, error) {
+	result := &CustodianTPRatio{
+		HoldPubTokenAmount: GetTotalHoldPubTokenAmount(portalState, custodian, pTokenId),
+	}
+	lockedAmount := custodian.GetLockedAmountCollateral()[pTokenId]
+	totalLockedAmountInWaitingPorting := GetTotalLockedCollateralAmountInWaitingPortings(portalState, custodian, pTokenId)
+	if lockedAmount > totalLockedAmountInWaitingPorting {
+		result.LockedCollateral = lockedAmount - totalLockedAmountInWaitingPorting
+	}
+	if result.HoldPubTokenAmount == 0 {
+		return result, nil
+	}
+
+	convertExchangeRatesObj := NewConvertExchangeRatesObject(exchangeRates)
+	holdPubTokenInPRV, err := convertExchangeRatesObj.ExchangePToken2PRVByTokenId(pTokenId, result.HoldPubTokenAmount)
+	if err != nil {
+		return nil, err
+	}
+	if holdPubTokenInPRV == 0 {
+		return nil, fmt.Errorf("Value of public token %v in PRV is zero", pTokenId)
+	}
+	result.HoldPubTokenInPRV = holdPubTokenInPRV
+
+	// lockedAmount * 100 / holdPubTokenInPRV
+	tmp := new(big.Int).Mul(new(big.Int).SetUint64(result.LockedCollateral), big.NewInt(100))
+	result.TPRatio = new(big.Int).Div(tmp, new(big.Int).SetUint64(holdPubTokenInPRV)).Uint64()
+	result.IsTP120, result.IsTP130 = checkTPRatio(result.TPRatio, portalParams)
+	return result, nil
+}
+
 func sortCustodiansByAmountHoldingPubTokenAscent(tokenID string, custodians map[string]*statedb.CustodianState) []*CustodianStateSlice {
 	sortedCustodians := make([]*CustodianStateSlice, 0)
 	for key, value := range custodians {
@@ -445,7 +483,7 @@ func pickupCustodianForRedeem(redeemAmount uint64, tokenID string, portalState *
 
 // convertIncPBNBAmountToExternalBNBAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
 func convertIncPBNBAmountToExternalBNBAmount(incPBNBAmount int64) int64 {
-	return incPBNBAmount / 10 // incPBNBAmount / 1^9 * 1^8
+	return bnb.ConvertIncPBNBAmountToExternalBNBAmount(incPBNBAmount)
 }
 
 // updateCustodianStateAfterReqUnlockCollateral updates custodian state (amount collaterals) when custodian returns redeemAmount public token to user
@@ -620,6 +658,24 @@ func deleteWaitingPortingRequest(state *CurrentPortalState, waitingPortingReques
 	delete(state.WaitingPortingRequests, waitingPortingRequestKey)
 }
 
+// isExchangeRatesStale returns whether final rate of tokenID or PRV is older than MaxExchangeRatesAgeBlocks at beaconHeight,
+// rates are never stale when exchange rates oracle is not enabled
+func isExchangeRatesStale(finalExchangeRates *statedb.FinalExchangeRatesState, tokenID string, beaconHeight uint64, portalParams PortalParams) bool {
+	if len(portalParams.FeederAddresses) == 0 || portalParams.MaxExchangeRatesAgeBlocks == 0 {
+		return false
+	}
+	if finalExchangeRates == nil {
+		return true
+	}
+	for _, id := range []string{tokenID, common.PRVIDStr} {
+		detail, ok := finalExchangeRates.Rates()[id]
+		if !ok || detail.BeaconHeight+portalParams.MaxExchangeRatesAgeBlocks < beaconHeight {
+			return true
+		}
+	}
+	return false
+}
+
 type ConvertExchangeRatesObject struct {
 	finalExchangeRates *statedb.FinalExchangeRatesState
 }
@@ -628,36 +684,24 @@ func NewConvertExchangeRatesObject(finalExchangeRates *statedb.FinalExchangeRate
 	return &ConvertExchangeRatesObject{finalExchangeRates: finalExchangeRates}
 }
 
+// ExchangePToken2PRVByTokenId converts value of portal token pTokenId to PRV with final exchange rates
 func (c ConvertExchangeRatesObject) ExchangePToken2PRVByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		result, err := c.ExchangeBTC2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
-	case common.PortalBNBIDStr:
-		result, err := c.ExchangeBNB2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
+	if !common.IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
-
-	return 0, errors.New("Ptoken is not support")
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
+	return c.convert(value, pTokenRates, PRVRates)
 }
 
+// ExchangePRV2PTokenByTokenId converts value of PRV to portal token pTokenId with final exchange rates
 func (c *ConvertExchangeRatesObject) ExchangePRV2PTokenByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		return c.ExchangePRV2BTC(value)
-	case common.PortalBNBIDStr:
-		return c.ExchangePRV2BNB(value)
+	if !common.IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
-
-	return 0, errors.New("Ptoken is not support")
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
+	return c.convert(value, PRVRates, pTokenRates)
 }
 
 func (c *ConvertExchangeRatesObject) convert(value uint64, ratesFrom uint64, RatesTo uint64) (uint64, error) {
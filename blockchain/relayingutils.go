@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/relaying"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 	"github.com/pkg/errors"
@@ -176,6 +177,25 @@ func NewPortalManager() *portalManager {
 	}
 }
 
+// relayingHeaderMetaTokenIDs maps metadata type of relaying header txs to public token of the relaying chain
+var relayingHeaderMetaTokenIDs = map[int]string{
+	metadata.RelayingBTCHeaderMeta: common.PortalBTCIDStr,
+	metadata.RelayingBNBHeaderMeta: common.PortalBNBIDStr,
+}
+
+// initRelayingChains registers external chains which portal supports
+func (bc *BlockChain) initRelayingChains() {
+	var btcRelayingChainID, bnbRelayingChainID string
+	if bc.config.ChainParams != nil {
+		btcRelayingChainID = bc.config.ChainParams.BTCRelayingHeaderChainID
+		bnbRelayingChainID = bc.config.ChainParams.BNBRelayingHeaderChainID
+	}
+	bc.relayingChains = map[string]relaying.RelayingChain{
+		common.PortalBTCIDStr: btcrelaying.NewPortalChain(bc.config.BTCChain, common.PortalBTCIDStr, btcRelayingChainID),
+		common.PortalBNBIDStr: bnbrelaying.NewPortalChain(bc, common.PortalBNBIDStr, bnbRelayingChainID),
+	}
+}
+
 type RelayingHeaderChainState struct {
 	BNBHeaderChain *bnbrelaying.BNBChainState
 	BTCHeaderChain *btcrelaying.BlockChain
@@ -24,6 +24,26 @@ func (blockchain *BlockChain) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	return blockchain.config.ChainParams.BeaconHeightBreakPointBurnAddr
 }
 
+func (blockchain *BlockChain) GetBeaconHeightBreakPointPrivacyV2() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointPrivacyV2
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointSlashing() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointSlashing
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointLimitOrder() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointLimitOrder
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointBestRoute() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointBestRoute
+}
+
+func (blockchain *BlockChain) GetBeaconHeightBreakPointDelegation() uint64 {
+	return blockchain.config.ChainParams.BeaconHeightBreakPointDelegation
+}
+
 func (blockchain *BlockChain) GetBurningAddress(beaconHeight uint64) string {
 	breakPoint := blockchain.GetBeaconHeightBreakPointBurnAddr()
 	if beaconHeight == 0 {
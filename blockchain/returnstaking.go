@@ -19,6 +19,7 @@ type returnStakingInfo struct {
 	FunderAddress privacy.PaymentAddress
 	StakingTx     metadata.Transaction
 	StakingAmount uint64
+	SlashedAmount uint64
 }
 
 func (blockchain *BlockChain) buildReturnStakingTxFromBeaconInstructions(
@@ -143,7 +144,7 @@ func (blockchain *BlockChain) buildReturnStakingAmountTx(
 		metadata.ReturnStakingMeta,
 	)
 	returnStakingTx := new(transaction.Tx)
-	stakeAmount := info.StakingTx.CalculateTxValue()
+	stakeAmount := info.StakingTx.CalculateTxValue() - info.SlashedAmount
 	err := returnStakingTx.InitTxSalary(
 		stakeAmount,
 		&info.FunderAddress,
@@ -177,7 +178,13 @@ func (blockchain *BlockChain) getReturnStakingInfoFromBeaconInstructions(
 	for _, beaconBlock := range beaconBlocks {
 		beaconConsensusStateDB = nil
 		for _, l := range beaconBlock.Body.Instructions {
+			outPublicKeys := []string{}
 			if l[0] == SwapAction {
+				outPublicKeys = strings.Split(l[2], ",")
+			} else if slashingContent, ok := blockchain.parseDoubleSignSlashingInst(l, beaconBlock.GetHeight()); ok && slashingContent.Ejected {
+				outPublicKeys = []string{slashingContent.CommitteePublicKey}
+			}
+			if len(outPublicKeys) != 0 {
 				if beaconConsensusStateDB == nil {
 					beaconConsensusRootHash, err = blockchain.GetBeaconConsensusRootHash(beaconView, beaconBlock.GetHeight())
 					if err != nil {
@@ -185,7 +192,7 @@ func (blockchain *BlockChain) getReturnStakingInfoFromBeaconInstructions(
 					}
 					beaconConsensusStateDB, err = statedb.NewWithPrefixTrie(beaconConsensusRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
 				}
-				for _, outPublicKey := range strings.Split(l[2], ",") {
+				for _, outPublicKey := range outPublicKeys {
 					if len(outPublicKey) == 0 {
 						continue
 					}
@@ -202,7 +209,11 @@ func (blockchain *BlockChain) getReturnStakingInfoFromBeaconInstructions(
 					if stakerInfo.AutoStaking() || (stakerInfo.TxStakingID() == common.HashH([]byte{0})) {
 						continue
 					}
-					if _, ok := res[stakerInfo.TxStakingID()]; ok {
+					if returnInfo, ok := res[stakerInfo.TxStakingID()]; ok {
+						// an ejected offender could also be swapped out by a shard block built before its ejection
+						if returnInfo.SwapoutPubKey == outPublicKey {
+							continue
+						}
 						err = errors.Errorf("Dupdate return staking using tx staking %v", stakerInfo.TxStakingID().String())
 						return nil, nil, err
 					}
@@ -236,11 +247,20 @@ func (blockchain *BlockChain) getReturnStakingInfoFromBeaconInstructions(
 						Logger.log.Error(err)
 						continue
 					}
+					slashedAmount := uint64(0)
+					slashedStake, has, err := statedb.GetSlashedStake(beaconConsensusStateDB, outPublicKey)
+					if err != nil {
+						return nil, nil, err
+					}
+					if has && slashedStake.TxStakingID() == stakerInfo.TxStakingID() {
+						slashedAmount = slashedStake.SlashedAmount()
+					}
 					res[stakerInfo.TxStakingID()] = returnStakingInfo{
 						SwapoutPubKey: outPublicKey,
 						FunderAddress: keyWallet.KeySet.PaymentAddress,
 						StakingTx:     txData,
-						StakingAmount: txMeta.StakingAmountShard,
+						StakingAmount: txMeta.StakingAmountShard - slashedAmount,
+						SlashedAmount: slashedAmount,
 					}
 				}
 			}
@@ -2,6 +2,8 @@ package blockchain
 
 import (
 	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -67,7 +69,8 @@ func (blockchain *BlockChain) addShardRewardRequestToBeacon(beaconBlock *BeaconB
 }
 
 func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.StateDB, beaconBlocks []*BeaconBlock, shardID byte) error {
-	cInfos := make(map[int][]*statedb.StakerInfo)
+	cInfos := make(map[int]map[string]*statedb.StakerInfo)
+	cDelegationInfos := make(map[int]map[string]*statedb.CommitteeDelegationInfo)
 	isInit := false
 	epoch := uint64(0)
 	for _, beaconBlock := range beaconBlocks {
@@ -141,13 +144,46 @@ func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.S
 					if err != nil {
 						return NewBlockChainError(ProcessSalaryInstructionsError, err)
 					}
-					cInfos = statedb.GetAllCommitteeStakeInfo(beaconConsensusStateDB, blockchain.GetShardIDs())
+					cInfos, err = statedb.GetAllCommitteeStakeInfoByCommitteePublicKey(beaconConsensusStateDB, blockchain.GetShardIDs())
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
+					cDelegationInfos, err = statedb.GetAllCommitteeDelegationInfo(beaconConsensusStateDB, blockchain.GetShardIDs(), shardRewardInfo.Epoch)
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
 				}
-				err = blockchain.addShardCommitteeRewardV2(rewardStateDB, shardID, shardRewardInfo, cInfos[int(shardToProcess)])
+				// rewards are split with delegators only after delegations are enabled
+				var shardDelegationInfos map[string]*statedb.CommitteeDelegationInfo
+				if blockchain.isDelegationEnabled(beaconBlock.Header.Height) {
+					shardDelegationInfos = cDelegationInfos[int(shardToProcess)]
+				}
+				err = blockchain.addShardCommitteeRewardV2(rewardStateDB, shardID, shardRewardInfo, cInfos[int(shardToProcess)], shardDelegationInfos)
 				if err != nil {
 					return err
 				}
 				continue
+			case metadata.DoubleSignEvidenceMeta:
+				// the slashed stake of a double sign offender goes to the DAO
+				slashingContent, ok := blockchain.parseDoubleSignSlashingInst(l, beaconBlock.Header.Height)
+				if !ok {
+					continue
+				}
+				keyWalletDevAccount, err := wallet.Base58CheckDeserialize(blockchain.config.ChainParams.IncognitoDAOAddress)
+				if err != nil {
+					return NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+				daoPk := keyWalletDevAccount.KeySet.PaymentAddress.Pk
+				if common.GetShardIDFromLastByte(daoPk[len(daoPk)-1]) != shardID {
+					continue
+				}
+				tempPublicKey := base58.Base58Check{}.Encode(daoPk, common.Base58Version)
+				Logger.log.Criticalf("Add Committee Reward DoubleSignSlashing, Public Key %+v, reward %+v, token %+v", tempPublicKey, slashingContent.SlashedAmount, common.PRVCoinID)
+				err = statedb.AddCommitteeReward(rewardStateDB, tempPublicKey, slashingContent.SlashedAmount, common.PRVCoinID)
+				if err != nil {
+					return NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+				continue
 			}
 
 		}
@@ -159,17 +195,47 @@ func (blockchain *BlockChain) addShardCommitteeRewardV2(
 	rewardStateDB *statedb.StateDB,
 	shardID byte,
 	rewardInfoShardToProcess *metadata.ShardBlockRewardInfo,
-	cStakeInfos []*statedb.StakerInfo,
+	cStakeInfos map[string]*statedb.StakerInfo,
+	cDelegationInfos map[string]*statedb.CommitteeDelegationInfo,
 ) (
 	err error,
 ) {
 	committeeSize := len(cStakeInfos)
-	for _, candidate := range cStakeInfos {
-		if common.GetShardIDFromLastByte(candidate.RewardReceiver().Pk[common.PublicKeySize-1]) == shardID {
-			for key, value := range rewardInfoShardToProcess.ShardReward {
+	committeePublicKeys := []string{}
+	for committeePublicKey := range cStakeInfos {
+		committeePublicKeys = append(committeePublicKeys, committeePublicKey)
+	}
+	sort.Strings(committeePublicKeys)
+	for _, committeePublicKey := range committeePublicKeys {
+		candidate := cStakeInfos[committeePublicKey]
+		delegationInfo := cDelegationInfos[committeePublicKey]
+		for key, value := range rewardInfoShardToProcess.ShardReward {
+			candidateReward, delegatorRewards := splitCommitteeRewardWithDelegations(value/uint64(committeeSize), blockchain.config.ChainParams.StakingAmountShard, delegationInfo)
+			if common.GetShardIDFromLastByte(candidate.RewardReceiver().Pk[common.PublicKeySize-1]) == shardID {
 				tempPK := base58.Base58Check{}.Encode(candidate.RewardReceiver().Pk, common.Base58Version)
-				Logger.log.Criticalf("Add Committee Reward ShardCommitteeReward, Public Key %+v, reward %+v, token %+v", tempPK, value/uint64(committeeSize), key)
-				err = statedb.AddCommitteeReward(rewardStateDB, tempPK, value/uint64(committeeSize), key)
+				Logger.log.Criticalf("Add Committee Reward ShardCommitteeReward, Public Key %+v, reward %+v, token %+v", tempPK, candidateReward, key)
+				err = statedb.AddCommitteeReward(rewardStateDB, tempPK, candidateReward, key)
+				if err != nil {
+					return NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+			}
+			delegatorAddresses := []string{}
+			for delegatorAddress := range delegatorRewards {
+				delegatorAddresses = append(delegatorAddresses, delegatorAddress)
+			}
+			sort.Strings(delegatorAddresses)
+			for _, delegatorAddress := range delegatorAddresses {
+				keyWallet, err := wallet.Base58CheckDeserialize(delegatorAddress)
+				if err != nil {
+					return NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+				delegatorPk := keyWallet.KeySet.PaymentAddress.Pk
+				if common.GetShardIDFromLastByte(delegatorPk[len(delegatorPk)-1]) != shardID {
+					continue
+				}
+				tempPK := base58.Base58Check{}.Encode(delegatorPk, common.Base58Version)
+				Logger.log.Criticalf("Add Committee Reward DelegatorReward, Public Key %+v, reward %+v, token %+v", tempPK, delegatorRewards[delegatorAddress], key)
+				err = statedb.AddCommitteeReward(rewardStateDB, tempPK, delegatorRewards[delegatorAddress], key)
 				if err != nil {
 					return NewBlockChainError(ProcessSalaryInstructionsError, err)
 				}
@@ -179,6 +245,51 @@ func (blockchain *BlockChain) addShardCommitteeRewardV2(
 	return nil
 }
 
+// splitCommitteeRewardWithDelegations splits the reward of a validator by the share of its stake and of its delegations,
+// the stake of the validator is what remains of stakingAmount after its slash for double signing, if any.
+// The validator takes its commission on the part earned by delegations and the rest is shared by delegators pro rata,
+// remainders of integer divisions go to the validator
+func splitCommitteeRewardWithDelegations(
+	reward uint64,
+	stakingAmount uint64,
+	delegationInfo *statedb.CommitteeDelegationInfo,
+) (uint64, map[string]uint64) {
+	delegatorRewards := map[string]uint64{}
+	if delegationInfo == nil || len(delegationInfo.Delegations) == 0 || reward == 0 {
+		return reward, delegatorRewards
+	}
+	if delegationInfo.SlashedAmount >= stakingAmount {
+		stakingAmount = 0
+	} else {
+		stakingAmount -= delegationInfo.SlashedAmount
+	}
+	totalDelegated := big.NewInt(0)
+	for _, delegation := range delegationInfo.Delegations {
+		totalDelegated.Add(totalDelegated, new(big.Int).SetUint64(delegation.Amount()))
+	}
+	total := new(big.Int).Add(totalDelegated, new(big.Int).SetUint64(stakingAmount))
+	if totalDelegated.Sign() == 0 || total.Sign() == 0 {
+		return reward, delegatorRewards
+	}
+	delegatedReward := new(big.Int).Mul(new(big.Int).SetUint64(reward), totalDelegated)
+	delegatedReward.Div(delegatedReward, total)
+	commission := new(big.Int).Mul(delegatedReward, new(big.Int).SetUint64(delegationInfo.CommissionRate))
+	commission.Div(commission, big.NewInt(metadata.MaxCommissionRate))
+	delegatorsReward := new(big.Int).Sub(delegatedReward, commission)
+
+	distributed := uint64(0)
+	for _, delegation := range delegationInfo.Delegations {
+		delegatorReward := new(big.Int).Mul(delegatorsReward, new(big.Int).SetUint64(delegation.Amount()))
+		delegatorReward.Div(delegatorReward, totalDelegated)
+		if delegatorReward.Sign() == 0 {
+			continue
+		}
+		delegatorRewards[delegation.DelegatorAddress()] += delegatorReward.Uint64()
+		distributed += delegatorReward.Uint64()
+	}
+	return reward - distributed, delegatorRewards
+}
+
 func (blockchain *BlockChain) addShardCommitteeReward(rewardStateDB *statedb.StateDB, shardID byte, rewardInfoShardToProcess *metadata.ShardBlockRewardInfo, committeeOfShardToProcess []incognitokey.CommitteePublicKey, rewardReceiver map[string]string) (err error) {
 	committeeSize := len(committeeOfShardToProcess)
 	for _, candidate := range committeeOfShardToProcess {
@@ -655,6 +655,10 @@ func (oldBestState *ShardBestState) updateShardBestState(blockchain *BlockChain,
 	if err != nil {
 		return nil, err
 	}
+	err = shardBestState.processDoubleSignSlashingForShard(blockchain, beaconBlocks, shardBlock, committeeChange)
+	if err != nil {
+		return nil, err
+	}
 	//updateShardBestState best cross shard
 	for shardID, crossShardBlock := range shardBlock.Body.CrossTransactions {
 		shardBestState.BestCrossShard[shardID] = crossShardBlock[len(crossShardBlock)-1].BlockHeight
@@ -335,6 +335,10 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDECrossPoolTradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
+			case metadata.PDELimitOrderRequestMeta:
+				if len(l) >= 4 && l[2] != common.PDELimitOrderWaitingChainStatus {
+					newTx, err = blockGenerator.buildPDELimitOrderIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
 			case metadata.PDEWithdrawalRequestMeta:
 				if len(l) >= 4 && l[2] == common.PDEWithdrawalAcceptedChainStatus {
 					newTx, err = blockGenerator.buildPDEWithdrawalTx(l[3], producerPrivateKey, shardID, curView, beaconView)
@@ -351,6 +355,14 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 						newTx, err = blockGenerator.buildPDEMatchedNReturnedContributionTx(l[3], producerPrivateKey, shardID, curView, beaconView)
 					}
 				}
+			case metadata.DelegationMeta:
+				if len(l) >= 4 && l[2] == common.DelegationRejectedChainStatus {
+					newTx, err = blockGenerator.buildReturnDelegationTx(l, producerPrivateKey, shardID, curView)
+				}
+			case metadata.UnDelegationMeta:
+				if len(l) >= 4 && l[2] == common.DelegationReturnedChainStatus {
+					newTx, err = blockGenerator.buildReturnDelegationTx(l, producerPrivateKey, shardID, curView)
+				}
 			// portal
 			case metadata.PortalUserRegisterMeta:
 				if len(l) >= 4 && l[2] == common.PortalPortingRequestRejectedChainStatus {
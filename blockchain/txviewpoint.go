@@ -26,6 +26,8 @@ type TxViewPoint struct {
 	mapCommitments map[string][][]byte //map[base58check.encode{pubkey}]([]([]byte-commitment))
 	// use to fetch output coin
 	mapOutputCoins map[string][]privacy.OutputCoin
+	// commitments of output coins of privacy v2, which are stored in the list of v2 coins
+	mapCommitmentsV2 map[string]bool
 
 	// data of PRIVACY custom token
 	privacyCustomTokenViewPoint map[int32]*TxViewPoint // sub tx viewpoint for token
@@ -43,6 +45,7 @@ func NewTxViewPoint(shardID byte) *TxViewPoint {
 		listSerialNumbers:           make([][]byte, 0),
 		mapCommitments:              make(map[string][][]byte),
 		mapOutputCoins:              make(map[string][]privacy.OutputCoin),
+		mapCommitmentsV2:            make(map[string]bool),
 		mapSnD:                      make(map[string][][]byte),
 		tokenID:                     &common.Hash{},
 		privacyCustomTokenViewPoint: make(map[int32]*TxViewPoint),
@@ -132,6 +135,9 @@ func (view *TxViewPoint) processFetchTxViewPoint(stateDB *statedb.StateDB, shard
 				acceptedOutputcoins[publicKeyStr] = make([]privacy.OutputCoin, 0)
 			}
 			acceptedOutputcoins[publicKeyStr] = append(acceptedOutputcoins[publicKeyStr], *item)
+			if proof.IsPrivacyV2() {
+				view.mapCommitmentsV2[string(commitment)] = true
+			}
 		}
 
 		// get data for Snderivators
@@ -315,6 +321,11 @@ func (view *TxViewPoint) processFetchCrossOutputViewPoint(stateDB *statedb.State
 				acceptedOutputcoins[pubkeyStr] = make([]privacy.OutputCoin, 0)
 			}
 			acceptedOutputcoins[pubkeyStr] = append(acceptedOutputcoins[pubkeyStr], *item)
+			// cross shard outputs do not keep version of their txs, privacy v1 txs can not create
+			// output coins with snDerivator of privacy v2 after its activation, so coins are told apart by snDerivator
+			if privacy.IsOutputCoinV2(item.CoinDetails) {
+				view.mapCommitmentsV2[string(commitment)] = true
+			}
 		}
 
 		// get data for Snderivators
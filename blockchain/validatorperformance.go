@@ -0,0 +1,365 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// max number of finalized blocks of a chain which are indexed in a batch of validator performance
+const validatorPerformanceBlocksPerPass = 100
+
+// ValidatorPerformance - statistics of a committee member in an epoch, derived from finalized blocks:
+// VoteLatency is the total number of timeslots between the production of the blocks voted by the member
+// and the proposal gathering their votes (re-proposed blocks have a latency greater than 0)
+type ValidatorPerformance struct {
+	ProposedBlocks  uint64
+	MissedProposals uint64
+	VotedBlocks     uint64
+	MissedVotes     uint64
+	VoteLatency     uint64
+}
+
+// EpochValidatorPerformance - statistics of the committee members of a chain (shard ID or -1 for beacon) in an epoch
+type EpochValidatorPerformance struct {
+	ChainID    int
+	Epoch      uint64
+	Validators map[string]*ValidatorPerformance
+}
+
+// blockValidators is the part of the validation data of a block (blsbft & blsbftv2) listing the committee members who voted
+type blockValidators struct {
+	ValidatiorsIdx []int
+}
+
+func NewEpochValidatorPerformance(chainID int, epoch uint64) *EpochValidatorPerformance {
+	return &EpochValidatorPerformance{
+		ChainID:    chainID,
+		Epoch:      epoch,
+		Validators: make(map[string]*ValidatorPerformance),
+	}
+}
+
+func (epochPerformance *EpochValidatorPerformance) getValidator(committeePublicKey string) *ValidatorPerformance {
+	performance, ok := epochPerformance.Validators[committeePublicKey]
+	if !ok {
+		performance = &ValidatorPerformance{}
+		epochPerformance.Validators[committeePublicKey] = performance
+	}
+	return performance
+}
+
+// addBlock updates statistics with a finalized block, committee is the committee which validated the block,
+// timeslots without block between prevBlock and block (consensus version 2 only) are missed by their proposers
+func (epochPerformance *EpochValidatorPerformance) addBlock(
+	block common.BlockInterface,
+	prevBlock common.BlockInterface,
+	committee []string,
+	minCommitteeSize int,
+) error {
+	if len(committee) == 0 {
+		return nil
+	}
+	proposer := block.GetProposer()
+	if proposer == common.EmptyString {
+		proposer = block.GetProducer()
+	}
+	epochPerformance.getValidator(proposer).ProposedBlocks++
+
+	voteLatency := uint64(0)
+	if block.GetVersion() == 2 {
+		proposeTimeSlot := common.CalculateTimeSlot(block.GetProposeTime())
+		produceTimeSlot := common.CalculateTimeSlot(block.GetProduceTime())
+		if proposeTimeSlot > produceTimeSlot {
+			voteLatency = uint64(proposeTimeSlot - produceTimeSlot)
+		}
+		if prevBlock != nil && prevBlock.GetVersion() == 2 && minCommitteeSize > 0 {
+			for timeSlot := common.CalculateTimeSlot(prevBlock.GetProposeTime()) + 1; timeSlot < proposeTimeSlot; timeSlot++ {
+				index := GetProposerByTimeSlot(timeSlot, minCommitteeSize)
+				if index < len(committee) {
+					epochPerformance.getValidator(committee[index]).MissedProposals++
+				}
+			}
+		}
+	}
+
+	var validators blockValidators
+	if err := json.Unmarshal([]byte(block.GetValidationField()), &validators); err != nil {
+		return err
+	}
+	voted := make(map[int]bool)
+	for _, index := range validators.ValidatiorsIdx {
+		voted[index] = true
+	}
+	for index, committeePublicKey := range committee {
+		performance := epochPerformance.getValidator(committeePublicKey)
+		if voted[index] {
+			performance.VotedBlocks++
+			performance.VoteLatency += voteLatency
+		} else {
+			performance.MissedVotes++
+		}
+	}
+	return nil
+}
+
+// GetValidatorPerformance returns statistics of the committee members of a chain (shard ID or -1 for beacon) in an epoch
+func (blockchain *BlockChain) GetValidatorPerformance(chainID int, epoch uint64) (*EpochValidatorPerformance, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return getValidatorPerformance(db, chainID, epoch)
+}
+
+func getValidatorPerformance(db incdb.KeyValueReader, chainID int, epoch uint64) (*EpochValidatorPerformance, error) {
+	has, err := rawdbv2.HasValidatorPerformance(db, chainID, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return NewEpochValidatorPerformance(chainID, epoch), nil
+	}
+	data, err := rawdbv2.GetValidatorPerformance(db, chainID, epoch)
+	if err != nil {
+		return nil, err
+	}
+	epochPerformance := NewEpochValidatorPerformance(chainID, epoch)
+	err = json.Unmarshal(data, epochPerformance)
+	return epochPerformance, err
+}
+
+func (blockchain *BlockChain) getChainDatabase(chainID int) (incdb.Database, error) {
+	if chainID == common.BeaconChainDataBaseID {
+		return blockchain.GetBeaconChainDatabase(), nil
+	}
+	if chainID < 0 || chainID >= blockchain.GetActiveShardNumber() {
+		return nil, fmt.Errorf("chain ID %+v not found", chainID)
+	}
+	return blockchain.GetShardChainDatabase(byte(chainID)), nil
+}
+
+// getValidatorCommitteeOfBlock returns the committee which validated a block, stored in the consensus state of its previous block
+func (blockchain *BlockChain) getValidatorCommitteeOfBlock(chainID int, block common.BlockInterface) ([]string, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	var consensusStateDBRootHash common.Hash
+	if chainID == common.BeaconChainDataBaseID {
+		data, err := rawdbv2.GetBeaconRootsHash(db, block.GetPrevHash())
+		if err != nil {
+			return nil, err
+		}
+		bRH := &BeaconRootHash{}
+		if err := json.Unmarshal(data, bRH); err != nil {
+			return nil, err
+		}
+		consensusStateDBRootHash = bRH.ConsensusStateDBRootHash
+	} else {
+		data, err := rawdbv2.GetShardRootsHash(db, byte(chainID), block.GetPrevHash())
+		if err != nil {
+			return nil, err
+		}
+		sRH := &ShardRootHash{}
+		if err := json.Unmarshal(data, sRH); err != nil {
+			return nil, err
+		}
+		consensusStateDBRootHash = sRH.ConsensusStateDBRootHash
+	}
+	consensusStateDB, err := statedb.NewWithPrefixTrie(consensusStateDBRootHash, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, err
+	}
+	var committee []incognitokey.CommitteePublicKey
+	if chainID == common.BeaconChainDataBaseID {
+		committee = statedb.GetBeaconCommittee(consensusStateDB)
+	} else {
+		committee = statedb.GetOneShardCommittee(consensusStateDB, byte(chainID))
+	}
+	return incognitokey.CommitteeKeyListToString(committee)
+}
+
+func (blockchain *BlockChain) getPreviousBlock(chainID int, block common.BlockInterface) (common.BlockInterface, error) {
+	if chainID == common.BeaconChainDataBaseID {
+		prevBlock, _, err := blockchain.GetBeaconBlockByHash(block.GetPrevHash())
+		return prevBlock, err
+	}
+	prevBlock, _, err := blockchain.GetShardBlockByHashWithShardID(block.GetPrevHash(), byte(chainID))
+	return prevBlock, err
+}
+
+// getFinalizedBlock returns the finalized block of a chain at a height
+func (blockchain *BlockChain) getFinalizedBlock(chainID int, height uint64) (common.BlockInterface, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if chainID == common.BeaconChainDataBaseID {
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+		if err != nil {
+			return nil, err
+		}
+		block, _, err := blockchain.GetBeaconBlockByHash(*hash)
+		return block, err
+	}
+	hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, byte(chainID), height)
+	if err != nil {
+		return nil, err
+	}
+	block, _, err := blockchain.GetShardBlockByHashWithShardID(*hash, byte(chainID))
+	return block, err
+}
+
+// storeValidatorPerformance updates statistics of the committee members with finalized blocks (in ascending order of height)
+// and returns the updated statistics, these statistics are tracked by this node only and are not part of the chain state
+func (blockchain *BlockChain) storeValidatorPerformance(
+	batch incdb.Batch,
+	chainID int,
+	finalizedBlocks []common.BlockInterface,
+	minCommitteeSize int,
+) ([]*EpochValidatorPerformance, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	epochPerformances := []*EpochValidatorPerformance{}
+	var epochPerformance *EpochValidatorPerformance
+	var prevBlock common.BlockInterface
+	for _, block := range finalizedBlocks {
+		if block.GetHeight() <= 1 {
+			prevBlock = block
+			continue
+		}
+		if epochPerformance == nil || epochPerformance.Epoch != block.GetCurrentEpoch() {
+			epochPerformance, err = getValidatorPerformance(db, chainID, block.GetCurrentEpoch())
+			if err != nil {
+				return nil, err
+			}
+			epochPerformances = append(epochPerformances, epochPerformance)
+		}
+		if prevBlock == nil || *prevBlock.Hash() != block.GetPrevHash() {
+			prevBlock, err = blockchain.getPreviousBlock(chainID, block)
+			if err != nil {
+				return nil, err
+			}
+		}
+		committee, err := blockchain.getValidatorCommitteeOfBlock(chainID, block)
+		if err != nil {
+			return nil, err
+		}
+		if err := epochPerformance.addBlock(block, prevBlock, committee, minCommitteeSize); err != nil {
+			return nil, err
+		}
+		prevBlock = block
+	}
+	for _, epochPerformance := range epochPerformances {
+		data, err := json.Marshal(epochPerformance)
+		if err != nil {
+			return nil, err
+		}
+		if err := rawdbv2.StoreValidatorPerformance(batch, chainID, epochPerformance.Epoch, data); err != nil {
+			return nil, err
+		}
+	}
+	return epochPerformances, nil
+}
+
+// indexValidatorPerformance indexes finalized blocks of a chain after the last indexed height, a chain which is not indexed yet
+// is indexed from its current final height; on error, blocks which are not stored are indexed again with the next block of the chain
+func (blockchain *BlockChain) indexValidatorPerformance(chainID int) error {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return err
+	}
+	var finalHeight uint64
+	var minCommitteeSize int
+	if chainID == common.BeaconChainDataBaseID {
+		finalHeight = blockchain.BeaconChain.GetFinalViewHeight()
+		minCommitteeSize = blockchain.config.ChainParams.MinBeaconCommitteeSize
+	} else {
+		finalHeight = blockchain.ShardChain[byte(chainID)].GetFinalViewHeight()
+		minCommitteeSize = blockchain.config.ChainParams.MinShardCommitteeSize
+	}
+	indexedHeight, ok, err := rawdbv2.GetValidatorPerformanceIndexedHeight(db, chainID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return rawdbv2.StoreValidatorPerformanceIndexedHeight(db, chainID, finalHeight)
+	}
+	for indexedHeight < finalHeight {
+		toHeight := indexedHeight + validatorPerformanceBlocksPerPass
+		if toHeight > finalHeight {
+			toHeight = finalHeight
+		}
+		finalizedBlocks := []common.BlockInterface{}
+		for height := indexedHeight + 1; height <= toHeight; height++ {
+			block, err := blockchain.getFinalizedBlock(chainID, height)
+			if err != nil {
+				return err
+			}
+			finalizedBlocks = append(finalizedBlocks, block)
+		}
+		batch := db.NewBatch()
+		epochPerformances, err := blockchain.storeValidatorPerformance(batch, chainID, finalizedBlocks, minCommitteeSize)
+		if err != nil {
+			return err
+		}
+		if err := rawdbv2.StoreValidatorPerformanceIndexedHeight(batch, chainID, toHeight); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		for _, epochPerformance := range epochPerformances {
+			go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ValidatorPerformanceTopic, epochPerformance))
+		}
+		indexedHeight = toHeight
+	}
+	return nil
+}
+
+// IndexValidatorPerformance tracks statistics of committee members from finalized blocks until cQuit is closed,
+// it runs apart from block insertion: each inserted block of a chain triggers indexing of the chain up to its final height
+func (blockchain *BlockChain) IndexValidatorPerformance(cQuit chan struct{}) {
+	pubSubManager := blockchain.config.PubSubManager
+	shardSubID, shardSubChan, err := pubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		Logger.log.Errorf("Validator performance | Subscribe shard blocks error %+v", err)
+		return
+	}
+	defer pubSubManager.Unsubscribe(pubsub.NewShardblockTopic, shardSubID)
+	beaconSubID, beaconSubChan, err := pubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	if err != nil {
+		Logger.log.Errorf("Validator performance | Subscribe beacon blocks error %+v", err)
+		return
+	}
+	defer pubSubManager.Unsubscribe(pubsub.NewBeaconBlockTopic, beaconSubID)
+	for {
+		select {
+		case msg := <-shardSubChan:
+			shardBlock, ok := msg.Value.(*ShardBlock)
+			if !ok {
+				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+				continue
+			}
+			if err := blockchain.indexValidatorPerformance(int(shardBlock.Header.ShardID)); err != nil {
+				Logger.log.Errorf("SHARD %+v | Index validator performance error %+v", shardBlock.Header.ShardID, err)
+			}
+		case <-beaconSubChan:
+			if err := blockchain.indexValidatorPerformance(common.BeaconChainDataBaseID); err != nil {
+				Logger.log.Errorf("Beacon | Index validator performance error %+v", err)
+			}
+		case <-cQuit:
+			return
+		}
+	}
+}
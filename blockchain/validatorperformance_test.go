@@ -0,0 +1,41 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpochValidatorPerformanceAddBlock(t *testing.T) {
+	committee := []string{"cpk-0", "cpk-1", "cpk-2", "cpk-3"}
+	newBlock := func(producer string, proposer string, produceTimeSlot int64, proposeTimeSlot int64, validationData string) *ShardBlock {
+		return &ShardBlock{
+			ValidationData: validationData,
+			Header: ShardHeader{
+				Version:     2,
+				Producer:    producer,
+				Proposer:    proposer,
+				Timestamp:   produceTimeSlot * common.TIMESLOT,
+				ProposeTime: proposeTimeSlot * common.TIMESLOT,
+			},
+		}
+	}
+	epochPerformance := NewEpochValidatorPerformance(0, 1)
+
+	// timeslot 101 proposed in time, all committee members voted
+	prevBlock := newBlock("cpk-0", "cpk-0", 100, 100, `{"ValidatiorsIdx":[0,1,2,3]}`)
+	block := newBlock("cpk-1", "cpk-1", 101, 101, `{"ValidatiorsIdx":[0,1,2,3]}`)
+	assert.NoError(t, epochPerformance.addBlock(block, prevBlock, committee, 4))
+	// timeslots 102, 103 missed by cpk-2, cpk-3, block produced by cpk-0 at timeslot 104 re-proposed by cpk-1 at 105 without the vote of cpk-3
+	prevBlock = block
+	block = newBlock("cpk-0", "cpk-1", 104, 105, `{"ValidatiorsIdx":[0,1,2]}`)
+	assert.NoError(t, epochPerformance.addBlock(block, prevBlock, committee, 4))
+
+	assert.Equal(t, &ValidatorPerformance{MissedProposals: 1, VotedBlocks: 2, VoteLatency: 1}, epochPerformance.Validators["cpk-0"])
+	assert.Equal(t, &ValidatorPerformance{ProposedBlocks: 2, VotedBlocks: 2, VoteLatency: 1}, epochPerformance.Validators["cpk-1"])
+	assert.Equal(t, &ValidatorPerformance{MissedProposals: 1, VotedBlocks: 2, VoteLatency: 1}, epochPerformance.Validators["cpk-2"])
+	assert.Equal(t, &ValidatorPerformance{MissedProposals: 1, VotedBlocks: 1, MissedVotes: 1}, epochPerformance.Validators["cpk-3"])
+
+	assert.Error(t, epochPerformance.addBlock(newBlock("cpk-2", "cpk-2", 106, 106, "invalid"), block, committee, 4))
+}
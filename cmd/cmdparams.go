@@ -45,6 +45,25 @@ type params struct {
 	WalletAccountName string `long:"walletaccountname" description:"Wallet account name"`
 	ShardID           int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
 
+	// offline signing
+	PrivateKey     string `long:"privatekey" description:"Private key to sign transaction, wallet account is used if empty"`
+	UnsignedTxFile string `long:"unsignedtxfile" description:"File contains unsigned transaction from createunsignedtransaction"`
+	SignedTxFile   string `long:"signedtxfile" description:"File to store signed transaction"`
+	// without --expect-output, the transaction is shown and signed after confirmation
+	ExpectOutputs      []string `long:"expect-output" description:"Expected payment of transaction as paymentaddress:amount or paymentaddress:amount:tokenid, repeated for each payment"`
+	MaxFee             uint64   `long:"max-fee" description:"Max fee of transaction, required with --expect-output"`
+	ExpectMetadataType int      `long:"expect-metadata-type" description:"Expected metadata type of transaction, transaction without metadata if empty"`
+
+	// threshold multisig
+	MultisigParties     string `long:"multisigparties" description:"Payment addresses of parties separated by comma, party i is the i-th address"`
+	MultisigThreshold   int    `long:"multisigthreshold" description:"Number of parties needed to sign"`
+	MultisigRound       int    `long:"multisiground" description:"Round of multisig command"`
+	MultisigKeyFile     string `long:"multisigkeyfile" description:"File to store key share, encrypted by wallet passphrase"`
+	MultisigSessionFile string `long:"multisigsessionfile" description:"File to store secret nonces of a spending session, encrypted by wallet passphrase"`
+	MultisigInFiles     string `long:"multisiginfiles" description:"Message files of parties separated by comma"`
+	MultisigTxFile      string `long:"multisigtxfile" description:"File to store tx built from serial number proofs"`
+	MultisigOutFile     string `long:"multisigoutfile" description:"File to store message of party"`
+
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
 	PToken   string `long:"pToken" description:"Bridge token"`
@@ -8,6 +8,10 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	signTransactionCmd     = "signtransaction"
+	multisigDKGCmd         = "multisigdkg"
+	multisigSpendCmd       = "multisigspend"
+	multisigCombineCmd     = "multisigcombine"
 )
 
 var CmdList = []string{
@@ -18,4 +22,8 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	signTransactionCmd,
+	multisigDKGCmd,
+	multisigSpendCmd,
+	multisigCombineCmd,
 }
@@ -0,0 +1,937 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumbernoprivacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Threshold multisig: t of n parties control a payment address whose private key is never reconstructed.
+// Parties exchange messages of each round as files, any party (or anyone with the messages) can relay them.
+//
+// Key generation (all n parties):
+//   - multisigdkg round 1: deal shares of a random secret to parties, encrypted to their payment addresses
+//   - multisigdkg round 2: combine shares dealt by all parties into a key share, stored encrypted by wallet passphrase
+//
+// Spending a tx without privacy built by createunsignedtransaction with payment address and readonly key of the group:
+//   - multisigspend round 1 and 2 (at least 2t-1 parties): compute serial numbers of input coins by masked inversion
+//   - multisigspend round 3 (signers, at least t parties): commit to nonces of serial number proofs and signatures
+//   - multisigspend round 4 (signers): respond to serial number proofs, then multisigcombine round 4 builds the tx
+//   - multisigspend round 5 (signers): check the tx and sign it, then multisigcombine round 5 outputs the signed tx
+// Rounds 1, 2 and 4 are skipped if the tx has no input coins, e.g. a withdraw reward request.
+
+// multisigKeyShare is the share of threshold key of a party
+type multisigKeyShare struct {
+	Index            uint64   `json:"Index"`
+	Threshold        int      `json:"Threshold"`
+	Parties          []string `json:"Parties"`
+	Share            []byte   `json:"Share"`
+	GroupCommitments [][]byte `json:"GroupCommitments"`
+	PaymentAddress   string   `json:"PaymentAddress"`
+	ReadonlyKey      string   `json:"ReadonlyKey"`
+	IdentityKey      []byte   `json:"IdentityKey"` // receiving key of payment address of party, to decrypt shares dealt to it
+}
+
+type multisigNonceCommitment struct {
+	D [][]byte `json:"D"`
+	E [][]byte `json:"E"`
+}
+
+// multisigMessage is the message of a party in a round
+type multisigMessage struct {
+	Cmd             string                     `json:"Cmd"`
+	Round           int                        `json:"Round"`
+	Session         string                     `json:"Session"`
+	Index           uint64                     `json:"Index"`
+	Commitments     [][][]byte                 `json:"Commitments,omitempty"`
+	EncryptedShares map[uint64][]byte          `json:"EncryptedShares,omitempty"`
+	SeedHash        []byte                     `json:"SeedHash,omitempty"`
+	Products        [][]byte                   `json:"Products,omitempty"`
+	SerialNumbers   [][]byte                   `json:"SerialNumbers,omitempty"`
+	SNNonces        []*multisigNonceCommitment `json:"SNNonces,omitempty"`
+	SigNonces       []*multisigNonceCommitment `json:"SigNonces,omitempty"`
+	SNResponses     [][]byte                   `json:"SNResponses,omitempty"`
+	SigResponses    [][]byte                   `json:"SigResponses,omitempty"`
+}
+
+// multisigNonce is a secret nonce pair of a signer
+type multisigNonce struct {
+	D []byte `json:"D"`
+	E []byte `json:"E"`
+}
+
+// multisigSession keeps secret nonces of a signer between rounds, a nonce is erased once it is used
+type multisigSession struct {
+	Session       string           `json:"Session"`
+	SerialNumbers [][]byte         `json:"SerialNumbers"`
+	SNNonces      []*multisigNonce `json:"SNNonces"`
+	SigNonces     []*multisigNonce `json:"SigNonces"`
+}
+
+func newMultisigNonce(nonce *privacy.ThresholdNonce) *multisigNonce {
+	return &multisigNonce{D: nonce.D.ToBytesS(), E: nonce.E.ToBytesS()}
+}
+
+func (nonce *multisigNonce) toThresholdNonce() (*privacy.ThresholdNonce, error) {
+	if nonce == nil {
+		return nil, errors.New("nonce is missing")
+	}
+	d, err := scalarFromBytes(nonce.D)
+	if err != nil {
+		return nil, err
+	}
+	e, err := scalarFromBytes(nonce.E)
+	if err != nil {
+		return nil, err
+	}
+	return &privacy.ThresholdNonce{D: d, E: e}, nil
+}
+
+func scalarFromBytes(data []byte) (*privacy.Scalar, error) {
+	if len(data) != privacy.Ed25519KeySize {
+		return nil, errors.New("invalid scalar")
+	}
+	scalar := new(privacy.Scalar).FromBytesS(data)
+	if !scalar.ScalarValid() {
+		return nil, errors.New("invalid scalar")
+	}
+	return scalar, nil
+}
+
+func pointsToBytes(points []*privacy.Point) [][]byte {
+	result := make([][]byte, len(points))
+	for i, point := range points {
+		result[i] = point.ToBytesS()
+	}
+	return result
+}
+
+func pointsFromBytes(data [][]byte) ([]*privacy.Point, error) {
+	points := make([]*privacy.Point, len(data))
+	for i, pointBytes := range data {
+		point, err := new(privacy.Point).FromBytesS(pointBytes)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = point
+	}
+	return points, nil
+}
+
+func newMultisigNonceCommitment(commitment *privacy.ThresholdNonceCommitment) *multisigNonceCommitment {
+	return &multisigNonceCommitment{D: pointsToBytes(commitment.D), E: pointsToBytes(commitment.E)}
+}
+
+func (commitment *multisigNonceCommitment) toThresholdNonceCommitment() (*privacy.ThresholdNonceCommitment, error) {
+	if commitment == nil {
+		return nil, errors.New("nonce commitment is missing")
+	}
+	d, err := pointsFromBytes(commitment.D)
+	if err != nil {
+		return nil, err
+	}
+	e, err := pointsFromBytes(commitment.E)
+	if err != nil {
+		return nil, err
+	}
+	return &privacy.ThresholdNonceCommitment{D: d, E: e}, nil
+}
+
+func writeEncryptedFile(fileName string, data interface{}) error {
+	if cfg.WalletPassphrase == "" {
+		return errors.New("Wallet passphrase is required")
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := wallet.EncryptByPassPhrase(cfg.WalletPassphrase, plaintext)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, []byte(ciphertext), 0600)
+}
+
+func readEncryptedFile(fileName string, data interface{}) error {
+	if cfg.WalletPassphrase == "" {
+		return errors.New("Wallet passphrase is required")
+	}
+	ciphertext, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	plaintext, err := wallet.DecryptByPassPhrase(cfg.WalletPassphrase, strings.TrimSpace(string(ciphertext)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(plaintext, data)
+}
+
+func writeMultisigMessage(msg *multisigMessage) (*multisigMessage, error) {
+	if cfg.MultisigOutFile == "" {
+		return nil, errors.New("Out file is required")
+	}
+	data, err := json.MarshalIndent(msg, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return msg, ioutil.WriteFile(cfg.MultisigOutFile, data, 0600)
+}
+
+// readMultisigMessages reads messages of a round from --multisiginfiles, at most one message of each party
+func readMultisigMessages(cmd string, round int, session string) (map[uint64]*multisigMessage, error) {
+	msgs := make(map[uint64]*multisigMessage)
+	for _, fileName := range strings.Split(cfg.MultisigInFiles, ",") {
+		if fileName == "" {
+			continue
+		}
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		msg := new(multisigMessage)
+		if err := json.Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("Can not parse message %s: %v", fileName, err)
+		}
+		if msg.Cmd != cmd || msg.Round != round {
+			continue
+		}
+		if msg.Session != session {
+			return nil, fmt.Errorf("Message %s is of another session", fileName)
+		}
+		if _, ok := msgs[msg.Index]; ok {
+			return nil, fmt.Errorf("Duplicate message of party %d in round %d", msg.Index, round)
+		}
+		msgs[msg.Index] = msg
+	}
+	return msgs, nil
+}
+
+func sortedMessageIndices(msgs map[uint64]*multisigMessage) []uint64 {
+	indices := make([]uint64, 0, len(msgs))
+	for index := range msgs {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+// encryptShares encrypts shares dealt to each party with transmission key of its payment address
+func encryptShares(parties []string, shares [][]byte) (map[uint64][]byte, error) {
+	encryptedShares := make(map[uint64][]byte)
+	for j, party := range parties {
+		keyWallet, err := wallet.Base58CheckDeserialize(party)
+		if err != nil {
+			return nil, err
+		}
+		transmissionKey, err := new(privacy.Point).FromBytesS(keyWallet.KeySet.PaymentAddress.Tk)
+		if err != nil {
+			return nil, err
+		}
+		ciphertext, err := privacy.HybridEncrypt(shares[j], transmissionKey)
+		if err != nil {
+			return nil, err
+		}
+		encryptedShares[uint64(j+1)] = ciphertext.Bytes()
+	}
+	return encryptedShares, nil
+}
+
+// decryptShares decrypts shares dealt to party in msg, which are scalars of 32 bytes
+func decryptShares(msg *multisigMessage, index uint64, identityKey []byte) ([][]byte, error) {
+	ciphertext := new(privacy.HybridCipherText)
+	if err := ciphertext.SetBytes(msg.EncryptedShares[index]); err != nil {
+		return nil, fmt.Errorf("Can not get shares dealt by party %d: %v", msg.Index, err)
+	}
+	plaintext, err := privacy.HybridDecrypt(ciphertext, new(privacy.Scalar).FromBytesS(identityKey))
+	if err != nil {
+		return nil, fmt.Errorf("Can not decrypt shares dealt by party %d: %v", msg.Index, err)
+	}
+	if len(plaintext)%privacy.Ed25519KeySize != 0 {
+		return nil, fmt.Errorf("Invalid shares dealt by party %d", msg.Index)
+	}
+	shares := make([][]byte, len(plaintext)/privacy.Ed25519KeySize)
+	for i := range shares {
+		shares[i] = plaintext[i*privacy.Ed25519KeySize : (i+1)*privacy.Ed25519KeySize]
+	}
+	return shares, nil
+}
+
+// multisigDKG runs key generation of threshold key among parties of --multisigparties
+func multisigDKG(round int) (interface{}, error) {
+	parties := strings.Split(cfg.MultisigParties, ",")
+	threshold := cfg.MultisigThreshold
+	if len(parties) < 2 || threshold < 1 || threshold > len(parties) {
+		return nil, errors.New("Invalid parties or threshold")
+	}
+	privateKey, err := getSigningPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	identity := incognitokey.KeySet{}
+	if err := identity.InitFromPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	index := uint64(0)
+	for j, party := range parties {
+		keyWallet, err := wallet.Base58CheckDeserialize(party)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid payment address of party %d", j+1)
+		}
+		if bytes.Equal(keyWallet.KeySet.PaymentAddress.Pk, identity.PaymentAddress.Pk) {
+			index = uint64(j + 1)
+		}
+	}
+	if index == 0 {
+		return nil, errors.New("Private key is not a key of parties")
+	}
+	session := common.HashH([]byte(strconv.Itoa(threshold) + cfg.MultisigParties)).String()
+
+	switch round {
+	case 1:
+		dealing, err := privacy.NewThresholdDealing(nil, threshold, len(parties))
+		if err != nil {
+			return nil, err
+		}
+		// seed of each party is hashed into receiving key of the group
+		seed := privacy.RandomScalar().ToBytesS()
+		shares := make([][]byte, len(parties))
+		for j := range shares {
+			shares[j] = append(dealing.Shares[j].ToBytesS(), seed...)
+		}
+		encryptedShares, err := encryptShares(parties, shares)
+		if err != nil {
+			return nil, err
+		}
+		return writeMultisigMessage(&multisigMessage{
+			Cmd:             multisigDKGCmd,
+			Round:           1,
+			Session:         session,
+			Index:           index,
+			Commitments:     [][][]byte{pointsToBytes(dealing.Commitments)},
+			EncryptedShares: encryptedShares,
+			SeedHash:        common.HashB(seed),
+		})
+	case 2:
+		if cfg.MultisigKeyFile == "" {
+			return nil, errors.New("Key file is required")
+		}
+		msgs, err := readMultisigMessages(multisigDKGCmd, 1, session)
+		if err != nil {
+			return nil, err
+		}
+		if len(msgs) != len(parties) {
+			return nil, fmt.Errorf("Need messages of all %d parties, got %d", len(parties), len(msgs))
+		}
+		share := new(privacy.Scalar).FromUint64(0)
+		seeds := make([]byte, 0)
+		commitmentsList := make([][]*privacy.Point, 0)
+		for _, dealer := range sortedMessageIndices(msgs) {
+			msg := msgs[dealer]
+			if len(msg.Commitments) != 1 || len(msg.Commitments[0]) != threshold {
+				return nil, fmt.Errorf("Invalid commitments of party %d", dealer)
+			}
+			commitments, err := pointsFromBytes(msg.Commitments[0])
+			if err != nil {
+				return nil, err
+			}
+			shares, err := decryptShares(msg, index, identity.ReadonlyKey.Rk)
+			if err != nil {
+				return nil, err
+			}
+			if len(shares) != 2 || !bytes.Equal(common.HashB(shares[1]), msg.SeedHash) {
+				return nil, fmt.Errorf("Invalid seed dealt by party %d", dealer)
+			}
+			dealtShare, err := scalarFromBytes(shares[0])
+			if err != nil || !privacy.VerifyThresholdShare(index, dealtShare, commitments) {
+				return nil, fmt.Errorf("Invalid share dealt by party %d", dealer)
+			}
+			share.Add(share, dealtShare)
+			seeds = append(seeds, shares[1]...)
+			commitmentsList = append(commitmentsList, commitments)
+		}
+		groupCommitments, err := privacy.SumThresholdCommitments(commitmentsList)
+		if err != nil {
+			return nil, err
+		}
+
+		receivingKey := privacy.HashToScalar(seeds)
+		group := &wallet.KeyWallet{}
+		group.KeySet.PaymentAddress.Pk = groupCommitments[0].ToBytesS()
+		group.KeySet.PaymentAddress.Tk = privacy.GenerateTransmissionKey(receivingKey.ToBytesS())
+		group.KeySet.ReadonlyKey.Pk = group.KeySet.PaymentAddress.Pk
+		group.KeySet.ReadonlyKey.Rk = receivingKey.ToBytesS()
+		keyShare := &multisigKeyShare{
+			Index:            index,
+			Threshold:        threshold,
+			Parties:          parties,
+			Share:            share.ToBytesS(),
+			GroupCommitments: pointsToBytes(groupCommitments),
+			PaymentAddress:   group.Base58CheckSerialize(wallet.PaymentAddressType),
+			ReadonlyKey:      group.Base58CheckSerialize(wallet.ReadonlyKeyType),
+			IdentityKey:      identity.ReadonlyKey.Rk,
+		}
+		if err := writeEncryptedFile(cfg.MultisigKeyFile, keyShare); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"Index":          index,
+			"Threshold":      threshold,
+			"PaymentAddress": keyShare.PaymentAddress,
+			"ReadonlyKey":    keyShare.ReadonlyKey,
+		}, nil
+	}
+	return nil, fmt.Errorf("Invalid round %d of %s", round, multisigDKGCmd)
+}
+
+// multisigSpendContext contains data of a spending session which every round needs
+type multisigSpendContext struct {
+	keyShare         *multisigKeyShare
+	share            *privacy.Scalar
+	groupCommitments []*privacy.Point
+	senderAddress    privacy.PaymentAddress
+	unsignedTx       *transaction.UnsignedTx
+	session          string
+	snds             []*privacy.Scalar // snds of input coins of PRV then of token
+	numComponents    int
+}
+
+func loadMultisigSpendContext() (*multisigSpendContext, error) {
+	if cfg.MultisigKeyFile == "" || cfg.UnsignedTxFile == "" {
+		return nil, errors.New("Key file and unsigned tx file are required")
+	}
+	ctx := &multisigSpendContext{keyShare: new(multisigKeyShare)}
+	if err := readEncryptedFile(cfg.MultisigKeyFile, ctx.keyShare); err != nil {
+		return nil, err
+	}
+	var err error
+	if ctx.share, err = scalarFromBytes(ctx.keyShare.Share); err != nil {
+		return nil, err
+	}
+	if ctx.groupCommitments, err = pointsFromBytes(ctx.keyShare.GroupCommitments); err != nil {
+		return nil, err
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(ctx.keyShare.PaymentAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx.senderAddress = keyWallet.KeySet.PaymentAddress
+
+	unsignedTxData, err := ioutil.ReadFile(cfg.UnsignedTxFile)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.unsignedTx, err = readUnsignedTx(cfg.UnsignedTxFile); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(ctx.unsignedTx.SenderPk, ctx.senderAddress.Pk) {
+		return nil, errors.New("Unsigned tx is not a tx of threshold key")
+	}
+	ctx.session = common.HashH([]byte(strings.TrimSpace(string(unsignedTxData)))).String()
+	inputCoins, err := ctx.unsignedTx.GetThresholdInputCoins()
+	if err != nil {
+		return nil, err
+	}
+	ctx.numComponents = len(inputCoins)
+	for _, componentInputCoins := range inputCoins {
+		for _, inputCoin := range componentInputCoins {
+			ctx.snds = append(ctx.snds, inputCoin.CoinDetails.GetSNDerivator())
+		}
+	}
+	return ctx, nil
+}
+
+func (ctx *multisigSpendContext) readSession() (*multisigSession, error) {
+	if cfg.MultisigSessionFile == "" {
+		return nil, errors.New("Session file is required")
+	}
+	session := new(multisigSession)
+	if err := readEncryptedFile(cfg.MultisigSessionFile, session); err != nil {
+		return nil, err
+	}
+	if session.Session != ctx.session {
+		return nil, errors.New("Session file is of another session")
+	}
+	return session, nil
+}
+
+// readSigners reads round 3 messages of signers, they must commit to the same serial numbers
+func (ctx *multisigSpendContext) readSigners() (map[uint64]*multisigMessage, []uint64, [][]byte, error) {
+	msgs, err := readMultisigMessages(multisigSpendCmd, 3, ctx.session)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(msgs) < ctx.keyShare.Threshold {
+		return nil, nil, nil, fmt.Errorf("Need messages of %d signers, got %d", ctx.keyShare.Threshold, len(msgs))
+	}
+	signers := sortedMessageIndices(msgs)
+	serialNumbers := msgs[signers[0]].SerialNumbers
+	for _, signer := range signers {
+		msg := msgs[signer]
+		if len(msg.SerialNumbers) != len(ctx.snds) || len(msg.SNNonces) != len(ctx.snds) || len(msg.SigNonces) != ctx.numComponents {
+			return nil, nil, nil, fmt.Errorf("Invalid round 3 message of party %d", signer)
+		}
+		for i := range serialNumbers {
+			if !bytes.Equal(serialNumbers[i], msg.SerialNumbers[i]) {
+				return nil, nil, nil, fmt.Errorf("Serial numbers of party %d differ", signer)
+			}
+		}
+	}
+	return msgs, signers, serialNumbers, nil
+}
+
+// serialNumberProofParams returns group nonces, challenge and binding factors of serial number proof of input i
+func (ctx *multisigSpendContext) serialNumberProofParams(i int, msgs map[uint64]*multisigMessage, serialNumber []byte) ([]*privacy.Point, *privacy.Scalar, map[uint64]*privacy.Scalar, error) {
+	commitments := make(map[uint64]*privacy.ThresholdNonceCommitment)
+	for signer, msg := range msgs {
+		commitment, err := msg.SNNonces[i].toThresholdNonceCommitment()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		commitments[signer] = commitment
+	}
+	groupNonces, bindingFactors, err := privacy.ThresholdGroupNonce(append([]byte(ctx.session), serialNumber...), 2, commitments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return groupNonces, serialnumbernoprivacy.ThresholdChallenge(groupNonces[0], groupNonces[1]), bindingFactors, nil
+}
+
+// signatureParams returns negated challenge, challenge and binding factors of signature of component c on hash
+func (ctx *multisigSpendContext) signatureParams(c int, msgs map[uint64]*multisigMessage, hash *common.Hash) (*privacy.Scalar, *privacy.Scalar, map[uint64]*privacy.Scalar, error) {
+	commitments := make(map[uint64]*privacy.ThresholdNonceCommitment)
+	for signer, msg := range msgs {
+		commitment, err := msg.SigNonces[c].toThresholdNonceCommitment()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		commitments[signer] = commitment
+	}
+	groupNonces, bindingFactors, err := privacy.ThresholdGroupNonce(hash[:], 1, commitments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	e, err := privacy.ThresholdSchnorrChallenge(groupNonces[0], hash[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return new(privacy.Scalar).Sub(new(privacy.Scalar).FromUint64(0), e), e, bindingFactors, nil
+}
+
+// readThresholdTx reads tx built by multisigcombine round 4
+func (ctx *multisigSpendContext) readThresholdTx() (metadata.Transaction, error) {
+	if cfg.MultisigTxFile == "" {
+		return nil, errors.New("Tx file is required")
+	}
+	data, err := ioutil.ReadFile(cfg.MultisigTxFile)
+	if err != nil {
+		return nil, err
+	}
+	var tx metadata.Transaction
+	if ctx.unsignedTx.TokenData == nil {
+		tx = new(transaction.Tx)
+	} else {
+		tx = new(transaction.TxCustomTokenPrivacy)
+	}
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+	if err := ctx.unsignedTx.CheckThresholdTx(tx, ctx.senderAddress); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// multisigSpend runs a round of spending of party
+func multisigSpend(round int) (interface{}, error) {
+	ctx, err := loadMultisigSpendContext()
+	if err != nil {
+		return nil, err
+	}
+	keyShare := ctx.keyShare
+	numParties := len(keyShare.Parties)
+	msg := &multisigMessage{Cmd: multisigSpendCmd, Round: round, Session: ctx.session, Index: keyShare.Index}
+	if len(ctx.snds) == 0 && (round == 1 || round == 2 || round == 4) {
+		return nil, errors.New("Tx has no input coins, start from round 3")
+	}
+
+	switch round {
+	case 1:
+		// deal a random r and a zero of threshold 2t-1 for each input coin
+		shares := make([][]byte, numParties)
+		for range ctx.snds {
+			rDealing, err := privacy.NewThresholdDealing(nil, keyShare.Threshold, numParties)
+			if err != nil {
+				return nil, err
+			}
+			zeroDealing, err := privacy.NewThresholdDealing(new(privacy.Scalar).FromUint64(0), 2*keyShare.Threshold-1, numParties)
+			if err != nil {
+				return nil, err
+			}
+			msg.Commitments = append(msg.Commitments, pointsToBytes(rDealing.Commitments), pointsToBytes(zeroDealing.Commitments))
+			for j := range shares {
+				shares[j] = append(shares[j], rDealing.Shares[j].ToBytesS()...)
+				shares[j] = append(shares[j], zeroDealing.Shares[j].ToBytesS()...)
+			}
+		}
+		if msg.EncryptedShares, err = encryptShares(keyShare.Parties, shares); err != nil {
+			return nil, err
+		}
+		return writeMultisigMessage(msg)
+	case 2:
+		dealings, err := readMultisigMessages(multisigSpendCmd, 1, ctx.session)
+		if err != nil {
+			return nil, err
+		}
+		if len(dealings) == 0 {
+			return nil, errors.New("Round 1 messages are required")
+		}
+		rShares := make([]*privacy.Scalar, len(ctx.snds))
+		zeroShares := make([]*privacy.Scalar, len(ctx.snds))
+		for i := range ctx.snds {
+			rShares[i] = new(privacy.Scalar).FromUint64(0)
+			zeroShares[i] = new(privacy.Scalar).FromUint64(0)
+		}
+		for _, dealer := range sortedMessageIndices(dealings) {
+			dealing := dealings[dealer]
+			shares, err := decryptShares(dealing, keyShare.Index, keyShare.IdentityKey)
+			if err != nil {
+				return nil, err
+			}
+			if len(shares) != 2*len(ctx.snds) || len(dealing.Commitments) != 2*len(ctx.snds) {
+				return nil, fmt.Errorf("Invalid dealing of party %d", dealer)
+			}
+			for i := range ctx.snds {
+				rCommitments, err := pointsFromBytes(dealing.Commitments[2*i])
+				if err != nil {
+					return nil, err
+				}
+				zeroCommitments, err := pointsFromBytes(dealing.Commitments[2*i+1])
+				if err != nil {
+					return nil, err
+				}
+				if len(rCommitments) != keyShare.Threshold || len(zeroCommitments) != 2*keyShare.Threshold-1 || !zeroCommitments[0].IsIdentity() {
+					return nil, fmt.Errorf("Invalid dealing of party %d", dealer)
+				}
+				rShare, err := scalarFromBytes(shares[2*i])
+				if err != nil || !privacy.VerifyThresholdShare(keyShare.Index, rShare, rCommitments) {
+					return nil, fmt.Errorf("Invalid share dealt by party %d", dealer)
+				}
+				zeroShare, err := scalarFromBytes(shares[2*i+1])
+				if err != nil || !privacy.VerifyThresholdShare(keyShare.Index, zeroShare, zeroCommitments) {
+					return nil, fmt.Errorf("Invalid share dealt by party %d", dealer)
+				}
+				rShares[i].Add(rShares[i], rShare)
+				zeroShares[i].Add(zeroShares[i], zeroShare)
+			}
+		}
+		for i, snd := range ctx.snds {
+			msg.Products = append(msg.Products, privacy.ThresholdInverseProduct(rShares[i], ctx.share, snd, zeroShares[i]).ToBytesS())
+		}
+		return writeMultisigMessage(msg)
+	case 3:
+		if cfg.MultisigSessionFile == "" {
+			return nil, errors.New("Session file is required")
+		}
+		if _, err := os.Stat(cfg.MultisigSessionFile); err == nil {
+			return nil, errors.New("Session file exists, nonces must not be reused")
+		}
+		serialNumbers, err := ctx.combineSerialNumbers()
+		if err != nil {
+			return nil, err
+		}
+		session := &multisigSession{Session: ctx.session}
+		for _, serialNumber := range serialNumbers {
+			nonce, commitment := privacy.NewThresholdNonce([]*privacy.Point{privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], serialNumber})
+			session.SNNonces = append(session.SNNonces, newMultisigNonce(nonce))
+			session.SerialNumbers = append(session.SerialNumbers, serialNumber.ToBytesS())
+			msg.SNNonces = append(msg.SNNonces, newMultisigNonceCommitment(commitment))
+		}
+		for c := 0; c < ctx.numComponents; c++ {
+			nonce, commitment := privacy.NewThresholdNonce([]*privacy.Point{privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]})
+			session.SigNonces = append(session.SigNonces, newMultisigNonce(nonce))
+			msg.SigNonces = append(msg.SigNonces, newMultisigNonceCommitment(commitment))
+		}
+		msg.SerialNumbers = session.SerialNumbers
+		if err := writeEncryptedFile(cfg.MultisigSessionFile, session); err != nil {
+			return nil, err
+		}
+		return writeMultisigMessage(msg)
+	case 4:
+		session, err := ctx.readSession()
+		if err != nil {
+			return nil, err
+		}
+		if len(session.SNNonces) != len(ctx.snds) {
+			return nil, errors.New("Nonces of serial number proofs were used")
+		}
+		msgs, signers, serialNumbers, err := ctx.readSigners()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := msgs[keyShare.Index]; !ok {
+			return nil, errors.New("Party is not a signer of round 3")
+		}
+		lagrange, err := privacy.ThresholdLagrangeCoefficient(keyShare.Index, signers)
+		if err != nil {
+			return nil, err
+		}
+		for i := range ctx.snds {
+			if !bytes.Equal(serialNumbers[i], session.SerialNumbers[i]) {
+				return nil, errors.New("Serial numbers of signers differ from serial numbers of party")
+			}
+			_, challenge, bindingFactors, err := ctx.serialNumberProofParams(i, msgs, serialNumbers[i])
+			if err != nil {
+				return nil, err
+			}
+			nonce, err := session.SNNonces[i].toThresholdNonce()
+			if err != nil {
+				return nil, err
+			}
+			msg.SNResponses = append(msg.SNResponses, nonce.Response(bindingFactors[keyShare.Index], challenge, lagrange, ctx.share).ToBytesS())
+		}
+		// erase used nonces before responses are revealed
+		session.SNNonces = nil
+		if err := writeEncryptedFile(cfg.MultisigSessionFile, session); err != nil {
+			return nil, err
+		}
+		return writeMultisigMessage(msg)
+	case 5:
+		session, err := ctx.readSession()
+		if err != nil {
+			return nil, err
+		}
+		msgs, signers, _, err := ctx.readSigners()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := msgs[keyShare.Index]; !ok {
+			return nil, errors.New("Party is not a signer of round 3")
+		}
+		tx, err := ctx.readThresholdTx()
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := transaction.GetThresholdSigningHashes(tx)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) != len(session.SigNonces) {
+			return nil, errors.New("Invalid nonces of signatures")
+		}
+		lagrange, err := privacy.ThresholdLagrangeCoefficient(keyShare.Index, signers)
+		if err != nil {
+			return nil, err
+		}
+		for c, hash := range hashes {
+			challenge, _, bindingFactors, err := ctx.signatureParams(c, msgs, hash)
+			if err != nil {
+				return nil, err
+			}
+			nonce, err := session.SigNonces[c].toThresholdNonce()
+			if err != nil {
+				return nil, err
+			}
+			msg.SigResponses = append(msg.SigResponses, nonce.Response(bindingFactors[keyShare.Index], challenge, lagrange, ctx.share).ToBytesS())
+		}
+		// session is over, nonces must not be reused
+		if err := os.Remove(cfg.MultisigSessionFile); err != nil {
+			return nil, err
+		}
+		return writeMultisigMessage(msg)
+	}
+	return nil, fmt.Errorf("Invalid round %d of %s", round, multisigSpendCmd)
+}
+
+// combineSerialNumbers opens serial numbers of input coins from round 1 and round 2 messages
+func (ctx *multisigSpendContext) combineSerialNumbers() ([]*privacy.Point, error) {
+	if len(ctx.snds) == 0 {
+		return nil, nil
+	}
+	dealings, err := readMultisigMessages(multisigSpendCmd, 1, ctx.session)
+	if err != nil {
+		return nil, err
+	}
+	productMsgs, err := readMultisigMessages(multisigSpendCmd, 2, ctx.session)
+	if err != nil {
+		return nil, err
+	}
+	serialNumbers := make([]*privacy.Point, len(ctx.snds))
+	for i := range ctx.snds {
+		rCommitment := new(privacy.Point).Identity()
+		for dealer, dealing := range dealings {
+			if len(dealing.Commitments) != 2*len(ctx.snds) || len(dealing.Commitments[2*i]) == 0 {
+				return nil, fmt.Errorf("Invalid dealing of party %d", dealer)
+			}
+			commitment, err := new(privacy.Point).FromBytesS(dealing.Commitments[2*i][0])
+			if err != nil {
+				return nil, err
+			}
+			rCommitment.Add(rCommitment, commitment)
+		}
+		products := make(map[uint64]*privacy.Scalar)
+		for index, productMsg := range productMsgs {
+			if len(productMsg.Products) != len(ctx.snds) {
+				return nil, fmt.Errorf("Invalid products of party %d", index)
+			}
+			if products[index], err = scalarFromBytes(productMsg.Products[i]); err != nil {
+				return nil, err
+			}
+		}
+		if serialNumbers[i], err = privacy.CombineThresholdInverse(products, ctx.keyShare.Threshold, rCommitment); err != nil {
+			return nil, err
+		}
+	}
+	return serialNumbers, nil
+}
+
+// multisigCombine aggregates responses of signers: serial number proofs into the tx in round 4,
+// signatures into the signed tx in round 5
+func multisigCombine(round int) (interface{}, error) {
+	ctx, err := loadMultisigSpendContext()
+	if err != nil {
+		return nil, err
+	}
+	msgs, signers, serialNumbers, err := ctx.readSigners()
+	if err != nil {
+		return nil, err
+	}
+	responseMsgs, err := readMultisigMessages(multisigSpendCmd, round, ctx.session)
+	if err != nil {
+		return nil, err
+	}
+	for _, signer := range signers {
+		if _, ok := responseMsgs[signer]; !ok {
+			return nil, fmt.Errorf("Round %d message of signer %d is missing", round, signer)
+		}
+	}
+	lagranges := make(map[uint64]*privacy.Scalar)
+	for _, signer := range signers {
+		if lagranges[signer], err = privacy.ThresholdLagrangeCoefficient(signer, signers); err != nil {
+			return nil, err
+		}
+	}
+	// collectResponses returns responses of signers to proof i, a signer whose response is invalid is reported
+	collectResponses := func(i int, getResponses func(*multisigMessage) [][]byte, getNonces func(*multisigMessage) []*multisigNonceCommitment, challenge *privacy.Scalar, bindingFactors map[uint64]*privacy.Scalar) ([]*privacy.Scalar, error) {
+		responses := make([]*privacy.Scalar, 0)
+		for _, signer := range signers {
+			signerResponses := getResponses(responseMsgs[signer])
+			if len(signerResponses) <= i {
+				return nil, fmt.Errorf("Responses of signer %d are missing", signer)
+			}
+			response, err := scalarFromBytes(signerResponses[i])
+			if err != nil {
+				return nil, err
+			}
+			commitment, err := getNonces(msgs[signer])[i].toThresholdNonceCommitment()
+			if err != nil {
+				return nil, err
+			}
+			verificationKey := privacy.ThresholdCommitmentAt(signer, ctx.groupCommitments)
+			if !privacy.VerifyThresholdResponse(response, commitment, bindingFactors[signer], challenge, lagranges[signer], verificationKey) {
+				return nil, fmt.Errorf("Invalid response of signer %d", signer)
+			}
+			responses = append(responses, response)
+		}
+		return responses, nil
+	}
+
+	switch round {
+	case 4:
+		proofs := make([][]*serialnumbernoprivacy.SNNoPrivacyProof, ctx.numComponents)
+		numPRVInputs := len(ctx.unsignedTx.PRV.InputCoins)
+		for i, snd := range ctx.snds {
+			groupNonces, challenge, bindingFactors, err := ctx.serialNumberProofParams(i, msgs, serialNumbers[i])
+			if err != nil {
+				return nil, err
+			}
+			responses, err := collectResponses(i,
+				func(msg *multisigMessage) [][]byte { return msg.SNResponses },
+				func(msg *multisigMessage) []*multisigNonceCommitment { return msg.SNNonces },
+				challenge, bindingFactors)
+			if err != nil {
+				return nil, err
+			}
+			serialNumber, err := new(privacy.Point).FromBytesS(serialNumbers[i])
+			if err != nil {
+				return nil, err
+			}
+			proof := serialnumbernoprivacy.NewThresholdSNNoPrivacyProof(serialNumber, ctx.groupCommitments[0], snd, groupNonces[0], groupNonces[1], responses)
+			c := 0
+			if i >= numPRVInputs {
+				c = 1
+			}
+			proofs[c] = append(proofs[c], proof)
+		}
+		tx, err := ctx.unsignedTx.BuildThresholdTx(ctx.senderAddress, proofs)
+		if err != nil {
+			return nil, err
+		}
+		txBytes, err := json.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.MultisigTxFile == "" {
+			return nil, errors.New("Tx file is required")
+		}
+		if err := ioutil.WriteFile(cfg.MultisigTxFile, txBytes, 0600); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case 5:
+		tx, err := ctx.readThresholdTx()
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := transaction.GetThresholdSigningHashes(tx)
+		if err != nil {
+			return nil, err
+		}
+		signatures := make([]*privacy.SchnSignature, len(hashes))
+		for c, hash := range hashes {
+			challenge, e, bindingFactors, err := ctx.signatureParams(c, msgs, hash)
+			if err != nil {
+				return nil, err
+			}
+			responses, err := collectResponses(c,
+				func(msg *multisigMessage) [][]byte { return msg.SigResponses },
+				func(msg *multisigMessage) []*multisigNonceCommitment { return msg.SigNonces },
+				challenge, bindingFactors)
+			if err != nil {
+				return nil, err
+			}
+			signatures[c] = privacy.NewThresholdSchnSignature(e, responses)
+		}
+		if err := transaction.SetThresholdSignatures(tx, signatures); err != nil {
+			return nil, err
+		}
+		txBytes, err := json.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		result := jsonresult.NewCreateTransactionResult(tx.Hash(), common.EmptyString, txBytes, shardID)
+		if cfg.SignedTxFile != "" {
+			if err := ioutil.WriteFile(cfg.SignedTxFile, []byte(result.Base58CheckData), 0600); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("Invalid round %d of %s", round, multisigCombineCmd)
+}
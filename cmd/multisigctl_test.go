@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multisig")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := func(name string, index int) string {
+		return filepath.Join(dir, fmt.Sprintf("%s%d", name, index))
+	}
+
+	privateKeys := make([]string, 3)
+	addresses := make([]string, 3)
+	for i := range privateKeys {
+		keySet := incognitokey.KeySet{}
+		privateKey := privacy.GeneratePrivateKey([]byte{byte(i)})
+		assert.Nil(t, keySet.InitFromPrivateKey(&privateKey))
+		keyWallet := wallet.KeyWallet{KeySet: keySet, ChildNumber: make([]byte, 4), ChainCode: make([]byte, 32)}
+		privateKeys[i] = keyWallet.Base58CheckSerialize(wallet.PriKeyType)
+		addresses[i] = keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	}
+	// runRound runs a round of cmd for parties with messages of inRounds as input
+	runRound := func(cmd string, round int, parties []int, inRounds []int) []interface{} {
+		results := make([]interface{}, 0)
+		for _, i := range parties {
+			// messages of spending rounds are combined by multisigcombine
+			inCmd := cmd
+			if cmd == multisigCombineCmd {
+				inCmd = multisigSpendCmd
+			}
+			inFiles := make([]string, 0)
+			for _, inRound := range inRounds {
+				for j := range privateKeys {
+					if _, err := os.Stat(file(fmt.Sprintf("%s%d-", inCmd, inRound), j)); err == nil {
+						inFiles = append(inFiles, file(fmt.Sprintf("%s%d-", inCmd, inRound), j))
+					}
+				}
+			}
+			cfg = &params{
+				PrivateKey:          privateKeys[i],
+				WalletPassphrase:    "passphrase",
+				MultisigParties:     strings.Join(addresses, ","),
+				MultisigThreshold:   2,
+				MultisigKeyFile:     file("key", i),
+				MultisigSessionFile: file("session", i),
+				MultisigInFiles:     strings.Join(inFiles, ","),
+				MultisigOutFile:     file(fmt.Sprintf("%s%d-", cmd, round), i),
+				MultisigTxFile:      file("tx", 0),
+				UnsignedTxFile:      file("unsignedtx", 0),
+				SignedTxFile:        file("signedtx", 0),
+			}
+			var result interface{}
+			var err error
+			switch cmd {
+			case multisigDKGCmd:
+				result, err = multisigDKG(round)
+			case multisigSpendCmd:
+				result, err = multisigSpend(round)
+			default:
+				result, err = multisigCombine(round)
+			}
+			assert.Nil(t, err, "%s round %d of party %d", cmd, round, i)
+			results = append(results, result)
+		}
+		return results
+	}
+
+	runRound(multisigDKGCmd, 1, []int{0, 1, 2}, nil)
+	results := runRound(multisigDKGCmd, 2, []int{0, 1, 2}, []int{1})
+	groupAddress := results[0].(map[string]interface{})["PaymentAddress"].(string)
+	assert.Equal(t, groupAddress, results[2].(map[string]interface{})["PaymentAddress"])
+	groupWallet, err := wallet.Base58CheckDeserialize(groupAddress)
+	assert.Nil(t, err)
+	groupPk, err := new(privacy.Point).FromBytesS(groupWallet.KeySet.PaymentAddress.Pk)
+	assert.Nil(t, err)
+
+	inputCoins := make([]*privacy.InputCoin, 2)
+	for i := range inputCoins {
+		coin := new(privacy.Coin)
+		coin.SetPublicKey(groupPk)
+		coin.SetValue(1000)
+		coin.SetSNDerivator(privacy.RandomScalar())
+		coin.SetRandomness(privacy.RandomScalar())
+		assert.Nil(t, coin.CommitAll())
+		inputCoins[i] = &privacy.InputCoin{CoinDetails: coin}
+	}
+	receiver, err := wallet.Base58CheckDeserialize(addresses[1])
+	assert.Nil(t, err)
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiver.KeySet.PaymentAddress, Amount: 1500}}
+	unsignedTx := transaction.UnsignedTx{
+		Type:     common.TxNormalType,
+		SenderPk: groupWallet.KeySet.PaymentAddress.Pk,
+		LockTime: 1,
+		PRV:      transaction.NewUnsignedTxComponent(false, 100, paymentInfos, inputCoins, nil, nil, nil, []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()}),
+	}
+	unsignedTxBytes, err := json.Marshal(unsignedTx)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(file("unsignedtx", 0), []byte(base58.Base58Check{}.Encode(unsignedTxBytes, common.ZeroByte)), 0600))
+
+	// serial numbers need 2t-1 = 3 parties, signing needs 2 parties
+	runRound(multisigSpendCmd, 1, []int{0, 1, 2}, nil)
+	runRound(multisigSpendCmd, 2, []int{0, 1, 2}, []int{1})
+	runRound(multisigSpendCmd, 3, []int{0, 2}, []int{1, 2})
+	runRound(multisigSpendCmd, 4, []int{0, 2}, []int{3})
+	runRound(multisigCombineCmd, 4, []int{0}, []int{3, 4})
+	runRound(multisigSpendCmd, 5, []int{0, 2}, []int{3})
+	results = runRound(multisigCombineCmd, 5, []int{0}, []int{3, 5})
+
+	result := results[0].(jsonresult.CreateTransactionResult)
+	txBytes, _, err := base58.Base58Check{}.Decode(result.Base58CheckData)
+	assert.Nil(t, err)
+	tx := new(transaction.Tx)
+	assert.Nil(t, json.Unmarshal(txBytes, tx))
+	shardID := common.GetShardIDFromLastByte(tx.PubKeyLastByteSender)
+	valid, err := tx.Proof.Verify(false, tx.SigPubKey, tx.Fee, nil, shardID, &common.PRVCoinID, false)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+	_, err = os.Stat(file("session", 0))
+	assert.True(t, os.IsNotExist(err))
+
+	// nonces of a session are not reused
+	_, err = multisigSpend(4)
+	assert.NotNil(t, err)
+}
@@ -124,6 +124,51 @@ func processCmd() {
 			}
 			log.Println(string(result))
 		}
+	case signTransactionCmd:
+		{
+			if cfg.UnsignedTxFile == "" {
+				log.Println("Wrong param")
+				return
+			}
+			signedTx, err := signTransaction(cfg.UnsignedTxFile, cfg.SignedTxFile)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			result, err := parseToJsonString(signedTx)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
+	case multisigDKGCmd, multisigSpendCmd, multisigCombineCmd:
+		{
+			if cfg.MultisigRound == 0 {
+				log.Println("Wrong param")
+				return
+			}
+			var res interface{}
+			var err error
+			switch cfg.Command {
+			case multisigDKGCmd:
+				res, err = multisigDKG(cfg.MultisigRound)
+			case multisigSpendCmd:
+				res, err = multisigSpend(cfg.MultisigRound)
+			default:
+				res, err = multisigCombine(cfg.MultisigRound)
+			}
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			result, err := parseToJsonString(res)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
 	case backupChain:
 		{
 			if cfg.Beacon == false && cfg.ShardIDs == "" {
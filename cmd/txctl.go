@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// getSigningPrivateKey returns private key from --privatekey or from account of local wallet
+func getSigningPrivateKey() (*privacy.PrivateKey, error) {
+	if cfg.PrivateKey != "" {
+		keyWallet, err := wallet.Base58CheckDeserialize(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyWallet.KeySet.PrivateKey) == 0 {
+			return nil, errors.New("Private key is invalid")
+		}
+		return &keyWallet.KeySet.PrivateKey, nil
+	}
+	if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.WalletAccountName == "" {
+		return nil, errors.New("Private key or wallet account is required")
+	}
+	walletObj, err := loadWallet()
+	if err != nil {
+		return nil, err
+	}
+	keySet, err := walletObj.GetSpendingKeySet(cfg.WalletAccountName)
+	if err != nil {
+		return nil, err
+	}
+	return &keySet.PrivateKey, nil
+}
+
+// readUnsignedTx reads unsigned tx from file which contains Base58CheckData result of createunsignedtransaction
+func readUnsignedTx(fileName string) (*transaction.UnsignedTx, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTxBytes, _, err := base58.Base58Check{}.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx := new(transaction.UnsignedTx)
+	err = json.Unmarshal(unsignedTxBytes, unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	return unsignedTx, nil
+}
+
+// parseExpectedOutputs parses payments of --expect-output as paymentaddress:amount or paymentaddress:amount:tokenid
+func parseExpectedOutputs(expectedOutputStrs []string) ([]transaction.UnsignedTxOutput, error) {
+	expectedOutputs := []transaction.UnsignedTxOutput{}
+	for _, expectedOutputStr := range expectedOutputStrs {
+		parts := strings.Split(expectedOutputStr, ":")
+		if len(parts) != 2 && len(parts) != 3 {
+			return nil, fmt.Errorf("Expected output %s is invalid", expectedOutputStr)
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		expectedOutput := transaction.UnsignedTxOutput{PaymentAddress: keyWallet.KeySet.PaymentAddress, Amount: amount}
+		if len(parts) == 3 {
+			expectedOutput.TokenID = parts[2]
+		}
+		expectedOutputs = append(expectedOutputs, expectedOutput)
+	}
+	return expectedOutputs, nil
+}
+
+// describeUnsignedTx lists payments, fees and metadata type of unsigned tx for the sender to check before signing
+func describeUnsignedTx(unsignedTx *transaction.UnsignedTx) string {
+	lines := []string{"Unsigned transaction:"}
+	for _, output := range unsignedTx.Outputs() {
+		keyWallet := wallet.KeyWallet{KeySet: incognitokey.KeySet{PaymentAddress: output.PaymentAddress}}
+		tokenID := output.TokenID
+		if tokenID == "" {
+			tokenID = "PRV"
+		}
+		lines = append(lines, fmt.Sprintf("  pay %d of %s to %s", output.Amount, tokenID, keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)))
+	}
+	lines = append(lines, fmt.Sprintf("  fee %d PRV", unsignedTx.PRV.Fee))
+	if unsignedTx.TokenData != nil {
+		lines = append(lines, fmt.Sprintf("  token fee %d of %s", unsignedTx.TokenData.Fee, unsignedTx.TokenData.PropertyID))
+	}
+	if unsignedTx.Metadata != nil {
+		lines = append(lines, fmt.Sprintf("  metadata type %d", unsignedTx.Metadata.GetType()))
+	} else {
+		lines = append(lines, "  no metadata")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkUnsignedTx shows unsigned tx, then checks it against --expect-output, --max-fee and --expect-metadata-type,
+// or asks the sender to confirm it if no payment is expected
+func checkUnsignedTx(unsignedTx *transaction.UnsignedTx) error {
+	log.Println(describeUnsignedTx(unsignedTx))
+	if len(cfg.ExpectOutputs) > 0 {
+		if cfg.MaxFee == 0 {
+			return errors.New("Max fee is required with expected outputs")
+		}
+		expectedOutputs, err := parseExpectedOutputs(cfg.ExpectOutputs)
+		if err != nil {
+			return err
+		}
+		return unsignedTx.CheckExpected(expectedOutputs, cfg.MaxFee, cfg.ExpectMetadataType)
+	}
+	fmt.Print("Sign this transaction? [y/N]: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return errors.New("Transaction is not confirmed")
+	}
+	return nil
+}
+
+// signTransaction proves and signs unsigned tx without connecting to any node
+// The unsigned tx is built by a node, so it is checked by checkUnsignedTx before signing.
+// Base58CheckData of result is broadcasted by sendtransaction (or sendrawprivacycustomtokentransaction for privacy token tx)
+func signTransaction(unsignedTxFile string, signedTxFile string) (interface{}, error) {
+	privateKey, err := getSigningPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx, err := readUnsignedTx(unsignedTxFile)
+	if err != nil {
+		return nil, err
+	}
+	err = checkUnsignedTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := unsignedTx.Sign(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	result := jsonresult.NewCreateTransactionResult(tx.Hash(), common.EmptyString, txBytes, shardID)
+	if signedTxFile != "" {
+		err = ioutil.WriteFile(signedTxFile, []byte(result.Base58CheckData), 0600)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -58,8 +58,9 @@ var (
 
 // special token ids (aka. PropertyID in custom token)
 var (
-	PRVCoinID   = Hash{4} // To send PRV in custom token
-	PRVCoinName = "PRV"   // To send PRV in custom token
+	PRVCoinID           = Hash{4} // To send PRV in custom token
+	PRVCoinName         = "PRV"   // To send PRV in custom token
+	ConfidentialAssetID = Hash{5} // To send custom token whose token id is blinded
 )
 
 // CONSENSUS
@@ -134,6 +135,15 @@ const (
 	PDEFeeWithdrawalAcceptedStatus = 1
 	PDEFeeWithdrawalRejectedStatus = 2
 
+	PDELimitOrderWaitingStatus   = 1
+	PDELimitOrderMatchedStatus   = 2
+	PDELimitOrderRejectedStatus  = 3
+	PDELimitOrderExpiredStatus   = 4
+	PDELimitOrderCancelledStatus = 5
+
+	PDECancelLimitOrderAcceptedStatus = 1
+	PDECancelLimitOrderRejectedStatus = 2
+
 	MinTxFeesOnTokenRequirement                             = 10000000000000 // 10000 prv, this requirement is applied from beacon height 87301 mainnet
 	BeaconBlockHeighMilestoneForMinTxFeesOnTokenRequirement = 87301          // milestone of beacon height, when apply min fee on token requirement
 
@@ -218,6 +228,36 @@ const (
 	PDECrossPoolTradeFeeRefundChainStatus          = "xPoolTradeRefundFee"
 	PDECrossPoolTradeSellingTokenRefundChainStatus = "xPoolTradeRefundSellingToken"
 	PDECrossPoolTradeAcceptedChainStatus           = "xPoolTradeAccepted"
+
+	PDELimitOrderWaitingChainStatus            = "limitOrderWaiting"
+	PDELimitOrderMatchedChainStatus            = "limitOrderMatched"
+	PDELimitOrderFeeRefundChainStatus          = "limitOrderRefundFee"
+	PDELimitOrderSellingTokenRefundChainStatus = "limitOrderRefundSellingToken"
+
+	// reasons of refunding a limit order
+	PDELimitOrderRejectedChainStatus  = "rejected"
+	PDELimitOrderExpiredChainStatus   = "expired"
+	PDELimitOrderCancelledChainStatus = "cancelled"
+	PDELimitOrderDustChainStatus      = "dust"
+
+	PDECancelLimitOrderAcceptedChainStatus = "accepted"
+	PDECancelLimitOrderRejectedChainStatus = "rejected"
+)
+
+// Delegation status for chain
+const (
+	DelegationAcceptedChainStatus = "accepted"
+	DelegationRejectedChainStatus = "rejected"
+	DelegationReturnedChainStatus = "returned"
+
+	SetCommissionRateAcceptedChainStatus = "accepted"
+	SetCommissionRateRejectedChainStatus = "rejected"
+)
+
+// Double sign slashing status for chain
+const (
+	DoubleSignEvidenceAcceptedChainStatus = "accepted"
+	DoubleSignEvidenceRejectedChainStatus = "rejected"
 )
 
 // Portal status for chain
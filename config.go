@@ -121,6 +121,7 @@ type config struct {
 	// Net config
 	TestNet        string `long:"testnet" description:"Use the test network"`
 	TestNetVersion string `long:"testnetversion" description:"Use the test network"`
+	NetworkConfig  string `long:"networkconfig" description:"Path to json or yaml file defining a custom network (e.g. a local devnet), testnet flags are ignored when it is set"`
 
 	NodeMode    string `long:"nodemode" description:"Role of this node (beacon/shard/wallet/relay | default role is 'relay' (relayshards must be set to run), 'auto' mode will switch between 'beacon' and 'shard')"`
 	RelayShards string `long:"relayshards" description:"set relay shards of this node when in 'relay' mode if noderole is auto then it only sync shard data when user is a shard producer/validator"`
@@ -131,6 +132,9 @@ type config struct {
 	WalletAutoInit   bool   `long:"walletautoinit" description:"Init wallet automatically if not exist"`
 	WalletShardID    int    `long:"walletshardid" description:"ShardID which wallet use to create account"`
 
+	// For coin scanner
+	CoinScannerPassphrase string `long:"coinscannerpassphrase" description:"Passphrase to encrypt keys registered to coin scanner, coin scanner is disabled if it is empty"`
+
 	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
 
 	TxPoolTTL   uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
@@ -148,6 +152,8 @@ type config struct {
 	EnableMining      bool   `long:"mining" description:"enable mining"`
 	MiningKeys        string `long:"miningkeys" description:"keys used for different consensus algorigthm"`
 	PrivateKey        string `long:"privatekey" description:"your wallet privatekey"`
+	RemoteSigner      string `long:"remotesigner" description:"address of the remote signer holding the mining key (consensus v2), unix:<socket path> or tcp:<host>:<port>"`
+	RemoteSignerToken string `long:"remotesignertoken" description:"auth token shared with the remote signer, required for a tcp remote signer"`
 	Accelerator       bool   `long:"accelerator" description:"Relay Node Configuration For Consensus"`
 
 	// Highway
@@ -437,7 +443,16 @@ func loadConfig() (*config, []string, error) {
 	numNets := 0
 	// Count number of network flags passed; assign active network component
 	// while we're at it
-	if cfg.IsTestnet() {
+	if cfg.NetworkConfig != "" {
+		numNets++
+		customNetParams, err := loadCustomNetParams(cfg.NetworkConfig)
+		if err != nil {
+			err := fmt.Errorf("%s: Failed to load network config %v: %v", funcName, cfg.NetworkConfig, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		activeNetParams = customNetParams
+	} else if cfg.IsTestnet() {
 		numNets++
 		if cfg.TestNetVersion == "2" {
 			activeNetParams = &testNet2Params
@@ -647,7 +662,7 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
-	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.NodeMode != common.NodeModeRelay {
+	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.RemoteSigner == "" && cfg.NodeMode != common.NodeModeRelay {
 		return nil, nil, errors.New("MiningKeys can't be empty if nodemode isn't relay")
 	}
 
@@ -23,7 +23,7 @@ type BLSBFT_V2 struct {
 	ChainID  int
 	PeerID   string
 
-	UserKeySet   *MiningKey
+	UserKeySet   Signer
 	BFTMessageCh chan wire.MessageBFT
 	isStarted    bool
 	StopCh       chan struct{}
@@ -400,14 +400,15 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := e.UserKeySet.BLSSignData(v.block.Hash().GetBytes(), selfIdx, bytelist)
+	signedBlock := NewSignedBlock(e.ChainID, v.block)
+	blsSig, err := e.UserKeySet.BLSSignVote(signedBlock, selfIdx, bytelist)
 	if err != nil {
 		e.Logger.Error(err)
 		return NewConsensusError(UnExpectedError, err)
 	}
 	bridgeSig := []byte{}
 	if metadata.HasBridgeInstructions(v.block.GetInstructions()) {
-		bridgeSig, err = e.UserKeySet.BriSignData(v.block.Hash().GetBytes())
+		bridgeSig, err = e.UserKeySet.BriSignVote(signedBlock)
 		if err != nil {
 			e.Logger.Error(err)
 			return NewConsensusError(UnExpectedError, err)
@@ -420,7 +421,7 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	userPk := e.UserKeySet.GetPublicKey()
 	Vote.Validator = userPk.GetMiningKeyBase58(common.BlsConsensus)
 	Vote.PrevBlockHash = v.block.GetPrevHash().String()
-	err = Vote.signVote(e.UserKeySet)
+	err = Vote.signVote(e.UserKeySet, signedBlock)
 	if err != nil {
 		e.Logger.Error(err)
 		return NewConsensusError(UnExpectedError, err)
@@ -470,7 +471,10 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 		return nil, NewConsensusError(BlockCreationError, errors.New("block is nil"))
 	}
 
-	validationData := e.CreateValidationData(block)
+	validationData, err := e.CreateValidationData(block)
+	if err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
 	validationDataString, _ := EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
 	blockData, _ := json.Marshal(block)
@@ -519,14 +523,9 @@ func (e *BLSBFT_V2) preValidateVote(blockHash []byte, Vote *BFTVote, candidate [
 	return err
 }
 
-func (s *BFTVote) signVote(key *MiningKey) error {
-	data := []byte{}
-	data = append(data, s.BlockHash...)
-	data = append(data, s.BLS...)
-	data = append(data, s.BRI...)
-	data = common.HashB(data)
+func (s *BFTVote) signVote(key Signer, block *SignedBlock) error {
 	var err error
-	s.Confirmation, err = key.BriSignData(data)
+	s.Confirmation, err = key.BriSignVoteConfirmation(block, s.BLS, s.BRI)
 	return err
 }
 
@@ -63,6 +63,42 @@ func (miningKey *MiningKey) BriSignData(
 	return sig, nil
 }
 
+// BriSignProposal - MiningKey is held by this node only, double signing is prevented by the propose and vote history of the consensus
+func (miningKey *MiningKey) BriSignProposal(block *SignedBlock) ([]byte, error) {
+	data, err := block.GetHashBytes()
+	if err != nil {
+		return nil, err
+	}
+	return miningKey.BriSignData(data)
+}
+
+func (miningKey *MiningKey) BLSSignVote(
+	block *SignedBlock,
+	selfIdx int,
+	committee []blsmultisig.PublicKey,
+) (
+	[]byte,
+	error,
+) {
+	data, err := block.GetHashBytes()
+	if err != nil {
+		return nil, err
+	}
+	return miningKey.BLSSignData(data, selfIdx, committee)
+}
+
+func (miningKey *MiningKey) BriSignVote(block *SignedBlock) ([]byte, error) {
+	data, err := block.GetHashBytes()
+	if err != nil {
+		return nil, err
+	}
+	return miningKey.BriSignData(data)
+}
+
+func (miningKey *MiningKey) BriSignVoteConfirmation(block *SignedBlock, bls []byte, bri []byte) ([]byte, error) {
+	return miningKey.BriSignData(block.GetVoteConfirmationData(bls, bri))
+}
+
 func (e *BLSBFT_V2) LoadUserKey(privateSeed string) error {
 	var miningKey MiningKey
 	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
@@ -85,6 +121,11 @@ func (e *BLSBFT_V2) LoadUserKey(privateSeed string) error {
 	return nil
 }
 
+// LoadRemoteSigner - use the mining key held by a remote signer
+func (e *BLSBFT_V2) LoadRemoteSigner(signer *RemoteSigner) {
+	e.UserKeySet = signer
+}
+
 func (e *BLSBFT_V2) LoadUserKeyFromIncPrivateKey(privateKey string) (string, error) {
 	wl, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -0,0 +1,154 @@
+package blsbftv2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/rpc"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const (
+	RemoteSignerServiceName       = "RemoteSigner"
+	remoteGetPublicKey            = RemoteSignerServiceName + ".GetPublicKey"
+	remoteBriSignData             = RemoteSignerServiceName + ".BriSignData"
+	remoteBriSignProposal         = RemoteSignerServiceName + ".BriSignProposal"
+	remoteBLSSignVote             = RemoteSignerServiceName + ".BLSSignVote"
+	remoteBriSignVote             = RemoteSignerServiceName + ".BriSignVote"
+	remoteBriSignVoteConfirmation = RemoteSignerServiceName + ".BriSignVoteConfirmation"
+)
+
+// RemoteSignArgs - request to a remote signer, the signer computes the signed bytes of a block from Block,
+// Data is only signed for requests which are not bound to a block.
+// AuthToken is the secret shared by the node and the signer, required on tcp
+type RemoteSignArgs struct {
+	AuthToken string
+	Data      []byte
+	SelfIdx   int
+	Committee []blsmultisig.PublicKey
+	Block     *SignedBlock
+	BLS       []byte
+	BRI       []byte
+}
+
+// RemoteSigner serves signatures of a mining key held by a separate process (see remotesigner),
+// which keeps its own double sign protection database
+type RemoteSigner struct {
+	network   string
+	address   string
+	authToken string
+	client    *rpc.Client
+	lock      sync.Mutex
+	publicKey incognitokey.CommitteePublicKey
+}
+
+// ParseRemoteSignerAddress splits a remote signer address "unix:<socket path>" or "tcp:<host>:<port>" into network and address
+func ParseRemoteSignerAddress(address string) (string, string, error) {
+	addressParts := strings.SplitN(address, ":", 2)
+	if len(addressParts) != 2 || (addressParts[0] != "unix" && addressParts[0] != "tcp") {
+		return "", "", NewConsensusError(RemoteSignerError, errors.New("remote signer address should be unix:<socket path> or tcp:<host>:<port>"))
+	}
+	return addressParts[0], addressParts[1], nil
+}
+
+// NewRemoteSigner connects to a remote signer and loads its public key,
+// authToken is required for a tcp signer which has no other protection against other clients
+func NewRemoteSigner(address string, authToken string) (*RemoteSigner, error) {
+	network, address, err := ParseRemoteSignerAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "tcp" && authToken == "" {
+		return nil, NewConsensusError(RemoteSignerError, errors.New("auth token of a tcp remote signer is empty"))
+	}
+	signer := &RemoteSigner{
+		network:   network,
+		address:   address,
+		authToken: authToken,
+	}
+	if err := signer.call(remoteGetPublicKey, &RemoteSignArgs{}, &signer.publicKey); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// call calls a method of the remote signer, reconnecting if the connection was shut down
+func (signer *RemoteSigner) call(method string, args *RemoteSignArgs, reply interface{}) error {
+	args.AuthToken = signer.authToken
+	signer.lock.Lock()
+	defer signer.lock.Unlock()
+	for retry := 0; retry < 2; retry++ {
+		if signer.client == nil {
+			client, err := rpc.Dial(signer.network, signer.address)
+			if err != nil {
+				return NewConsensusError(RemoteSignerError, err)
+			}
+			signer.client = client
+		}
+		err := signer.client.Call(method, args, reply)
+		if err == rpc.ErrShutdown {
+			signer.client.Close()
+			signer.client = nil
+			continue
+		}
+		if err != nil {
+			return NewConsensusError(RemoteSignerError, err)
+		}
+		return nil
+	}
+	return NewConsensusError(RemoteSignerError, rpc.ErrShutdown)
+}
+
+func (signer *RemoteSigner) GetPublicKey() incognitokey.CommitteePublicKey {
+	return signer.publicKey
+}
+
+func (signer *RemoteSigner) GetPublicKeyBase58() string {
+	keyBytes, err := json.Marshal(signer.publicKey)
+	if err != nil {
+		return ""
+	}
+	return base58.Base58Check{}.Encode(keyBytes, common.ZeroByte)
+}
+
+func (signer *RemoteSigner) BriSignData(data []byte) ([]byte, error) {
+	var sig []byte
+	err := signer.call(remoteBriSignData, &RemoteSignArgs{Data: data}, &sig)
+	return sig, err
+}
+
+func (signer *RemoteSigner) BriSignProposal(block *SignedBlock) ([]byte, error) {
+	var sig []byte
+	err := signer.call(remoteBriSignProposal, &RemoteSignArgs{Block: block}, &sig)
+	return sig, err
+}
+
+func (signer *RemoteSigner) BLSSignVote(
+	block *SignedBlock,
+	selfIdx int,
+	committee []blsmultisig.PublicKey,
+) (
+	[]byte,
+	error,
+) {
+	var sig []byte
+	err := signer.call(remoteBLSSignVote, &RemoteSignArgs{SelfIdx: selfIdx, Committee: committee, Block: block}, &sig)
+	return sig, err
+}
+
+func (signer *RemoteSigner) BriSignVote(block *SignedBlock) ([]byte, error) {
+	var sig []byte
+	err := signer.call(remoteBriSignVote, &RemoteSignArgs{Block: block}, &sig)
+	return sig, err
+}
+
+func (signer *RemoteSigner) BriSignVoteConfirmation(block *SignedBlock, bls []byte, bri []byte) ([]byte, error) {
+	var sig []byte
+	err := signer.call(remoteBriSignVoteConfirmation, &RemoteSignArgs{Block: block, BLS: bls, BRI: bri}, &sig)
+	return sig, err
+}
@@ -0,0 +1,83 @@
+package blsbftv2
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// Signer signs consensus messages with the mining key of the node,
+// private keys are either held in process (MiningKey) or by a separate process (RemoteSigner).
+// Signed bytes of a block are computed by the signer from the block itself, so a signer with double sign protection
+// knows exactly which block it signs
+type Signer interface {
+	GetPublicKey() incognitokey.CommitteePublicKey
+	GetPublicKeyBase58() string
+	// BriSignData - sign data which is not bound to a block (ex: peer handshake)
+	BriSignData(data []byte) ([]byte, error)
+	// BriSignProposal - producer signature of a proposed block
+	BriSignProposal(block *SignedBlock) ([]byte, error)
+	// BLSSignVote, BriSignVote, BriSignVoteConfirmation - signatures of a vote for a block
+	BLSSignVote(block *SignedBlock, selfIdx int, committee []blsmultisig.PublicKey) ([]byte, error)
+	BriSignVote(block *SignedBlock) ([]byte, error)
+	BriSignVoteConfirmation(block *SignedBlock, bls []byte, bri []byte) ([]byte, error)
+}
+
+// SignedBlock - block which signatures are bound to,
+// produce and propose timeslots are the timeslots used by the voting rules of the consensus
+type SignedBlock struct {
+	ChainID         int
+	Height          uint64
+	ProduceTimeSlot int64
+	ProposeTimeSlot int64
+	BlockHash       string
+}
+
+func NewSignedBlock(chainID int, block common.BlockInterface) *SignedBlock {
+	return &SignedBlock{
+		ChainID:         chainID,
+		Height:          block.GetHeight(),
+		ProduceTimeSlot: common.CalculateTimeSlot(block.GetProduceTime()),
+		ProposeTimeSlot: common.CalculateTimeSlot(block.GetProposeTime()),
+		BlockHash:       block.Hash().String(),
+	}
+}
+
+// GetHashBytes returns the bytes of the block hash, signed by the producer and the BLS and bridge signatures of a vote
+func (block *SignedBlock) GetHashBytes() ([]byte, error) {
+	blockHash, err := common.Hash{}.NewHashFromStr(block.BlockHash)
+	if err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
+	return blockHash.GetBytes(), nil
+}
+
+// GetVoteConfirmationData returns the data signed by the confirmation of a vote (see BFTVote.validateVoteOwner)
+func (block *SignedBlock) GetVoteConfirmationData(bls []byte, bri []byte) []byte {
+	data := []byte{}
+	data = append(data, block.BlockHash...)
+	data = append(data, bls...)
+	data = append(data, bri...)
+	return common.HashB(data)
+}
+
+// CanVoteAfter returns nil if the voting rules of the consensus allow voting for block after votedBlock
+// (the last block voted at the same height of the chain): the same block is signed again,
+// a block produced in an earlier timeslot, or the same produce timeslot re-proposed in a later timeslot
+func (block *SignedBlock) CanVoteAfter(votedBlock *SignedBlock) error {
+	if block.ChainID != votedBlock.ChainID || block.Height != votedBlock.Height {
+		return nil
+	}
+	if block.BlockHash == votedBlock.BlockHash {
+		return nil
+	}
+	if block.ProduceTimeSlot < votedBlock.ProduceTimeSlot {
+		return nil
+	}
+	if block.ProduceTimeSlot == votedBlock.ProduceTimeSlot && block.ProposeTimeSlot > votedBlock.ProposeTimeSlot {
+		return nil
+	}
+	return errors.New("block does not follow the voting rules after the voted block")
+}
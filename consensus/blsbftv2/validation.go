@@ -42,10 +42,11 @@ func EncodeValidationData(validationData ValidationData) (string, error) {
 	return string(result), nil
 }
 
-func (e BLSBFT_V2) CreateValidationData(block common.BlockInterface) ValidationData {
+func (e BLSBFT_V2) CreateValidationData(block common.BlockInterface) (ValidationData, error) {
 	var valData ValidationData
-	valData.ProducerBLSSig, _ = e.UserKeySet.BriSignData(block.Hash().GetBytes())
-	return valData
+	var err error
+	valData.ProducerBLSSig, err = e.UserKeySet.BriSignProposal(NewSignedBlock(e.ChainID, block))
+	return valData, err
 }
 
 func ValidateProducerSig(block common.BlockInterface) error {
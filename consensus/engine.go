@@ -18,6 +18,7 @@ type Engine struct {
 	BFTProcess           map[int]ConsensusInterface //chainID -> consensus
 	userMiningPublicKeys map[string]*incognitokey.CommitteePublicKey
 	userKeyListString    string
+	remoteSigner         *blsbft2.RemoteSigner
 	consensusName        string
 	currentMiningProcess ConsensusInterface
 	config               *EngineConfig
@@ -32,6 +33,9 @@ type Engine struct {
 	version int
 
 	lock *sync.Mutex
+	// isDraining - stop proposing and voting (ex: before switching mining keys), guarded by miningLock
+	isDraining bool
+	miningLock *sync.Mutex
 }
 
 func (engine *Engine) GetUserLayer() (string, int) {
@@ -60,6 +64,14 @@ func (s *Engine) WatchCommitteeChange() {
 		time.AfterFunc(time.Second*3, s.WatchCommitteeChange)
 	}()
 
+	s.updateMiningState()
+}
+
+// updateMiningState updates the role of the node and starts (or stops) the consensus process of its chain
+func (s *Engine) updateMiningState() {
+	s.miningLock.Lock()
+	defer s.miningLock.Unlock()
+
 	//check if enable
 	if s.IsEnabled == 0 || s.config == nil {
 		return
@@ -92,7 +104,7 @@ func (s *Engine) WatchCommitteeChange() {
 	}
 
 	for _, BFTProcess := range s.BFTProcess {
-		if role != "committee" || chainID != BFTProcess.GetChainID() {
+		if role != "committee" || chainID != BFTProcess.GetChainID() || s.isDraining {
 			BFTProcess.Stop()
 		}
 	}
@@ -102,7 +114,7 @@ func (s *Engine) WatchCommitteeChange() {
 
 	var miningProcess ConsensusInterface = nil
 	//TODO: optimize - if in pending start to listen propose block, but not vote
-	if role == "committee" {
+	if role == "committee" && !s.isDraining {
 		chainName := "beacon"
 
 		if chainID >= 0 {
@@ -130,12 +142,12 @@ func (s *Engine) WatchCommitteeChange() {
 			}
 		}
 
-		s.BFTProcess[chainID].Start()
 		miningProcess = s.BFTProcess[chainID]
 		s.currentMiningProcess = s.BFTProcess[chainID]
-		if err := s.LoadMiningKeys(s.userKeyListString); err != nil {
+		if err := s.loadUserKeys(); err != nil {
 			panic(err)
 		}
+		s.BFTProcess[chainID].Start()
 
 	}
 	s.currentMiningProcess = miningProcess
@@ -150,6 +162,7 @@ func NewConsensusEngine() *Engine {
 		userMiningPublicKeys: make(map[string]*incognitokey.CommitteePublicKey),
 		version:              1,
 		lock:                 new(sync.Mutex),
+		miningLock:           new(sync.Mutex),
 	}
 	return engine
 }
@@ -198,8 +211,14 @@ func (engine *Engine) Start() error {
 		engine.userKeyListString = keyList
 	} else if engine.config.Node.GetMiningKeys() != "" {
 		engine.userKeyListString = engine.config.Node.GetMiningKeys()
+	} else if engine.config.Node.GetRemoteSigner() != "" {
+		remoteSigner, err := blsbft2.NewRemoteSigner(engine.config.Node.GetRemoteSigner(), engine.config.Node.GetRemoteSignerToken())
+		if err != nil {
+			panic(err)
+		}
+		engine.remoteSigner = remoteSigner
 	}
-	err := engine.LoadMiningKeys(engine.userKeyListString)
+	err := engine.loadUserKeys()
 	if err != nil {
 		panic(err)
 	}
@@ -20,6 +20,8 @@ type NodeInterface interface {
 	IsEnableMining() bool
 	GetMiningKeys() string
 	GetPrivateKey() string
+	GetRemoteSigner() string
+	GetRemoteSignerToken() string
 	GetUserMiningState() (role string, chainID int)
 	RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) (err error)
 	GetSelfPeerID() peer.ID
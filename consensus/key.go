@@ -48,6 +48,82 @@ func (engine *Engine) LoadMiningKeys(keysString string) error {
 	return nil
 }
 
+// loadUserKeys loads the mining keys of the node or the key held by the remote signer into the current mining process
+func (engine *Engine) loadUserKeys() error {
+	if engine.remoteSigner != nil {
+		return engine.LoadRemoteSigner(engine.remoteSigner)
+	}
+	return engine.LoadMiningKeys(engine.userKeyListString)
+}
+
+// LoadRemoteSigner - use the mining key held by a remote signer, only supported by consensus version 2
+func (engine *Engine) LoadRemoteSigner(remoteSigner *blsbftv2.RemoteSigner) error {
+	if engine.currentMiningProcess != nil {
+		f, ok := engine.currentMiningProcess.(*blsbftv2.BLSBFT_V2)
+		if !ok {
+			return errors.New("Remote signer is not supported by consensus version 1")
+		}
+		f.LoadRemoteSigner(remoteSigner)
+	}
+	publicKey := remoteSigner.GetPublicKey()
+	engine.SetMiningPublicKeys(common.BlsConsensus, &publicKey)
+	return nil
+}
+
+// SetMiningDrainMode - in drain mode, the node stops proposing and voting blocks (consensus processes are stopped),
+// mining keys can be switched safely
+func (engine *Engine) SetMiningDrainMode(drain bool) {
+	engine.miningLock.Lock()
+	engine.isDraining = drain
+	engine.miningLock.Unlock()
+	engine.updateMiningState()
+}
+
+func (engine *Engine) IsMiningDrainMode() bool {
+	engine.miningLock.Lock()
+	defer engine.miningLock.Unlock()
+	return engine.isDraining
+}
+
+// SwitchMiningKeys - replace the mining keys of the node (same format as --miningkeys) without restarting the node,
+// the node should be in drain mode, the role of the node is updated with the new keys.
+// Keys held by a remote signer can not be switched, the remote signer should be restarted with the new key instead
+func (engine *Engine) SwitchMiningKeys(keysString string) error {
+	if err := engine.switchMiningKeys(keysString); err != nil {
+		return err
+	}
+	engine.updateMiningState()
+	return nil
+}
+
+func (engine *Engine) switchMiningKeys(keysString string) error {
+	engine.miningLock.Lock()
+	defer engine.miningLock.Unlock()
+	if !engine.isDraining {
+		return errors.New("Mining should be drained before switching mining keys")
+	}
+	if keysString == "" {
+		return errors.New("Mining keys is empty")
+	}
+	if engine.remoteSigner != nil {
+		return errors.New("Mining key is held by a remote signer, switching mining keys is not supported")
+	}
+	engine.lock.Lock()
+	oldMiningPublicKeys := engine.userMiningPublicKeys
+	engine.userMiningPublicKeys = make(map[string]*incognitokey.CommitteePublicKey)
+	engine.lock.Unlock()
+	// consensus processes are stopped in drain mode, new keys are loaded into them when they start again
+	engine.currentMiningProcess = nil
+	if err := engine.LoadMiningKeys(keysString); err != nil {
+		engine.lock.Lock()
+		engine.userMiningPublicKeys = oldMiningPublicKeys
+		engine.lock.Unlock()
+		return err
+	}
+	engine.userKeyListString = keysString
+	return nil
+}
+
 func (engine *Engine) GetCurrentMiningPublicKey() (publickey string, keyType string) {
 	if engine != nil && engine.GetMiningPublicKeys() != nil {
 		name := engine.consensusName
@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func TestEngine_SwitchMiningKeys(t *testing.T) {
+	newMiningKey := func(seed string) (string, string) {
+		privateSeed := base58.Base58Check{}.Encode(common.HashB([]byte(seed)), common.Base58Version)
+		miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(privateSeed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKey := miningKey.GetPublicKey()
+		return "bls:" + privateSeed, "bls:" + publicKey.GetMiningKeyBase58(common.BlsConsensus)
+	}
+	oldKeys, oldPublicKey := newMiningKey("old")
+	newKeys, newPublicKey := newMiningKey("new")
+
+	engine := NewConsensusEngine()
+	assert.NoError(t, engine.LoadMiningKeys(oldKeys))
+	assert.Equal(t, []string{oldPublicKey}, engine.GetAllMiningPublicKeys())
+
+	// mining should be drained first
+	assert.Error(t, engine.SwitchMiningKeys(newKeys))
+	assert.Equal(t, []string{oldPublicKey}, engine.GetAllMiningPublicKeys())
+
+	engine.SetMiningDrainMode(true)
+	assert.True(t, engine.IsMiningDrainMode())
+	// invalid keys keep the current keys
+	assert.Error(t, engine.SwitchMiningKeys("bls:invalid"))
+	assert.Equal(t, []string{oldPublicKey}, engine.GetAllMiningPublicKeys())
+
+	assert.NoError(t, engine.SwitchMiningKeys(newKeys))
+	assert.Equal(t, []string{newPublicKey}, engine.GetAllMiningPublicKeys())
+	assert.Equal(t, newKeys, engine.userKeyListString)
+
+	// keys held by a remote signer can not be switched
+	engine.remoteSigner = &blsbftv2.RemoteSigner{}
+	assert.Error(t, engine.SwitchMiningKeys(oldKeys))
+	assert.Equal(t, []string{newPublicKey}, engine.GetAllMiningPublicKeys())
+	assert.NotNil(t, engine.remoteSigner)
+
+	engine.SetMiningDrainMode(false)
+	assert.False(t, engine.IsMiningDrainMode())
+}
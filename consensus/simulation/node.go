@@ -227,6 +227,16 @@ func (Node) GetPrivateKey() string {
 	panic("implement me")
 }
 
+func (Node) GetRemoteSigner() string {
+	//not use in bft
+	panic("implement me")
+}
+
+func (Node) GetRemoteSignerToken() string {
+	//not use in bft
+	panic("implement me")
+}
+
 func (Node) DropAllConnections() {
 	//not use in bft
 	return
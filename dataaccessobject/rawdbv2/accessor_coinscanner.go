@@ -0,0 +1,41 @@
+package rawdbv2
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// StoreCoinScannerKey - store encrypted state of a key which is registered to coin scanner of this node
+func StoreCoinScannerKey(db incdb.KeyValueWriter, keyID common.Hash, val []byte) error {
+	key := GetCoinScannerKeyKey(keyID)
+	if err := db.Put(key, val); err != nil {
+		return NewRawdbError(StoreCoinScannerKeyError, err, keyID.String())
+	}
+	return nil
+}
+
+// DeleteCoinScannerKey - delete state of a key which is unregistered from coin scanner
+func DeleteCoinScannerKey(db incdb.KeyValueWriter, keyID common.Hash) error {
+	key := GetCoinScannerKeyKey(keyID)
+	if err := db.Delete(key); err != nil {
+		return NewRawdbError(DeleteCoinScannerKeyError, err, keyID.String())
+	}
+	return nil
+}
+
+// GetAllCoinScannerKeys - get encrypted states of all keys registered to coin scanner
+func GetAllCoinScannerKeys(db incdb.Database) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(GetCoinScannerKeyPrefix())
+	defer iterator.Release()
+	result := make([][]byte, 0)
+	for iterator.Next() {
+		value := iterator.Value()
+		tempValue := make([]byte, len(value))
+		copy(tempValue, value)
+		result = append(result, tempValue)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetCoinScannerKeyError, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package rawdbv2
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// StorePayoutBatch - store payout batch which is tracked by payout rpc of this node
+func StorePayoutBatch(db incdb.KeyValueWriter, batchID common.Hash, val []byte) error {
+	key := GetPayoutBatchKey(batchID)
+	if err := db.Put(key, val); err != nil {
+		return NewRawdbError(StorePayoutBatchError, err, batchID.String())
+	}
+	return nil
+}
+
+// GetPayoutBatch - get payout batch as a json in byte format
+func GetPayoutBatch(db incdb.KeyValueReader, batchID common.Hash) ([]byte, error) {
+	key := GetPayoutBatchKey(batchID)
+	res, err := db.Get(key)
+	if err != nil {
+		return nil, NewRawdbError(GetPayoutBatchError, err, batchID.String())
+	}
+	return res, nil
+}
+
+// GetAllPayoutBatches - get all payout batches as jsons in byte format
+func GetAllPayoutBatches(db incdb.Database) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(GetPayoutBatchPrefix())
+	defer iterator.Release()
+	result := make([][]byte, 0)
+	for iterator.Next() {
+		value := iterator.Value()
+		tempValue := make([]byte, len(value))
+		copy(tempValue, value)
+		result = append(result, tempValue)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetPayoutBatchError, err)
+	}
+	return result, nil
+}
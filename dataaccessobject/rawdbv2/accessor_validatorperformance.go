@@ -0,0 +1,66 @@
+package rawdbv2
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// StoreValidatorPerformance - store performance of committee members of a chain (shard ID or -1 for beacon) in an epoch,
+// which is tracked from finalized blocks by this node
+func StoreValidatorPerformance(db incdb.KeyValueWriter, chainID int, epoch uint64, val []byte) error {
+	key := GetValidatorPerformanceKey(chainID, epoch)
+	if err := db.Put(key, val); err != nil {
+		return NewRawdbError(StoreValidatorPerformanceError, err, chainID, epoch)
+	}
+	return nil
+}
+
+// HasValidatorPerformance - check performance of committee members of a chain in an epoch is tracked
+func HasValidatorPerformance(db incdb.KeyValueReader, chainID int, epoch uint64) (bool, error) {
+	key := GetValidatorPerformanceKey(chainID, epoch)
+	has, err := db.Has(key)
+	if err != nil {
+		return false, NewRawdbError(GetValidatorPerformanceError, err, chainID, epoch)
+	}
+	return has, nil
+}
+
+// GetValidatorPerformance - get performance of committee members of a chain in an epoch as a json in byte format
+func GetValidatorPerformance(db incdb.KeyValueReader, chainID int, epoch uint64) ([]byte, error) {
+	key := GetValidatorPerformanceKey(chainID, epoch)
+	res, err := db.Get(key)
+	if err != nil {
+		return nil, NewRawdbError(GetValidatorPerformanceError, err, chainID, epoch)
+	}
+	return res, nil
+}
+
+// StoreValidatorPerformanceIndexedHeight - store height of the last finalized block of a chain which is indexed in performance of committee members
+func StoreValidatorPerformanceIndexedHeight(db incdb.KeyValueWriter, chainID int, height uint64) error {
+	key := GetValidatorPerformanceIndexedHeightKey(chainID)
+	if err := db.Put(key, common.Uint64ToBytes(height)); err != nil {
+		return NewRawdbError(StoreValidatorPerformanceError, err, chainID, height)
+	}
+	return nil
+}
+
+// GetValidatorPerformanceIndexedHeight - get height of the last indexed finalized block of a chain, false if the chain is not indexed yet
+func GetValidatorPerformanceIndexedHeight(db incdb.KeyValueReader, chainID int) (uint64, bool, error) {
+	key := GetValidatorPerformanceIndexedHeightKey(chainID)
+	has, err := db.Has(key)
+	if err != nil {
+		return 0, false, NewRawdbError(GetValidatorPerformanceError, err, chainID)
+	}
+	if !has {
+		return 0, false, nil
+	}
+	res, err := db.Get(key)
+	if err != nil {
+		return 0, false, NewRawdbError(GetValidatorPerformanceError, err, chainID)
+	}
+	height, err := common.BytesToUint64(res)
+	if err != nil {
+		return 0, false, NewRawdbError(GetValidatorPerformanceError, err, chainID)
+	}
+	return height, true, nil
+}
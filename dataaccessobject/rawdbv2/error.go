@@ -88,6 +88,19 @@ const (
 	StoreRelayingBNBHeaderError
 	GetRelayingBNBHeaderError
 	GetBNBDataHashError
+
+	// payout
+	StorePayoutBatchError
+	GetPayoutBatchError
+
+	// coin scanner
+	StoreCoinScannerKeyError
+	GetCoinScannerKeyError
+	DeleteCoinScannerKeyError
+
+	// validator performance
+	StoreValidatorPerformanceError
+	GetValidatorPerformanceError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -173,6 +186,19 @@ var ErrCodeMessage = map[int]struct {
 	StoreRelayingBNBHeaderError: {-5001, "Store relaying header bnb error"},
 	GetRelayingBNBHeaderError:   {-5002, "Get relaying header bnb error"},
 	GetBNBDataHashError:         {-5003, "Get bnb data hash by block height error"},
+
+	// payout
+	StorePayoutBatchError: {-6000, "Store payout batch error"},
+	GetPayoutBatchError:   {-6001, "Get payout batch error"},
+
+	// coin scanner
+	StoreCoinScannerKeyError:  {-6100, "Store coin scanner key error"},
+	GetCoinScannerKeyError:    {-6101, "Get coin scanner key error"},
+	DeleteCoinScannerKeyError: {-6102, "Delete coin scanner key error"},
+
+	// validator performance
+	StoreValidatorPerformanceError: {-6200, "Store validator performance error"},
+	GetValidatorPerformanceError:   {-6201, "Get validator performance error"},
 }
 
 type RawdbError struct {
@@ -35,6 +35,10 @@ var (
 	shardSlashRootHashPrefix           = []byte("s-sl" + string(splitter))
 	shardFeatureRootHashPrefix         = []byte("s-fe" + string(splitter))
 	previousBestStatePrefix            = []byte("previous-best-state" + string(splitter))
+	payoutBatchPrefix                  = []byte("payout-b" + string(splitter))
+	coinScannerKeyPrefix               = []byte("coinscan-k" + string(splitter))
+	validatorPerformancePrefix         = []byte("val-perf" + string(splitter))
+	validatorPerformanceHeightPrefix   = []byte("val-perf-h" + string(splitter))
 	splitter                           = []byte("-[-]-")
 )
 
@@ -333,3 +337,38 @@ func getShardPreCommitteeInfoForShardKey(hash common.Hash) []byte {
 func getShardPendingValidatorsKey(hash common.Hash) []byte {
 	return hash.Bytes()
 }
+
+func GetPayoutBatchPrefix() []byte {
+	temp := make([]byte, 0, len(payoutBatchPrefix))
+	return append(temp, payoutBatchPrefix...)
+}
+
+func GetPayoutBatchKey(batchID common.Hash) []byte {
+	return append(GetPayoutBatchPrefix(), batchID[:]...)
+}
+
+func GetCoinScannerKeyPrefix() []byte {
+	temp := make([]byte, 0, len(coinScannerKeyPrefix))
+	return append(temp, coinScannerKeyPrefix...)
+}
+
+func GetCoinScannerKeyKey(keyID common.Hash) []byte {
+	return append(GetCoinScannerKeyPrefix(), keyID[:]...)
+}
+
+func GetValidatorPerformancePrefix(chainID int) []byte {
+	temp := make([]byte, 0, len(validatorPerformancePrefix))
+	temp = append(temp, validatorPerformancePrefix...)
+	return append(temp, byte(chainID))
+}
+
+func GetValidatorPerformanceKey(chainID int, epoch uint64) []byte {
+	key := append(GetValidatorPerformancePrefix(chainID), splitter...)
+	return append(key, common.Uint64ToBytes(epoch)...)
+}
+
+func GetValidatorPerformanceIndexedHeightKey(chainID int) []byte {
+	temp := make([]byte, 0, len(validatorPerformanceHeightPrefix))
+	temp = append(temp, validatorPerformanceHeightPrefix...)
+	return append(temp, byte(chainID))
+}
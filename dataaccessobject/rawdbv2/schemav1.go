@@ -19,6 +19,9 @@ var (
 	PDETradeStatusPrefix         = []byte("pdetradestatus-")
 	PDEWithdrawalStatusPrefix    = []byte("pdewithdrawalstatus-")
 	PDEFeeWithdrawalStatusPrefix = []byte("pdefeewithdrawalstatus-")
+	PDELimitOrderPrefix          = []byte("pdeorder-")
+	PDELimitOrderStatusPrefix    = []byte("pdeorderstatus-")
+	PDECancelOrderStatusPrefix   = []byte("pdecancelorderstatus-")
 )
 
 // TODO - change json to CamelCase
@@ -56,6 +59,23 @@ func NewPDEPoolForPair(token1IDStr string, token1PoolValue uint64, token2IDStr s
 	return &PDEPoolForPair{Token1IDStr: token1IDStr, Token1PoolValue: token1PoolValue, Token2IDStr: token2IDStr, Token2PoolValue: token2PoolValue}
 }
 
+// PDELimitOrder is a limit order resting in the order book of pDEX until it is matched, cancelled or expired
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	TradingFee          uint64
+	ExpiryBeaconHeight  uint64
+	ShardID             byte
+}
+
+func NewPDELimitOrder(orderID common.Hash, traderAddressStr string, tokenIDToBuyStr string, tokenIDToSellStr string, sellAmount uint64, minAcceptableAmount uint64, tradingFee uint64, expiryBeaconHeight uint64, shardID byte) *PDELimitOrder {
+	return &PDELimitOrder{OrderID: orderID, TraderAddressStr: traderAddressStr, TokenIDToBuyStr: tokenIDToBuyStr, TokenIDToSellStr: tokenIDToSellStr, SellAmount: sellAmount, MinAcceptableAmount: minAcceptableAmount, TradingFee: tradingFee, ExpiryBeaconHeight: expiryBeaconHeight, ShardID: shardID}
+}
+
 func BuildPDESharesKey(
 	beaconHeight uint64,
 	token1IDStr string,
@@ -129,3 +149,12 @@ func BuildWaitingPDEContributionKey(
 	waitingPDEContribByBCHeightPrefix := append(WaitingPDEContributionPrefix, beaconHeightBytes...)
 	return append(waitingPDEContribByBCHeightPrefix, []byte(pairID)...)
 }
+
+func BuildPDELimitOrderKey(
+	beaconHeight uint64,
+	orderID string,
+) []byte {
+	beaconHeightBytes := []byte(fmt.Sprintf("%d-", beaconHeight))
+	pdeLimitOrderByBCHeightPrefix := append(PDELimitOrderPrefix, beaconHeightBytes...)
+	return append(pdeLimitOrderByBCHeightPrefix, []byte(orderID)...)
+}
@@ -311,6 +311,11 @@ func GetAllCommitteeStakeInfo(stateDB *StateDB, shardIDs []int) map[int][]*Stake
 	return stateDB.getShardsCommitteeInfo(shardIDs)
 }
 
+// GetAllCommitteeStakeInfoByCommitteePublicKey lists staker info of current validators keyed by their committee public keys
+func GetAllCommitteeStakeInfoByCommitteePublicKey(stateDB *StateDB, shardIDs []int) (map[int]map[string]*StakerInfo, error) {
+	return stateDB.getShardsCommitteeInfoByCommitteePublicKey(shardIDs)
+}
+
 func GetMapAutoStaking(bcDB *StateDB, shardIDs []int) map[string]bool {
 	res, err := bcDB.getMapAutoStaking(shardIDs)
 	if err != nil {
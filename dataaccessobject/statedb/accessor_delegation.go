@@ -0,0 +1,137 @@
+package statedb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// CommitteeDelegationInfo is the commission rate and the delegations of a current validator,
+// with the amount slashed from its current stake for double signing
+type CommitteeDelegationInfo struct {
+	CommitteePublicKey string
+	CommissionRate     uint64
+	Delegations        []*DelegationState
+	SlashedAmount      uint64
+}
+
+// StoreDelegation stores a delegation and indexes it by its validator
+func StoreDelegation(stateDB *StateDB, delegation *DelegationState) error {
+	key := GenerateDelegationObjectKey(delegation.TxDelegationID())
+	err := stateDB.SetStateObject(DelegationObjectType, key, delegation)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	indexKey := GenerateValidatorDelegationsObjectKey(delegation.CommitteePublicKey())
+	validatorDelegations, has, err := stateDB.getValidatorDelegationsByKey(indexKey)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	if !has {
+		validatorDelegations = NewValidatorDelegationsStateWithValue(delegation.CommitteePublicKey(), []common.Hash{})
+	}
+	txDelegationIDsLen := len(validatorDelegations.TxDelegationIDs())
+	validatorDelegations.AddTxDelegationID(delegation.TxDelegationID())
+	if has && len(validatorDelegations.TxDelegationIDs()) == txDelegationIDsLen {
+		return nil
+	}
+	err = stateDB.SetStateObject(ValidatorDelegationsObjectType, indexKey, validatorDelegations)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	return nil
+}
+
+func GetDelegation(stateDB *StateDB, txDelegationID common.Hash) (*DelegationState, bool, error) {
+	key := GenerateDelegationObjectKey(txDelegationID)
+	delegation, has, err := stateDB.getDelegationByKey(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetDelegationError, err)
+	}
+	return delegation, has, nil
+}
+
+// DeleteDelegation deletes a delegation and removes it from the index of its validator
+func DeleteDelegation(stateDB *StateDB, txDelegationID common.Hash) error {
+	key := GenerateDelegationObjectKey(txDelegationID)
+	delegation, has, err := stateDB.getDelegationByKey(key)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	if !has {
+		return nil
+	}
+	stateDB.MarkDeleteStateObject(DelegationObjectType, key)
+	indexKey := GenerateValidatorDelegationsObjectKey(delegation.CommitteePublicKey())
+	validatorDelegations, has, err := stateDB.getValidatorDelegationsByKey(indexKey)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	if !has {
+		return nil
+	}
+	validatorDelegations.RemoveTxDelegationID(txDelegationID)
+	if len(validatorDelegations.TxDelegationIDs()) == 0 {
+		stateDB.MarkDeleteStateObject(ValidatorDelegationsObjectType, indexKey)
+		return nil
+	}
+	err = stateDB.SetStateObject(ValidatorDelegationsObjectType, indexKey, validatorDelegations)
+	if err != nil {
+		return NewStatedbError(StoreDelegationError, err)
+	}
+	return nil
+}
+
+func GetAllDelegations(stateDB *StateDB) []*DelegationState {
+	return stateDB.getAllDelegationState()
+}
+
+// GetDelegationsByCommitteePublicKey returns all delegations locked to a validator, including unbonding ones
+func GetDelegationsByCommitteePublicKey(stateDB *StateDB, committeePublicKey string) ([]*DelegationState, error) {
+	delegations, err := stateDB.getDelegationsByCommitteePublicKey(committeePublicKey)
+	if err != nil {
+		return nil, NewStatedbError(GetDelegationError, err)
+	}
+	return delegations, nil
+}
+
+// GetAllValidatorsWithDelegations returns committee public keys of validators which have delegations
+func GetAllValidatorsWithDelegations(stateDB *StateDB) []string {
+	return stateDB.getAllValidatorsWithDelegations()
+}
+
+// StoreValidatorCommission sets the commission rate of a validator applied from fromEpoch
+func StoreValidatorCommission(stateDB *StateDB, committeePublicKey string, commissionRate uint64, fromEpoch uint64) error {
+	key := GenerateValidatorCommissionObjectKey(committeePublicKey)
+	value, has, err := stateDB.getValidatorCommissionByKey(key)
+	if err != nil {
+		return NewStatedbError(StoreValidatorCommissionError, err)
+	}
+	if !has {
+		value = NewValidatorCommissionStateWithValue(committeePublicKey, 0)
+	}
+	value.SetCommissionRateFrom(commissionRate, fromEpoch)
+	err = stateDB.SetStateObject(ValidatorCommissionObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(StoreValidatorCommissionError, err)
+	}
+	return nil
+}
+
+// GetValidatorCommission returns the commission rate of a validator applied in an epoch, 0 if it has never been set
+func GetValidatorCommission(stateDB *StateDB, committeePublicKey string, epoch uint64) (uint64, bool, error) {
+	key := GenerateValidatorCommissionObjectKey(committeePublicKey)
+	commission, has, err := stateDB.getValidatorCommissionByKey(key)
+	if err != nil {
+		return 0, false, NewStatedbError(GetValidatorCommissionError, err)
+	}
+	return commission.CommissionRateAt(epoch), has, nil
+}
+
+// GetAllCommitteeDelegationInfo lists delegation info of current validators keyed by their committee public keys,
+// with the commission rates applied in epoch
+func GetAllCommitteeDelegationInfo(stateDB *StateDB, shardIDs []int, epoch uint64) (map[int]map[string]*CommitteeDelegationInfo, error) {
+	res, err := stateDB.getShardsCommitteeDelegationInfo(shardIDs, epoch)
+	if err != nil {
+		return nil, NewStatedbError(GetDelegationError, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStoreAndGetDelegation(t *testing.T) {
+	sDB, _ := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	txID1 := common.HashH([]byte("delegation-1"))
+	txID2 := common.HashH([]byte("delegation-2"))
+	txID3 := common.HashH([]byte("delegation-3"))
+	delegations := []*DelegationState{
+		NewDelegationStateWithValue("cpk-1", "delegator-1", txID1, 100, false, false),
+		NewDelegationStateWithValue("cpk-1", "delegator-2", txID2, 200, true, false),
+		NewDelegationStateWithValue("cpk-2", "delegator-1", txID3, 300, false, false),
+	}
+	for _, delegation := range delegations {
+		if err := StoreDelegation(sDB, delegation); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wrarperDB.TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+	tempStateDB, err := NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delegation, has, err := GetDelegation(tempStateDB, txID2)
+	if err != nil || !has {
+		t.Fatalf("GetDelegation() has = %v, err = %v", has, err)
+	}
+	if delegation.Amount() != 200 || !delegation.Unbonding() || delegation.DelegatorAddress() != "delegator-2" {
+		t.Fatalf("GetDelegation() got = %+v", delegation)
+	}
+	if got := len(GetAllDelegations(tempStateDB)); got != 3 {
+		t.Fatalf("GetAllDelegations() got %v delegations, want 3", got)
+	}
+	delegationsOfCPK1, err := GetDelegationsByCommitteePublicKey(tempStateDB, "cpk-1")
+	if err != nil || len(delegationsOfCPK1) != 2 {
+		t.Fatalf("GetDelegationsByCommitteePublicKey() got %v delegations, err = %v, want 2", len(delegationsOfCPK1), err)
+	}
+	if got := len(GetAllValidatorsWithDelegations(tempStateDB)); got != 2 {
+		t.Fatalf("GetAllValidatorsWithDelegations() got %v validators, want 2", got)
+	}
+
+	if err := DeleteDelegation(tempStateDB, txID1); err != nil {
+		t.Fatal(err)
+	}
+	if _, has, _ := GetDelegation(tempStateDB, txID1); has {
+		t.Fatal("GetDelegation() got a deleted delegation")
+	}
+	delegationsOfCPK1, err = GetDelegationsByCommitteePublicKey(tempStateDB, "cpk-1")
+	if err != nil || len(delegationsOfCPK1) != 1 || delegationsOfCPK1[0].TxDelegationID() != txID2 {
+		t.Fatalf("GetDelegationsByCommitteePublicKey() got %+v, err = %v after deleting a delegation", delegationsOfCPK1, err)
+	}
+}
+
+func TestStoreAndGetValidatorCommission(t *testing.T) {
+	sDB, _ := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if _, has, err := GetValidatorCommission(sDB, "cpk-1", 1); has || err != nil {
+		t.Fatalf("GetValidatorCommission() has = %v, err = %v", has, err)
+	}
+	checkCommissionRates := func(want map[uint64]uint64) {
+		for epoch, wantRate := range want {
+			commissionRate, has, err := GetValidatorCommission(sDB, "cpk-1", epoch)
+			if err != nil || !has || commissionRate != wantRate {
+				t.Fatalf("GetValidatorCommission() at epoch %v got = %v, has = %v, err = %v, want %v", epoch, commissionRate, has, err, wantRate)
+			}
+		}
+	}
+	// set in epoch 1, applied from epoch 2
+	if err := StoreValidatorCommission(sDB, "cpk-1", 500, 2); err != nil {
+		t.Fatal(err)
+	}
+	checkCommissionRates(map[uint64]uint64{1: 0, 2: 500, 3: 500})
+	// set again in epoch 1, replaces the pending rate
+	if err := StoreValidatorCommission(sDB, "cpk-1", 400, 2); err != nil {
+		t.Fatal(err)
+	}
+	checkCommissionRates(map[uint64]uint64{1: 0, 2: 400})
+	// set in epoch 3, applied from epoch 4
+	if err := StoreValidatorCommission(sDB, "cpk-1", 900, 4); err != nil {
+		t.Fatal(err)
+	}
+	checkCommissionRates(map[uint64]uint64{3: 400, 4: 900, 10: 900})
+}
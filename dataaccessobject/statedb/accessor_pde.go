@@ -185,3 +185,56 @@ func GetPDETradingFees(stateDB *StateDB, beaconHeight uint64) (map[string]uint64
 	}
 	return pdeTradingFees, nil
 }
+
+func StorePDELimitOrders(stateDB *StateDB, beaconHeight uint64, pdeLimitOrders map[string]*rawdbv2.PDELimitOrder) error {
+	for tempKey, order := range pdeLimitOrders {
+		strs := strings.Split(tempKey, "-")
+		orderID := strings.Join(strs[2:], "-")
+		key := GeneratePDELimitOrderObjectKey(orderID)
+		value := NewPDELimitOrderStateWithValue(order.OrderID, order.TraderAddressStr, order.TokenIDToBuyStr, order.TokenIDToSellStr, order.SellAmount, order.MinAcceptableAmount, order.TradingFee, order.ExpiryBeaconHeight, order.ShardID)
+		err := stateDB.SetStateObject(PDELimitOrderObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDELimitOrderError, err)
+		}
+	}
+	return nil
+}
+
+func GetPDELimitOrders(stateDB *StateDB, beaconHeight uint64) (map[string]*rawdbv2.PDELimitOrder, error) {
+	pdeLimitOrders := make(map[string]*rawdbv2.PDELimitOrder)
+	pdeLimitOrderStates := stateDB.getAllPDELimitOrderState()
+	for _, loState := range pdeLimitOrderStates {
+		key := string(GetPDELimitOrderKey(beaconHeight, loState.OrderID().String()))
+		value := rawdbv2.NewPDELimitOrder(loState.OrderID(), loState.TraderAddress(), loState.TokenIDToBuy(), loState.TokenIDToSell(), loState.SellAmount(), loState.MinAcceptableAmount(), loState.TradingFee(), loState.ExpiryBeaconHeight(), loState.ShardID())
+		pdeLimitOrders[key] = value
+	}
+	return pdeLimitOrders, nil
+}
+
+func DeletePDELimitOrders(stateDB *StateDB, deletedPDELimitOrders map[string]*rawdbv2.PDELimitOrder) {
+	for tempKey, _ := range deletedPDELimitOrders {
+		strs := strings.Split(tempKey, "-")
+		orderID := strings.Join(strs[2:], "-")
+		key := GeneratePDELimitOrderObjectKey(orderID)
+		stateDB.MarkDeleteStateObject(PDELimitOrderObjectType, key)
+	}
+}
+
+func StorePDEPriceCumulative(stateDB *StateDB, priceCumulative *PDEPriceCumulativeState) error {
+	key := GeneratePDEPriceCumulativeObjectKey(priceCumulative.Token1ID(), priceCumulative.Token2ID())
+	err := stateDB.SetStateObject(PDEPriceCumulativeObjectType, key, priceCumulative)
+	if err != nil {
+		return NewStatedbError(StorePDEPriceCumulativeError, err)
+	}
+	return nil
+}
+
+// GetPDEPriceCumulative returns price accumulators of a pool pair, token ids could be passed in any order
+func GetPDEPriceCumulative(stateDB *StateDB, token1ID string, token2ID string) (*PDEPriceCumulativeState, bool, error) {
+	key := GeneratePDEPriceCumulativeObjectKey(token1ID, token2ID)
+	priceCumulative, has, err := stateDB.getPDEPriceCumulativeByKey(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetPDEPriceCumulativeError, err)
+	}
+	return priceCumulative, has, nil
+}
@@ -0,0 +1,20 @@
+package statedb
+
+func StoreSlashedStake(stateDB *StateDB, slashedStake *SlashedStakeState) error {
+	key := GenerateSlashedStakeObjectKey(slashedStake.CommitteePublicKey())
+	err := stateDB.SetStateObject(SlashedStakeObjectType, key, slashedStake)
+	if err != nil {
+		return NewStatedbError(StoreSlashedStakeError, err)
+	}
+	return nil
+}
+
+// GetSlashedStake returns the stake slashed from a committee public key for double signing, if any
+func GetSlashedStake(stateDB *StateDB, committeePublicKey string) (*SlashedStakeState, bool, error) {
+	key := GenerateSlashedStakeObjectKey(committeePublicKey)
+	slashedStake, has, err := stateDB.getSlashedStakeByKey(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetSlashedStakeError, err)
+	}
+	return slashedStake, has, nil
+}
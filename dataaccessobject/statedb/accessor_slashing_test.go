@@ -0,0 +1,41 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStoreAndGetSlashedStake(t *testing.T) {
+	sDB, _ := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if _, has, err := GetSlashedStake(sDB, "cpk-1"); has || err != nil {
+		t.Fatalf("GetSlashedStake() has = %v, err = %v", has, err)
+	}
+	txStakingID := common.HashH([]byte("staking"))
+	txEvidenceID := common.HashH([]byte("evidence"))
+	if err := StoreSlashedStake(sDB, NewSlashedStakeStateWithValue("cpk-1", txStakingID, txEvidenceID, 175)); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wrarperDB.TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+	tempStateDB, err := NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slashedStake, has, err := GetSlashedStake(tempStateDB, "cpk-1")
+	if err != nil || !has {
+		t.Fatalf("GetSlashedStake() has = %v, err = %v", has, err)
+	}
+	if slashedStake.TxStakingID() != txStakingID || slashedStake.TxEvidenceID() != txEvidenceID || slashedStake.SlashedAmount() != 175 {
+		t.Fatalf("GetSlashedStake() got = %+v", slashedStake)
+	}
+	if _, has, _ := GetSlashedStake(tempStateDB, "cpk-2"); has {
+		t.Fatal("GetSlashedStake() got a slashed stake of another key")
+	}
+}
@@ -49,6 +49,14 @@ const (
 	PDETradingFeeObjectType
 
 	StakerObjectType
+
+	PDELimitOrderObjectType
+	PDEPriceCumulativeObjectType
+
+	DelegationObjectType
+	ValidatorCommissionObjectType
+	SlashedStakeObjectType
+	ValidatorDelegationsObjectType
 )
 
 // Prefix length
@@ -43,6 +43,12 @@ const (
 	ErrInvalidPortalLockedCollateralStateType = "invalid portal locked collateral state type"
 	ErrInvalidRewardFeatureStateType          = "invalid feature reward state type"
 	ErrInvalidPDETradingFeeStateType          = "invalid pde trading fee state type"
+	ErrInvalidPDELimitOrderStateType          = "invalid pde limit order state type"
+	ErrInvalidPDEPriceCumulativeStateType     = "invalid pde price cumulative state type"
+	ErrInvalidDelegationStateType             = "invalid delegation state type"
+	ErrInvalidValidatorCommissionStateType    = "invalid validator commission state type"
+	ErrInvalidSlashedStakeStateType           = "invalid slashed stake state type"
+	ErrInvalidValidatorDelegationsStateType   = "invalid validator delegations state type"
 	ErrInvalidBlockHashType                   = "invalid block hash type"
 )
 const (
@@ -208,7 +214,20 @@ const (
 
 	// PDEX v2
 	StorePDETradingFeeError
-	
+	StorePDELimitOrderError
+	StorePDEPriceCumulativeError
+	GetPDEPriceCumulativeError
+
+	// delegation
+	StoreDelegationError
+	GetDelegationError
+	StoreValidatorCommissionError
+	GetValidatorCommissionError
+
+	// slashing
+	StoreSlashedStakeError
+	GetSlashedStakeError
+
 	InvalidStakerInfoTypeError
 )
 
@@ -266,6 +285,12 @@ var ErrCodeMessage = map[int]struct {
 	StoreBlackListProducersError:           {-3013, "Store Black List Producers Error"},
 	StoreOneShardSubstitutesValidatorError: {-3014, "Store One Shard Substitutes Validator Error"},
 	StoreBeaconSubstitutesValidatorError:   {-3014, "Store Beacon Substitutes Validator Error"},
+	StoreDelegationError:                   {-3015, "Store Delegation Error"},
+	GetDelegationError:                     {-3016, "Get Delegation Error"},
+	StoreValidatorCommissionError:          {-3017, "Store Validator Commission Error"},
+	GetValidatorCommissionError:            {-3018, "Get Validator Commission Error"},
+	StoreSlashedStakeError:                 {-3019, "Store Slashed Stake Error"},
+	GetSlashedStakeError:                   {-3020, "Get Slashed Stake Error"},
 	// -4xxx: pdex error
 	StoreWaitingPDEContributionError: {-4000, "Store Waiting PDEX Contribution Error"},
 	StorePDEPoolPairError:            {-4001, "Store PDEX Pool Pair Error"},
@@ -273,6 +298,9 @@ var ErrCodeMessage = map[int]struct {
 	GetPDEPoolForPairError:           {-4003, "Get PDEX Pool Pair Error"},
 	TrackPDEStatusError:              {-4004, "Track PDEX Status Error"},
 	GetPDEStatusError:                {-4005, "Get PDEX Status Error"},
+	StorePDELimitOrderError:          {-4006, "Store PDEX Limit Order Error"},
+	StorePDEPriceCumulativeError:     {-4007, "Store PDEX Price Cumulative Error"},
+	GetPDEPriceCumulativeError:       {-4008, "Get PDEX Price Cumulative Error"},
 	// -5xxx: bridge error
 	BridgeInsertETHTxHashIssuedError: {-5000, "Bridge Insert ETH Tx Hash Issued Error"},
 	IsETHTxHashIssuedError:           {-5001, "Is ETH Tx Hash Issued Error"},
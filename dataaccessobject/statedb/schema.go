@@ -37,16 +37,23 @@ var (
 	pdeTradeStatusPrefix               = []byte("pdetradestatus-")
 	pdeWithdrawalStatusPrefix          = []byte("pdewithdrawalstatus-")
 	pdeStatusPrefix                    = []byte("pdestatus-")
+	pdeLimitOrderPrefix                = []byte("pdeorder-")
+	pdePriceCumulativePrefix           = []byte("pdepricecumulative-")
 	bridgeEthTxPrefix                  = []byte("bri-eth-tx-")
 	bridgeCentralizedTokenInfoPrefix   = []byte("bri-cen-token-info-")
 	bridgeDecentralizedTokenInfoPrefix = []byte("bri-de-token-info-")
 	bridgeStatusPrefix                 = []byte("bri-status-")
 	burnPrefix                         = []byte("burn-")
 	stakerInfoPrefix                   = common.HashB([]byte("stk-info-"))[:prefixHashKeyLength]
+	delegationPrefix                   = []byte("delegation-")
+	validatorCommissionPrefix          = []byte("validator-commission-")
+	slashedStakePrefix                 = []byte("slashed-stake-")
+	validatorDelegationsPrefix         = []byte("validator-delegations-")
 
 	// portal
 	portalFinaExchangeRatesStatePrefix            = []byte("portalfinalexchangeratesstate-")
 	portalExchangeRatesRequestStatusPrefix        = []byte("portalexchangeratesrequeststatus-")
+	portalExchangeRatesHistoryStatusPrefix        = []byte("portalexchangerateshistorystatus-")
 	portalPortingRequestStatusPrefix              = []byte("portalportingrequeststatus-")
 	portalPortingRequestTxStatusPrefix            = []byte("portalportingrequesttxstatus-")
 	portalCustodianWithdrawStatusPrefix           = []byte("portalcustodianwithdrawstatus-")
@@ -129,6 +136,26 @@ func GetStakerInfoKey(stakerPublicKey []byte) common.Hash {
 	return *finalHash
 }
 
+func GetDelegationPrefix() []byte {
+	h := common.HashH(delegationPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetValidatorCommissionPrefix() []byte {
+	h := common.HashH(validatorCommissionPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetValidatorDelegationsPrefix() []byte {
+	h := common.HashH(validatorDelegationsPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetSlashedStakePrefix() []byte {
+	h := common.HashH(slashedStakePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetCommitteeRewardPrefix() []byte {
 	h := common.HashH(committeeRewardPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -211,6 +238,16 @@ func GetPDEStatusPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPDELimitOrderPrefix() []byte {
+	h := common.HashH(pdeLimitOrderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetPDEPriceCumulativePrefix() []byte {
+	h := common.HashH(pdePriceCumulativePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetBridgeEthTxPrefix() []byte {
 	h := common.HashH(bridgeEthTxPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -256,6 +293,9 @@ func PDETradeStatusPrefix() []byte {
 func PDEWithdrawalStatusPrefix() []byte {
 	return pdeWithdrawalStatusPrefix
 }
+func PDELimitOrderPrefix() []byte {
+	return pdeLimitOrderPrefix
+}
 
 // GetWaitingPDEContributionKey: WaitingPDEContributionPrefix - beacon height - pairid
 func GetWaitingPDEContributionKey(beaconHeight uint64, pairID string) []byte {
@@ -287,6 +327,12 @@ func GetPDETradingFeeKey(beaconHeight uint64, token1ID string, token2ID string,
 	return append(prefix, []byte(tokenIDs[0]+"-"+tokenIDs[1]+"-"+contributorAddress)...)
 }
 
+// GetPDELimitOrderKey: PDELimitOrderPrefix - beacon height - orderid
+func GetPDELimitOrderKey(beaconHeight uint64, orderID string) []byte {
+	prefix := append(pdeLimitOrderPrefix, []byte(fmt.Sprintf("%d-", beaconHeight))...)
+	return append(prefix, []byte(orderID)...)
+}
+
 func GetPDEStatusKey(prefix []byte, suffix []byte) []byte {
 	return append(prefix, suffix...)
 }
@@ -309,6 +355,10 @@ func PortalExchangeRatesRequestStatusPrefix() []byte {
 	return portalExchangeRatesRequestStatusPrefix
 }
 
+func PortalExchangeRatesHistoryStatusPrefix() []byte {
+	return portalExchangeRatesHistoryStatusPrefix
+}
+
 func PortalCustodianWithdrawStatusPrefix() []byte {
 	return portalCustodianWithdrawStatusPrefix
 }
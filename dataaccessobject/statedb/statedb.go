@@ -747,6 +747,34 @@ func (stateDB *StateDB) getShardsCommitteeInfo(sIDs []int) (curValidatorInfo map
 	return curValidatorInfo
 }
 
+// getShardsCommitteeInfoByCommitteePublicKey works as getShardsCommitteeInfo,
+// staker infos of current validators are keyed by their base58 committee public keys
+func (stateDB *StateDB) getShardsCommitteeInfoByCommitteePublicKey(sIDs []int) (map[int]map[string]*StakerInfo, error) {
+	curValidatorInfo := make(map[int]map[string]*StakerInfo)
+	for _, shardID := range sIDs {
+		prefixCurrentValidator := GetCommitteePrefixWithRole(CurrentValidator, shardID)
+		resCurrentValidator := stateDB.iterateWithCommitteeState(prefixCurrentValidator)
+		tempStakerInfos := make(map[string]*StakerInfo)
+		for _, c := range resCurrentValidator {
+			committeePublicKey, err := c.committeePublicKey.ToBase58()
+			if err != nil {
+				return nil, err
+			}
+			cPKBytes, _ := c.committeePublicKey.RawBytes()
+			s, has, err := stateDB.getStakerInfo(GetStakerInfoKey(cPKBytes))
+			if err != nil {
+				return nil, err
+			}
+			if !has || s == nil {
+				return nil, errors.Errorf("Can not found staker info for this committee %v", c.committeePublicKey)
+			}
+			tempStakerInfos[committeePublicKey] = s
+		}
+		curValidatorInfo[shardID] = tempStakerInfos
+	}
+	return curValidatorInfo, nil
+}
+
 func (beaconConsensusStateDB *StateDB) GetAllStakingTX(ids []int) (map[string]string, error) {
 	allStaker := []*CommitteeState{}
 	mapStakingTx := map[string]string{}
@@ -1305,6 +1333,24 @@ func (stateDB *StateDB) getAllPDETradingFeeState() []*PDETradingFeeState {
 	return pdeTradingFeeStates
 }
 
+func (stateDB *StateDB) getAllPDELimitOrderState() []*PDELimitOrderState {
+	pdeLimitOrderStates := []*PDELimitOrderState{}
+	temp := stateDB.trie.NodeIterator(GetPDELimitOrderPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		lo := NewPDELimitOrderState()
+		err := json.Unmarshal(newValue, lo)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdeLimitOrderStates = append(pdeLimitOrderStates, lo)
+	}
+	return pdeLimitOrderStates
+}
+
 func (stateDB *StateDB) getAllPDEStatus() []*PDEStatusState {
 	pdeStatusStates := []*PDEStatusState{}
 	temp := stateDB.trie.NodeIterator(GetPDEStatusPrefix())
@@ -1323,6 +1369,17 @@ func (stateDB *StateDB) getAllPDEStatus() []*PDEStatusState {
 	return pdeStatusStates
 }
 
+func (stateDB *StateDB) getPDEPriceCumulativeByKey(key common.Hash) (*PDEPriceCumulativeState, bool, error) {
+	priceCumulativeState, err := stateDB.getStateObject(PDEPriceCumulativeObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if priceCumulativeState != nil {
+		return priceCumulativeState.GetValue().(*PDEPriceCumulativeState), true, nil
+	}
+	return NewPDEPriceCumulativeState(), false, nil
+}
+
 func (stateDB *StateDB) getPDEStatusByKey(key common.Hash) (*PDEStatusState, bool, error) {
 	pdeStatusState, err := stateDB.getStateObject(PDEStatusObjectType, key)
 	if err != nil {
@@ -1624,3 +1681,153 @@ func (stateDB *StateDB) getAllFeatureRewards(epoch uint64) (*RewardFeatureState,
 	}
 	return result, true, nil
 }
+
+// ================================= Delegation OBJECT =======================================
+func (stateDB *StateDB) getDelegationByKey(key common.Hash) (*DelegationState, bool, error) {
+	delegationState, err := stateDB.getStateObject(DelegationObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if delegationState != nil {
+		return delegationState.GetValue().(*DelegationState), true, nil
+	}
+	return NewDelegationState(), false, nil
+}
+
+func (stateDB *StateDB) getAllDelegationState() []*DelegationState {
+	delegationStates := []*DelegationState{}
+	temp := stateDB.trie.NodeIterator(GetDelegationPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		ds := NewDelegationState()
+		err := json.Unmarshal(newValue, ds)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		delegationStates = append(delegationStates, ds)
+	}
+	return delegationStates
+}
+
+func (stateDB *StateDB) getValidatorCommissionByKey(key common.Hash) (*ValidatorCommissionState, bool, error) {
+	validatorCommissionState, err := stateDB.getStateObject(ValidatorCommissionObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if validatorCommissionState != nil {
+		return validatorCommissionState.GetValue().(*ValidatorCommissionState), true, nil
+	}
+	return NewValidatorCommissionState(), false, nil
+}
+
+// getAllValidatorsWithDelegations iterates the index of delegations, which has one entry per validator
+func (stateDB *StateDB) getAllValidatorsWithDelegations() []string {
+	committeePublicKeys := []string{}
+	temp := stateDB.trie.NodeIterator(GetValidatorDelegationsPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		vd := NewValidatorDelegationsState()
+		err := json.Unmarshal(newValue, vd)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		committeePublicKeys = append(committeePublicKeys, vd.committeePublicKey)
+	}
+	return committeePublicKeys
+}
+
+func (stateDB *StateDB) getValidatorDelegationsByKey(key common.Hash) (*ValidatorDelegationsState, bool, error) {
+	validatorDelegationsState, err := stateDB.getStateObject(ValidatorDelegationsObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if validatorDelegationsState != nil {
+		return validatorDelegationsState.GetValue().(*ValidatorDelegationsState), true, nil
+	}
+	return NewValidatorDelegationsState(), false, nil
+}
+
+// getDelegationsByCommitteePublicKey reads delegations of a validator from the index of its delegations
+func (stateDB *StateDB) getDelegationsByCommitteePublicKey(committeePublicKey string) ([]*DelegationState, error) {
+	validatorDelegations, _, err := stateDB.getValidatorDelegationsByKey(GenerateValidatorDelegationsObjectKey(committeePublicKey))
+	if err != nil {
+		return nil, err
+	}
+	delegations := []*DelegationState{}
+	for _, txDelegationID := range validatorDelegations.txDelegationIDs {
+		delegation, has, err := stateDB.getDelegationByKey(GenerateDelegationObjectKey(txDelegationID))
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, fmt.Errorf("delegation %+v of validator %+v not found", txDelegationID, committeePublicKey)
+		}
+		delegations = append(delegations, delegation)
+	}
+	return delegations, nil
+}
+
+// getShardsCommitteeDelegationInfo lists delegation info of current validators keyed by their base58 committee public keys,
+// with the commission rates applied in epoch
+func (stateDB *StateDB) getShardsCommitteeDelegationInfo(sIDs []int, epoch uint64) (map[int]map[string]*CommitteeDelegationInfo, error) {
+	curValidatorDelegationInfo := make(map[int]map[string]*CommitteeDelegationInfo)
+	for _, shardID := range sIDs {
+		prefixCurrentValidator := GetCommitteePrefixWithRole(CurrentValidator, shardID)
+		resCurrentValidator := stateDB.iterateWithCommitteeState(prefixCurrentValidator)
+		tempDelegationInfos := make(map[string]*CommitteeDelegationInfo)
+		for _, c := range resCurrentValidator {
+			committeePublicKey, err := c.committeePublicKey.ToBase58()
+			if err != nil {
+				return nil, err
+			}
+			commission, _, err := stateDB.getValidatorCommissionByKey(GenerateValidatorCommissionObjectKey(committeePublicKey))
+			if err != nil {
+				return nil, err
+			}
+			delegations, err := stateDB.getDelegationsByCommitteePublicKey(committeePublicKey)
+			if err != nil {
+				return nil, err
+			}
+			// a slash only applies to the staking tx it was taken from
+			slashedAmount := uint64(0)
+			cPKBytes, _ := c.committeePublicKey.RawBytes()
+			stakerInfo, hasStakerInfo, err := stateDB.getStakerInfo(GetStakerInfoKey(cPKBytes))
+			if err != nil {
+				return nil, err
+			}
+			slashedStake, hasSlashedStake, err := stateDB.getSlashedStakeByKey(GenerateSlashedStakeObjectKey(committeePublicKey))
+			if err != nil {
+				return nil, err
+			}
+			if hasStakerInfo && hasSlashedStake && slashedStake.TxStakingID() == stakerInfo.TxStakingID() {
+				slashedAmount = slashedStake.SlashedAmount()
+			}
+			tempDelegationInfos[committeePublicKey] = &CommitteeDelegationInfo{
+				CommitteePublicKey: committeePublicKey,
+				CommissionRate:     commission.CommissionRateAt(epoch),
+				Delegations:        delegations,
+				SlashedAmount:      slashedAmount,
+			}
+		}
+		curValidatorDelegationInfo[shardID] = tempDelegationInfos
+	}
+	return curValidatorDelegationInfo, nil
+}
+
+// ================================= Slashed Stake OBJECT =======================================
+func (stateDB *StateDB) getSlashedStakeByKey(key common.Hash) (*SlashedStakeState, bool, error) {
+	slashedStakeState, err := stateDB.getStateObject(SlashedStakeObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if slashedStakeState != nil {
+		return slashedStakeState.GetValue().(*SlashedStakeState), true, nil
+	}
+	return NewSlashedStakeState(), false, nil
+}
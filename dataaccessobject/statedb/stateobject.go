@@ -53,6 +53,18 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newPDEShareObjectWithValue(db, hash, value)
 	case PDETradingFeeObjectType:
 		return newPDETradingFeeObjectWithValue(db, hash, value)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObjectWithValue(db, hash, value)
+	case PDEPriceCumulativeObjectType:
+		return newPDEPriceCumulativeObjectWithValue(db, hash, value)
+	case DelegationObjectType:
+		return newDelegationObjectWithValue(db, hash, value)
+	case ValidatorCommissionObjectType:
+		return newValidatorCommissionObjectWithValue(db, hash, value)
+	case SlashedStakeObjectType:
+		return newSlashedStakeObjectWithValue(db, hash, value)
+	case ValidatorDelegationsObjectType:
+		return newValidatorDelegationsObjectWithValue(db, hash, value)
 	case PDEStatusObjectType:
 		return newPDEStatusObjectWithValue(db, hash, value)
 	case BridgeEthTxObjectType:
@@ -120,6 +132,18 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newPDEShareObject(db, hash)
 	case PDETradingFeeObjectType:
 		return newPDETradingFeeObject(db, hash)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObject(db, hash)
+	case PDEPriceCumulativeObjectType:
+		return newPDEPriceCumulativeObject(db, hash)
+	case DelegationObjectType:
+		return newDelegationObject(db, hash)
+	case ValidatorCommissionObjectType:
+		return newValidatorCommissionObject(db, hash)
+	case SlashedStakeObjectType:
+		return newSlashedStakeObject(db, hash)
+	case ValidatorDelegationsObjectType:
+		return newValidatorDelegationsObject(db, hash)
 	case PDEStatusObjectType:
 		return newPDEStatusObject(db, hash)
 	case BridgeEthTxObjectType:
@@ -0,0 +1,266 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// DelegationState is an amount of PRV locked by a delegator to a validator (committee public key),
+// unbonding is set when the delegator requests to undelegate and unlocked is set when the validator
+// is swapped out while the delegation is unbonding or the validator stopped auto staking,
+// an unlocked delegation is returned to the delegator by the next beacon block
+type DelegationState struct {
+	committeePublicKey string
+	delegatorAddress   string
+	txDelegationID     common.Hash
+	amount             uint64
+	unbonding          bool
+	unlocked           bool
+}
+
+func (ds DelegationState) CommitteePublicKey() string {
+	return ds.committeePublicKey
+}
+
+func (ds *DelegationState) SetCommitteePublicKey(committeePublicKey string) {
+	ds.committeePublicKey = committeePublicKey
+}
+
+func (ds DelegationState) DelegatorAddress() string {
+	return ds.delegatorAddress
+}
+
+func (ds *DelegationState) SetDelegatorAddress(delegatorAddress string) {
+	ds.delegatorAddress = delegatorAddress
+}
+
+func (ds DelegationState) TxDelegationID() common.Hash {
+	return ds.txDelegationID
+}
+
+func (ds *DelegationState) SetTxDelegationID(txDelegationID common.Hash) {
+	ds.txDelegationID = txDelegationID
+}
+
+func (ds DelegationState) Amount() uint64 {
+	return ds.amount
+}
+
+func (ds *DelegationState) SetAmount(amount uint64) {
+	ds.amount = amount
+}
+
+func (ds DelegationState) Unbonding() bool {
+	return ds.unbonding
+}
+
+func (ds *DelegationState) SetUnbonding(unbonding bool) {
+	ds.unbonding = unbonding
+}
+
+func (ds DelegationState) Unlocked() bool {
+	return ds.unlocked
+}
+
+func (ds *DelegationState) SetUnlocked(unlocked bool) {
+	ds.unlocked = unlocked
+}
+
+func (ds DelegationState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		DelegatorAddress   string
+		TxDelegationID     common.Hash
+		Amount             uint64
+		Unbonding          bool
+		Unlocked           bool
+	}{
+		CommitteePublicKey: ds.committeePublicKey,
+		DelegatorAddress:   ds.delegatorAddress,
+		TxDelegationID:     ds.txDelegationID,
+		Amount:             ds.amount,
+		Unbonding:          ds.unbonding,
+		Unlocked:           ds.unlocked,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (ds *DelegationState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		DelegatorAddress   string
+		TxDelegationID     common.Hash
+		Amount             uint64
+		Unbonding          bool
+		Unlocked           bool
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	ds.committeePublicKey = temp.CommitteePublicKey
+	ds.delegatorAddress = temp.DelegatorAddress
+	ds.txDelegationID = temp.TxDelegationID
+	ds.amount = temp.Amount
+	ds.unbonding = temp.Unbonding
+	ds.unlocked = temp.Unlocked
+	return nil
+}
+
+func NewDelegationState() *DelegationState {
+	return &DelegationState{}
+}
+
+func NewDelegationStateWithValue(
+	committeePublicKey string,
+	delegatorAddress string,
+	txDelegationID common.Hash,
+	amount uint64,
+	unbonding bool,
+	unlocked bool,
+) *DelegationState {
+	return &DelegationState{
+		committeePublicKey: committeePublicKey,
+		delegatorAddress:   delegatorAddress,
+		txDelegationID:     txDelegationID,
+		amount:             amount,
+		unbonding:          unbonding,
+		unlocked:           unlocked,
+	}
+}
+
+type DelegationObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version         int
+	delegationHash  common.Hash
+	delegationState *DelegationState
+	objectType      int
+	deleted         bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newDelegationObject(db *StateDB, hash common.Hash) *DelegationObject {
+	return &DelegationObject{
+		version:         defaultVersion,
+		db:              db,
+		delegationHash:  hash,
+		delegationState: NewDelegationState(),
+		objectType:      DelegationObjectType,
+		deleted:         false,
+	}
+}
+
+func newDelegationObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*DelegationObject, error) {
+	var newDelegationState = NewDelegationState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newDelegationState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newDelegationState, ok = data.(*DelegationState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationStateType, reflect.TypeOf(data))
+		}
+	}
+	return &DelegationObject{
+		version:         defaultVersion,
+		delegationHash:  key,
+		delegationState: newDelegationState,
+		db:              db,
+		objectType:      DelegationObjectType,
+		deleted:         false,
+	}, nil
+}
+
+func GenerateDelegationObjectKey(txDelegationID common.Hash) common.Hash {
+	prefixHash := GetDelegationPrefix()
+	valueHash := common.HashH(txDelegationID[:])
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t DelegationObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *DelegationObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t DelegationObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *DelegationObject) SetValue(data interface{}) error {
+	newDelegationState, ok := data.(*DelegationState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationStateType, reflect.TypeOf(data))
+	}
+	t.delegationState = newDelegationState
+	return nil
+}
+
+func (t DelegationObject) GetValue() interface{} {
+	return t.delegationState
+}
+
+func (t DelegationObject) GetValueBytes() []byte {
+	delegationState, ok := t.GetValue().(*DelegationState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(delegationState)
+	if err != nil {
+		panic("failed to marshal delegation state")
+	}
+	return value
+}
+
+func (t DelegationObject) GetHash() common.Hash {
+	return t.delegationHash
+}
+
+func (t DelegationObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *DelegationObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *DelegationObject) Reset() bool {
+	t.delegationState = NewDelegationState()
+	return true
+}
+
+func (t DelegationObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t DelegationObject) IsEmpty() bool {
+	temp := NewDelegationState()
+	return reflect.DeepEqual(temp, t.delegationState) || t.delegationState == nil
+}
@@ -0,0 +1,301 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PDEPriceCumulativeState keeps price accumulators of a pool pair (token1ID < token2ID),
+// token1PriceCumulative is the sum over beacon blocks of token1 price in token2 (scaled), and vice versa,
+// token1LastPrice and token2LastPrice are the (scaled) prices of the pool at the last update, carried forward while the pool is empty
+type PDEPriceCumulativeState struct {
+	token1ID                string
+	token2ID                string
+	token1PriceCumulative   *big.Int
+	token2PriceCumulative   *big.Int
+	token1LastPrice         *big.Int
+	token2LastPrice         *big.Int
+	lastUpdatedBeaconHeight uint64
+}
+
+func (pc PDEPriceCumulativeState) Token1ID() string {
+	return pc.token1ID
+}
+
+func (pc *PDEPriceCumulativeState) SetToken1ID(token1ID string) {
+	pc.token1ID = token1ID
+}
+
+func (pc PDEPriceCumulativeState) Token2ID() string {
+	return pc.token2ID
+}
+
+func (pc *PDEPriceCumulativeState) SetToken2ID(token2ID string) {
+	pc.token2ID = token2ID
+}
+
+func (pc PDEPriceCumulativeState) Token1PriceCumulative() *big.Int {
+	return new(big.Int).Set(pc.token1PriceCumulative)
+}
+
+func (pc *PDEPriceCumulativeState) SetToken1PriceCumulative(token1PriceCumulative *big.Int) {
+	pc.token1PriceCumulative = new(big.Int).Set(token1PriceCumulative)
+}
+
+func (pc PDEPriceCumulativeState) Token2PriceCumulative() *big.Int {
+	return new(big.Int).Set(pc.token2PriceCumulative)
+}
+
+func (pc *PDEPriceCumulativeState) SetToken2PriceCumulative(token2PriceCumulative *big.Int) {
+	pc.token2PriceCumulative = new(big.Int).Set(token2PriceCumulative)
+}
+
+func (pc PDEPriceCumulativeState) Token1LastPrice() *big.Int {
+	return new(big.Int).Set(pc.token1LastPrice)
+}
+
+func (pc *PDEPriceCumulativeState) SetToken1LastPrice(token1LastPrice *big.Int) {
+	pc.token1LastPrice = new(big.Int).Set(token1LastPrice)
+}
+
+func (pc PDEPriceCumulativeState) Token2LastPrice() *big.Int {
+	return new(big.Int).Set(pc.token2LastPrice)
+}
+
+func (pc *PDEPriceCumulativeState) SetToken2LastPrice(token2LastPrice *big.Int) {
+	pc.token2LastPrice = new(big.Int).Set(token2LastPrice)
+}
+
+func (pc PDEPriceCumulativeState) LastUpdatedBeaconHeight() uint64 {
+	return pc.lastUpdatedBeaconHeight
+}
+
+func (pc *PDEPriceCumulativeState) SetLastUpdatedBeaconHeight(lastUpdatedBeaconHeight uint64) {
+	pc.lastUpdatedBeaconHeight = lastUpdatedBeaconHeight
+}
+
+func (pc PDEPriceCumulativeState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Token1ID                string
+		Token2ID                string
+		Token1PriceCumulative   *big.Int
+		Token2PriceCumulative   *big.Int
+		Token1LastPrice         *big.Int
+		Token2LastPrice         *big.Int
+		LastUpdatedBeaconHeight uint64
+	}{
+		Token1ID:                pc.token1ID,
+		Token2ID:                pc.token2ID,
+		Token1PriceCumulative:   pc.token1PriceCumulative,
+		Token2PriceCumulative:   pc.token2PriceCumulative,
+		Token1LastPrice:         pc.token1LastPrice,
+		Token2LastPrice:         pc.token2LastPrice,
+		LastUpdatedBeaconHeight: pc.lastUpdatedBeaconHeight,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (pc *PDEPriceCumulativeState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Token1ID                string
+		Token2ID                string
+		Token1PriceCumulative   *big.Int
+		Token2PriceCumulative   *big.Int
+		Token1LastPrice         *big.Int
+		Token2LastPrice         *big.Int
+		LastUpdatedBeaconHeight uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	pc.token1ID = temp.Token1ID
+	pc.token2ID = temp.Token2ID
+	pc.token1PriceCumulative = big.NewInt(0)
+	if temp.Token1PriceCumulative != nil {
+		pc.token1PriceCumulative = temp.Token1PriceCumulative
+	}
+	pc.token2PriceCumulative = big.NewInt(0)
+	if temp.Token2PriceCumulative != nil {
+		pc.token2PriceCumulative = temp.Token2PriceCumulative
+	}
+	pc.token1LastPrice = big.NewInt(0)
+	if temp.Token1LastPrice != nil {
+		pc.token1LastPrice = temp.Token1LastPrice
+	}
+	pc.token2LastPrice = big.NewInt(0)
+	if temp.Token2LastPrice != nil {
+		pc.token2LastPrice = temp.Token2LastPrice
+	}
+	pc.lastUpdatedBeaconHeight = temp.LastUpdatedBeaconHeight
+	return nil
+}
+
+func NewPDEPriceCumulativeState() *PDEPriceCumulativeState {
+	return &PDEPriceCumulativeState{
+		token1PriceCumulative: big.NewInt(0),
+		token2PriceCumulative: big.NewInt(0),
+		token1LastPrice:       big.NewInt(0),
+		token2LastPrice:       big.NewInt(0),
+	}
+}
+
+func NewPDEPriceCumulativeStateWithValue(
+	token1ID string,
+	token2ID string,
+	token1PriceCumulative *big.Int,
+	token2PriceCumulative *big.Int,
+	token1LastPrice *big.Int,
+	token2LastPrice *big.Int,
+	lastUpdatedBeaconHeight uint64,
+) *PDEPriceCumulativeState {
+	return &PDEPriceCumulativeState{
+		token1ID:                token1ID,
+		token2ID:                token2ID,
+		token1PriceCumulative:   new(big.Int).Set(token1PriceCumulative),
+		token2PriceCumulative:   new(big.Int).Set(token2PriceCumulative),
+		token1LastPrice:         new(big.Int).Set(token1LastPrice),
+		token2LastPrice:         new(big.Int).Set(token2LastPrice),
+		lastUpdatedBeaconHeight: lastUpdatedBeaconHeight,
+	}
+}
+
+type PDEPriceCumulativeObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                 int
+	pdePriceCumulativeHash  common.Hash
+	pdePriceCumulativeState *PDEPriceCumulativeState
+	objectType              int
+	deleted                 bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPDEPriceCumulativeObject(db *StateDB, hash common.Hash) *PDEPriceCumulativeObject {
+	return &PDEPriceCumulativeObject{
+		version:                 defaultVersion,
+		db:                      db,
+		pdePriceCumulativeHash:  hash,
+		pdePriceCumulativeState: NewPDEPriceCumulativeState(),
+		objectType:              PDEPriceCumulativeObjectType,
+		deleted:                 false,
+	}
+}
+
+func newPDEPriceCumulativeObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PDEPriceCumulativeObject, error) {
+	var newPDEPriceCumulativeState = NewPDEPriceCumulativeState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPDEPriceCumulativeState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPDEPriceCumulativeState, ok = data.(*PDEPriceCumulativeState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPDEPriceCumulativeStateType, reflect.TypeOf(data))
+		}
+	}
+	return &PDEPriceCumulativeObject{
+		version:                 defaultVersion,
+		pdePriceCumulativeHash:  key,
+		pdePriceCumulativeState: newPDEPriceCumulativeState,
+		db:                      db,
+		objectType:              PDEPriceCumulativeObjectType,
+		deleted:                 false,
+	}, nil
+}
+
+func GeneratePDEPriceCumulativeObjectKey(token1ID, token2ID string) common.Hash {
+	tokenIDs := []string{token1ID, token2ID}
+	sort.Strings(tokenIDs)
+	prefixHash := GetPDEPriceCumulativePrefix()
+	valueHash := common.HashH([]byte(tokenIDs[0] + tokenIDs[1]))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t PDEPriceCumulativeObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PDEPriceCumulativeObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PDEPriceCumulativeObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PDEPriceCumulativeObject) SetValue(data interface{}) error {
+	newPDEPriceCumulativeState, ok := data.(*PDEPriceCumulativeState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPDEPriceCumulativeStateType, reflect.TypeOf(data))
+	}
+	t.pdePriceCumulativeState = newPDEPriceCumulativeState
+	return nil
+}
+
+func (t PDEPriceCumulativeObject) GetValue() interface{} {
+	return t.pdePriceCumulativeState
+}
+
+func (t PDEPriceCumulativeObject) GetValueBytes() []byte {
+	pdePriceCumulativeState, ok := t.GetValue().(*PDEPriceCumulativeState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(pdePriceCumulativeState)
+	if err != nil {
+		panic("failed to marshal pde price cumulative state")
+	}
+	return value
+}
+
+func (t PDEPriceCumulativeObject) GetHash() common.Hash {
+	return t.pdePriceCumulativeHash
+}
+
+func (t PDEPriceCumulativeObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PDEPriceCumulativeObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *PDEPriceCumulativeObject) Reset() bool {
+	t.pdePriceCumulativeState = NewPDEPriceCumulativeState()
+	return true
+}
+
+func (t PDEPriceCumulativeObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PDEPriceCumulativeObject) IsEmpty() bool {
+	temp := NewPDEPriceCumulativeState()
+	return reflect.DeepEqual(temp, t.pdePriceCumulativeState) || t.pdePriceCumulativeState == nil
+}
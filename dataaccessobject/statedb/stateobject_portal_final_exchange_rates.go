@@ -9,6 +9,15 @@ import (
 
 type FinalExchangeRatesDetail struct {
 	Amount uint64
+	// fields of exchange rates oracle, they are empty when rates are submitted by the only feeder
+	BeaconHeight uint64                `json:",omitempty"` // beacon height at which Amount is updated
+	FeederRates  map[string]FeederRate `json:",omitempty"` // feeder address -> latest rate of feeder
+}
+
+// FeederRate is a rate submitted by a feeder at BeaconHeight
+type FeederRate struct {
+	Rate         uint64
+	BeaconHeight uint64
 }
 
 type FinalExchangeRatesState struct {
@@ -0,0 +1,234 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// SlashedStakeState is the amount slashed from the stake (TxStakingID) of a validator which signed
+// two conflicting blocks, proven by the evidence tx TxEvidenceID
+type SlashedStakeState struct {
+	committeePublicKey string
+	txStakingID        common.Hash
+	txEvidenceID       common.Hash
+	slashedAmount      uint64
+}
+
+func (ss SlashedStakeState) CommitteePublicKey() string {
+	return ss.committeePublicKey
+}
+
+func (ss *SlashedStakeState) SetCommitteePublicKey(committeePublicKey string) {
+	ss.committeePublicKey = committeePublicKey
+}
+
+func (ss SlashedStakeState) TxStakingID() common.Hash {
+	return ss.txStakingID
+}
+
+func (ss *SlashedStakeState) SetTxStakingID(txStakingID common.Hash) {
+	ss.txStakingID = txStakingID
+}
+
+func (ss SlashedStakeState) TxEvidenceID() common.Hash {
+	return ss.txEvidenceID
+}
+
+func (ss *SlashedStakeState) SetTxEvidenceID(txEvidenceID common.Hash) {
+	ss.txEvidenceID = txEvidenceID
+}
+
+func (ss SlashedStakeState) SlashedAmount() uint64 {
+	return ss.slashedAmount
+}
+
+func (ss *SlashedStakeState) SetSlashedAmount(slashedAmount uint64) {
+	ss.slashedAmount = slashedAmount
+}
+
+func (ss SlashedStakeState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		TxStakingID        common.Hash
+		TxEvidenceID       common.Hash
+		SlashedAmount      uint64
+	}{
+		CommitteePublicKey: ss.committeePublicKey,
+		TxStakingID:        ss.txStakingID,
+		TxEvidenceID:       ss.txEvidenceID,
+		SlashedAmount:      ss.slashedAmount,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (ss *SlashedStakeState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		TxStakingID        common.Hash
+		TxEvidenceID       common.Hash
+		SlashedAmount      uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	ss.committeePublicKey = temp.CommitteePublicKey
+	ss.txStakingID = temp.TxStakingID
+	ss.txEvidenceID = temp.TxEvidenceID
+	ss.slashedAmount = temp.SlashedAmount
+	return nil
+}
+
+func NewSlashedStakeState() *SlashedStakeState {
+	return &SlashedStakeState{}
+}
+
+func NewSlashedStakeStateWithValue(
+	committeePublicKey string,
+	txStakingID common.Hash,
+	txEvidenceID common.Hash,
+	slashedAmount uint64,
+) *SlashedStakeState {
+	return &SlashedStakeState{
+		committeePublicKey: committeePublicKey,
+		txStakingID:        txStakingID,
+		txEvidenceID:       txEvidenceID,
+		slashedAmount:      slashedAmount,
+	}
+}
+
+type SlashedStakeObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version           int
+	slashedStakeHash  common.Hash
+	slashedStakeState *SlashedStakeState
+	objectType        int
+	deleted           bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newSlashedStakeObject(db *StateDB, hash common.Hash) *SlashedStakeObject {
+	return &SlashedStakeObject{
+		version:           defaultVersion,
+		db:                db,
+		slashedStakeHash:  hash,
+		slashedStakeState: NewSlashedStakeState(),
+		objectType:        SlashedStakeObjectType,
+		deleted:           false,
+	}
+}
+
+func newSlashedStakeObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*SlashedStakeObject, error) {
+	var newSlashedStakeState = NewSlashedStakeState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newSlashedStakeState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newSlashedStakeState, ok = data.(*SlashedStakeState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidSlashedStakeStateType, reflect.TypeOf(data))
+		}
+	}
+	return &SlashedStakeObject{
+		version:           defaultVersion,
+		slashedStakeHash:  key,
+		slashedStakeState: newSlashedStakeState,
+		db:                db,
+		objectType:        SlashedStakeObjectType,
+		deleted:           false,
+	}, nil
+}
+
+func GenerateSlashedStakeObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetSlashedStakePrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t SlashedStakeObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *SlashedStakeObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t SlashedStakeObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *SlashedStakeObject) SetValue(data interface{}) error {
+	newSlashedStakeState, ok := data.(*SlashedStakeState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidSlashedStakeStateType, reflect.TypeOf(data))
+	}
+	t.slashedStakeState = newSlashedStakeState
+	return nil
+}
+
+func (t SlashedStakeObject) GetValue() interface{} {
+	return t.slashedStakeState
+}
+
+func (t SlashedStakeObject) GetValueBytes() []byte {
+	slashedStakeState, ok := t.GetValue().(*SlashedStakeState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(slashedStakeState)
+	if err != nil {
+		panic("failed to marshal slashed stake state")
+	}
+	return value
+}
+
+func (t SlashedStakeObject) GetHash() common.Hash {
+	return t.slashedStakeHash
+}
+
+func (t SlashedStakeObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *SlashedStakeObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *SlashedStakeObject) Reset() bool {
+	t.slashedStakeState = NewSlashedStakeState()
+	return true
+}
+
+func (t SlashedStakeObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t SlashedStakeObject) IsEmpty() bool {
+	temp := NewSlashedStakeState()
+	return reflect.DeepEqual(temp, t.slashedStakeState) || t.slashedStakeState == nil
+}
@@ -0,0 +1,243 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// ValidatorCommissionState keeps the commission rate (in basis points) a validator takes
+// on the reward earned by the PRV delegated to it, a new rate is pending until pendingFromEpoch (0 if there is none)
+type ValidatorCommissionState struct {
+	committeePublicKey    string
+	commissionRate        uint64
+	pendingCommissionRate uint64
+	pendingFromEpoch      uint64
+}
+
+func (vc ValidatorCommissionState) CommitteePublicKey() string {
+	return vc.committeePublicKey
+}
+
+func (vc *ValidatorCommissionState) SetCommitteePublicKey(committeePublicKey string) {
+	vc.committeePublicKey = committeePublicKey
+}
+
+func (vc ValidatorCommissionState) CommissionRate() uint64 {
+	return vc.commissionRate
+}
+
+func (vc *ValidatorCommissionState) SetCommissionRate(commissionRate uint64) {
+	vc.commissionRate = commissionRate
+}
+
+func (vc ValidatorCommissionState) PendingCommissionRate() uint64 {
+	return vc.pendingCommissionRate
+}
+
+func (vc *ValidatorCommissionState) SetPendingCommissionRate(pendingCommissionRate uint64) {
+	vc.pendingCommissionRate = pendingCommissionRate
+}
+
+func (vc ValidatorCommissionState) PendingFromEpoch() uint64 {
+	return vc.pendingFromEpoch
+}
+
+func (vc *ValidatorCommissionState) SetPendingFromEpoch(pendingFromEpoch uint64) {
+	vc.pendingFromEpoch = pendingFromEpoch
+}
+
+// CommissionRateAt returns the commission rate applied in an epoch
+func (vc ValidatorCommissionState) CommissionRateAt(epoch uint64) uint64 {
+	if vc.pendingFromEpoch != 0 && epoch >= vc.pendingFromEpoch {
+		return vc.pendingCommissionRate
+	}
+	return vc.commissionRate
+}
+
+// SetCommissionRateFrom sets a new commission rate applied from fromEpoch,
+// the rate applied before fromEpoch becomes the current rate and replaces any earlier pending rate
+func (vc *ValidatorCommissionState) SetCommissionRateFrom(commissionRate uint64, fromEpoch uint64) {
+	vc.commissionRate = vc.CommissionRateAt(fromEpoch - 1)
+	vc.pendingCommissionRate = commissionRate
+	vc.pendingFromEpoch = fromEpoch
+}
+
+func (vc ValidatorCommissionState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey    string
+		CommissionRate        uint64
+		PendingCommissionRate uint64
+		PendingFromEpoch      uint64
+	}{
+		CommitteePublicKey:    vc.committeePublicKey,
+		CommissionRate:        vc.commissionRate,
+		PendingCommissionRate: vc.pendingCommissionRate,
+		PendingFromEpoch:      vc.pendingFromEpoch,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (vc *ValidatorCommissionState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey    string
+		CommissionRate        uint64
+		PendingCommissionRate uint64
+		PendingFromEpoch      uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	vc.committeePublicKey = temp.CommitteePublicKey
+	vc.commissionRate = temp.CommissionRate
+	vc.pendingCommissionRate = temp.PendingCommissionRate
+	vc.pendingFromEpoch = temp.PendingFromEpoch
+	return nil
+}
+
+func NewValidatorCommissionState() *ValidatorCommissionState {
+	return &ValidatorCommissionState{}
+}
+
+func NewValidatorCommissionStateWithValue(committeePublicKey string, commissionRate uint64) *ValidatorCommissionState {
+	return &ValidatorCommissionState{
+		committeePublicKey: committeePublicKey,
+		commissionRate:     commissionRate,
+	}
+}
+
+type ValidatorCommissionObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                  int
+	validatorCommissionHash  common.Hash
+	validatorCommissionState *ValidatorCommissionState
+	objectType               int
+	deleted                  bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newValidatorCommissionObject(db *StateDB, hash common.Hash) *ValidatorCommissionObject {
+	return &ValidatorCommissionObject{
+		version:                  defaultVersion,
+		db:                       db,
+		validatorCommissionHash:  hash,
+		validatorCommissionState: NewValidatorCommissionState(),
+		objectType:               ValidatorCommissionObjectType,
+		deleted:                  false,
+	}
+}
+
+func newValidatorCommissionObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*ValidatorCommissionObject, error) {
+	var newValidatorCommissionState = NewValidatorCommissionState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newValidatorCommissionState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newValidatorCommissionState, ok = data.(*ValidatorCommissionState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidValidatorCommissionStateType, reflect.TypeOf(data))
+		}
+	}
+	return &ValidatorCommissionObject{
+		version:                  defaultVersion,
+		validatorCommissionHash:  key,
+		validatorCommissionState: newValidatorCommissionState,
+		db:                       db,
+		objectType:               ValidatorCommissionObjectType,
+		deleted:                  false,
+	}, nil
+}
+
+func GenerateValidatorCommissionObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetValidatorCommissionPrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t ValidatorCommissionObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *ValidatorCommissionObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t ValidatorCommissionObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *ValidatorCommissionObject) SetValue(data interface{}) error {
+	newValidatorCommissionState, ok := data.(*ValidatorCommissionState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidValidatorCommissionStateType, reflect.TypeOf(data))
+	}
+	t.validatorCommissionState = newValidatorCommissionState
+	return nil
+}
+
+func (t ValidatorCommissionObject) GetValue() interface{} {
+	return t.validatorCommissionState
+}
+
+func (t ValidatorCommissionObject) GetValueBytes() []byte {
+	validatorCommissionState, ok := t.GetValue().(*ValidatorCommissionState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(validatorCommissionState)
+	if err != nil {
+		panic("failed to marshal validator commission state")
+	}
+	return value
+}
+
+func (t ValidatorCommissionObject) GetHash() common.Hash {
+	return t.validatorCommissionHash
+}
+
+func (t ValidatorCommissionObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *ValidatorCommissionObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *ValidatorCommissionObject) Reset() bool {
+	t.validatorCommissionState = NewValidatorCommissionState()
+	return true
+}
+
+func (t ValidatorCommissionObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t ValidatorCommissionObject) IsEmpty() bool {
+	temp := NewValidatorCommissionState()
+	return reflect.DeepEqual(temp, t.validatorCommissionState) || t.validatorCommissionState == nil
+}
@@ -0,0 +1,222 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// ValidatorDelegationsState indexes delegations by validator: tx ids of the delegations locked to a validator,
+// including unbonding and unlocked ones, so delegations of a validator are read without iterating all delegations
+type ValidatorDelegationsState struct {
+	committeePublicKey string
+	txDelegationIDs    []common.Hash
+}
+
+func (vd ValidatorDelegationsState) CommitteePublicKey() string {
+	return vd.committeePublicKey
+}
+
+func (vd *ValidatorDelegationsState) SetCommitteePublicKey(committeePublicKey string) {
+	vd.committeePublicKey = committeePublicKey
+}
+
+func (vd ValidatorDelegationsState) TxDelegationIDs() []common.Hash {
+	return vd.txDelegationIDs
+}
+
+func (vd *ValidatorDelegationsState) SetTxDelegationIDs(txDelegationIDs []common.Hash) {
+	vd.txDelegationIDs = txDelegationIDs
+}
+
+// AddTxDelegationID adds the tx id of a delegation if it is not indexed yet
+func (vd *ValidatorDelegationsState) AddTxDelegationID(txDelegationID common.Hash) {
+	for _, txID := range vd.txDelegationIDs {
+		if txID.IsEqual(&txDelegationID) {
+			return
+		}
+	}
+	vd.txDelegationIDs = append(vd.txDelegationIDs, txDelegationID)
+}
+
+// RemoveTxDelegationID removes the tx id of a delegation, the order of other tx ids is kept
+func (vd *ValidatorDelegationsState) RemoveTxDelegationID(txDelegationID common.Hash) {
+	txDelegationIDs := []common.Hash{}
+	for _, txID := range vd.txDelegationIDs {
+		if !txID.IsEqual(&txDelegationID) {
+			txDelegationIDs = append(txDelegationIDs, txID)
+		}
+	}
+	vd.txDelegationIDs = txDelegationIDs
+}
+
+func (vd ValidatorDelegationsState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		TxDelegationIDs    []common.Hash
+	}{
+		CommitteePublicKey: vd.committeePublicKey,
+		TxDelegationIDs:    vd.txDelegationIDs,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (vd *ValidatorDelegationsState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		TxDelegationIDs    []common.Hash
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	vd.committeePublicKey = temp.CommitteePublicKey
+	vd.txDelegationIDs = temp.TxDelegationIDs
+	return nil
+}
+
+func NewValidatorDelegationsState() *ValidatorDelegationsState {
+	return &ValidatorDelegationsState{}
+}
+
+func NewValidatorDelegationsStateWithValue(committeePublicKey string, txDelegationIDs []common.Hash) *ValidatorDelegationsState {
+	return &ValidatorDelegationsState{
+		committeePublicKey: committeePublicKey,
+		txDelegationIDs:    txDelegationIDs,
+	}
+}
+
+type ValidatorDelegationsObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                   int
+	validatorDelegationsHash  common.Hash
+	validatorDelegationsState *ValidatorDelegationsState
+	objectType                int
+	deleted                   bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newValidatorDelegationsObject(db *StateDB, hash common.Hash) *ValidatorDelegationsObject {
+	return &ValidatorDelegationsObject{
+		version:                   defaultVersion,
+		db:                        db,
+		validatorDelegationsHash:  hash,
+		validatorDelegationsState: NewValidatorDelegationsState(),
+		objectType:                ValidatorDelegationsObjectType,
+		deleted:                   false,
+	}
+}
+
+func newValidatorDelegationsObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*ValidatorDelegationsObject, error) {
+	var newValidatorDelegationsState = NewValidatorDelegationsState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newValidatorDelegationsState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newValidatorDelegationsState, ok = data.(*ValidatorDelegationsState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidValidatorDelegationsStateType, reflect.TypeOf(data))
+		}
+	}
+	return &ValidatorDelegationsObject{
+		version:                   defaultVersion,
+		validatorDelegationsHash:  key,
+		validatorDelegationsState: newValidatorDelegationsState,
+		db:                        db,
+		objectType:                ValidatorDelegationsObjectType,
+		deleted:                   false,
+	}, nil
+}
+
+func GenerateValidatorDelegationsObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetValidatorDelegationsPrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t ValidatorDelegationsObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *ValidatorDelegationsObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t ValidatorDelegationsObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *ValidatorDelegationsObject) SetValue(data interface{}) error {
+	newValidatorDelegationsState, ok := data.(*ValidatorDelegationsState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidValidatorDelegationsStateType, reflect.TypeOf(data))
+	}
+	t.validatorDelegationsState = newValidatorDelegationsState
+	return nil
+}
+
+func (t ValidatorDelegationsObject) GetValue() interface{} {
+	return t.validatorDelegationsState
+}
+
+func (t ValidatorDelegationsObject) GetValueBytes() []byte {
+	validatorDelegationsState, ok := t.GetValue().(*ValidatorDelegationsState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(validatorDelegationsState)
+	if err != nil {
+		panic("failed to marshal validator delegations state")
+	}
+	return value
+}
+
+func (t ValidatorDelegationsObject) GetHash() common.Hash {
+	return t.validatorDelegationsHash
+}
+
+func (t ValidatorDelegationsObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *ValidatorDelegationsObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all shard committee value into default value
+func (t *ValidatorDelegationsObject) Reset() bool {
+	t.validatorDelegationsState = NewValidatorDelegationsState()
+	return true
+}
+
+func (t ValidatorDelegationsObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t ValidatorDelegationsObject) IsEmpty() bool {
+	temp := NewValidatorDelegationsState()
+	return reflect.DeepEqual(temp, t.validatorDelegationsState) || t.validatorDelegationsState == nil
+}
@@ -285,6 +285,7 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 	txDescs := []*metadata.TxDesc{}
 	txHashes := []common.Hash{}
 	batch := transaction.NewBatchTransaction(txs)
+	batch.SetStateRoots(shardView.TransactionStateDBRootHash, beaconView.FeatureStateDBRootHash)
 	ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB())
 	if err != nil {
 		return nil, nil, err
@@ -516,11 +517,16 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 		}
 	}
 	// Condition 6: ValidateTransaction tx by it self
-	if !isBatch {
+	// tx which was verified against the same state roots is not verified again
+	if !isBatch && !transaction.DefaultTxVerifier.IsVerified(tx, shardView.TransactionStateDBRootHash, beaconView.FeatureStateDBRootHash) {
 		validated, errValidateTxByItself := tx.ValidateTxByItself(tx.IsPrivacy(), shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), tp.config.BlockChain, shardID, isNewTransaction, nil, nil)
 		if !validated {
 			return NewMempoolTxError(RejectInvalidTx, errValidateTxByItself)
 		}
+		// only new txs are verified with all conditions (e.g. existence of output snd), so they are safe to be skipped later
+		if isNewTransaction {
+			transaction.DefaultTxVerifier.MarkVerified(tx, shardView.TransactionStateDBRootHash, beaconView.FeatureStateDBRootHash)
+		}
 	}
 	// Condition 7: validate tx with data of blockchain
 	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardView, beaconView, shardID, shardView.GetCopiedTransactionStateDB())
@@ -57,6 +57,16 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &WithDrawRewardResponse{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case DelegationMeta:
+		md = &DelegationMetadata{}
+	case UnDelegationMeta:
+		md = &UnDelegationMetadata{}
+	case SetCommissionRateMeta:
+		md = &SetCommissionRateMetadata{}
+	case ReturnDelegationMeta:
+		md = &ReturnDelegationMetadata{}
+	case DoubleSignEvidenceMeta:
+		md = &DoubleSignEvidenceMetadata{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDEPRVRequiredContributionRequestMeta:
@@ -69,6 +79,12 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PDECrossPoolTradeRequest{}
 	case PDECrossPoolTradeResponseMeta:
 		md = &PDECrossPoolTradeResponse{}
+	case PDELimitOrderRequestMeta:
+		md = &PDELimitOrderRequest{}
+	case PDELimitOrderResponseMeta:
+		md = &PDELimitOrderResponse{}
+	case PDECancelLimitOrderRequestMeta:
+		md = &PDECancelLimitOrderRequest{}
 	case PDEWithdrawalRequestMeta:
 		md = &PDEWithdrawalRequest{}
 	case PDEWithdrawalResponseMeta:
@@ -28,6 +28,15 @@ const (
 	StopAutoStakingMeta = 127
 	BeaconStakingMeta   = 64
 
+	// delegation
+	DelegationMeta        = 65
+	UnDelegationMeta      = 66
+	SetCommissionRateMeta = 67
+	ReturnDelegationMeta  = 68
+
+	// slashing
+	DoubleSignEvidenceMeta = 69
+
 	// Incognito -> Ethereum bridge
 	BeaconSwapConfirmMeta = 70
 	BridgeSwapConfirmMeta = 71
@@ -46,6 +55,9 @@ const (
 	PDEFeeWithdrawalRequestMeta           = 207
 	PDEFeeWithdrawalResponseMeta          = 208
 	PDETradingFeesDistributionMeta        = 209
+	PDELimitOrderRequestMeta              = 210
+	PDELimitOrderResponseMeta             = 211
+	PDECancelLimitOrderRequestMeta        = 212
 
 	// portal
 	PortalCustodianDepositMeta                      = 100
@@ -98,9 +110,11 @@ var minerCreatedMetaTypes = []int{
 	IssuingResponseMeta,
 	IssuingETHResponseMeta,
 	ReturnStakingMeta,
+	ReturnDelegationMeta,
 	WithDrawRewardResponseMeta,
 	PDETradeResponseMeta,
 	PDECrossPoolTradeResponseMeta,
+	PDELimitOrderResponseMeta,
 	PDEWithdrawalResponseMeta,
 	PDEFeeWithdrawalResponseMeta,
 	PDEContributionResponseMeta,
@@ -139,6 +153,22 @@ var (
 const (
 	StopAutoStakingAmount = 0
 	ETHConfirmationBlocks = 15
+
+	// MinDelegationAmount is the smallest amount of PRV (in nano) a delegator could lock to a validator
+	MinDelegationAmount = 1e9
+	// MaxCommissionRate is the commission rate in basis points when a validator keeps all reward of delegations
+	MaxCommissionRate = 10000
+	// MaxCommissionRateChangePerEpoch is the max change in basis points of the commission rate of a validator from an epoch to the next one
+	MaxCommissionRateChangePerEpoch = 500
+
+	// MinPDELimitOrderSellAmount is the smallest amount a limit order could sell
+	MinPDELimitOrderSellAmount = 1e6
+	// MaxPDELimitOrderLifetime is the max number of beacon blocks from a limit order request to its expiry
+	MaxPDELimitOrderLifetime = 20000
+
+	// evidence types of double signing
+	DoubleSignEvidenceProposeType = "propose"
+	DoubleSignEvidenceVoteType    = "vote"
 )
 
 var AcceptedWithdrawRewardRequestVersion = []int{0, 1}
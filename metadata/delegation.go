@@ -0,0 +1,389 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// DelegationMetadata - lock DelegationAmount of PRV to a validator (committee public key),
+// the delegated amount earns a part of the validator reward until it is returned to DelegatorPaymentAddress
+type DelegationMetadata struct {
+	MetadataBase
+	DelegatorPaymentAddress string
+	CommitteePublicKey      string
+	DelegationAmount        uint64
+}
+
+type DelegationAction struct {
+	Meta    DelegationMetadata
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// UnDelegationMetadata - request to unbond a delegation, DelegationTxID is the tx id of the delegation request,
+// like stop auto staking the delegated amount is returned once the validator is swapped out of its committee
+type UnDelegationMetadata struct {
+	MetadataBase
+	DelegationTxID          common.Hash
+	DelegatorPaymentAddress string
+}
+
+type UnDelegationAction struct {
+	Meta    UnDelegationMetadata
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// SetCommissionRateMetadata - set the commission rate (in basis points) a validator takes on the reward of its delegations,
+// it must be sent by the sender of the staking tx of the validator. The rate applies from the next epoch
+// and differs by at most MaxCommissionRateChangePerEpoch from the rate of the current epoch
+type SetCommissionRateMetadata struct {
+	MetadataBase
+	CommitteePublicKey string
+	CommissionRate     uint64
+}
+
+type SetCommissionRateAction struct {
+	Meta    SetCommissionRateMetadata
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewDelegationMetadata(
+	delegatorPaymentAddress string,
+	committeePublicKey string,
+	delegationAmount uint64,
+	metaType int,
+) (*DelegationMetadata, error) {
+	if metaType != DelegationMeta {
+		return nil, errors.New("invalid delegation type")
+	}
+	metadataBase := NewMetadataBase(metaType)
+	return &DelegationMetadata{
+		MetadataBase:            *metadataBase,
+		DelegatorPaymentAddress: delegatorPaymentAddress,
+		CommitteePublicKey:      committeePublicKey,
+		DelegationAmount:        delegationAmount,
+	}, nil
+}
+
+func NewUnDelegationMetadata(
+	delegationTxID common.Hash,
+	delegatorPaymentAddress string,
+	metaType int,
+) (*UnDelegationMetadata, error) {
+	if metaType != UnDelegationMeta {
+		return nil, errors.New("invalid undelegation type")
+	}
+	metadataBase := NewMetadataBase(metaType)
+	return &UnDelegationMetadata{
+		MetadataBase:            *metadataBase,
+		DelegationTxID:          delegationTxID,
+		DelegatorPaymentAddress: delegatorPaymentAddress,
+	}, nil
+}
+
+func NewSetCommissionRateMetadata(
+	committeePublicKey string,
+	commissionRate uint64,
+	metaType int,
+) (*SetCommissionRateMetadata, error) {
+	if metaType != SetCommissionRateMeta {
+		return nil, errors.New("invalid set commission rate type")
+	}
+	metadataBase := NewMetadataBase(metaType)
+	return &SetCommissionRateMetadata{
+		MetadataBase:       *metadataBase,
+		CommitteePublicKey: committeePublicKey,
+		CommissionRate:     commissionRate,
+	}, nil
+}
+
+func NewDelegationMetadataFromRPC(data map[string]interface{}) (Metadata, error) {
+	delegatorPaymentAddress, ok := data["DelegatorPaymentAddress"].(string)
+	if !ok {
+		return nil, errors.New("Invalid delegator payment address")
+	}
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, errors.New("Invalid committee public key")
+	}
+	delegationAmount, ok := data["DelegationAmount"].(float64)
+	if !ok {
+		return nil, errors.New("Invalid delegation amount")
+	}
+	return NewDelegationMetadata(delegatorPaymentAddress, committeePublicKey, uint64(delegationAmount), DelegationMeta)
+}
+
+func NewUnDelegationMetadataFromRPC(data map[string]interface{}) (Metadata, error) {
+	delegationTxIDStr, ok := data["DelegationTxID"].(string)
+	if !ok {
+		return nil, errors.New("Invalid delegation tx id")
+	}
+	delegationTxID, err := common.Hash{}.NewHashFromStr(delegationTxIDStr)
+	if err != nil {
+		return nil, err
+	}
+	delegatorPaymentAddress, ok := data["DelegatorPaymentAddress"].(string)
+	if !ok {
+		return nil, errors.New("Invalid delegator payment address")
+	}
+	return NewUnDelegationMetadata(*delegationTxID, delegatorPaymentAddress, UnDelegationMeta)
+}
+
+func NewSetCommissionRateMetadataFromRPC(data map[string]interface{}) (Metadata, error) {
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, errors.New("Invalid committee public key")
+	}
+	commissionRate, ok := data["CommissionRate"].(float64)
+	if !ok || commissionRate < 0 {
+		return nil, errors.New("Invalid commission rate")
+	}
+	return NewSetCommissionRateMetadata(committeePublicKey, uint64(commissionRate), SetCommissionRateMeta)
+}
+
+func isValidCommitteePublicKeyStr(committeePublicKeyStr string) bool {
+	if !incognitokey.IsInBase58ShortFormat([]string{committeePublicKeyStr}) {
+		return false
+	}
+	committeePublicKey := new(incognitokey.CommitteePublicKey)
+	if err := committeePublicKey.FromString(committeePublicKeyStr); err != nil {
+		return false
+	}
+	return committeePublicKey.CheckSanityData()
+}
+
+// validateDelegationTxSanity checks the tx is a no privacy tx of amount to the burning address, sent by the owner of paymentAddressStr
+func validateDelegationTxSanity(chainRetriever ChainRetriever, beaconHeight uint64, tx Transaction, paymentAddressStr string, expectedAmount uint64) error {
+	if beaconHeight < chainRetriever.GetBeaconHeightBreakPointDelegation() {
+		return fmt.Errorf("delegation txs are not accepted before beacon height %+v", chainRetriever.GetBeaconHeightBreakPointDelegation())
+	}
+	if tx.IsPrivacy() {
+		return errors.New("Delegation Transaction Is No Privacy Transaction")
+	}
+	onlyOne, pubkey, amount := tx.GetUniqueReceiver()
+	if !onlyOne {
+		return errors.New("Delegation Transaction Should Have 1 Output Amount crossponding to 1 Receiver")
+	}
+	burningAddress := chainRetriever.GetBurningAddress(beaconHeight)
+	keyWalletBurningAdd, err := wallet.Base58CheckDeserialize(burningAddress)
+	if err != nil {
+		return errors.New("burning address is invalid")
+	}
+	if !bytes.Equal(pubkey, keyWalletBurningAdd.KeySet.PaymentAddress.Pk) {
+		return errors.New("receiver Should be Burning Address")
+	}
+	if amount != expectedAmount {
+		return fmt.Errorf("receiver amount should be %d", expectedAmount)
+	}
+	if len(paymentAddressStr) == 0 {
+		return nil
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil || keyWallet == nil {
+		return errors.New("Invalid Delegator Payment Address, Failed to Deserialized Into Key Wallet")
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Pk) != common.PublicKeySize {
+		return errors.New("Invalid Public Key of Delegator Payment Address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], keyWallet.KeySet.PaymentAddress.Pk[:]) {
+		return errors.New("Delegator Payment Address should be the sender of the transaction")
+	}
+	return nil
+}
+
+func buildDelegationReqAction(metaType int, actionContent interface{}) ([][]string, error) {
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(metaType), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (dm *DelegationMetadata) ValidateMetadataByItself() bool {
+	delegatorWallet, err := wallet.Base58CheckDeserialize(dm.DelegatorPaymentAddress)
+	if err != nil || delegatorWallet == nil {
+		return false
+	}
+	if !isValidCommitteePublicKeyStr(dm.CommitteePublicKey) {
+		return false
+	}
+	return dm.Type == DelegationMeta
+}
+
+// ValidateTxWithBlockChain checks the validator is staked with auto re-staking on,
+// the beacon checks it again when the delegation is processed
+func (dm DelegationMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	autoStakingList := beaconViewRetriever.GetAutoStakingList()
+	if isAutoStaking, ok := autoStakingList[dm.CommitteePublicKey]; !ok || !isAutoStaking {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, fmt.Errorf("Committee Publickey %+v is not a validator accepting delegations", dm.CommitteePublicKey))
+	}
+	return true, nil
+}
+
+func (dm DelegationMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if dm.DelegationAmount < MinDelegationAmount {
+		return false, false, fmt.Errorf("delegation amount should be at least %d", uint64(MinDelegationAmount))
+	}
+	err := validateDelegationTxSanity(chainRetriever, beaconHeight, tx, dm.DelegatorPaymentAddress, dm.DelegationAmount)
+	if err != nil {
+		return false, false, err
+	}
+	if !isValidCommitteePublicKeyStr(dm.CommitteePublicKey) {
+		return false, false, errors.New("Invalid Commitee Public Key of Validator")
+	}
+	return true, true, nil
+}
+
+func (dm DelegationMetadata) Hash() *common.Hash {
+	record := dm.MetadataBase.Hash().String()
+	record += dm.DelegatorPaymentAddress
+	record += dm.CommitteePublicKey
+	record += strconv.FormatUint(dm.DelegationAmount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (dm *DelegationMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	return buildDelegationReqAction(dm.Type, DelegationAction{
+		Meta:    *dm,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	})
+}
+
+func (dm *DelegationMetadata) CalculateSize() uint64 {
+	return calculateSize(dm)
+}
+
+func (um *UnDelegationMetadata) ValidateMetadataByItself() bool {
+	delegatorWallet, err := wallet.Base58CheckDeserialize(um.DelegatorPaymentAddress)
+	if err != nil || delegatorWallet == nil {
+		return false
+	}
+	return um.Type == UnDelegationMeta
+}
+
+func (um UnDelegationMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the delegation is checked against the beacon consensus state by beacon
+	return true, nil
+}
+
+func (um UnDelegationMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	err := validateDelegationTxSanity(chainRetriever, beaconHeight, tx, um.DelegatorPaymentAddress, StopAutoStakingAmount)
+	if err != nil {
+		return false, false, err
+	}
+	if um.DelegationTxID.IsEqual(&common.Hash{}) {
+		return false, false, errors.New("DelegationTxID should not be empty")
+	}
+	return true, true, nil
+}
+
+func (um UnDelegationMetadata) Hash() *common.Hash {
+	record := um.MetadataBase.Hash().String()
+	record += um.DelegationTxID.String()
+	record += um.DelegatorPaymentAddress
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (um *UnDelegationMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	return buildDelegationReqAction(um.Type, UnDelegationAction{
+		Meta:    *um,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	})
+}
+
+func (um *UnDelegationMetadata) CalculateSize() uint64 {
+	return calculateSize(um)
+}
+
+func (sm *SetCommissionRateMetadata) ValidateMetadataByItself() bool {
+	if !isValidCommitteePublicKeyStr(sm.CommitteePublicKey) {
+		return false
+	}
+	if sm.CommissionRate > MaxCommissionRate {
+		return false
+	}
+	return sm.Type == SetCommissionRateMeta
+}
+
+// ValidateTxWithBlockChain Validate Condition to Request Set Commission Rate With Blockchain
+// - Requested Committee Publickey is in staking tx list,
+// - Requester (sender of tx) must be address, which create staking transaction for current requested committee public key
+func (sm SetCommissionRateMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	setCommissionRateMetadata, ok := tx.GetMetadata().(*SetCommissionRateMetadata)
+	if !ok {
+		return false, NewMetadataTxError(DelegationRequestTypeAssertionError, fmt.Errorf("Expect *SetCommissionRateMetadata type but get %+v", reflect.TypeOf(tx.GetMetadata())))
+	}
+	requestedPublicKey := setCommissionRateMetadata.CommitteePublicKey
+	stakingTx := shardViewRetriever.GetStakingTx()
+	tempStakingTxHash, ok := stakingTx[requestedPublicKey]
+	if !ok {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, fmt.Errorf("No Committe Publickey %+v found in StakingTx of Shard %+v", requestedPublicKey, shardID))
+	}
+	stakingTxHash, err := common.Hash{}.NewHashFromStr(tempStakingTxHash)
+	if err != nil {
+		return false, err
+	}
+	_, _, _, _, stakingTransaction, err := chainRetriever.GetTransactionByHash(*stakingTxHash)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidatorNotFoundError, err)
+	}
+	if !bytes.Equal(stakingTransaction.GetSender(), tx.GetSender()) {
+		return false, NewMetadataTxError(DelegationRequestInvalidTransactionSenderError, fmt.Errorf("Expect %+v to send set commission rate request but get %+v", stakingTransaction.GetSender(), tx.GetSender()))
+	}
+	return true, nil
+}
+
+func (sm SetCommissionRateMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	err := validateDelegationTxSanity(chainRetriever, beaconHeight, tx, "", StopAutoStakingAmount)
+	if err != nil {
+		return false, false, err
+	}
+	if sm.CommissionRate > MaxCommissionRate {
+		return false, false, fmt.Errorf("commission rate should not be greater than %d", MaxCommissionRate)
+	}
+	if !isValidCommitteePublicKeyStr(sm.CommitteePublicKey) {
+		return false, false, errors.New("Invalid Commitee Public Key of Validator")
+	}
+	return true, true, nil
+}
+
+func (sm SetCommissionRateMetadata) Hash() *common.Hash {
+	record := sm.MetadataBase.Hash().String()
+	record += sm.CommitteePublicKey
+	record += strconv.FormatUint(sm.CommissionRate, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sm *SetCommissionRateMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	return buildDelegationReqAction(sm.Type, SetCommissionRateAction{
+		Meta:    *sm,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	})
+}
+
+func (sm *SetCommissionRateMetadata) CalculateSize() uint64 {
+	return calculateSize(sm)
+}
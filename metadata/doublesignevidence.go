@@ -0,0 +1,195 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// DoubleSignMessage is a consensus message (BFTPropose or BFTVote of blsbftv2) signed by the offender:
+// Header is the header of the proposed (or voted) shard block,
+// Signature is the producer signature of the block hash for a propose message or the vote confirmation for a vote message,
+// BLS and BRI are the signatures carried by a vote message
+type DoubleSignMessage struct {
+	Header    json.RawMessage
+	BLS       []byte
+	BRI       []byte
+	Signature []byte
+}
+
+// DoubleSignEvidenceMetadata - proves CommitteePublicKey signed two different shard blocks of the same height
+// which no honest node signs (see verifyDoubleSignEvidence of blockchain), the committee which created these blocks
+// is loaded by beacon from its consensus state so it is not part of the evidence
+type DoubleSignEvidenceMetadata struct {
+	MetadataBase
+	EvidenceType       string
+	ShardID            byte
+	CommitteePublicKey string
+	FirstMessage       DoubleSignMessage
+	SecondMessage      DoubleSignMessage
+}
+
+type DoubleSignEvidenceAction struct {
+	Meta    DoubleSignEvidenceMetadata
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// DoubleSignSlashingContent is the content of an accepted double sign evidence instruction,
+// Ejected is false when the offender is kept in the committee to keep it from falling under the min committee size
+type DoubleSignSlashingContent struct {
+	CommitteePublicKey string
+	ShardID            byte
+	TxStakingID        common.Hash
+	TxEvidenceID       common.Hash
+	SlashedAmount      uint64
+	Ejected            bool
+}
+
+func NewDoubleSignEvidenceMetadata(
+	evidenceType string,
+	shardID byte,
+	committeePublicKey string,
+	firstMessage DoubleSignMessage,
+	secondMessage DoubleSignMessage,
+	metaType int,
+) (*DoubleSignEvidenceMetadata, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &DoubleSignEvidenceMetadata{
+		MetadataBase:       metadataBase,
+		EvidenceType:       evidenceType,
+		ShardID:            shardID,
+		CommitteePublicKey: committeePublicKey,
+		FirstMessage:       firstMessage,
+		SecondMessage:      secondMessage,
+	}, nil
+}
+
+func NewDoubleSignEvidenceMetadataFromRPC(data map[string]interface{}) (Metadata, error) {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	evidence := &DoubleSignEvidenceMetadata{}
+	err = json.Unmarshal(dataBytes, evidence)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid double sign evidence %+v", err)
+	}
+	evidence.Type = DoubleSignEvidenceMeta
+	return evidence, nil
+}
+
+func (dm *DoubleSignEvidenceMetadata) ValidateMetadataByItself() bool {
+	if dm.EvidenceType != DoubleSignEvidenceProposeType && dm.EvidenceType != DoubleSignEvidenceVoteType {
+		return false
+	}
+	if !isValidCommitteePublicKeyStr(dm.CommitteePublicKey) {
+		return false
+	}
+	for _, message := range []DoubleSignMessage{dm.FirstMessage, dm.SecondMessage} {
+		if len(message.Header) == 0 || len(message.Signature) == 0 {
+			return false
+		}
+	}
+	return dm.Type == DoubleSignEvidenceMeta
+}
+
+// ValidateTxWithBlockChain checks the offender is still in the shard committee,
+// the evidence itself is verified by beacon against the headers of the conflicting blocks
+func (dm DoubleSignEvidenceMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	shardCommittees, _, _, _, _, _, _, _, err := beaconViewRetriever.GetAllCommitteeValidatorCandidate()
+	if err != nil {
+		return false, err
+	}
+	committeePublicKey := incognitokey.CommitteePublicKey{}
+	if err := committeePublicKey.FromString(dm.CommitteePublicKey); err != nil {
+		return false, err
+	}
+	if incognitokey.IndexOfCommitteeKey(committeePublicKey, shardCommittees[dm.ShardID]) == -1 {
+		return false, NewMetadataTxError(DoubleSignEvidenceNotInCommitteeError, fmt.Errorf("Committee Publickey %+v is not in the committee of shard %+v", dm.CommitteePublicKey, dm.ShardID))
+	}
+	return true, nil
+}
+
+func (dm DoubleSignEvidenceMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < chainRetriever.GetBeaconHeightBreakPointSlashing() {
+		return false, false, fmt.Errorf("double sign evidences are not accepted before beacon height %+v", chainRetriever.GetBeaconHeightBreakPointSlashing())
+	}
+	if dm.EvidenceType != DoubleSignEvidenceProposeType && dm.EvidenceType != DoubleSignEvidenceVoteType {
+		return false, false, fmt.Errorf("evidence type should be %s or %s", DoubleSignEvidenceProposeType, DoubleSignEvidenceVoteType)
+	}
+	if !isValidCommitteePublicKeyStr(dm.CommitteePublicKey) {
+		return false, false, errors.New("Invalid Commitee Public Key of Offender")
+	}
+	if bytes.Equal(dm.FirstMessage.Header, dm.SecondMessage.Header) {
+		return false, false, errors.New("Evidence should contain two different blocks")
+	}
+	if dm.EvidenceType == DoubleSignEvidenceVoteType {
+		for _, message := range []DoubleSignMessage{dm.FirstMessage, dm.SecondMessage} {
+			if len(message.BLS) == 0 {
+				return false, false, errors.New("Vote evidence should contain the BLS signature of the votes")
+			}
+		}
+	}
+	return true, true, nil
+}
+
+func (dm DoubleSignEvidenceMetadata) Hash() *common.Hash {
+	record := dm.MetadataBase.Hash().String()
+	record += dm.EvidenceType
+	record += strconv.Itoa(int(dm.ShardID))
+	record += dm.CommitteePublicKey
+	for _, message := range []DoubleSignMessage{dm.FirstMessage, dm.SecondMessage} {
+		record += string(message.Header)
+		record += base58.Base58Check{}.Encode(message.BLS, common.ZeroByte)
+		record += base58.Base58Check{}.Encode(message.BRI, common.ZeroByte)
+		record += base58.Base58Check{}.Encode(message.Signature, common.ZeroByte)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (dm *DoubleSignEvidenceMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	return buildDelegationReqAction(dm.Type, DoubleSignEvidenceAction{
+		Meta:    *dm,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	})
+}
+
+func (dm *DoubleSignEvidenceMetadata) CalculateSize() uint64 {
+	return calculateSize(dm)
+}
+
+// ParseDoubleSignSlashingInst returns the content of an accepted double sign evidence instruction
+func ParseDoubleSignSlashingInst(inst []string) (*DoubleSignSlashingContent, bool) {
+	if len(inst) < 4 {
+		return nil, false
+	}
+	if inst[0] != strconv.Itoa(DoubleSignEvidenceMeta) || inst[2] != common.DoubleSignEvidenceAcceptedChainStatus {
+		return nil, false
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+	if err != nil {
+		Logger.log.Error("WARNING - VALIDATION: an error occured while decoding instruction content: ", err)
+		return nil, false
+	}
+	var content DoubleSignSlashingContent
+	err = json.Unmarshal(contentBytes, &content)
+	if err != nil {
+		Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+		return nil, false
+	}
+	return &content, true
+}
@@ -34,6 +34,10 @@ const (
 	StopAutoStakingRequestNoAutoStakingAvaiableError
 	StopAutoStakingRequestTypeAssertionError
 	StopAutoStakingRequestAlreadyStopError
+	DelegationRequestValidatorNotFoundError
+	DelegationRequestInvalidTransactionSenderError
+	DelegationRequestTypeAssertionError
+	DoubleSignEvidenceNotInCommitteeError
 
 	WrongIncognitoDAOPaymentAddressError
 
@@ -84,6 +88,10 @@ var ErrCodeMessage = map[int]struct {
 	StopAutoStakingRequestNoAutoStakingAvaiableError:      {-4003, "Stop Auto-Staking Request No Auto Staking Avaliable Error"},
 	StopAutoStakingRequestTypeAssertionError:              {-4004, "Stop Auto-Staking Request Type Assertion Error"},
 	StopAutoStakingRequestAlreadyStopError:                {-4005, "Stop Auto Staking Request Already Stop Error"},
+	DelegationRequestValidatorNotFoundError:               {-4006, "Delegation Request Validator Not Found Error"},
+	DelegationRequestInvalidTransactionSenderError:        {-4007, "Delegation Request Invalid Transaction Sender Error"},
+	DelegationRequestTypeAssertionError:                   {-4008, "Delegation Request Type Assertion Error"},
+	DoubleSignEvidenceNotInCommitteeError:                 {-4009, "Double Sign Evidence Committee Public Key Not In Shard Committee Error"},
 
 	// -5xxx dev reward error
 	WrongIncognitoDAOPaymentAddressError: {-5001, "Invalid dev account"},
@@ -6,13 +6,12 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/privacy"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
+	"github.com/incognitochain/incognito-chain/relaying"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
-	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
 // Interface for all types of metadata in tx
@@ -58,13 +57,16 @@ type ChainRetriever interface {
 	GetStakingAmountShard() uint64
 	GetCentralizedWebsitePaymentAddress(uint64) string
 	GetBeaconHeightBreakPointBurnAddr() uint64
+	GetBeaconHeightBreakPointPrivacyV2() uint64
+	GetBeaconHeightBreakPointSlashing() uint64
+	GetBeaconHeightBreakPointLimitOrder() uint64
+	GetBeaconHeightBreakPointBestRoute() uint64
+	GetBeaconHeightBreakPointDelegation() uint64
 	GetBurningAddress(blockHeight uint64) string
 	GetTransactionByHash(common.Hash) (byte, common.Hash, uint64, int, Transaction, error)
 	ListPrivacyTokenAndBridgeTokenAndPRVByShardID(byte) ([]common.Hash, error)
-	GetBNBChainID() string
-	GetBTCChainID() string
-	GetBTCHeaderChain() *btcrelaying.BlockChain
-	GetPortalFeederAddress() string
+	GetRelayingChain(tokenID string) relaying.RelayingChain
+	GetPortalFeederAddresses(beaconHeight uint64) []string
 	GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar
 }
 
@@ -250,25 +252,10 @@ func IsValidRemoteAddress(
 	bcr ChainRetriever,
 	remoteAddress string,
 	tokenID string,
-	chainID string,
 ) bool {
-	if tokenID == common.PortalBNBIDStr {
-		return bnb.IsValidBNBAddress(remoteAddress, chainID)
-	} else if tokenID == common.PortalBTCIDStr {
-		btcHeaderChain := bcr.GetBTCHeaderChain()
-		if btcHeaderChain == nil {
-			return false
-		}
-		return btcHeaderChain.IsBTCAddressValid(remoteAddress)
-	}
-	return false
-}
-
-func GetChainIDByTokenID(tokenID string, chainRetriever ChainRetriever) string {
-	if tokenID == common.PortalBNBIDStr {
-		return chainRetriever.GetBNBChainID()
-	} else if tokenID == common.PortalBTCIDStr {
-		return chainRetriever.GetBTCChainID()
+	relayingChain := bcr.GetRelayingChain(tokenID)
+	if relayingChain == nil {
+		return false
 	}
-	return ""
+	return relayingChain.IsValidRemoteAddress(remoteAddress)
 }
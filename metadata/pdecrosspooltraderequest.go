@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -23,6 +24,9 @@ type PDECrossPoolTradeRequest struct {
 	MinAcceptableAmount uint64
 	TradingFee          uint64
 	TraderAddressStr    string
+	// UseBestRoute lets beacon route the trade through any pools (up to a max number of hops)
+	// instead of the default route via PRV pools
+	UseBestRoute bool `json:",omitempty"`
 	MetadataBase
 }
 
@@ -60,6 +64,7 @@ func NewPDECrossPoolTradeRequest(
 	minAcceptableAmount uint64,
 	tradingFee uint64,
 	traderAddressStr string,
+	useBestRoute bool,
 	metaType int,
 ) (*PDECrossPoolTradeRequest, error) {
 	metadataBase := MetadataBase{
@@ -72,6 +77,7 @@ func NewPDECrossPoolTradeRequest(
 		MinAcceptableAmount: minAcceptableAmount,
 		TradingFee:          tradingFee,
 		TraderAddressStr:    traderAddressStr,
+		UseBestRoute:        useBestRoute,
 	}
 	pdeCrossPoolTradeRequest.MetadataBase = metadataBase
 	return pdeCrossPoolTradeRequest, nil
@@ -88,6 +94,10 @@ func (pc PDECrossPoolTradeRequest) ValidateSanityData(chainRetriever ChainRetrie
 		return true, true, nil
 	}
 
+	if pc.UseBestRoute && beaconHeight < chainRetriever.GetBeaconHeightBreakPointBestRoute() {
+		return false, false, fmt.Errorf("Best route trades are not accepted before beacon height %+v", chainRetriever.GetBeaconHeightBreakPointBestRoute())
+	}
+
 	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
 	if err != nil {
 		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
@@ -172,6 +182,9 @@ func (pc PDECrossPoolTradeRequest) Hash() *common.Hash {
 	record += strconv.FormatUint(pc.SellAmount, 10)
 	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
 	record += strconv.FormatUint(pc.TradingFee, 10)
+	if pc.UseBestRoute {
+		record += strconv.FormatBool(pc.UseBestRoute)
+	}
 	// final hash
 	hash := common.HashH([]byte(record))
 	return &hash
@@ -0,0 +1,202 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderRequest - privacy dex limit order
+// the order rests in the order book of beacon until pool price reaches MinAcceptableAmount,
+// it is refunded when it is cancelled by the trader or reaches ExpiryBeaconHeight (at most MaxPDELimitOrderLifetime blocks ahead)
+type PDELimitOrderRequest struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64 // must be equal to vout value
+	MinAcceptableAmount uint64
+	TradingFee          uint64
+	TraderAddressStr    string
+	ExpiryBeaconHeight  uint64
+	MetadataBase
+}
+
+type PDELimitOrderRequestAction struct {
+	Meta    PDELimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type PDELimitOrderRefundContent struct {
+	TraderAddressStr string
+	TokenIDStr       string
+	Amount           uint64
+	ShardID          byte
+	OrderID          common.Hash
+	Reason           string
+}
+
+func NewPDELimitOrderRequest(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+	expiryBeaconHeight uint64,
+	metaType int,
+) (*PDELimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeLimitOrderRequest := &PDELimitOrderRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+		ExpiryBeaconHeight:  expiryBeaconHeight,
+	}
+	pdeLimitOrderRequest.MetadataBase = metadataBase
+	return pdeLimitOrderRequest, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the order is checked against pool pairs and expiry by beacon
+	return true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	if beaconHeight < chainRetriever.GetBeaconHeightBreakPointLimitOrder() {
+		return false, false, fmt.Errorf("Limit orders are not accepted before beacon height %+v", chainRetriever.GetBeaconHeightBreakPointLimitOrder())
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+
+	_, err = common.Hash{}.NewHashFromStr(pc.TokenIDToBuyStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToBuyStr incorrect"))
+	}
+
+	if pc.TokenIDToSellStr == pc.TokenIDToBuyStr {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr should be different from TokenIDToBuyStr"))
+	}
+
+	if pc.SellAmount < MinPDELimitOrderSellAmount || pc.MinAcceptableAmount == 0 {
+		return false, false, fmt.Errorf("Selling amount of limit order should be at least %+v and min acceptable amount should be greater than 0", uint64(MinPDELimitOrderSellAmount))
+	}
+
+	if pc.ExpiryBeaconHeight <= beaconHeight || pc.ExpiryBeaconHeight > beaconHeight+MaxPDELimitOrderLifetime {
+		return false, false, fmt.Errorf("Expiry beacon height of limit order should be in (%+v, %+v]", beaconHeight, beaconHeight+MaxPDELimitOrderLifetime)
+	}
+
+	if tx.GetType() == common.TxNormalType {
+		if pc.TokenIDToSellStr != common.PRVCoinID.String() {
+			return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token")
+		}
+		if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+			return false, false, errors.New("Must send coin to burning address")
+		}
+		if (pc.SellAmount + pc.TradingFee) != tx.CalculateTxValue() {
+			return false, false, errors.New("Total of selling amount and trading fee should be equal to the tx value")
+		}
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		if pc.TokenIDToSellStr == common.PRVCoinID.String() {
+			return false, false, errors.New("With custom token privacy tx, the tokenIDStr should not be PRV, but custom token")
+		}
+		tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr incorrect"))
+		}
+		if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+			return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id")
+		}
+
+		if pc.TradingFee == 0 {
+			if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+				return false, false, errors.New("Must send custom coin to burning address")
+			}
+			pTokenAmt := tx.CalculateTxValue()
+			if pTokenAmt != pc.SellAmount {
+				return false, false, errors.New("Sell amount should be equal to the burned pToken amount")
+			}
+		} else {
+			if !tx.IsFullBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+				return false, false, errors.New("Must send coins to burning address")
+			}
+			prvAmt, pTokenAmt := tx.GetFullTxValues()
+			if prvAmt != pc.TradingFee {
+				return false, false, errors.New("Trading fee should be equal to the burned prv amount")
+			}
+			if pTokenAmt != pc.SellAmount {
+				return false, false, errors.New("Sell amount should be equal to the burned pToken amount")
+			}
+		}
+	}
+
+	return true, true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDELimitOrderRequestMeta
+}
+
+func (pc PDELimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	record += strconv.FormatUint(pc.TradingFee, 10)
+	record += strconv.FormatUint(pc.ExpiryBeaconHeight, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDELimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDELimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDELimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -0,0 +1,159 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderResponse - pays out a matched limit order or refunds a rejected, expired or cancelled one,
+// RequestedTxID is the id of the limit order
+type PDELimitOrderResponse struct {
+	MetadataBase
+	OrderStatus   string
+	RequestedTxID common.Hash
+}
+
+func NewPDELimitOrderResponse(
+	orderStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *PDELimitOrderResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &PDELimitOrderResponse{
+		OrderStatus:   orderStatus,
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes PDELimitOrderResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes PDELimitOrderResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == PDELimitOrderResponseMeta
+}
+
+func (iRes PDELimitOrderResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.OrderStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *PDELimitOrderResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes PDELimitOrderResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	chainRetriever ChainRetriever,
+	ac *AccumulatedValues,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not PDELimitOrderRequestMeta instruction
+			continue
+		}
+		instMetaType := inst[0]
+		if instUsed[i] > 0 ||
+			instMetaType != strconv.Itoa(PDELimitOrderRequestMeta) {
+			continue
+		}
+		instOrderStatus := inst[2]
+		if instOrderStatus != iRes.OrderStatus ||
+			(instOrderStatus != common.PDELimitOrderFeeRefundChainStatus &&
+				instOrderStatus != common.PDELimitOrderSellingTokenRefundChainStatus &&
+				instOrderStatus != common.PDELimitOrderMatchedChainStatus) {
+			continue
+		}
+
+		var shardIDFromInst byte
+		var orderIDFromInst common.Hash
+		var receiverAddrStrFromInst string
+		var receivingAmtFromInst uint64
+		var receivingTokenIDStr string
+		if instOrderStatus == common.PDELimitOrderFeeRefundChainStatus ||
+			instOrderStatus == common.PDELimitOrderSellingTokenRefundChainStatus {
+			contentBytes := []byte(inst[3])
+			var refundContent PDELimitOrderRefundContent
+			err := json.Unmarshal(contentBytes, &refundContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde limit order refund content: ", err)
+				continue
+			}
+			shardIDFromInst = refundContent.ShardID
+			orderIDFromInst = refundContent.OrderID
+			receiverAddrStrFromInst = refundContent.TraderAddressStr
+			receivingTokenIDStr = refundContent.TokenIDStr
+			receivingAmtFromInst = refundContent.Amount
+		} else { // order matched
+			contentBytes := []byte(inst[3])
+			var matchedContents []PDECrossPoolTradeAcceptedContent
+			err := json.Unmarshal(contentBytes, &matchedContents)
+			cLen := len(matchedContents)
+			if err != nil || cLen == 0 {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde limit order matched content: ", err)
+				continue
+			}
+			lastMatchedContent := matchedContents[cLen-1]
+			shardIDFromInst = lastMatchedContent.ShardID
+			orderIDFromInst = lastMatchedContent.RequestedTxID
+			receiverAddrStrFromInst = lastMatchedContent.TraderAddressStr
+			receivingTokenIDStr = lastMatchedContent.TokenIDToBuyStr
+			receivingAmtFromInst = lastMatchedContent.ReceiveAmount
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], orderIDFromInst[:]) ||
+			shardID != shardIDFromInst {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(receiverAddrStrFromInst)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			receivingTokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the instruction for this response
+		return false, fmt.Errorf(fmt.Sprintf("no PDELimitOrderRequestMeta instruction found for PDELimitOrderResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -165,8 +165,7 @@ func (custodianDeposit PortalCustodianDeposit) ValidateSanityData(chainRetriever
 		if len(remoteAddr) == 0 {
 			return false, false, errors.New("Remote address is invalid")
 		}
-		chainID := GetChainIDByTokenID(tokenID, chainRetriever)
-		if !IsValidRemoteAddress(chainRetriever, remoteAddr, tokenID, chainID) {
+		if !IsValidRemoteAddress(chainRetriever, remoteAddr, tokenID) {
 			return false, false, fmt.Errorf("Remote address %v is not a valid address of tokenID %v", remoteAddr, tokenID)
 		}
 	}
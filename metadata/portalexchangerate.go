@@ -40,6 +40,22 @@ func NewExchangeRatesRequestStatus(status byte, senderAddress string, rates []*E
 	return &ExchangeRatesRequestStatus{Status: status, SenderAddress: senderAddress, Rates: rates}
 }
 
+// ExchangeRatesSubmission is an exchange rates request accepted in a beacon block,
+// RejectedTokenIDs are tokens of which rates deviate too much from median of feeders
+type ExchangeRatesSubmission struct {
+	TxReqID          string
+	SenderAddress    string
+	Rates            []*ExchangeRateInfo
+	RejectedTokenIDs []string
+}
+
+// ExchangeRatesHistory is submitted and final exchange rates of a beacon block
+type ExchangeRatesHistory struct {
+	BeaconHeight uint64
+	Submissions  []*ExchangeRatesSubmission
+	FinalRates   map[string]uint64
+}
+
 func NewPortalExchangeRates(metaType int, senderAddress string, currency []*ExchangeRateInfo) (*PortalExchangeRates, error) {
 	metadataBase := MetadataBase{Type: metaType}
 
@@ -73,9 +89,9 @@ func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
-	feederAddress := chainRetriever.GetPortalFeederAddress()
-	if portalExchangeRates.SenderAddress != feederAddress {
-		return false, false, fmt.Errorf("Sender must be feeder's address %v\n", feederAddress)
+	feederAddresses := chainRetriever.GetPortalFeederAddresses(beaconHeight)
+	if common.IndexOfStr(portalExchangeRates.SenderAddress, feederAddresses) == -1 {
+		return false, false, fmt.Errorf("Sender must be one of feeders' addresses %v\n", feederAddresses)
 	}
 
 	keyWallet, err := wallet.Base58CheckDeserialize(portalExchangeRates.SenderAddress)
@@ -158,8 +158,7 @@ func (redeemReq PortalRedeemRequest) ValidateSanityData(chainRetriever ChainRetr
 	if len(redeemReq.RemoteAddress) == 0 {
 		return false, false, NewMetadataTxError(PortalRedeemRequestParamError, errors.New("Remote address is invalid"))
 	}
-	chainID := GetChainIDByTokenID(redeemReq.TokenID, chainRetriever)
-	if !IsValidRemoteAddress(chainRetriever, redeemReq.RemoteAddress, redeemReq.TokenID, chainID) {
+	if !IsValidRemoteAddress(chainRetriever, redeemReq.RemoteAddress, redeemReq.TokenID) {
 		return false, false, fmt.Errorf("Remote address %v is not a valid address of tokenID %v", redeemReq.RemoteAddress, redeemReq.TokenID)
 	}
 
@@ -0,0 +1,143 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// DelegationReturnContent is the content of an instruction returning a delegated amount to its delegator
+type DelegationReturnContent struct {
+	TxDelegationID     common.Hash
+	CommitteePublicKey string
+	DelegatorAddress   string
+	Amount             uint64
+	ShardID            byte
+}
+
+// ReturnDelegationMetadata - pays back the amount of a delegation (DelegationTxID) which is rejected by beacon or returned after unbonding
+type ReturnDelegationMetadata struct {
+	MetadataBase
+	DelegationTxID common.Hash
+}
+
+func NewReturnDelegationMetadata(
+	delegationTxID common.Hash,
+	metaType int,
+) *ReturnDelegationMetadata {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &ReturnDelegationMetadata{
+		DelegationTxID: delegationTxID,
+		MetadataBase:   metadataBase,
+	}
+}
+
+func (iRes ReturnDelegationMetadata) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes ReturnDelegationMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with the beacon instruction (via DelegationTxID)
+	return false, nil
+}
+
+func (iRes ReturnDelegationMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes ReturnDelegationMetadata) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == ReturnDelegationMeta
+}
+
+func (iRes ReturnDelegationMetadata) Hash() *common.Hash {
+	record := iRes.DelegationTxID.String()
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *ReturnDelegationMetadata) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+// ParseDelegationReturnInst returns the delegation tx id, the delegator address and the amount to pay back
+// of a rejected delegation instruction or a returned undelegation instruction
+func ParseDelegationReturnInst(inst []string) (common.Hash, string, uint64, byte, bool) {
+	if len(inst) < 4 {
+		return common.Hash{}, "", 0, 0, false
+	}
+	isRejectedDelegation := inst[0] == strconv.Itoa(DelegationMeta) && inst[2] == common.DelegationRejectedChainStatus
+	isReturnedDelegation := inst[0] == strconv.Itoa(UnDelegationMeta) && inst[2] == common.DelegationReturnedChainStatus
+	if !isRejectedDelegation && !isReturnedDelegation {
+		return common.Hash{}, "", 0, 0, false
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+	if err != nil {
+		Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+		return common.Hash{}, "", 0, 0, false
+	}
+	if isRejectedDelegation {
+		var delegationAction DelegationAction
+		err = json.Unmarshal(contentBytes, &delegationAction)
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+			return common.Hash{}, "", 0, 0, false
+		}
+		return delegationAction.TxReqID, delegationAction.Meta.DelegatorPaymentAddress, delegationAction.Meta.DelegationAmount, delegationAction.ShardID, true
+	}
+	var returnContent DelegationReturnContent
+	err = json.Unmarshal(contentBytes, &returnContent)
+	if err != nil {
+		Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+		return common.Hash{}, "", 0, 0, false
+	}
+	return returnContent.TxDelegationID, returnContent.DelegatorAddress, returnContent.Amount, returnContent.ShardID, true
+}
+
+func (iRes ReturnDelegationMetadata) VerifyMinerCreatedTxBeforeGettingInBlock(txsInBlock []Transaction, txsUsed []int, insts [][]string, instUsed []int, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if instUsed[i] > 0 {
+			continue
+		}
+		delegationTxID, delegatorAddressStr, returnAmount, instShardID, ok := ParseDelegationReturnInst(inst)
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(iRes.DelegationTxID[:], delegationTxID[:]) ||
+			shardID != instShardID {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(delegatorAddressStr)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing delegator address string: ", err)
+			continue
+		}
+
+		_, pk, amount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			returnAmount != amount ||
+			common.PRVCoinID.String() != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the delegation instruction for this response
+		return false, errors.Errorf("no delegation instruction found for the ReturnDelegation tx %s", tx.Hash().String())
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
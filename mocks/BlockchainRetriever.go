@@ -168,6 +168,34 @@ func (_m *BlockchainRetriever) GetBeaconHeight() uint64 {
 	return r0
 }
 
+// GetBeaconHeightBreakPointBestRoute provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointBestRoute() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointDelegation provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointDelegation() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetBeaconHeightBreakPointBurnAddr provides a mock function with given fields:
 func (_m *BlockchainRetriever) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	ret := _m.Called()
@@ -182,6 +210,48 @@ func (_m *BlockchainRetriever) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	return r0
 }
 
+// GetBeaconHeightBreakPointPrivacyV2 provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointPrivacyV2() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointLimitOrder provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointLimitOrder() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetBeaconHeightBreakPointSlashing provides a mock function with given fields:
+func (_m *BlockchainRetriever) GetBeaconHeightBreakPointSlashing() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetBeaconRewardStateDB provides a mock function with given fields:
 func (_m *BlockchainRetriever) GetBeaconRewardStateDB() *statedb.StateDB {
 	ret := _m.Called()
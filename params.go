@@ -34,6 +34,38 @@ var testNet2Params = params{
 	wsPort:  Testnet2WsServerPort,
 }
 
+// loadCustomNetParams loads parameters of a custom network from a network config file,
+// rpc and websocket ports of its base network are used if they are not set in the file
+func loadCustomNetParams(path string) (*params, error) {
+	netConfig, err := blockchain.LoadNetworkConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	chainParams, err := netConfig.ChainParams()
+	if err != nil {
+		return nil, err
+	}
+	customNetParams := &params{
+		Params:  chainParams,
+		rpcPort: netConfig.RPCPort,
+		wsPort:  netConfig.WSPort,
+	}
+	baseNetParams := testNetParams
+	if netConfig.Base == blockchain.MainetName {
+		baseNetParams = mainNetParams
+	} else if netConfig.Base == blockchain.Testnet2Name {
+		baseNetParams = testNet2Params
+	}
+	if customNetParams.rpcPort == "" {
+		customNetParams.rpcPort = baseNetParams.rpcPort
+	}
+	if customNetParams.wsPort == "" {
+		customNetParams.wsPort = baseNetParams.wsPort
+	}
+	blockchain.GenesisParam = chainParams.GenesisParams
+	return customNetParams, nil
+}
+
 // netName returns the name used when referring to a coin network.
 func netName(chainParams *params) string {
 	return chainParams.Name
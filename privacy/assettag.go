@@ -0,0 +1,81 @@
+package privacy
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Confidential coins are coins of privacy v2 which hide their token id.
+// Token id t is mapped to the asset tag H_t = Hp("assettag" || t), and each confidential coin carries a blinded asset tag
+//
+//	A = H_t + a*G_randomness
+//
+// in its info, the blinder a and the token id are encrypted for receiver together with value and randomness of coin.
+// Spending a confidential coin proves that its asset tag and the asset tag of outputs only differ by a multiple of G_randomness,
+// i.e. they blind the same token, without revealing which coin in ring is spent (see transaction/txprivacyv2.go).
+var assetTagInfoPrefix = []byte{0x00, 0x02}
+
+const assetTagDomain = "assettag"
+
+// ConfidentialAsset is the token id and the blinder of asset tag of a confidential coin, only known by sender and receiver
+type ConfidentialAsset struct {
+	TokenID common.Hash
+	Blinder *Scalar
+}
+
+// AssetTag returns the unblinded asset tag of token
+func AssetTag(tokenID common.Hash) *Point {
+	return HashToPoint(append([]byte(assetTagDomain), tokenID[:]...))
+}
+
+// BlindAssetTag returns asset tag of token blinded by blinder
+func BlindAssetTag(tokenID common.Hash, blinder *Scalar) *Point {
+	tag := AssetTag(tokenID)
+	if blinder == nil {
+		return tag
+	}
+	return tag.Add(tag, new(Point).ScalarMult(PedCom.G[PedersenRandomnessIndex], blinder))
+}
+
+// MaxSizeInfoConfidentialCoin is max size of info of a confidential coin, excluding its asset tag
+const MaxSizeInfoConfidentialCoin = MaxSizeInfoCoin - 2 - Ed25519KeySize
+
+func infoWithAssetTag(tag *Point, info []byte) ([]byte, error) {
+	if len(info) > MaxSizeInfoConfidentialCoin {
+		return nil, errors.New("info of confidential coin is too large")
+	}
+	res := append(append([]byte{}, assetTagInfoPrefix...), tag.ToBytesS()...)
+	return append(res, info...), nil
+}
+
+// HasAssetTagPrefix checks whether info starts with the prefix of asset tags, only info of confidential coins may start with it,
+// otherwise the info chosen by sender of a coin could be read as an asset tag
+func HasAssetTagPrefix(info []byte) bool {
+	return bytes.HasPrefix(info, assetTagInfoPrefix)
+}
+
+// GetAssetTag returns blinded asset tag of a confidential coin, it returns nil for other coins
+func GetAssetTag(coin *Coin) *Point {
+	if coin == nil {
+		return nil
+	}
+	info := coin.GetInfo()
+	if len(info) < len(assetTagInfoPrefix)+Ed25519KeySize || !HasAssetTagPrefix(info) {
+		return nil
+	}
+	tag, err := new(Point).FromBytesS(info[len(assetTagInfoPrefix) : len(assetTagInfoPrefix)+Ed25519KeySize])
+	if err != nil {
+		return nil
+	}
+	return tag
+}
+
+// GetInfoOfConfidentialCoin returns info of a confidential coin without its asset tag
+func GetInfoOfConfidentialCoin(coin *Coin) []byte {
+	if GetAssetTag(coin) == nil {
+		return coin.GetInfo()
+	}
+	return coin.GetInfo()[len(assetTagInfoPrefix)+Ed25519KeySize:]
+}
@@ -83,10 +83,14 @@ func (ciphertext *HybridCipherText) SetBytes(bytes []byte) error {
 // using AES key to encrypt message
 // After that, using ElGamal encryption encrypt aesKeyPoint using publicKey
 func HybridEncrypt(msg []byte, publicKey *Point) (ciphertext *HybridCipherText, err error) {
-	ciphertext = new(HybridCipherText)
-
 	// Generate a AES key bytes
-	sKeyPoint := RandomPoint()
+	return hybridEncryptWithKeyPoint(msg, publicKey, RandomPoint())
+}
+
+// hybridEncryptWithKeyPoint is the same as HybridEncrypt, but the AES key point is given by caller,
+// so that the caller can also use it as a secret shared with the receiver
+func hybridEncryptWithKeyPoint(msg []byte, publicKey *Point, sKeyPoint *Point) (ciphertext *HybridCipherText, err error) {
+	ciphertext = new(HybridCipherText)
 	sKeyByte := sKeyPoint.ToBytes()
 	// Encrypt msg using aesKeyByte
 
@@ -111,24 +115,7 @@ func HybridEncrypt(msg []byte, publicKey *Point) (ciphertext *HybridCipherText,
 // it decrypts aesKeyPoint, using ElGamal encryption with privateKey
 // Using X-coordinate of aesKeyPoint to decrypts message
 func HybridDecrypt(ciphertext *HybridCipherText, privateKey *Scalar) (msg []byte, err error) {
-	// Validate ciphertext
-	if ciphertext.IsNil() {
-		return []byte{}, errors.New("ciphertext must not be nil")
-	}
-
-	// Get receiving key, which is a private key of ElGamal cryptosystem
-	privKey := new(elGamalPrivateKey)
-	privKey.set(privateKey)
-
-	// Parse encrypted AES key encoded as an elliptic point from EncryptedSymKey
-	encryptedAESKey := new(elGamalCipherText)
-	err = encryptedAESKey.SetBytes(ciphertext.symKeyEncrypted)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	// Decrypt encryptedAESKey using recipient's receiving key
-	aesKeyPoint, err := privKey.decrypt(encryptedAESKey)
+	aesKeyPoint, err := hybridDecryptKeyPoint(ciphertext, privateKey)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -147,3 +134,25 @@ func HybridDecrypt(ciphertext *HybridCipherText, privateKey *Scalar) (msg []byte
 	}
 	return msg, nil
 }
+
+// hybridDecryptKeyPoint decrypts AES key point of ciphertext, using ElGamal encryption with privateKey
+func hybridDecryptKeyPoint(ciphertext *HybridCipherText, privateKey *Scalar) (*Point, error) {
+	// Validate ciphertext
+	if ciphertext.IsNil() {
+		return nil, errors.New("ciphertext must not be nil")
+	}
+
+	// Get receiving key, which is a private key of ElGamal cryptosystem
+	privKey := new(elGamalPrivateKey)
+	privKey.set(privateKey)
+
+	// Parse encrypted AES key encoded as an elliptic point from EncryptedSymKey
+	encryptedAESKey := new(elGamalCipherText)
+	err := encryptedAESKey.SetBytes(ciphertext.symKeyEncrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt encryptedAESKey using recipient's receiving key
+	return privKey.decrypt(encryptedAESKey)
+}
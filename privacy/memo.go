@@ -0,0 +1,73 @@
+package privacy
+
+import (
+	"bytes"
+	"crypto/aes"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// encrypted memo is stored in info of output coin as
+// prefix || hybrid ciphertext of (memo || checksum of memo)
+// only the receiver of coin can decrypt it by its receiving key
+var encryptedMemoPrefix = []byte{0x00, 0x01}
+
+const memoChecksumSize = 4
+
+// MaxSizeMemo is max size of memo which still fits in info of output coin after being encrypted
+const MaxSizeMemo = MaxSizeInfoCoin - 2 - elGamalCiphertextSize - aes.BlockSize - memoChecksumSize
+
+func memoChecksum(memo []byte) []byte {
+	return common.HashB(memo)[:memoChecksumSize]
+}
+
+// IsEncryptedMemo checks whether info of output coin is an encrypted memo
+func IsEncryptedMemo(info []byte) bool {
+	return len(info) > len(encryptedMemoPrefix)+elGamalCiphertextSize && bytes.HasPrefix(info, encryptedMemoPrefix)
+}
+
+// EncryptMemo encrypts memo with transmission key of receiver, the result is used as info of output coin
+func EncryptMemo(memo []byte, transmissionKey []byte) ([]byte, error) {
+	if len(memo) == 0 {
+		return nil, errors.New("memo is empty")
+	}
+	if len(memo) > MaxSizeMemo {
+		return nil, errors.New("memo is too large")
+	}
+	publicKey, err := new(Point).FromBytesS(transmissionKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := append(append([]byte{}, memo...), memoChecksum(memo)...)
+	ciphertext, err := HybridEncrypt(plaintext, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, encryptedMemoPrefix...), ciphertext.Bytes()...), nil
+}
+
+// DecryptMemo decrypts memo in info of output coin by receiving key
+// it returns error if info is not an encrypted memo or the coin is not sent to receiving key
+func DecryptMemo(info []byte, receivingKey []byte) ([]byte, error) {
+	if !IsEncryptedMemo(info) {
+		return nil, errors.New("info is not an encrypted memo")
+	}
+	ciphertext := new(HybridCipherText)
+	err := ciphertext.SetBytes(info[len(encryptedMemoPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := HybridDecrypt(ciphertext, new(Scalar).FromBytesS(receivingKey))
+	if err != nil {
+		return nil, err
+	}
+	if len(plaintext) <= memoChecksumSize {
+		return nil, errors.New("memo is invalid")
+	}
+	memo := plaintext[:len(plaintext)-memoChecksumSize]
+	if !bytes.Equal(plaintext[len(plaintext)-memoChecksumSize:], memoChecksum(memo)) {
+		return nil, errors.New("memo can not be decrypted by receiving key")
+	}
+	return memo, nil
+}
@@ -0,0 +1,40 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptMemo(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{1})
+	receivingKey := GenerateReceivingKey(privateKey)
+	transmissionKey := GenerateTransmissionKey(receivingKey)
+	otherPrivateKey := GeneratePrivateKey([]byte{2})
+	otherReceivingKey := GenerateReceivingKey(otherPrivateKey)
+
+	memo := []byte("deposit reference 123456")
+	info, err := EncryptMemo(memo, transmissionKey)
+	assert.Nil(t, err)
+	assert.True(t, len(info) <= MaxSizeInfoCoin)
+	assert.True(t, IsEncryptedMemo(info))
+	assert.NotContains(t, string(info), string(memo))
+
+	decryptedMemo, err := DecryptMemo(info, receivingKey)
+	assert.Nil(t, err)
+	assert.Equal(t, memo, decryptedMemo)
+
+	_, err = DecryptMemo(info, otherReceivingKey)
+	assert.NotNil(t, err)
+
+	_, err = DecryptMemo([]byte("plain info"), receivingKey)
+	assert.NotNil(t, err)
+
+	maxMemo := make([]byte, MaxSizeMemo)
+	info, err = EncryptMemo(maxMemo, transmissionKey)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxSizeInfoCoin, len(info))
+
+	_, err = EncryptMemo(make([]byte, MaxSizeMemo+1), transmissionKey)
+	assert.NotNil(t, err)
+}
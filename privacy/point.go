@@ -256,6 +256,13 @@ func IsPointEqual(pa *Point, pb *Point) bool {
 	return subtle.ConstantTimeCompare(tmpa, tmpb) == 1
 }
 
+// IsInPrimeOrderSubgroup returns true if L * p is identity (L is the order of the curve basepoint),
+// i.e. p has no small order (torsion) component
+func (p Point) IsInPrimeOrderSubgroup() bool {
+	l := C25519.CurveOrder()
+	return *C25519.ScalarMultKey(&p.key, &l) == C25519.Identity
+}
+
 func HashToPointFromIndex(index int64, padStr string) *Point {
 	array := C25519.GBASE.ToBytes()
 	msg := array[:]
@@ -2,6 +2,7 @@ package privacy
 
 import (
 	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 
 	//C25519 "github.com/deroproject/derosuite/crypto"
@@ -234,3 +235,26 @@ func TestPoint_FromBytes(t *testing.T) {
 	//	t.Fatalf("expected point is valid!")
 	//}
 }
+
+// torsionPointHex is a point of order 8
+const torsionPointHex = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+
+func TestPoint_IsInPrimeOrderSubgroup(t *testing.T) {
+	torsionBytes, _ := hex.DecodeString(torsionPointHex)
+	torsion, err := new(Point).FromBytesS(torsionBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if torsion.IsIdentity() || torsion.IsInPrimeOrderSubgroup() {
+		t.Fatalf("expected a torsion point out of the prime order subgroup")
+	}
+
+	point := RandomPoint()
+	if !point.IsInPrimeOrderSubgroup() {
+		t.Fatalf("expected a random point in the prime order subgroup")
+	}
+	taintedPoint := new(Point).Add(point, torsion)
+	if taintedPoint.IsInPrimeOrderSubgroup() {
+		t.Fatalf("expected a tainted point out of the prime order subgroup")
+	}
+}
@@ -0,0 +1,253 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Threshold Schnorr lets any t of n parties sign for a public key PK = sk*G, sk is never reconstructed:
+//   - distributed key generation: each party i deals a Feldman verifiable sharing of a random secret,
+//     the share of party j is x_j = sum_i f_i(j), sk = sum_i f_i(0) and PK = sum_i C_i0
+//   - nonce commitment: each signer j commits to a pair of nonces (d_j, e_j) on every base of the proof
+//   - partial signature: signer j responds d_j + rho_j*e_j + c*lambda_j*x_j where rho_j binds its nonces to the message
+//     and the signer set, lambda_j is its Lagrange coefficient, responses sum up to a response for sk.
+//
+// Serial numbers of coins of the threshold key are (sk + snd)^-1 * G, they are computed by masked inversion:
+// parties share a random r with a Feldman sharing (R = r*G is public), open u = r*(sk + snd) and SN = u^-1 * R.
+// The product of two sharings of threshold t has threshold 2t-1, so at least 2t-1 parties are needed to open u.
+
+// ThresholdDealing is a Feldman verifiable sharing of a secret by one party:
+// Shares[j-1] = f(j) is the share of party j, Commitments[k] = a_k*G where a_k is the k-th coefficient of f
+type ThresholdDealing struct {
+	Commitments []*Point
+	Shares      []*Scalar
+}
+
+// NewThresholdDealing shares secret among numParties parties, any threshold of them can reconstruct it,
+// secret is random if it is nil
+func NewThresholdDealing(secret *Scalar, threshold int, numParties int) (*ThresholdDealing, error) {
+	if threshold < 1 || threshold > numParties {
+		return nil, NewPrivacyErr(InvalidThresholdParamsErr, fmt.Errorf("threshold %d of %d parties", threshold, numParties))
+	}
+	coefficients := make([]*Scalar, threshold)
+	for k := range coefficients {
+		coefficients[k] = RandomScalar()
+	}
+	if secret != nil {
+		coefficients[0] = new(Scalar).Set(secret)
+	}
+	dealing := &ThresholdDealing{
+		Commitments: make([]*Point, threshold),
+		Shares:      make([]*Scalar, numParties),
+	}
+	for k, coefficient := range coefficients {
+		dealing.Commitments[k] = new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], coefficient)
+	}
+	for j := 1; j <= numParties; j++ {
+		// Horner's method
+		share := new(Scalar).FromUint64(0)
+		index := new(Scalar).FromUint64(uint64(j))
+		for k := threshold - 1; k >= 0; k-- {
+			share.MulAdd(share, index, coefficients[k])
+		}
+		dealing.Shares[j-1] = share
+	}
+	return dealing, nil
+}
+
+// ThresholdCommitmentAt returns f(index)*G from Feldman commitments of f
+func ThresholdCommitmentAt(index uint64, commitments []*Point) *Point {
+	result := new(Point).Identity()
+	indexScalar := new(Scalar).FromUint64(index)
+	for k := len(commitments) - 1; k >= 0; k-- {
+		result = new(Point).ScalarMult(result, indexScalar)
+		result.Add(result, commitments[k])
+	}
+	return result
+}
+
+// VerifyThresholdShare checks share of party index against Feldman commitments of its dealing
+func VerifyThresholdShare(index uint64, share *Scalar, commitments []*Point) bool {
+	if share == nil || len(commitments) == 0 {
+		return false
+	}
+	return IsPointEqual(new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], share), ThresholdCommitmentAt(index, commitments))
+}
+
+// SumThresholdCommitments returns Feldman commitments of the sum of dealt polynomials
+func SumThresholdCommitments(commitmentsList [][]*Point) ([]*Point, error) {
+	if len(commitmentsList) == 0 {
+		return nil, NewPrivacyErr(InvalidThresholdParamsErr, errors.New("no dealing"))
+	}
+	result := make([]*Point, len(commitmentsList[0]))
+	for k := range result {
+		result[k] = new(Point).Identity()
+	}
+	for _, commitments := range commitmentsList {
+		if len(commitments) != len(result) {
+			return nil, NewPrivacyErr(InvalidThresholdParamsErr, errors.New("dealings have different thresholds"))
+		}
+		for k, commitment := range commitments {
+			result[k].Add(result[k], commitment)
+		}
+	}
+	return result, nil
+}
+
+// ThresholdLagrangeCoefficient returns coefficient of share of party index to interpolate f(0) from shares of indices
+func ThresholdLagrangeCoefficient(index uint64, indices []uint64) (*Scalar, error) {
+	numerator := new(Scalar).FromUint64(1)
+	denominator := new(Scalar).FromUint64(1)
+	found := false
+	for _, other := range indices {
+		if other == index {
+			if found {
+				return nil, NewPrivacyErr(InvalidThresholdParamsErr, fmt.Errorf("duplicate index %d", index))
+			}
+			found = true
+			continue
+		}
+		numerator.Mul(numerator, new(Scalar).FromUint64(other))
+		denominator.Mul(denominator, new(Scalar).Sub(new(Scalar).FromUint64(other), new(Scalar).FromUint64(index)))
+	}
+	if !found || index == 0 {
+		return nil, NewPrivacyErr(InvalidThresholdParamsErr, fmt.Errorf("index %d is not in signer set", index))
+	}
+	return numerator.Mul(numerator, new(Scalar).Invert(denominator)), nil
+}
+
+// ThresholdNonce is the secret nonce pair of a signer for one threshold proof, it must only be used once
+type ThresholdNonce struct {
+	D *Scalar
+	E *Scalar
+}
+
+// ThresholdNonceCommitment contains D*B and E*B of nonce pair of a signer for each base B of the proof
+type ThresholdNonceCommitment struct {
+	D []*Point
+	E []*Point
+}
+
+// NewThresholdNonce returns a fresh nonce pair and its commitments on bases
+func NewThresholdNonce(bases []*Point) (*ThresholdNonce, *ThresholdNonceCommitment) {
+	nonce := &ThresholdNonce{D: RandomScalar(), E: RandomScalar()}
+	commitment := &ThresholdNonceCommitment{
+		D: make([]*Point, len(bases)),
+		E: make([]*Point, len(bases)),
+	}
+	for i, base := range bases {
+		commitment.D[i] = new(Point).ScalarMult(base, nonce.D)
+		commitment.E[i] = new(Point).ScalarMult(base, nonce.E)
+	}
+	return nonce, commitment
+}
+
+func sortedThresholdIndices(commitments map[uint64]*ThresholdNonceCommitment) []uint64 {
+	indices := make([]uint64, 0, len(commitments))
+	for index := range commitments {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+// ThresholdGroupNonce returns binding factor of each signer and group nonce sum_j (D_j + rho_j*E_j) on each base,
+// binding factors depend on message and commitments of all signers so a signer can not adapt its nonce to the others
+func ThresholdGroupNonce(msg []byte, numBases int, commitments map[uint64]*ThresholdNonceCommitment) ([]*Point, map[uint64]*Scalar, error) {
+	indices := sortedThresholdIndices(commitments)
+	transcript := append([]byte{}, msg...)
+	for _, index := range indices {
+		commitment := commitments[index]
+		if commitment == nil || len(commitment.D) != numBases || len(commitment.E) != numBases {
+			return nil, nil, NewPrivacyErr(InvalidThresholdParamsErr, fmt.Errorf("invalid nonce commitment of signer %d", index))
+		}
+		transcript = append(transcript, common.Uint64ToBytes(index)...)
+		for i := 0; i < numBases; i++ {
+			transcript = append(transcript, commitment.D[i].ToBytesS()...)
+			transcript = append(transcript, commitment.E[i].ToBytesS()...)
+		}
+	}
+	transcriptHash := common.HashB(transcript)
+
+	groupNonces := make([]*Point, numBases)
+	for i := range groupNonces {
+		groupNonces[i] = new(Point).Identity()
+	}
+	bindingFactors := make(map[uint64]*Scalar)
+	for _, index := range indices {
+		bindingFactors[index] = HashToScalar(append(common.Uint64ToBytes(index), transcriptHash...))
+		for i := 0; i < numBases; i++ {
+			groupNonces[i].Add(groupNonces[i], commitments[index].D[i])
+			groupNonces[i].Add(groupNonces[i], new(Point).ScalarMult(commitments[index].E[i], bindingFactors[index]))
+		}
+	}
+	return groupNonces, bindingFactors, nil
+}
+
+// Response returns partial response d + rho*e + challenge*lambda*share of a signer
+func (nonce ThresholdNonce) Response(bindingFactor, challenge, lagrange, share *Scalar) *Scalar {
+	response := new(Scalar).MulAdd(bindingFactor, nonce.E, nonce.D)
+	return response.MulAdd(new(Scalar).Mul(challenge, lagrange), share, response)
+}
+
+// VerifyThresholdResponse checks partial response of a signer on base G against its verification key x_j*G
+func VerifyThresholdResponse(response *Scalar, commitment *ThresholdNonceCommitment, bindingFactor, challenge, lagrange *Scalar, verificationKey *Point) bool {
+	if response == nil || commitment == nil || len(commitment.D) == 0 || len(commitment.E) == 0 {
+		return false
+	}
+	expected := new(Point).Add(commitment.D[0], new(Point).ScalarMult(commitment.E[0], bindingFactor))
+	expected.Add(expected, new(Point).ScalarMult(verificationKey, new(Scalar).Mul(challenge, lagrange)))
+	return IsPointEqual(new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], response), expected)
+}
+
+// ThresholdSchnorrChallenge returns challenge of Schnorr signature on data with group nonce R,
+// signers respond to the negated challenge as z1 = s - e*sk
+func ThresholdSchnorrChallenge(groupNonce *Point, data []byte) (*Scalar, error) {
+	if len(data) != common.HashSize {
+		return nil, NewPrivacyErr(UnexpectedErr, errors.New("hash length must be 32 bytes"))
+	}
+	return HashToScalar(append(groupNonce.ToBytesS(), data...)), nil
+}
+
+// NewThresholdSchnSignature aggregates partial responses of signers to the negated challenge into a Schnorr signature
+// without privacy, which is verified by SchnorrPublicKey.Verify with the group public key
+func NewThresholdSchnSignature(challenge *Scalar, responses []*Scalar) *SchnSignature {
+	z1 := new(Scalar).FromUint64(0)
+	for _, response := range responses {
+		z1.Add(z1, response)
+	}
+	return &SchnSignature{e: new(Scalar).Set(challenge), z1: z1}
+}
+
+// ThresholdInverseProduct returns share of party of u = r*(sk + snd), masked by its share of a sharing of zero
+// of threshold 2t-1 so that opened shares only reveal u
+func ThresholdInverseProduct(rShare, keyShare, snd, zeroShare *Scalar) *Scalar {
+	product := new(Scalar).Mul(rShare, new(Scalar).Add(keyShare, snd))
+	return product.Add(product, zeroShare)
+}
+
+// CombineThresholdInverse opens u from product shares of at least 2t-1 parties and returns u^-1 * R = (sk + snd)^-1 * G
+func CombineThresholdInverse(products map[uint64]*Scalar, threshold int, rCommitment *Point) (*Point, error) {
+	if len(products) < 2*threshold-1 {
+		return nil, NewPrivacyErr(InvalidThresholdParamsErr, fmt.Errorf("need products of %d parties, got %d", 2*threshold-1, len(products)))
+	}
+	indices := make([]uint64, 0, len(products))
+	for index := range products {
+		indices = append(indices, index)
+	}
+	u := new(Scalar).FromUint64(0)
+	for _, index := range indices {
+		lagrange, err := ThresholdLagrangeCoefficient(index, indices)
+		if err != nil {
+			return nil, err
+		}
+		u.MulAdd(lagrange, products[index], u)
+	}
+	if u.IsZero() {
+		return nil, NewPrivacyErr(InvalidThresholdParamsErr, errors.New("opened product is zero"))
+	}
+	return new(Point).ScalarMult(rCommitment, new(Scalar).Invert(u)), nil
+}
@@ -0,0 +1,138 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// thresholdKeyGen runs distributed key generation among numParties parties,
+// it returns shares of parties, group commitments and the private key for checking
+func thresholdKeyGen(t *testing.T, threshold int, numParties int) ([]*Scalar, []*Point, *Scalar) {
+	dealings := make([]*ThresholdDealing, numParties)
+	commitmentsList := make([][]*Point, numParties)
+	for i := range dealings {
+		dealing, err := NewThresholdDealing(nil, threshold, numParties)
+		assert.Nil(t, err)
+		dealings[i] = dealing
+		commitmentsList[i] = dealing.Commitments
+	}
+	shares := make([]*Scalar, numParties)
+	for j := 1; j <= numParties; j++ {
+		shares[j-1] = new(Scalar).FromUint64(0)
+		for _, dealing := range dealings {
+			assert.True(t, VerifyThresholdShare(uint64(j), dealing.Shares[j-1], dealing.Commitments))
+			shares[j-1].Add(shares[j-1], dealing.Shares[j-1])
+		}
+	}
+	groupCommitments, err := SumThresholdCommitments(commitmentsList)
+	assert.Nil(t, err)
+
+	// interpolate private key from shares of the first threshold parties
+	indices := make([]uint64, threshold)
+	for i := range indices {
+		indices[i] = uint64(i + 1)
+	}
+	sk := new(Scalar).FromUint64(0)
+	for _, index := range indices {
+		lagrange, err := ThresholdLagrangeCoefficient(index, indices)
+		assert.Nil(t, err)
+		sk.MulAdd(lagrange, shares[index-1], sk)
+	}
+	assert.Equal(t, new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], sk), groupCommitments[0])
+	return shares, groupCommitments, sk
+}
+
+func TestThresholdDealing(t *testing.T) {
+	_, err := NewThresholdDealing(nil, 4, 3)
+	assert.NotNil(t, err)
+
+	secret := RandomScalar()
+	dealing, err := NewThresholdDealing(secret, 2, 3)
+	assert.Nil(t, err)
+	assert.False(t, VerifyThresholdShare(1, dealing.Shares[1], dealing.Commitments))
+	assert.Equal(t, new(Point).ScalarMult(PedCom.G[PedersenPrivateKeyIndex], secret), dealing.Commitments[0])
+
+	_, err = ThresholdLagrangeCoefficient(4, []uint64{1, 2})
+	assert.NotNil(t, err)
+}
+
+func TestThresholdSchnorrSignature(t *testing.T) {
+	threshold, numParties := 2, 3
+	shares, groupCommitments, _ := thresholdKeyGen(t, threshold, numParties)
+	signers := []uint64{1, 3}
+	data := RandomScalar().ToBytesS()
+
+	bases := []*Point{PedCom.G[PedersenPrivateKeyIndex]}
+	nonces := make(map[uint64]*ThresholdNonce)
+	commitments := make(map[uint64]*ThresholdNonceCommitment)
+	for _, index := range signers {
+		nonces[index], commitments[index] = NewThresholdNonce(bases)
+	}
+	groupNonces, bindingFactors, err := ThresholdGroupNonce(data, len(bases), commitments)
+	assert.Nil(t, err)
+	e, err := ThresholdSchnorrChallenge(groupNonces[0], data)
+	assert.Nil(t, err)
+	challenge := new(Scalar).Sub(new(Scalar).FromUint64(0), e)
+
+	responses := make([]*Scalar, 0)
+	for _, index := range signers {
+		lagrange, err := ThresholdLagrangeCoefficient(index, signers)
+		assert.Nil(t, err)
+		response := nonces[index].Response(bindingFactors[index], challenge, lagrange, shares[index-1])
+		verificationKey := ThresholdCommitmentAt(index, groupCommitments)
+		assert.True(t, VerifyThresholdResponse(response, commitments[index], bindingFactors[index], challenge, lagrange, verificationKey))
+		assert.False(t, VerifyThresholdResponse(RandomScalar(), commitments[index], bindingFactors[index], challenge, lagrange, verificationKey))
+		responses = append(responses, response)
+	}
+
+	signature := NewThresholdSchnSignature(e, responses)
+	publicKey := new(SchnorrPublicKey)
+	publicKey.Set(groupCommitments[0])
+	assert.True(t, publicKey.Verify(signature, data))
+
+	signature2 := new(SchnSignature)
+	assert.Nil(t, signature2.SetBytes(signature.Bytes()))
+	assert.True(t, publicKey.Verify(signature2, data))
+	assert.False(t, publicKey.Verify(signature, RandomScalar().ToBytesS()))
+}
+
+func TestThresholdInverse(t *testing.T) {
+	threshold, numParties := 2, 3
+	shares, _, sk := thresholdKeyGen(t, threshold, numParties)
+	snd := RandomScalar()
+
+	rShares := make([]*Scalar, numParties)
+	zeroShares := make([]*Scalar, numParties)
+	rCommitments := make([][]*Point, numParties)
+	for j := range rShares {
+		rShares[j] = new(Scalar).FromUint64(0)
+		zeroShares[j] = new(Scalar).FromUint64(0)
+	}
+	for i := 0; i < numParties; i++ {
+		rDealing, err := NewThresholdDealing(nil, threshold, numParties)
+		assert.Nil(t, err)
+		zeroDealing, err := NewThresholdDealing(new(Scalar).FromUint64(0), 2*threshold-1, numParties)
+		assert.Nil(t, err)
+		assert.True(t, zeroDealing.Commitments[0].IsIdentity())
+		rCommitments[i] = rDealing.Commitments
+		for j := 0; j < numParties; j++ {
+			rShares[j].Add(rShares[j], rDealing.Shares[j])
+			zeroShares[j].Add(zeroShares[j], zeroDealing.Shares[j])
+		}
+	}
+	groupRCommitments, err := SumThresholdCommitments(rCommitments)
+	assert.Nil(t, err)
+
+	products := make(map[uint64]*Scalar)
+	for j := 0; j < numParties; j++ {
+		products[uint64(j+1)] = ThresholdInverseProduct(rShares[j], shares[j], snd, zeroShares[j])
+	}
+	serialNumber, err := CombineThresholdInverse(products, threshold, groupRCommitments[0])
+	assert.Nil(t, err)
+	assert.Equal(t, new(Point).Derive(PedCom.G[PedersenPrivateKeyIndex], sk, snd), serialNumber)
+
+	delete(products, 2)
+	_, err = CombineThresholdInverse(products, threshold, groupRCommitments[0])
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,229 @@
+package privacy
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	C25519 "github.com/incognitochain/incognito-chain/privacy/curve25519"
+)
+
+// Output coins of privacy v2 are sent to one-time addresses.
+// Sender picks a random point K, which is encrypted for receiver's transmission key
+// as the AES key point of CoinDetailsEncrypted (see HybridEncrypt), so only sender and receiver know K.
+// One-time public key of coin is
+//
+//	P = H(K)*G + Pk
+//
+// and only receiver can spend it with one-time private key H(K) + sk.
+// Commitment of coin only commits to value and randomness: v*G_value + r*G_randomness,
+// serial number derivator is H(P), it's not used to spend coin but keeps coin compatible with the storage of v1 coins.
+const oneTimeAddressDomain = "onetimeaddress"
+
+const maxTriesGenerateOneTimeAddress = 1000
+
+func oneTimeSecret(sharedPoint *Point) *Scalar {
+	return HashToScalar(append([]byte(oneTimeAddressDomain), sharedPoint.ToBytesS()...))
+}
+
+func snDerivatorV2(oneTimePublicKey *Point) *Scalar {
+	return HashToScalar(oneTimePublicKey.ToBytesS())
+}
+
+// CommitValueV2 returns commitment of value with randomness in coins of privacy v2
+func CommitValueV2(value uint64, randomness *Scalar) *Point {
+	return PedCom.CommitAtIndex(new(Scalar).FromUint64(value), randomness, PedersenValueIndex)
+}
+
+// NewOutputCoinV2 creates an output coin which is sent to a one-time address of receiver,
+// the one-time address is in the same shard as public key of receiver
+func NewOutputCoinV2(receiver PaymentAddress, value uint64, info []byte) (*OutputCoin, error) {
+	return NewOutputCoinV2WithParams(receiver, value, info, nil, nil)
+}
+
+// NewOutputCoinV2WithParams creates an output coin of privacy v2 with given randomness (random if it is nil),
+// if asset is not nil, the coin is a confidential coin of asset
+func NewOutputCoinV2WithParams(receiver PaymentAddress, value uint64, info []byte, randomness *Scalar, asset *ConfidentialAsset) (*OutputCoin, error) {
+	publicKey, err := new(Point).FromBytesS(receiver.Pk)
+	if err != nil {
+		return nil, err
+	}
+	transmissionKey, err := new(Point).FromBytesS(receiver.Tk)
+	if err != nil {
+		return nil, err
+	}
+	receiverShardID := common.GetShardIDFromLastByte(receiver.Pk[len(receiver.Pk)-1])
+	if randomness == nil {
+		randomness = RandomScalar()
+	}
+	msg := append(randomness.ToBytesS(), common.Uint64ToBytes(value)...)
+	if asset != nil {
+		if asset.Blinder == nil {
+			return nil, errors.New("blinder of confidential asset is nil")
+		}
+		info, err = infoWithAssetTag(BlindAssetTag(asset.TokenID, asset.Blinder), info)
+		if err != nil {
+			return nil, err
+		}
+		msg = append(append(msg, asset.Blinder.ToBytesS()...), asset.TokenID[:]...)
+	} else if HasAssetTagPrefix(info) {
+		return nil, errors.New("info of coin which is not confidential can not start with prefix of asset tag")
+	}
+
+	for i := 0; i < maxTriesGenerateOneTimeAddress; i++ {
+		sharedPoint := RandomPoint()
+		oneTimePublicKey := new(Point).ScalarMultBase(oneTimeSecret(sharedPoint))
+		oneTimePublicKey.Add(oneTimePublicKey, publicKey)
+		oneTimePublicKeyBytes := oneTimePublicKey.ToBytesS()
+		if common.GetShardIDFromLastByte(oneTimePublicKeyBytes[len(oneTimePublicKeyBytes)-1]) != receiverShardID {
+			continue
+		}
+
+		outputCoin := new(OutputCoin).Init()
+		outputCoin.CoinDetails.SetPublicKey(oneTimePublicKey)
+		outputCoin.CoinDetails.SetValue(value)
+		outputCoin.CoinDetails.SetRandomness(randomness)
+		outputCoin.CoinDetails.SetSNDerivator(snDerivatorV2(oneTimePublicKey))
+		outputCoin.CoinDetails.SetInfo(info)
+		outputCoin.CoinDetails.SetCoinCommitment(CommitValueV2(value, randomness))
+
+		outputCoin.CoinDetailsEncrypted, err = hybridEncryptWithKeyPoint(msg, transmissionKey, sharedPoint)
+		if err != nil {
+			return nil, err
+		}
+		return outputCoin, nil
+	}
+	return nil, errors.New("can not generate one-time address in shard of receiver")
+}
+
+// IsOutputCoinV2 checks whether coin is a coin of privacy v2
+func IsOutputCoinV2(coin *Coin) bool {
+	if coin == nil || coin.GetPublicKey() == nil || coin.GetSNDerivator() == nil {
+		return false
+	}
+	return IsScalarEqual(coin.GetSNDerivator(), snDerivatorV2(coin.GetPublicKey()))
+}
+
+// DecryptOutputCoinV2 checks whether output coin of privacy v2 is sent to owner of viewing key,
+// if so, it sets value and randomness of coin and returns the one-time secret to derive one-time private key
+func DecryptOutputCoinV2(outputCoin *OutputCoin, viewingKey ViewingKey) (*Scalar, error) {
+	secret, _, err := DecryptConfidentialOutputCoin(outputCoin, viewingKey)
+	return secret, err
+}
+
+// DecryptConfidentialOutputCoin works as DecryptOutputCoinV2, it also returns the asset of coin if it is a confidential coin
+func DecryptConfidentialOutputCoin(outputCoin *OutputCoin, viewingKey ViewingKey) (*Scalar, *ConfidentialAsset, error) {
+	if outputCoin == nil || outputCoin.CoinDetailsEncrypted == nil || !IsOutputCoinV2(outputCoin.CoinDetails) {
+		return nil, nil, errors.New("coin is not an output coin of privacy v2")
+	}
+	publicKey, err := new(Point).FromBytesS(viewingKey.Pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	receivingKey := new(Scalar).FromBytesS(viewingKey.Rk)
+	sharedPoint, err := hybridDecryptKeyPoint(outputCoin.CoinDetailsEncrypted, receivingKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret := oneTimeSecret(sharedPoint)
+	oneTimePublicKey := new(Point).ScalarMultBase(secret)
+	oneTimePublicKey.Add(oneTimePublicKey, publicKey)
+	if !IsPointEqual(oneTimePublicKey, outputCoin.CoinDetails.GetPublicKey()) {
+		return nil, nil, errors.New("coin is not sent to viewing key")
+	}
+
+	msg, err := HybridDecrypt(outputCoin.CoinDetailsEncrypted, receivingKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	assetTag := GetAssetTag(outputCoin.CoinDetails)
+	lenMsg := Ed25519KeySize + common.Uint64Size
+	if assetTag != nil {
+		lenMsg += Ed25519KeySize + common.HashSize
+	}
+	if len(msg) != lenMsg {
+		return nil, nil, errors.New("encrypted details of coin is invalid")
+	}
+	randomness := new(Scalar).FromBytesS(msg[:Ed25519KeySize])
+	value, err := common.BytesToUint64(msg[Ed25519KeySize : Ed25519KeySize+common.Uint64Size])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !IsPointEqual(CommitValueV2(value, randomness), outputCoin.CoinDetails.GetCoinCommitment()) {
+		return nil, nil, errors.New("encrypted details of coin do not match its commitment")
+	}
+	var asset *ConfidentialAsset
+	if assetTag != nil {
+		offset := Ed25519KeySize + common.Uint64Size
+		asset = &ConfidentialAsset{Blinder: new(Scalar).FromBytesS(msg[offset : offset+Ed25519KeySize])}
+		copy(asset.TokenID[:], msg[offset+Ed25519KeySize:])
+		if !IsPointEqual(BlindAssetTag(asset.TokenID, asset.Blinder), assetTag) {
+			return nil, nil, errors.New("encrypted asset of coin does not match its asset tag")
+		}
+	}
+	outputCoin.CoinDetails.SetRandomness(randomness)
+	outputCoin.CoinDetails.SetValue(value)
+	return secret, asset, nil
+}
+
+// GetOneTimePrivateKey returns private key of one-time public key H(K)*G + Pk
+func GetOneTimePrivateKey(privateKey PrivateKey, oneTimeSecret *Scalar) *Scalar {
+	return new(Scalar).Add(new(Scalar).FromBytesS(privateKey), oneTimeSecret)
+}
+
+// GenerateKeyImage returns key image x*Hp(P) of one-time private key x,
+// it's unique for each one-time public key P and is used as serial number of coin to detect double spending
+func GenerateKeyImage(oneTimePrivateKey *Scalar) *Point {
+	oneTimePublicKey := new(Point).ScalarMultBase(oneTimePrivateKey)
+	return new(Point).ScalarMult(HashToPoint(oneTimePublicKey.ToBytesS()), oneTimePrivateKey)
+}
+
+// IsKeyImageValid checks that key image is in the prime order subgroup,
+// otherwise a coin could be spent twice with key images differing by a small order point
+func IsKeyImageValid(keyImage *Point) bool {
+	if keyImage == nil || keyImage.IsIdentity() || !keyImage.PointValid() {
+		return false
+	}
+	order := C25519.CurveOrder()
+	key := keyImage.GetKey()
+	return *C25519.ScalarMultKey(&key, &order) == C25519.Identity
+}
+
+// CoinV2Bytes returns bytes of one-time public key, commitment and asset tag (of confidential coins) of coin,
+// which are stored in the list of v2 coins and used as ring members when spending coins
+func CoinV2Bytes(coin *Coin) []byte {
+	res := append(coin.GetPublicKey().ToBytesS(), coin.GetCoinCommitment().ToBytesS()...)
+	if assetTag := GetAssetTag(coin); assetTag != nil {
+		res = append(res, assetTag.ToBytesS()...)
+	}
+	return res
+}
+
+// ParseCoinV2Bytes parses bytes of a coin in the list of v2 coins into its one-time public key, commitment and asset tag,
+// asset tag is nil if the coin is not a confidential coin
+func ParseCoinV2Bytes(data []byte) (*Point, *Point, *Point, error) {
+	if len(data) != 2*Ed25519KeySize && len(data) != 3*Ed25519KeySize {
+		return nil, nil, nil, errors.New("invalid length of coin v2 bytes")
+	}
+	publicKey, err := new(Point).FromBytesS(data[:Ed25519KeySize])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commitment, err := new(Point).FromBytesS(data[Ed25519KeySize : 2*Ed25519KeySize])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(data) == 2*Ed25519KeySize {
+		return publicKey, commitment, nil, nil
+	}
+	assetTag, err := new(Point).FromBytesS(data[2*Ed25519KeySize:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return publicKey, commitment, assetTag, nil
+}
+
+// GetCoinV2TokenID returns the id under which v2 coins of token are indexed,
+// it's different from token id so v1 coins and v2 coins are never mixed in rings
+func GetCoinV2TokenID(tokenID common.Hash) common.Hash {
+	return common.HashH(append([]byte("coinv2"), tokenID[:]...))
+}
@@ -0,0 +1,86 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputCoinV2(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{1})
+	paymentAddress := GeneratePaymentAddress(privateKey)
+	viewingKey := GenerateViewingKey(privateKey)
+	otherViewingKey := GenerateViewingKey(GeneratePrivateKey([]byte{2}))
+
+	outputCoin, err := NewOutputCoinV2(paymentAddress, 1000, []byte("info"))
+	assert.Nil(t, err)
+	assert.True(t, IsOutputCoinV2(outputCoin.CoinDetails))
+	assert.Equal(t, common.GetShardIDFromLastByte(paymentAddress.Pk[len(paymentAddress.Pk)-1]), common.GetShardIDFromLastByte(outputCoin.CoinDetails.GetPubKeyLastByte()))
+	assert.NotEqual(t, paymentAddress.Pk, PublicKey(outputCoin.CoinDetails.GetPublicKey().ToBytesS()))
+
+	// receiver only gets value of coin from its encrypted details
+	outputCoin.CoinDetails.SetValue(0)
+	outputCoin.CoinDetails.SetRandomness(nil)
+	_, err = DecryptOutputCoinV2(outputCoin, otherViewingKey)
+	assert.NotNil(t, err)
+	secret, err := DecryptOutputCoinV2(outputCoin, viewingKey)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1000), outputCoin.CoinDetails.GetValue())
+
+	oneTimePrivateKey := GetOneTimePrivateKey(privateKey, secret)
+	assert.True(t, IsPointEqual(new(Point).ScalarMultBase(oneTimePrivateKey), outputCoin.CoinDetails.GetPublicKey()))
+
+	keyImage := GenerateKeyImage(oneTimePrivateKey)
+	assert.True(t, IsKeyImageValid(keyImage))
+	assert.Equal(t, keyImage.ToBytesS(), GenerateKeyImage(oneTimePrivateKey).ToBytesS())
+
+	publicKey, commitment, assetTag, err := ParseCoinV2Bytes(CoinV2Bytes(outputCoin.CoinDetails))
+	assert.Nil(t, err)
+	assert.Nil(t, assetTag)
+	assert.True(t, IsPointEqual(publicKey, outputCoin.CoinDetails.GetPublicKey()))
+	assert.True(t, IsPointEqual(commitment, outputCoin.CoinDetails.GetCoinCommitment()))
+}
+
+func TestConfidentialOutputCoin(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{3})
+	paymentAddress := GeneratePaymentAddress(privateKey)
+	viewingKey := GenerateViewingKey(privateKey)
+	tokenID := common.Hash{1}
+	blinder := RandomScalar()
+
+	outputCoin, err := NewOutputCoinV2WithParams(paymentAddress, 500, []byte("info"), nil, &ConfidentialAsset{TokenID: tokenID, Blinder: blinder})
+	assert.Nil(t, err)
+	assetTag := GetAssetTag(outputCoin.CoinDetails)
+	assert.NotNil(t, assetTag)
+	assert.True(t, IsPointEqual(BlindAssetTag(tokenID, blinder), assetTag))
+	assert.False(t, IsPointEqual(AssetTag(tokenID), assetTag))
+	assert.Equal(t, []byte("info"), GetInfoOfConfidentialCoin(outputCoin.CoinDetails))
+
+	// receiver gets token id and blinder of asset tag together with value of coin
+	outputCoin.CoinDetails.SetValue(0)
+	outputCoin.CoinDetails.SetRandomness(nil)
+	_, asset, err := DecryptConfidentialOutputCoin(outputCoin, viewingKey)
+	assert.Nil(t, err)
+	assert.Equal(t, tokenID, asset.TokenID)
+	assert.True(t, IsScalarEqual(blinder, asset.Blinder))
+	assert.Equal(t, uint64(500), outputCoin.CoinDetails.GetValue())
+
+	_, _, parsedAssetTag, err := ParseCoinV2Bytes(CoinV2Bytes(outputCoin.CoinDetails))
+	assert.Nil(t, err)
+	assert.True(t, IsPointEqual(assetTag, parsedAssetTag))
+
+	// asset tag which does not match the encrypted token is rejected
+	otherCoin, err := NewOutputCoinV2WithParams(paymentAddress, 500, nil, nil, &ConfidentialAsset{TokenID: tokenID, Blinder: blinder})
+	assert.Nil(t, err)
+	info, err := infoWithAssetTag(AssetTag(common.Hash{2}), nil)
+	assert.Nil(t, err)
+	otherCoin.CoinDetails.SetInfo(info)
+	_, _, err = DecryptConfidentialOutputCoin(otherCoin, viewingKey)
+	assert.NotNil(t, err)
+
+	// info of coins which are not confidential can not be read as an asset tag
+	assert.True(t, HasAssetTagPrefix(info))
+	_, err = NewOutputCoinV2WithParams(paymentAddress, 500, info, nil, nil)
+	assert.NotNil(t, err)
+}
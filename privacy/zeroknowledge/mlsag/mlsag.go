@@ -0,0 +1,212 @@
+package mlsag
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/utils"
+)
+
+// Ring contains public keys of ring members, each member has one key for each row:
+// row 0 is one-time public key of coin (base G), which is linked by key image,
+// the other rows are commitments to zero (base G_randomness), e.g. commitment of coin minus pseudo commitment of input
+type Ring struct {
+	keys [][]*privacy.Point
+}
+
+func NewRing(keys [][]*privacy.Point) *Ring {
+	return &Ring{keys: keys}
+}
+
+func (ring Ring) GetKeys() [][]*privacy.Point {
+	return ring.keys
+}
+
+// MlsagSig is a multilayered linkable spontaneous anonymous group signature
+type MlsagSig struct {
+	c        *privacy.Scalar
+	keyImage *privacy.Point
+	r        [][]*privacy.Scalar
+}
+
+func (sig MlsagSig) GetKeyImage() *privacy.Point {
+	return sig.keyImage
+}
+
+// Mlsag signs a message with private keys of the member at index pi of ring
+type Mlsag struct {
+	ring        *Ring
+	pi          int
+	privateKeys []*privacy.Scalar
+}
+
+func NewMlsag(privateKeys []*privacy.Scalar, ring *Ring, pi int) *Mlsag {
+	return &Mlsag{ring: ring, pi: pi, privateKeys: privateKeys}
+}
+
+func getBase(row int) *privacy.Point {
+	if row == 0 {
+		return privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]
+	}
+	return privacy.PedCom.G[privacy.PedersenRandomnessIndex]
+}
+
+func checkRing(ring *Ring) (int, int, error) {
+	if ring == nil || len(ring.keys) == 0 || len(ring.keys[0]) == 0 {
+		return 0, 0, errors.New("ring is empty")
+	}
+	n := len(ring.keys)
+	m := len(ring.keys[0])
+	for i := 0; i < n; i++ {
+		if len(ring.keys[i]) != m {
+			return 0, 0, errors.New("ring members must have the same number of keys")
+		}
+		for j := 0; j < m; j++ {
+			if ring.keys[i][j] == nil {
+				return 0, 0, errors.New("key of ring member is nil")
+			}
+		}
+	}
+	return n, m, nil
+}
+
+// challenge hashes message with L, R of row 0 and L of the other rows
+func challenge(message []byte, l []*privacy.Point, keyImageR *privacy.Point) *privacy.Scalar {
+	values := make([][]byte, 0, len(l)+2)
+	values = append(values, message, keyImageR.ToBytesS())
+	for _, point := range l {
+		values = append(values, point.ToBytesS())
+	}
+	return utils.GenerateChallenge(values)
+}
+
+func (mlsag *Mlsag) Sign(message []byte) (*MlsagSig, error) {
+	n, m, err := checkRing(mlsag.ring)
+	if err != nil {
+		return nil, err
+	}
+	if mlsag.pi < 0 || mlsag.pi >= n {
+		return nil, errors.New("index of signer is out of ring")
+	}
+	if len(mlsag.privateKeys) != m {
+		return nil, errors.New("number of private keys must be equal to number of rows of ring")
+	}
+	for j := 0; j < m; j++ {
+		if !privacy.IsPointEqual(new(privacy.Point).ScalarMult(getBase(j), mlsag.privateKeys[j]), mlsag.ring.keys[mlsag.pi][j]) {
+			return nil, errors.New("private keys do not match keys of signer in ring")
+		}
+	}
+
+	hashedKey := privacy.HashToPoint(mlsag.ring.keys[mlsag.pi][0].ToBytesS())
+	keyImage := new(privacy.Point).ScalarMult(hashedKey, mlsag.privateKeys[0])
+
+	alpha := make([]*privacy.Scalar, m)
+	l := make([]*privacy.Point, m)
+	for j := 0; j < m; j++ {
+		alpha[j] = privacy.RandomScalar()
+		l[j] = new(privacy.Point).ScalarMult(getBase(j), alpha[j])
+	}
+	c := make([]*privacy.Scalar, n)
+	r := make([][]*privacy.Scalar, n)
+	c[(mlsag.pi+1)%n] = challenge(message, l, new(privacy.Point).ScalarMult(hashedKey, alpha[0]))
+
+	for k := 1; k < n; k++ {
+		i := (mlsag.pi + k) % n
+		r[i] = make([]*privacy.Scalar, m)
+		for j := 0; j < m; j++ {
+			r[i][j] = privacy.RandomScalar()
+		}
+		l, keyImageR := computeL(mlsag.ring.keys[i], keyImage, r[i], c[i])
+		c[(i+1)%n] = challenge(message, l, keyImageR)
+	}
+
+	r[mlsag.pi] = make([]*privacy.Scalar, m)
+	for j := 0; j < m; j++ {
+		r[mlsag.pi][j] = new(privacy.Scalar).Sub(alpha[j], new(privacy.Scalar).Mul(c[mlsag.pi], mlsag.privateKeys[j]))
+	}
+	return &MlsagSig{c: c[0], keyImage: keyImage, r: r}, nil
+}
+
+// computeL returns L_j = r_j*Base_j + c*K_j for each row and R = r_0*Hp(K_0) + c*I
+func computeL(keys []*privacy.Point, keyImage *privacy.Point, r []*privacy.Scalar, c *privacy.Scalar) ([]*privacy.Point, *privacy.Point) {
+	l := make([]*privacy.Point, len(keys))
+	for j := range keys {
+		l[j] = new(privacy.Point).AddPedersen(r[j], getBase(j), c, keys[j])
+	}
+	hashedKey := privacy.HashToPoint(keys[0].ToBytesS())
+	keyImageR := new(privacy.Point).AddPedersen(r[0], hashedKey, c, keyImage)
+	return l, keyImageR
+}
+
+// Verify checks the signature on message with ring, the key image of signature must be checked for double spending by caller
+func Verify(sig *MlsagSig, ring *Ring, message []byte) (bool, error) {
+	n, m, err := checkRing(ring)
+	if err != nil {
+		return false, err
+	}
+	if sig == nil || sig.c == nil || sig.keyImage == nil || len(sig.r) != n {
+		return false, errors.New("invalid mlsag signature")
+	}
+	if !privacy.IsKeyImageValid(sig.keyImage) {
+		return false, errors.New("invalid key image of mlsag signature")
+	}
+	c := sig.c
+	for i := 0; i < n; i++ {
+		if len(sig.r[i]) != m {
+			return false, errors.New("invalid mlsag signature")
+		}
+		for j := 0; j < m; j++ {
+			if sig.r[i][j] == nil || !sig.r[i][j].ScalarValid() {
+				return false, errors.New("invalid mlsag signature")
+			}
+		}
+		l, keyImageR := computeL(ring.keys[i], sig.keyImage, sig.r[i], c)
+		c = challenge(message, l, keyImageR)
+	}
+	if !privacy.IsScalarEqual(c, sig.c) {
+		return false, errors.New("failed to verify mlsag signature")
+	}
+	return true, nil
+}
+
+// Bytes returns c || key image || r of all members, row by row
+func (sig MlsagSig) Bytes() []byte {
+	bytes := make([]byte, 0, privacy.Ed25519KeySize*(2+len(sig.r)*2))
+	bytes = append(bytes, sig.c.ToBytesS()...)
+	bytes = append(bytes, sig.keyImage.ToBytesS()...)
+	for i := range sig.r {
+		for j := range sig.r[i] {
+			bytes = append(bytes, sig.r[i][j].ToBytesS()...)
+		}
+	}
+	return bytes
+}
+
+// SetBytes parses signature of a ring with numRows rows
+func (sig *MlsagSig) SetBytes(bytes []byte, numRows int) error {
+	if numRows <= 0 || len(bytes) < 2*privacy.Ed25519KeySize || (len(bytes)-2*privacy.Ed25519KeySize)%(numRows*privacy.Ed25519KeySize) != 0 {
+		return errors.New("invalid length of mlsag signature bytes")
+	}
+	var err error
+	sig.c = new(privacy.Scalar).FromBytesS(bytes[:privacy.Ed25519KeySize])
+	sig.keyImage, err = new(privacy.Point).FromBytesS(bytes[privacy.Ed25519KeySize : 2*privacy.Ed25519KeySize])
+	if err != nil {
+		return err
+	}
+	offset := 2 * privacy.Ed25519KeySize
+	n := (len(bytes) - offset) / (numRows * privacy.Ed25519KeySize)
+	sig.r = make([][]*privacy.Scalar, n)
+	for i := 0; i < n; i++ {
+		sig.r[i] = make([]*privacy.Scalar, numRows)
+		for j := 0; j < numRows; j++ {
+			sig.r[i][j] = new(privacy.Scalar).FromBytesS(bytes[offset : offset+privacy.Ed25519KeySize])
+			offset += privacy.Ed25519KeySize
+		}
+	}
+	return nil
+}
+
+// SigSize returns size in bytes of signature of a ring with n members and m rows
+func SigSize(n int, m int) int {
+	return privacy.Ed25519KeySize * (2 + n*m)
+}
@@ -0,0 +1,62 @@
+package mlsag
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRing(n int, pi int, privateKeys []*privacy.Scalar) *Ring {
+	keys := make([][]*privacy.Point, n)
+	for i := 0; i < n; i++ {
+		keys[i] = make([]*privacy.Point, len(privateKeys))
+		for j := range privateKeys {
+			if i == pi {
+				keys[i][j] = new(privacy.Point).ScalarMult(getBase(j), privateKeys[j])
+			} else {
+				keys[i][j] = privacy.RandomPoint()
+			}
+		}
+	}
+	return NewRing(keys)
+}
+
+func TestMlsag(t *testing.T) {
+	n := privacy.CommitmentRingSize
+	for pi := 0; pi < n; pi++ {
+		privateKeys := []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()}
+		ring := newTestRing(n, pi, privateKeys)
+		message := []byte("message")
+
+		sig, err := NewMlsag(privateKeys, ring, pi).Sign(message)
+		assert.Nil(t, err)
+		assert.Equal(t, privacy.GenerateKeyImage(privateKeys[0]).ToBytesS(), sig.GetKeyImage().ToBytesS())
+
+		valid, err := Verify(sig, ring, message)
+		assert.True(t, valid)
+		assert.Nil(t, err)
+
+		valid, _ = Verify(sig, ring, []byte("other message"))
+		assert.False(t, valid)
+
+		otherRing := newTestRing(n, pi, []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()})
+		valid, _ = Verify(sig, otherRing, message)
+		assert.False(t, valid)
+
+		sigBytes := sig.Bytes()
+		assert.Equal(t, SigSize(n, 2), len(sigBytes))
+		parsedSig := new(MlsagSig)
+		assert.Nil(t, parsedSig.SetBytes(sigBytes, 2))
+		valid, err = Verify(parsedSig, ring, message)
+		assert.True(t, valid)
+		assert.Nil(t, err)
+	}
+}
+
+func TestMlsagWrongPrivateKey(t *testing.T) {
+	privateKeys := []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()}
+	ring := newTestRing(privacy.CommitmentRingSize, 3, privateKeys)
+	_, err := NewMlsag([]*privacy.Scalar{privacy.RandomScalar(), privateKeys[1]}, ring, 3).Sign([]byte("message"))
+	assert.NotNil(t, err)
+}
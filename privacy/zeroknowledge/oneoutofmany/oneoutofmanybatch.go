@@ -0,0 +1,115 @@
+package oneoutofmany
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/utils"
+)
+
+// VerifyBatch verifies a list of one-out-of-many proofs at once
+// Each statement of a proof is an equation which is equal to identity point,
+// all statements are weighted by random scalars and summed up, so the whole batch is checked by one multi-scalar multiplication.
+// If the batch is invalid, proofs are verified one by one to return index of the first invalid proof
+func VerifyBatch(proofs []*OneOutOfManyProof) (bool, error, int) {
+	if len(proofs) == 0 {
+		return true, nil, -1
+	}
+	n := privacy.CommitmentRingSizeExp
+	N := privacy.CommitmentRingSize
+
+	// coefficients of generators are accumulated for all proofs
+	gSKScalar := new(privacy.Scalar).FromUint64(0)
+	hScalar := new(privacy.Scalar).FromUint64(0)
+	scalars := make([]*privacy.Scalar, 0, len(proofs)*(4*n+N)+2)
+	points := make([]*privacy.Point, 0, len(proofs)*(4*n+N)+2)
+
+	for k, proof := range proofs {
+		if proof == nil || proof.Statement == nil || len(proof.Statement.Commitments) != N {
+			return false, errors.New("Invalid length of commitments list in one out of many proof"), k
+		}
+		if !proof.ValidateSanity() {
+			return false, errors.New("Invalid one out of many proof"), k
+		}
+		// a proof with a torsion component is verified alone, as random weights of the batch could clear
+		// the torsion component of a statement which fails the single verification
+		if !proof.isInPrimeOrderSubgroup() {
+			valid, err := proof.Verify()
+			if !valid {
+				return false, err, k
+			}
+			continue
+		}
+
+		x := new(privacy.Scalar).FromUint64(0)
+		for j := 0; j < n; j++ {
+			x = utils.GenerateChallenge([][]byte{x.ToBytesS(), proof.cl[j].ToBytesS(), proof.ca[j].ToBytesS(), proof.cb[j].ToBytesS(), proof.cd[j].ToBytesS()})
+		}
+
+		for i := 0; i < n; i++ {
+			// statement 1: cl^x * ca * G_sk^(-f) * H^(-za) = 1
+			// statement 2: cl^(x-f) * cb * H^(-zb) = 1
+			r1 := privacy.RandomScalar()
+			r2 := privacy.RandomScalar()
+			xSubF := new(privacy.Scalar).Sub(x, proof.f[i])
+
+			clScalar := new(privacy.Scalar).Mul(r1, x)
+			clScalar.Add(clScalar, new(privacy.Scalar).Mul(r2, xSubF))
+			scalars = append(scalars, clScalar, r1, r2)
+			points = append(points, proof.cl[i], proof.ca[i], proof.cb[i])
+
+			gSKScalar.Sub(gSKScalar, new(privacy.Scalar).Mul(r1, proof.f[i]))
+			hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r1, proof.za[i]))
+			hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r2, proof.zb[i]))
+		}
+
+		// statement 3: prod(C_i^exp_i) * prod(cd_k^(-x^k)) * H^(-zd) = 1
+		r3 := privacy.RandomScalar()
+		for i := 0; i < N; i++ {
+			iBinary := privacy.ConvertIntToBinary(i, n)
+			exp := new(privacy.Scalar).Set(r3)
+			for j := 0; j < n; j++ {
+				if iBinary[j] == 1 {
+					exp.Mul(exp, proof.f[j])
+				} else {
+					exp.Mul(exp, new(privacy.Scalar).Sub(x, proof.f[j]))
+				}
+			}
+			scalars = append(scalars, exp)
+			points = append(points, proof.Statement.Commitments[i])
+		}
+		xk := new(privacy.Scalar).Set(r3)
+		for j := 0; j < n; j++ {
+			scalars = append(scalars, new(privacy.Scalar).Sub(new(privacy.Scalar).FromUint64(0), xk))
+			points = append(points, proof.cd[j])
+			xk.Mul(xk, x)
+		}
+		hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r3, proof.zd))
+	}
+
+	scalars = append(scalars, gSKScalar, hScalar)
+	points = append(points, privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], privacy.PedCom.G[privacy.PedersenRandomnessIndex])
+	if new(privacy.Point).MultiScalarMult(scalars, points).IsIdentity() {
+		return true, nil, -1
+	}
+
+	for k, proof := range proofs {
+		valid, err := proof.Verify()
+		if !valid {
+			return false, err, k
+		}
+	}
+	return false, errors.New("verify batch of one out of many proofs failed"), -1
+}
+
+// isInPrimeOrderSubgroup returns true if all points of the proof and its statement have no torsion component
+func (proof OneOutOfManyProof) isInPrimeOrderSubgroup() bool {
+	for _, points := range [][]*privacy.Point{proof.cl, proof.ca, proof.cb, proof.cd, proof.Statement.Commitments} {
+		for _, point := range points {
+			if !point.IsInPrimeOrderSubgroup() {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package oneoutofmany
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOneOutOfManyProof(t testing.TB) *OneOutOfManyProof {
+	indexIsZero := int(common.RandInt() % privacy.CommitmentRingSize)
+	commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
+	randoms := make([]*privacy.Scalar, privacy.CommitmentRingSize)
+	for i := 0; i < privacy.CommitmentRingSize; i++ {
+		randoms[i] = privacy.RandomScalar()
+		commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), randoms[i], privacy.PedersenSndIndex)
+	}
+	commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+
+	witness := new(OneOutOfManyWitness)
+	witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+	proof, err := witness.Prove()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func newTestOneOutOfManyProofs(t testing.TB, numProofs int) []*OneOutOfManyProof {
+	proofs := make([]*OneOutOfManyProof, numProofs)
+	for i := range proofs {
+		proofs[i] = newTestOneOutOfManyProof(t)
+	}
+	return proofs
+}
+
+func TestVerifyBatch(t *testing.T) {
+	valid, err, _ := VerifyBatch(nil)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	proofs := newTestOneOutOfManyProofs(t, 10)
+	valid, err, _ = VerifyBatch(proofs)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	// a proof for other commitments is invalid
+	proofs[6].Statement.Commitments = newTestOneOutOfManyProof(t).Statement.Commitments
+	valid, err, index := VerifyBatch(proofs)
+	assert.False(t, valid)
+	assert.NotNil(t, err)
+	assert.Equal(t, 6, index)
+
+	// tampered response of proof is invalid
+	proofs = newTestOneOutOfManyProofs(t, 4)
+	proofs[2].zd = privacy.RandomScalar()
+	valid, _, index = VerifyBatch(proofs)
+	assert.False(t, valid)
+	assert.Equal(t, 2, index)
+}
+
+func benchmarkOneOutOfManyVerify(numProofs int, isBatch bool, b *testing.B) {
+	proofs := newTestOneOutOfManyProofs(b, numProofs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if isBatch {
+			VerifyBatch(proofs)
+			continue
+		}
+		for _, proof := range proofs {
+			proof.Verify()
+		}
+	}
+}
+
+func BenchmarkOneOutOfManyProof_Verify16(b *testing.B)      { benchmarkOneOutOfManyVerify(16, false, b) }
+func BenchmarkOneOutOfManyProof_VerifyBatch16(b *testing.B) { benchmarkOneOutOfManyVerify(16, true, b) }
+func BenchmarkOneOutOfManyProof_Verify128(b *testing.B)     { benchmarkOneOutOfManyVerify(128, false, b) }
+func BenchmarkOneOutOfManyProof_VerifyBatch128(b *testing.B) {
+	benchmarkOneOutOfManyVerify(128, true, b)
+}
+
+// torsionPointHex is a point of order 8
+const torsionPointHex = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+
+func TestVerifyBatchWithTorsionTaintedProof(t *testing.T) {
+	torsionBytes, _ := hex.DecodeString(torsionPointHex)
+	torsion, err := new(privacy.Point).FromBytesS(torsionBytes)
+	assert.Nil(t, err)
+
+	// a commitment of the ring tainted by a torsion point adds a torsion component to statement 3,
+	// random weights of the batch could clear it, so the batch must give the same result as the single verification
+	for i := 0; i < 16; i++ {
+		proofs := newTestOneOutOfManyProofs(t, 2)
+		proofs[1].Statement.Commitments[i%privacy.CommitmentRingSize] = new(privacy.Point).Add(proofs[1].Statement.Commitments[i%privacy.CommitmentRingSize], torsion)
+		expected, _ := proofs[1].Verify()
+		valid, _, index := VerifyBatch(proofs)
+		assert.Equal(t, expected, valid)
+		if !expected {
+			assert.Equal(t, 1, index)
+		}
+	}
+}
@@ -42,6 +42,10 @@ type PaymentProof struct {
 	commitmentInputShardID   *privacy.Point
 
 	commitmentIndices []uint64
+
+	// proof of privacy v2, see paymentv2.go
+	isPrivacyV2  bool
+	isConversion bool
 }
 
 // GET/SET function
@@ -179,7 +183,12 @@ func (proof *PaymentProof) UnmarshalJSON(data []byte) error {
 
 func (proof *PaymentProof) Bytes() []byte {
 	var bytes []byte
-	hasPrivacy := len(proof.oneOfManyProof) > 0
+	hasPrivacy := len(proof.oneOfManyProof) > 0 || proof.isPrivacyV2
+	if proof.isConversion {
+		bytes = append(bytes, privacyV2ConversionProofFlag)
+	} else if proof.isPrivacyV2 {
+		bytes = append(bytes, privacyV2ProofFlag)
+	}
 
 	// OneOfManyProofSize
 	bytes = append(bytes, byte(len(proof.oneOfManyProof)))
@@ -312,6 +321,11 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	}
 
 	offset := 0
+	proof.isConversion = proofbytes[0] == privacyV2ConversionProofFlag
+	proof.isPrivacyV2 = proofbytes[0] == privacyV2ProofFlag || proof.isConversion
+	if proof.isPrivacyV2 {
+		offset += 1
+	}
 
 	// Set OneOfManyProofSize
 	if offset >= len(proofbytes) {
@@ -630,8 +644,14 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	}
 
 	// get commitments list
-	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*privacy.CommitmentRingSize)
-	for i := 0; i < len(proof.oneOfManyProof)*privacy.CommitmentRingSize; i++ {
+	numCommitmentIndices := len(proof.oneOfManyProof) * privacy.CommitmentRingSize
+	if proof.isConversion {
+		numCommitmentIndices = 0
+	} else if proof.isPrivacyV2 {
+		numCommitmentIndices = len(proof.inputCoins) * privacy.CommitmentRingSize
+	}
+	proof.commitmentIndices = make([]uint64, numCommitmentIndices)
+	for i := 0; i < numCommitmentIndices; i++ {
 		if offset+common.Uint64Size > len(proofbytes) {
 			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, errors.New("Out of range commitment indices"))
 		}
@@ -646,39 +666,11 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 
 func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash) (bool, error) {
 	var sumInputValue, sumOutputValue uint64
-	sumInputValue = 0
 	sumOutputValue = 0
 
-	pubKeyLastByteSender := pubKey[len(pubKey)-1]
-	senderShardID := common.GetShardIDFromLastByte(pubKeyLastByteSender)
-	cmShardIDSender := new(privacy.Point)
-	cmShardIDSender.ScalarMult(privacy.PedCom.G[privacy.PedersenShardIDIndex], new(privacy.Scalar).FromBytes([privacy.Ed25519KeySize]byte{senderShardID}))
-
-	for i := 0; i < len(proof.inputCoins); i++ {
-		// Check input coins' Serial number is created from input coins' input and sender's spending key
-		valid, err := proof.serialNumberNoPrivacyProof[i].Verify(nil)
-		if !valid {
-			privacy.Logger.Log.Errorf("Verify serial number no privacy proof failed")
-			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberNoPrivacyProofFailedErr, err)
-		}
-
-		// Check input coins' cm is calculated correctly
-		cmSK := proof.inputCoins[i].CoinDetails.GetPublicKey()
-		cmValue := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenValueIndex], new(privacy.Scalar).FromUint64(proof.inputCoins[i].CoinDetails.GetValue()))
-		cmSND := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenSndIndex], proof.inputCoins[i].CoinDetails.GetSNDerivator())
-		cmRandomness := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenRandomnessIndex], proof.inputCoins[i].CoinDetails.GetRandomness())
-		cmTmp := new(privacy.Point).Add(cmSK, cmValue)
-		cmTmp.Add(cmTmp, cmSND)
-		cmTmp.Add(cmTmp, cmShardIDSender)
-		cmTmp.Add(cmTmp, cmRandomness)
-
-		if !privacy.IsPointEqual(cmTmp, proof.inputCoins[i].CoinDetails.GetCoinCommitment()) {
-			privacy.Logger.Log.Errorf("Input coins %v commitment wrong!\n", i)
-			return false, privacy.NewPrivacyErr(privacy.VerifyCoinCommitmentInputFailedErr, nil)
-		}
-
-		// Calculate sum of input values
-		sumInputValue += proof.inputCoins[i].CoinDetails.GetValue()
+	sumInputValue, err := proof.verifyInputCoinsNoPrivacy(pubKey)
+	if err != nil {
+		return false, err
 	}
 
 	for i := 0; i < len(proof.outputCoins); i++ {
@@ -733,6 +725,44 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 	return true, nil
 }
 
+// verifyInputCoinsNoPrivacy checks input coins which are revealed in proof, it returns sum of their values
+func (proof PaymentProof) verifyInputCoinsNoPrivacy(pubKey privacy.PublicKey) (uint64, error) {
+	sumInputValue := uint64(0)
+	pubKeyLastByteSender := pubKey[len(pubKey)-1]
+	senderShardID := common.GetShardIDFromLastByte(pubKeyLastByteSender)
+	cmShardIDSender := new(privacy.Point)
+	cmShardIDSender.ScalarMult(privacy.PedCom.G[privacy.PedersenShardIDIndex], new(privacy.Scalar).FromBytes([privacy.Ed25519KeySize]byte{senderShardID}))
+
+	for i := 0; i < len(proof.inputCoins); i++ {
+		// Check input coins' Serial number is created from input coins' input and sender's spending key
+		valid, err := proof.serialNumberNoPrivacyProof[i].Verify(nil)
+		if !valid {
+			privacy.Logger.Log.Errorf("Verify serial number no privacy proof failed")
+			return 0, privacy.NewPrivacyErr(privacy.VerifySerialNumberNoPrivacyProofFailedErr, err)
+		}
+
+		// Check input coins' cm is calculated correctly
+		cmSK := proof.inputCoins[i].CoinDetails.GetPublicKey()
+		cmValue := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenValueIndex], new(privacy.Scalar).FromUint64(proof.inputCoins[i].CoinDetails.GetValue()))
+		cmSND := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenSndIndex], proof.inputCoins[i].CoinDetails.GetSNDerivator())
+		cmRandomness := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenRandomnessIndex], proof.inputCoins[i].CoinDetails.GetRandomness())
+		cmTmp := new(privacy.Point).Add(cmSK, cmValue)
+		cmTmp.Add(cmTmp, cmSND)
+		cmTmp.Add(cmTmp, cmShardIDSender)
+		cmTmp.Add(cmTmp, cmRandomness)
+
+		if !privacy.IsPointEqual(cmTmp, proof.inputCoins[i].CoinDetails.GetCoinCommitment()) {
+			privacy.Logger.Log.Errorf("Input coins %v commitment wrong!\n", i)
+			return 0, privacy.NewPrivacyErr(privacy.VerifyCoinCommitmentInputFailedErr, nil)
+		}
+
+		// Calculate sum of input values
+		sumInputValue += proof.inputCoins[i].CoinDetails.GetValue()
+	}
+
+	return sumInputValue, nil
+}
+
 func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
 	// verify for input coins
 	cmInputSum := make([]*privacy.Point, len(proof.oneOfManyProof))
@@ -774,16 +804,19 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 
 		proof.oneOfManyProof[i].Statement.Commitments = commitments
 
-		valid, err := proof.oneOfManyProof[i].Verify()
-		if !valid {
-			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
-			return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
-		}
-		// Verify for the Proof that input coins' serial number is derived from the committed derivator
-		valid, err = proof.serialNumberProof[i].Verify(nil)
-		if !valid {
-			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Serial number privacy failed")
-			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, err)
+		// in batch mode, one out of many proofs and serial number proofs are verified with other txs by VerifyBatch
+		if isBatch == false {
+			valid, err := proof.oneOfManyProof[i].Verify()
+			if !valid {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
+				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+			}
+			// Verify for the Proof that input coins' serial number is derived from the committed derivator
+			valid, err = proof.serialNumberProof[i].Verify(nil)
+			if !valid {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Serial number privacy failed")
+				return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, err)
+			}
 		}
 	}
 
@@ -837,6 +870,10 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 }
 
 func (proof PaymentProof) Verify(hasPrivacy bool, pubKey privacy.PublicKey, fee uint64, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
+	// proof of privacy v2 is verified by VerifyV2
+	if proof.isPrivacyV2 {
+		return false, errors.New("payment proof v2 can not be verified as payment proof v1")
+	}
 	// has no privacy
 	if !hasPrivacy {
 		return proof.verifyNoPrivacy(pubKey, fee, stateDB, shardID, tokenID)
@@ -0,0 +1,276 @@
+package zkp
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumbernoprivacy"
+)
+
+// PaymentProof of privacy v2 hides sender by ring signatures, which are carried by tx, so it only contains:
+//   - inputCoins: serial number of each input coin is key image of its one-time private key
+//   - commitmentIndices: indices of CommitmentRingSize ring members of each input in the list of v2 coins
+//   - commitmentInputValue: pseudo commitment of value of each input coin, committed with a new randomness
+//   - outputCoins: coins sent to one-time addresses (see privacy.NewOutputCoinV2)
+//   - commitmentOutputValue: commitment of value of each output coin
+//   - aggregatedRangeProof: proof that output values are not negative
+//
+// Its bytes are prefixed by privacyV2ProofFlag, number of one out of many proofs of v1 proofs never reaches it
+const privacyV2ProofFlag = byte(0xff)
+
+// Conversion proof converts coins of v1 into coins of privacy v2, it contains:
+//   - inputCoins, serialNumberNoPrivacyProof: input coins of v1 which are revealed as in proofs without privacy
+//   - outputCoins, commitmentOutputValue, aggregatedRangeProof: output coins of privacy v2 as in PaymentProof of privacy v2,
+//     randomness of output coins sums up to zero, so the balance is checked with the revealed input values
+//
+// Its bytes are prefixed by privacyV2ConversionProofFlag
+const privacyV2ConversionProofFlag = byte(0xfe)
+
+func (proof PaymentProof) IsPrivacyV2() bool {
+	return proof.isPrivacyV2
+}
+
+func (proof PaymentProof) IsConversion() bool {
+	return proof.isConversion
+}
+
+// GetOutputAssetTag returns asset tag of output coins, which is nil if they are not confidential coins,
+// all output coins of a proof of privacy v2 have the same asset tag
+func (proof PaymentProof) GetOutputAssetTag() (*privacy.Point, error) {
+	if len(proof.outputCoins) == 0 {
+		return nil, nil
+	}
+	assetTag := privacy.GetAssetTag(proof.outputCoins[0].CoinDetails)
+	for _, outputCoin := range proof.outputCoins[1:] {
+		tag := privacy.GetAssetTag(outputCoin.CoinDetails)
+		if (tag == nil) != (assetTag == nil) || (tag != nil && !privacy.IsPointEqual(tag, assetTag)) {
+			return nil, errors.New("output coins must have the same asset tag")
+		}
+	}
+	return assetTag, nil
+}
+
+// ProveV2 creates a payment proof of privacy v2, value and randomness of all input coins and output coins must be set.
+// It returns randomness of pseudo commitments of inputs, which are needed to sign ring signatures of inputs
+func ProveV2(inputCoins []*privacy.InputCoin, outputCoins []*privacy.OutputCoin, commitmentIndices []uint64) (*PaymentProof, []*privacy.Scalar, error) {
+	if len(inputCoins) == 0 || len(outputCoins) == 0 {
+		return nil, nil, errors.New("payment proof v2 must have input coins and output coins")
+	}
+	if len(commitmentIndices) != len(inputCoins)*privacy.CommitmentRingSize {
+		return nil, nil, errors.New("invalid length of commitment indices")
+	}
+	proof := new(PaymentProof)
+	proof.Init()
+	proof.isPrivacyV2 = true
+	proof.inputCoins = inputCoins
+	proof.outputCoins = outputCoins
+	proof.commitmentIndices = commitmentIndices
+	proof.commitmentInputSecretKey = nil
+	proof.commitmentInputShardID = nil
+
+	outputValues := make([]uint64, len(outputCoins))
+	outputRands := make([]*privacy.Scalar, len(outputCoins))
+	sumOutputRand := new(privacy.Scalar).FromUint64(0)
+	for i, outputCoin := range outputCoins {
+		outputValues[i] = outputCoin.CoinDetails.GetValue()
+		outputRands[i] = outputCoin.CoinDetails.GetRandomness()
+		sumOutputRand.Add(sumOutputRand, outputRands[i])
+		proof.commitmentOutputValue = append(proof.commitmentOutputValue, privacy.CommitValueV2(outputValues[i], outputRands[i]))
+	}
+
+	// randomness of pseudo commitments sums up to randomness of outputs, so that the balance can be checked publicly
+	pseudoRands := make([]*privacy.Scalar, len(inputCoins))
+	sumPseudoRand := new(privacy.Scalar).FromUint64(0)
+	for i, inputCoin := range inputCoins {
+		if i < len(inputCoins)-1 {
+			pseudoRands[i] = privacy.RandomScalar()
+			sumPseudoRand.Add(sumPseudoRand, pseudoRands[i])
+		} else {
+			pseudoRands[i] = new(privacy.Scalar).Sub(sumOutputRand, sumPseudoRand)
+		}
+		proof.commitmentInputValue = append(proof.commitmentInputValue, privacy.CommitValueV2(inputCoin.CoinDetails.GetValue(), pseudoRands[i]))
+	}
+
+	wit := new(aggregaterange.AggregatedRangeWitness)
+	wit.Set(outputValues, outputRands)
+	var err error
+	proof.aggregatedRangeProof, err = wit.Prove()
+	if err != nil {
+		return nil, nil, privacy.NewPrivacyErr(privacy.ProveAggregatedRangeErr, err)
+	}
+	return proof, pseudoRands, nil
+}
+
+// VerifyV2 verifies a payment proof of privacy v2 except ring signatures of inputs,
+// in batch mode, range proof is verified with other txs
+func (proof PaymentProof) VerifyV2(fee uint64, isBatch bool) (bool, error) {
+	if !proof.isPrivacyV2 {
+		return false, errors.New("proof is not a payment proof of privacy v2")
+	}
+	if len(proof.inputCoins) == 0 || len(proof.outputCoins) == 0 {
+		return false, errors.New("payment proof v2 must have input coins and output coins")
+	}
+	if proof.isConversion {
+		return false, errors.New("conversion proof is verified by VerifyConversionV2")
+	}
+	if len(proof.oneOfManyProof) > 0 || len(proof.serialNumberProof) > 0 || len(proof.serialNumberNoPrivacyProof) > 0 {
+		return false, errors.New("payment proof v2 must not contain proofs of v1")
+	}
+	if len(proof.commitmentInputValue) != len(proof.inputCoins) || len(proof.commitmentIndices) != len(proof.inputCoins)*privacy.CommitmentRingSize {
+		return false, errors.New("invalid length of input commitments in payment proof v2")
+	}
+	for _, inputCoin := range proof.inputCoins {
+		if inputCoin.CoinDetails == nil || !privacy.IsKeyImageValid(inputCoin.CoinDetails.GetSerialNumber()) {
+			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, errors.New("invalid key image of input coin"))
+		}
+	}
+
+	valid, err := proof.verifyOutputCoinsV2(isBatch)
+	if !valid {
+		return false, err
+	}
+
+	// Verify that sum of pseudo commitments of inputs is equal to sum of commitments of outputs and fee
+	comInputValueSum := new(privacy.Point).Identity()
+	for _, comInputValue := range proof.commitmentInputValue {
+		comInputValueSum.Add(comInputValueSum, comInputValue)
+	}
+	if !privacy.IsPointEqual(comInputValueSum, proof.sumOutputCommitmentsV2(fee)) {
+		privacy.Logger.Log.Error("VERIFICATION PAYMENT PROOF V2: Sum of input coins' value is not equal to sum of output coins' value")
+		return false, privacy.NewPrivacyErr(privacy.VerifyAmountPrivacyFailedErr, nil)
+	}
+	return true, nil
+}
+
+// verifyOutputCoinsV2 checks output coins of privacy v2 and their range proof, which is skipped in batch mode
+func (proof PaymentProof) verifyOutputCoinsV2(isBatch bool) (bool, error) {
+	if len(proof.commitmentOutputValue) != len(proof.outputCoins) || proof.aggregatedRangeProof == nil || len(proof.aggregatedRangeProof.GetCmValues()) != len(proof.outputCoins) {
+		return false, errors.New("invalid length of output commitments in payment proof v2")
+	}
+	for i, outputCoin := range proof.outputCoins {
+		if !privacy.IsOutputCoinV2(outputCoin.CoinDetails) || outputCoin.CoinDetails.GetValue() != 0 || outputCoin.CoinDetails.GetRandomness() != nil {
+			return false, errors.New("invalid output coin of payment proof v2")
+		}
+		if !privacy.IsPointEqual(proof.commitmentOutputValue[i], outputCoin.CoinDetails.GetCoinCommitment()) ||
+			!privacy.IsPointEqual(proof.commitmentOutputValue[i], proof.aggregatedRangeProof.GetCmValues()[i]) {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF V2: Commitment for output coins are not computed correctly")
+			return false, privacy.NewPrivacyErr(privacy.VerifyCoinCommitmentOutputFailedErr, nil)
+		}
+	}
+	if _, err := proof.GetOutputAssetTag(); err != nil {
+		return false, err
+	}
+
+	// Verify the proof that output values and sum of them do not exceed v_max
+	if !isBatch {
+		valid, err := proof.aggregatedRangeProof.Verify()
+		if !valid {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF V2: Multi-range failed")
+			return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+		}
+	}
+	return true, nil
+}
+
+// sumOutputCommitmentsV2 returns sum of commitments of outputs and fee
+func (proof PaymentProof) sumOutputCommitmentsV2(fee uint64) *privacy.Point {
+	comOutputValueSum := new(privacy.Point).Identity()
+	for _, comOutputValue := range proof.commitmentOutputValue {
+		comOutputValueSum.Add(comOutputValueSum, comOutputValue)
+	}
+	if fee > 0 {
+		comOutputValueSum.Add(comOutputValueSum, new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenValueIndex], new(privacy.Scalar).FromUint64(fee)))
+	}
+	return comOutputValueSum
+}
+
+// ProveConversionV2 creates a conversion proof, input coins must be fully revealed with their serial numbers,
+// value and randomness of output coins must be set and randomness of output coins must sum up to zero
+func ProveConversionV2(inputCoins []*privacy.InputCoin, outputCoins []*privacy.OutputCoin, privateKey *privacy.Scalar) (*PaymentProof, error) {
+	if len(inputCoins) == 0 || len(outputCoins) == 0 {
+		return nil, errors.New("conversion proof must have input coins and output coins")
+	}
+	proof := new(PaymentProof)
+	proof.Init()
+	proof.isPrivacyV2 = true
+	proof.isConversion = true
+	proof.inputCoins = inputCoins
+	proof.outputCoins = outputCoins
+	proof.commitmentInputSecretKey = nil
+	proof.commitmentInputShardID = nil
+
+	publicKey := new(privacy.Point).ScalarMultBase(privateKey)
+	for _, inputCoin := range inputCoins {
+		wit := new(serialnumbernoprivacy.SNNoPrivacyWitness)
+		wit.Set(inputCoin.CoinDetails.GetSerialNumber(), publicKey, inputCoin.CoinDetails.GetSNDerivator(), privateKey)
+		snNoPrivacyProof, err := wit.Prove(nil)
+		if err != nil {
+			return nil, privacy.NewPrivacyErr(privacy.ProveSerialNumberNoPrivacyErr, err)
+		}
+		proof.serialNumberNoPrivacyProof = append(proof.serialNumberNoPrivacyProof, snNoPrivacyProof)
+	}
+
+	outputValues := make([]uint64, len(outputCoins))
+	outputRands := make([]*privacy.Scalar, len(outputCoins))
+	for i, outputCoin := range outputCoins {
+		outputValues[i] = outputCoin.CoinDetails.GetValue()
+		outputRands[i] = outputCoin.CoinDetails.GetRandomness()
+		proof.commitmentOutputValue = append(proof.commitmentOutputValue, privacy.CommitValueV2(outputValues[i], outputRands[i]))
+	}
+	wit := new(aggregaterange.AggregatedRangeWitness)
+	wit.Set(outputValues, outputRands)
+	var err error
+	proof.aggregatedRangeProof, err = wit.Prove()
+	if err != nil {
+		return nil, privacy.NewPrivacyErr(privacy.ProveAggregatedRangeErr, err)
+	}
+	return proof, nil
+}
+
+// VerifyConversionV2 verifies a conversion proof of sender whose public key is pubKey,
+// in batch mode, range proof is verified with other txs
+func (proof PaymentProof) VerifyConversionV2(pubKey privacy.PublicKey, fee uint64, isBatch bool) (bool, error) {
+	if !proof.isConversion {
+		return false, errors.New("proof is not a conversion proof")
+	}
+	if len(proof.inputCoins) == 0 || len(proof.outputCoins) == 0 {
+		return false, errors.New("conversion proof must have input coins and output coins")
+	}
+	if len(proof.oneOfManyProof) > 0 || len(proof.serialNumberProof) > 0 || len(proof.serialNumberNoPrivacyProof) != len(proof.inputCoins) ||
+		len(proof.commitmentInputValue) > 0 || len(proof.commitmentIndices) > 0 {
+		return false, errors.New("invalid input proofs of conversion proof")
+	}
+	senderPublicKey, err := new(privacy.Point).FromBytesS(pubKey)
+	if err != nil {
+		return false, err
+	}
+	// serial number proofs must be about the input coins of sender
+	for i, inputCoin := range proof.inputCoins {
+		snProof := proof.serialNumberNoPrivacyProof[i]
+		if inputCoin.CoinDetails == nil || inputCoin.CoinDetails.GetPublicKey() == nil || inputCoin.CoinDetails.GetSerialNumber() == nil || inputCoin.CoinDetails.GetSNDerivator() == nil ||
+			!privacy.IsPointEqual(inputCoin.CoinDetails.GetPublicKey(), senderPublicKey) ||
+			!privacy.IsPointEqual(snProof.GetVKey(), senderPublicKey) ||
+			!privacy.IsPointEqual(snProof.GetOutput(), inputCoin.CoinDetails.GetSerialNumber()) ||
+			!privacy.IsScalarEqual(snProof.GetInput(), inputCoin.CoinDetails.GetSNDerivator()) {
+			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberNoPrivacyProofFailedErr, errors.New("serial number proof does not match input coin"))
+		}
+	}
+	sumInputValue, err := proof.verifyInputCoinsNoPrivacy(pubKey)
+	if err != nil {
+		return false, err
+	}
+
+	valid, err := proof.verifyOutputCoinsV2(isBatch)
+	if !valid {
+		return false, err
+	}
+
+	// Verify that sum of commitments of outputs and fee commits to sum of input values with zero randomness
+	comInputValueSum := new(privacy.Point).ScalarMult(privacy.PedCom.G[privacy.PedersenValueIndex], new(privacy.Scalar).FromUint64(sumInputValue))
+	if !privacy.IsPointEqual(comInputValueSum, proof.sumOutputCommitmentsV2(fee)) {
+		privacy.Logger.Log.Error("VERIFICATION CONVERSION PROOF: Sum of input coins' value is not equal to sum of output coins' value")
+		return false, privacy.NewPrivacyErr(privacy.VerifyAmountPrivacyFailedErr, nil)
+	}
+	return true, nil
+}
@@ -259,3 +259,26 @@ func (pro SNNoPrivacyProof) Verify(mess []byte) (bool, error) {
 
 	return true, nil
 }
+
+// ThresholdChallenge returns challenge of a serial number proof whose nonces tSeed = eSK*G and tOutput = eSK*sn
+// are aggregated from nonces of threshold signers, each signer responds eSK_j + x*lambda_j*SK_j
+func ThresholdChallenge(tSeed *privacy.Point, tOutput *privacy.Point) *privacy.Scalar {
+	return utils.GenerateChallenge([][]byte{tSeed.ToBytesS(), tOutput.ToBytesS()})
+}
+
+// NewThresholdSNNoPrivacyProof aggregates responses of threshold signers into a serial number proof
+func NewThresholdSNNoPrivacyProof(
+	output *privacy.Point,
+	vKey *privacy.Point,
+	input *privacy.Scalar,
+	tSeed *privacy.Point,
+	tOutput *privacy.Point,
+	responses []*privacy.Scalar) *SNNoPrivacyProof {
+	zSeed := new(privacy.Scalar).FromUint64(0)
+	for _, response := range responses {
+		zSeed.Add(zSeed, response)
+	}
+	proof := new(SNNoPrivacyProof).Init()
+	proof.Set(output, vKey, input, tSeed, tOutput, zSeed)
+	return proof
+}
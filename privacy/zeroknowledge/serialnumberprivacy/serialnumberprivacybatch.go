@@ -0,0 +1,92 @@
+package serialnumberprivacy
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/utils"
+)
+
+// VerifyBatch verifies a list of serial number privacy proofs, whose challenges are generated from their own data, at once
+// Three statements of each proof are weighted by random scalars and summed up,
+// so the whole batch is checked by one multi-scalar multiplication.
+// If the batch is invalid, proofs are verified one by one to return index of the first invalid proof
+func VerifyBatch(proofs []*SNPrivacyProof) (bool, error, int) {
+	if len(proofs) == 0 {
+		return true, nil, -1
+	}
+
+	// coefficients of generators are accumulated for all proofs
+	gSKScalar := new(privacy.Scalar).FromUint64(0)
+	gSNDScalar := new(privacy.Scalar).FromUint64(0)
+	hScalar := new(privacy.Scalar).FromUint64(0)
+	scalars := make([]*privacy.Scalar, 0, len(proofs)*6+3)
+	points := make([]*privacy.Point, 0, len(proofs)*6+3)
+
+	for k, proof := range proofs {
+		if proof == nil || proof.stmt == nil || proof.stmt.sn == nil || proof.stmt.comSK == nil || proof.stmt.comInput == nil ||
+			proof.tSK == nil || proof.tInput == nil || proof.tSN == nil ||
+			proof.zSK == nil || proof.zRSK == nil || proof.zInput == nil || proof.zRInput == nil {
+			return false, errors.New("Invalid serial number privacy proof"), k
+		}
+		// a proof with a torsion component is verified alone, as random weights of the batch could clear
+		// the torsion component of a statement which fails the single verification
+		if !proof.isInPrimeOrderSubgroup() {
+			valid, err := proof.Verify(nil)
+			if !valid {
+				return false, err, k
+			}
+			continue
+		}
+
+		x := utils.GenerateChallenge([][]byte{
+			proof.tSK.ToBytesS(),
+			proof.tInput.ToBytesS(),
+			proof.tSN.ToBytesS()})
+		r1 := privacy.RandomScalar()
+		r2 := privacy.RandomScalar()
+		r3 := privacy.RandomScalar()
+		zero := new(privacy.Scalar).FromUint64(0)
+
+		// statement 1: G_snd^zInput * H^zRInput * comInput^(-x) * tInput^(-1) = 1
+		gSNDScalar.Add(gSNDScalar, new(privacy.Scalar).Mul(r1, proof.zInput))
+		hScalar.Add(hScalar, new(privacy.Scalar).Mul(r1, proof.zRInput))
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, new(privacy.Scalar).Mul(r1, x)), new(privacy.Scalar).Sub(zero, r1))
+		points = append(points, proof.stmt.comInput, proof.tInput)
+
+		// statement 2: G_sk^zSK * H^zRSK * comSK^(-x) * tSK^(-1) = 1
+		gSKScalar.Add(gSKScalar, new(privacy.Scalar).Mul(r2, proof.zSK))
+		hScalar.Add(hScalar, new(privacy.Scalar).Mul(r2, proof.zRSK))
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, new(privacy.Scalar).Mul(r2, x)), new(privacy.Scalar).Sub(zero, r2))
+		points = append(points, proof.stmt.comSK, proof.tSK)
+
+		// statement 3: sn^(zSK + zInput) * G_sk^(-x) * tSN^(-1) = 1
+		gSKScalar.Sub(gSKScalar, new(privacy.Scalar).Mul(r3, x))
+		scalars = append(scalars, new(privacy.Scalar).Mul(r3, new(privacy.Scalar).Add(proof.zSK, proof.zInput)), new(privacy.Scalar).Sub(zero, r3))
+		points = append(points, proof.stmt.sn, proof.tSN)
+	}
+
+	scalars = append(scalars, gSKScalar, gSNDScalar, hScalar)
+	points = append(points, privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], privacy.PedCom.G[privacy.PedersenSndIndex], privacy.PedCom.G[privacy.PedersenRandomnessIndex])
+	if new(privacy.Point).MultiScalarMult(scalars, points).IsIdentity() {
+		return true, nil, -1
+	}
+
+	for k, proof := range proofs {
+		valid, err := proof.Verify(nil)
+		if !valid {
+			return false, err, k
+		}
+	}
+	return false, errors.New("verify batch of serial number privacy proofs failed"), -1
+}
+
+// isInPrimeOrderSubgroup returns true if all points of the proof and its statement have no torsion component
+func (proof SNPrivacyProof) isInPrimeOrderSubgroup() bool {
+	for _, point := range []*privacy.Point{proof.stmt.sn, proof.stmt.comSK, proof.stmt.comInput, proof.tSK, proof.tInput, proof.tSN} {
+		if !point.IsInPrimeOrderSubgroup() {
+			return false
+		}
+	}
+	return true
+}
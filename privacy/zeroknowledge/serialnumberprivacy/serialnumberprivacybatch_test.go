@@ -0,0 +1,107 @@
+package serialnumberprivacy
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	privacy.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func newTestSNPrivacyProof(t testing.TB) *SNPrivacyProof {
+	skScalar := new(privacy.Scalar).FromBytesS(privacy.GeneratePrivateKey(privacy.RandBytes(31)))
+	SND := privacy.RandomScalar()
+	rSK := privacy.RandomScalar()
+	rSND := privacy.RandomScalar()
+
+	stmt := new(SerialNumberPrivacyStatement)
+	stmt.Set(new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], skScalar, SND),
+		privacy.PedCom.CommitAtIndex(skScalar, rSK, privacy.PedersenPrivateKeyIndex),
+		privacy.PedCom.CommitAtIndex(SND, rSND, privacy.PedersenSndIndex))
+	witness := new(SNPrivacyWitness)
+	witness.Set(stmt, skScalar, rSK, SND, rSND)
+	proof, err := witness.Prove(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func newTestSNPrivacyProofs(t testing.TB, numProofs int) []*SNPrivacyProof {
+	proofs := make([]*SNPrivacyProof, numProofs)
+	for i := range proofs {
+		proofs[i] = newTestSNPrivacyProof(t)
+	}
+	return proofs
+}
+
+func TestVerifyBatch(t *testing.T) {
+	valid, err, _ := VerifyBatch(nil)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	proofs := newTestSNPrivacyProofs(t, 10)
+	valid, err, _ = VerifyBatch(proofs)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	// proof of other serial number is invalid
+	proofs[3].stmt.sn = privacy.RandomPoint()
+	valid, err, index := VerifyBatch(proofs)
+	assert.False(t, valid)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, index)
+
+	proofs = newTestSNPrivacyProofs(t, 4)
+	proofs[1].zRSK = privacy.RandomScalar()
+	valid, _, index = VerifyBatch(proofs)
+	assert.False(t, valid)
+	assert.Equal(t, 1, index)
+}
+
+func benchmarkSNPrivacyVerify(numProofs int, isBatch bool, b *testing.B) {
+	proofs := newTestSNPrivacyProofs(b, numProofs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if isBatch {
+			VerifyBatch(proofs)
+			continue
+		}
+		for _, proof := range proofs {
+			proof.Verify(nil)
+		}
+	}
+}
+
+func BenchmarkSNPrivacyProof_Verify16(b *testing.B)       { benchmarkSNPrivacyVerify(16, false, b) }
+func BenchmarkSNPrivacyProof_VerifyBatch16(b *testing.B)  { benchmarkSNPrivacyVerify(16, true, b) }
+func BenchmarkSNPrivacyProof_Verify128(b *testing.B)      { benchmarkSNPrivacyVerify(128, false, b) }
+func BenchmarkSNPrivacyProof_VerifyBatch128(b *testing.B) { benchmarkSNPrivacyVerify(128, true, b) }
+
+// torsionPointHex is a point of order 8
+const torsionPointHex = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+
+func TestVerifyBatchWithTorsionTaintedProof(t *testing.T) {
+	torsionBytes, _ := hex.DecodeString(torsionPointHex)
+	torsion, err := new(privacy.Point).FromBytesS(torsionBytes)
+	assert.Nil(t, err)
+
+	// a serial number tainted by a torsion point adds a torsion component to statement 3,
+	// random weights of the batch could clear it, so the batch must give the same result as the single verification
+	for i := 0; i < 16; i++ {
+		proofs := []*SNPrivacyProof{newTestSNPrivacyProof(t), newTestSNPrivacyProof(t)}
+		proofs[1].stmt.sn = new(privacy.Point).Add(proofs[1].stmt.sn, torsion)
+		expected, _ := proofs[1].Verify(nil)
+		valid, _, index := VerifyBatch(proofs)
+		assert.Equal(t, expected, valid)
+		if !expected {
+			assert.Equal(t, 1, index)
+		}
+	}
+}
@@ -17,6 +17,8 @@ const (
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
 	TestTopic                       = "testtopic"
+	CoinScannerTopic                = "coinscannertopic"
+	ValidatorPerformanceTopic       = "validatorperformancetopic"
 )
 
 var Topics = []string{
@@ -33,4 +35,6 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	CoinScannerTopic,
+	ValidatorPerformanceTopic,
 }
@@ -0,0 +1,168 @@
+package bnb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+	bnbtx "github.com/binance-chain/go-sdk/types/tx"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/relaying"
+)
+
+// HeaderRetriever gets headers of BNB chain, blockchain gets them from a BNB fullnode
+type HeaderRetriever interface {
+	GetBNBDataHash(blockHeight int64) ([]byte, error)
+	GetLatestBNBBlkHeight() (int64, error)
+}
+
+// PortingMemo is the memo of a BNB tx which ports public tokens
+type PortingMemo struct {
+	PortingID string `json:"PortingID"`
+}
+
+// RedeemMemo is the memo of a BNB tx which returns public tokens to redeemer, it is hashed in memo
+type RedeemMemo struct {
+	RedeemID                  string `json:"RedeemID"`
+	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
+}
+
+// PortalChain is BNB chain for portal, it verifies txs with headers of HeaderRetriever
+type PortalChain struct {
+	headerRetriever HeaderRetriever
+	tokenID         string
+	chainID         string
+}
+
+// PortalTx is a BNB tx with a verified inclusion proof
+type PortalTx struct {
+	tx      *bnbtx.StdTx
+	chainID string
+}
+
+func NewPortalChain(headerRetriever HeaderRetriever, tokenID string, chainID string) *PortalChain {
+	return &PortalChain{
+		headerRetriever: headerRetriever,
+		tokenID:         tokenID,
+		chainID:         chainID,
+	}
+}
+
+func (p *PortalChain) GetTokenID() string {
+	return p.tokenID
+}
+
+func (p *PortalChain) GetChainID() string {
+	return p.chainID
+}
+
+// ProcessHeader does nothing, txs are verified with headers of BNB fullnode instead of relayed headers
+func (p *PortalChain) ProcessHeader(header string, blockHeight uint64) error {
+	return nil
+}
+
+func (p *PortalChain) IsValidRemoteAddress(address string) bool {
+	return IsValidBNBAddress(address, p.chainID)
+}
+
+// ParseAndVerifyTxProof parses a base64 encoded BNBProof, it is valid if its block has MinConfirmationsBlock confirmations
+// and the proof is valid with data hash of the block
+func (p *PortalChain) ParseAndVerifyTxProof(proof string) (relaying.RelayingTx, error) {
+	txProofBNB, err := ParseBNBProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, fmt.Errorf("BNB proof is invalid %v", err)
+	}
+
+	// check minimum confirmations block of bnb proof
+	latestBNBBlockHeight, err2 := p.headerRetriever.GetLatestBNBBlkHeight()
+	if err2 != nil {
+		return nil, fmt.Errorf("Can not get latest relaying bnb block height %v", err2)
+	}
+	if latestBNBBlockHeight < txProofBNB.BlockHeight+MinConfirmationsBlock {
+		return nil, fmt.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v",
+			MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+	}
+	dataHash, err2 := p.headerRetriever.GetBNBDataHash(txProofBNB.BlockHeight)
+	if err2 != nil {
+		return nil, fmt.Errorf("Error when get data hash in blockHeight %v - %v", txProofBNB.BlockHeight, err2)
+	}
+	isValid, err := txProofBNB.Verify(dataHash)
+	if !isValid || err != nil {
+		return nil, fmt.Errorf("Verify txProofBNB failed %v", err)
+	}
+
+	// parse Tx from Data in txProofBNB
+	txBNB, err := ParseTxFromData(txProofBNB.Proof.Data)
+	if err != nil {
+		return nil, fmt.Errorf("Data in BNB proof is invalid %v", err)
+	}
+	if len(txBNB.Msgs) == 0 {
+		return nil, errors.New("BNB tx has no message")
+	}
+	if _, ok := txBNB.Msgs[0].(msg.SendMsg); !ok {
+		return nil, errors.New("BNB tx is not a send tx")
+	}
+	return &PortalTx{tx: txBNB, chainID: p.chainID}, nil
+}
+
+func (p *PortalChain) ConvertIncAmountToExternalAmount(incAmount uint64) uint64 {
+	return uint64(ConvertIncPBNBAmountToExternalBNBAmount(int64(incAmount)))
+}
+
+// ConvertIncPBNBAmountToExternalBNBAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
+func ConvertIncPBNBAmountToExternalBNBAmount(incPBNBAmount int64) int64 {
+	return incPBNBAmount / 10 // incPBNBAmount / 1^9 * 1^8
+}
+
+// IsPortingMemo checks memo of tx, it is base64 encoded json of PortingMemo
+func (t *PortalTx) IsPortingMemo(portingID string) bool {
+	memoBytes, err := base64.StdEncoding.DecodeString(t.tx.Memo)
+	if err != nil {
+		Logger.log.Errorf("Can not decode memo in tx bnb proof %v", err)
+		return false
+	}
+	var portingMemo PortingMemo
+	err = json.Unmarshal(memoBytes, &portingMemo)
+	if err != nil {
+		Logger.log.Errorf("Can not unmarshal memo in tx bnb proof %v", err)
+		return false
+	}
+	return portingMemo.PortingID == portingID
+}
+
+// IsRedeemMemo checks memo of tx, it is base64 encoded hash of json of RedeemMemo
+func (t *PortalTx) IsRedeemMemo(redeemID string, custodianAddress string) bool {
+	memoHashBytes, err := base64.StdEncoding.DecodeString(t.tx.Memo)
+	if err != nil {
+		Logger.log.Errorf("Can not decode memo in tx bnb proof %v", err)
+		return false
+	}
+	expectedRedeemMemo := RedeemMemo{
+		RedeemID:                  redeemID,
+		CustodianIncognitoAddress: custodianAddress,
+	}
+	expectedRedeemMemoBytes, _ := json.Marshal(expectedRedeemMemo)
+	return bytes.Equal(memoHashBytes, common.HashB(expectedRedeemMemoBytes))
+}
+
+// GetAmountTo sums amounts of BNB coins in the first output to address
+func (t *PortalTx) GetAmountTo(address string) (uint64, bool) {
+	outputs := t.tx.Msgs[0].(msg.SendMsg).Outputs
+	for _, out := range outputs {
+		addr, _ := GetAccAddressString(&out.Address, t.chainID)
+		if addr != address {
+			continue
+		}
+		amountTransfer := int64(0)
+		for _, coin := range out.Coins {
+			if coin.Denom == DenomBNB {
+				amountTransfer += coin.Amount
+			}
+		}
+		return uint64(amountTransfer), true
+	}
+	return 0, false
+}
@@ -0,0 +1,46 @@
+package bnb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	bnbtx "github.com/binance-chain/go-sdk/types/tx"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortalTx(t *testing.T) {
+	receiver, err := types.GetFromBech32("tbnb1fau9kq605jwkyfea2knw495we8cpa47r9r6uxv", types.TestNetwork.Bech32Prefixes())
+	assert.Nil(t, err)
+	sendMsg := msg.SendMsg{
+		Outputs: []msg.Output{
+			{Address: receiver, Coins: types.Coins{{Denom: DenomBNB, Amount: 100}, {Denom: "ABC", Amount: 5}}},
+		},
+	}
+
+	portingMemoBytes, _ := json.Marshal(PortingMemo{PortingID: "porting1"})
+	portingTx := &PortalTx{
+		tx:      &bnbtx.StdTx{Msgs: []msg.Msg{sendMsg}, Memo: base64.StdEncoding.EncodeToString(portingMemoBytes)},
+		chainID: TestnetBNBChainID,
+	}
+	assert.True(t, portingTx.IsPortingMemo("porting1"))
+	assert.False(t, portingTx.IsPortingMemo("porting2"))
+	assert.False(t, portingTx.IsRedeemMemo("porting1", "custodian1"))
+
+	amount, ok := portingTx.GetAmountTo("tbnb1fau9kq605jwkyfea2knw495we8cpa47r9r6uxv")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), amount)
+	_, ok = portingTx.GetAmountTo("tbnb1ywqvle9ppd9w8hq6d8y3e4mxx3a8j0uat5dnwm")
+	assert.False(t, ok)
+
+	redeemMemoBytes, _ := json.Marshal(RedeemMemo{RedeemID: "redeem1", CustodianIncognitoAddress: "custodian1"})
+	redeemTx := &PortalTx{
+		tx:      &bnbtx.StdTx{Msgs: []msg.Msg{sendMsg}, Memo: base64.StdEncoding.EncodeToString(common.HashB(redeemMemoBytes))},
+		chainID: TestnetBNBChainID,
+	}
+	assert.True(t, redeemTx.IsRedeemMemo("redeem1", "custodian1"))
+	assert.False(t, redeemTx.IsRedeemMemo("redeem1", "custodian2"))
+}
@@ -0,0 +1,130 @@
+package btcrelaying
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/incognitochain/incognito-chain/relaying"
+)
+
+// PortalChain is BTC chain for portal, it verifies txs with headers of BTC header chain
+type PortalChain struct {
+	btcChain *BlockChain
+	tokenID  string
+	chainID  string
+}
+
+// PortalTx is a BTC tx with a verified inclusion proof
+type PortalTx struct {
+	btcChain *BlockChain
+	tx       *wire.MsgTx
+}
+
+func NewPortalChain(btcChain *BlockChain, tokenID string, chainID string) *PortalChain {
+	return &PortalChain{
+		btcChain: btcChain,
+		tokenID:  tokenID,
+		chainID:  chainID,
+	}
+}
+
+func (p *PortalChain) GetTokenID() string {
+	return p.tokenID
+}
+
+func (p *PortalChain) GetChainID() string {
+	return p.chainID
+}
+
+func (p *PortalChain) GetBTCChain() *BlockChain {
+	return p.btcChain
+}
+
+// ProcessHeader adds a BTC block to BTC header chain, header is a base64 encoded json of the block
+func (p *PortalChain) ProcessHeader(header string, blockHeight uint64) error {
+	if p.btcChain == nil {
+		return errors.New("BTC relaying chain should not be null")
+	}
+	headerBytes, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return err
+	}
+	var msgBlk *wire.MsgBlock
+	err = json.Unmarshal(headerBytes, &msgBlk)
+	if err != nil {
+		return err
+	}
+	block := btcutil.NewBlock(msgBlk)
+	isMainChain, isOrphan, err := p.btcChain.ProcessBlockV2(block, BFNone)
+	if err != nil {
+		return fmt.Errorf("ProcessBlock fail with error: %v", err)
+	}
+	Logger.log.Infof("ProcessBlock (%s) success with result: isMainChain: %v, isOrphan: %v", block.Hash(), isMainChain, isOrphan)
+	return nil
+}
+
+func (p *PortalChain) IsValidRemoteAddress(address string) bool {
+	if p.btcChain == nil {
+		return false
+	}
+	return p.btcChain.IsBTCAddressValid(address)
+}
+
+// ParseAndVerifyTxProof parses a base64 encoded BTCProof and verifies its merkle proofs
+func (p *PortalChain) ParseAndVerifyTxProof(proof string) (relaying.RelayingTx, error) {
+	if p.btcChain == nil {
+		return nil, errors.New("BTC relaying chain should not be null")
+	}
+	btcTxProof, err := ParseBTCProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, fmt.Errorf("BTC proof is invalid %v", err)
+	}
+	isValid, err := p.btcChain.VerifyTxWithMerkleProofs(btcTxProof)
+	if !isValid || err != nil {
+		return nil, fmt.Errorf("Verify btcTxProof failed %v", err)
+	}
+	return &PortalTx{btcChain: p.btcChain, tx: btcTxProof.BTCTx}, nil
+}
+
+func (p *PortalChain) ConvertIncAmountToExternalAmount(incAmount uint64) uint64 {
+	return uint64(ConvertIncPBTCAmountToExternalBTCAmount(int64(incAmount)))
+}
+
+// IsPortingMemo checks the message attached in OP_RETURN output of tx
+func (t *PortalTx) IsPortingMemo(portingID string) bool {
+	attachedMsg, err := ExtractAttachedMsgFromTx(t.tx)
+	if err != nil {
+		Logger.log.Errorf("Could not extract attached message from BTC tx proof with err: %v", err)
+		return false
+	}
+	return attachedMsg == HashAndEncodeBase58(portingID)
+}
+
+// IsRedeemMemo checks the message attached in OP_RETURN output of tx, it is the hash of redeemID and custodianAddress
+func (t *PortalTx) IsRedeemMemo(redeemID string, custodianAddress string) bool {
+	attachedMsg, err := ExtractAttachedMsgFromTx(t.tx)
+	if err != nil {
+		Logger.log.Errorf("Could not extract attached message from BTC tx proof with err: %v", err)
+		return false
+	}
+	return attachedMsg == HashAndEncodeBase58(fmt.Sprintf("%s%s", redeemID, custodianAddress))
+}
+
+func (t *PortalTx) GetAmountTo(address string) (uint64, bool) {
+	for _, out := range t.tx.TxOut {
+		addrStr, err := t.btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		if err != nil {
+			Logger.log.Warnf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
+			continue
+		}
+		if addrStr != address {
+			continue
+		}
+		return uint64(out.Value), true
+	}
+	return 0, false
+}
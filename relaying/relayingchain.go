@@ -0,0 +1,35 @@
+// Package relaying defines external chains which portal supports.
+//
+// A new chain is added by implementing RelayingChain in its own package (as relaying/btc and relaying/bnb do),
+// adding the incognito token id of its public token to common.PortalSupportedIncTokenIDs and common.MinAmountPortalPToken,
+// and registering it in BlockChain.initRelayingChains with the metadata type of its relaying header txs.
+package relaying
+
+// RelayingChain is an external chain of which headers are relayed to incognito chain,
+// portal verifies porting and redeem txs on the chain with it
+type RelayingChain interface {
+	// GetTokenID returns incognito token id of the public token of the chain
+	GetTokenID() string
+	// GetChainID returns id of the network of the chain (mainnet, testnet, ...)
+	GetChainID() string
+	// ProcessHeader ingests a relayed header with blockHeight, header is as it is in relaying header metadata
+	ProcessHeader(header string, blockHeight uint64) error
+	// IsValidRemoteAddress returns whether address is a valid address on the chain
+	IsValidRemoteAddress(address string) bool
+	// ParseAndVerifyTxProof parses an encoded inclusion proof of a tx and verifies it with relayed headers,
+	// it returns the tx which the proof proves
+	ParseAndVerifyTxProof(proof string) (RelayingTx, error)
+	// ConvertIncAmountToExternalAmount converts amount of ptoken (decimal 9) to amount in the smallest unit of the chain
+	ConvertIncAmountToExternalAmount(incAmount uint64) uint64
+}
+
+// RelayingTx is a tx on an external chain of which inclusion proof is verified
+type RelayingTx interface {
+	// IsPortingMemo returns whether memo of tx is the one of porting request portingID
+	IsPortingMemo(portingID string) bool
+	// IsRedeemMemo returns whether memo of tx is the one custodian attaches when it returns public token of redeem request redeemID
+	IsRedeemMemo(redeemID string, custodianAddress string) bool
+	// GetAmountTo returns amount in the smallest unit of the chain which tx transfers to address in its first output to address,
+	// it returns false if tx does not transfer to address
+	GetAmountTo(address string) (uint64, bool)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// See loadConfig for details on the configuration load process.
+type config struct {
+	Listen     string `long:"listen" description:"Listen address of the signer, unix:<socket path> or tcp:<host>:<port> (should only be reachable by the node)"`
+	MiningKey  string `long:"miningkey" description:"Mining key (private seed) served by the signer"`
+	PrivateKey string `long:"privatekey" description:"Wallet private key used to generate the mining key if miningkey is empty"`
+	DataDir    string `long:"datadir" description:"Directory of the double sign protection database"`
+	AuthToken  string `long:"authtoken" description:"Secret shared with the node (--remotesignertoken), required to listen on tcp"`
+}
+
+// newConfigParser returns a new command line flags parser.
+func newConfigParser(cfg *config, options flags.Options) *flags.Parser {
+	parser := flags.NewParser(cfg, options)
+	return parser
+}
+
+// loadConfig
+// - set default config
+// - read config from cmd line params
+// - return config object
+func loadConfig() (*config, error) {
+	// create config object from default values
+	cfg := config{
+		Listen:  defaultListen,
+		DataDir: defaultDataDir,
+	}
+
+	preParser := newConfigParser(&cfg, flags.HelpFlag)
+	_, err := preParser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+	if cfg.MiningKey == "" && cfg.PrivateKey == "" {
+		return nil, errors.New("miningkey and privatekey can't be both empty")
+	}
+	if strings.HasPrefix(cfg.Listen, "tcp:") && cfg.AuthToken == "" {
+		return nil, errors.New("authtoken can't be empty when listening on tcp")
+	}
+
+	return &cfg, nil
+}
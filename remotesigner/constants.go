@@ -0,0 +1,8 @@
+package main
+
+const (
+	version            = "1.0.0"
+	defaultListen      = "unix:remotesigner.sock"
+	defaultDataDir     = "remotesigner-data"
+	protectionDBDriver = "leveldb"
+)
@@ -0,0 +1,74 @@
+//+build !test
+
+package main
+
+import (
+	"log"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/remotesigner/server"
+)
+
+var (
+	cfg *config
+)
+
+// Remote signer holds the mining key of an incognito node (started with --remotesigner) off the node process,
+// it signs blocks proposed and voted by the node and refuses to sign blocks breaking the proposing and voting rules of consensus v2
+func main() {
+	// Show Version at startup.
+	log.Printf("Version %s\n", version)
+
+	// Load config
+	tcfg, err := loadConfig()
+	if err != nil {
+		log.Println("Parse config error", err.Error())
+		return
+	}
+	cfg = tcfg
+
+	privateSeed := cfg.MiningKey
+	if privateSeed == "" {
+		privateSeed, err = blsbftv2.LoadUserKeyFromIncPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			log.Println("Load private key error", err.Error())
+			return
+		}
+	}
+	miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(privateSeed)
+	if err != nil {
+		log.Println("Load mining key error", err.Error())
+		return
+	}
+	network, address, err := blsbftv2.ParseRemoteSignerAddress(cfg.Listen)
+	if err != nil {
+		log.Println("Parse listen address error", err.Error())
+		return
+	}
+	db, err := incdb.Open(protectionDBDriver, cfg.DataDir)
+	if err != nil {
+		log.Println("Open protection database error", err.Error())
+		return
+	}
+	defer db.Close()
+
+	// create RPC config for RPC server
+	rpcConfig := server.RpcServerConfig{
+		Network:      network,
+		Address:      address,
+		AuthToken:    cfg.AuthToken,
+		MiningKey:    miningKey,
+		ProtectionDB: server.NewProtectionDB(db),
+	}
+
+	rpcServer := &server.RpcServer{}
+	log.Printf("Init rpcServer with mining key %+v\n", miningKey.GetPublicKeyBase58())
+	rpcServer.Init(&rpcConfig)
+
+	log.Printf("Start rpcServer on %+v\n", cfg.Listen)
+	if err := rpcServer.Start(); err != nil {
+		log.Println("Start rpcServer error", err.Error())
+	}
+}
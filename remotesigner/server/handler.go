@@ -0,0 +1,132 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+type Handler struct {
+	rpcServer *RpcServer
+}
+
+// checkAuth returns an error if the auth token of the request does not match the auth token of the signer
+func (s Handler) checkAuth(args *blsbftv2.RemoteSignArgs) error {
+	authToken := s.rpcServer.Config.AuthToken
+	if authToken == "" {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(args.AuthToken), []byte(authToken)) != 1 {
+		return errors.New("invalid auth token")
+	}
+	return nil
+}
+
+// checkBlock checks the auth token and that the request is bound to a block
+func (s Handler) checkBlock(args *blsbftv2.RemoteSignArgs) error {
+	if err := s.checkAuth(args); err != nil {
+		return err
+	}
+	if args.Block == nil {
+		return errors.New("signed block is empty")
+	}
+	return nil
+}
+
+// GetPublicKey - handler func which returns the committee public key of the mining key
+func (s Handler) GetPublicKey(args *blsbftv2.RemoteSignArgs, publicKey *incognitokey.CommitteePublicKey) error {
+	if err := s.checkAuth(args); err != nil {
+		return err
+	}
+	*publicKey = s.rpcServer.Config.MiningKey.GetPublicKey()
+	return nil
+}
+
+// BriSignData - handler func which signs data not bound to a block with the bridge key,
+// data of a hash size could be the hash of a block or of a vote confirmation so it is refused
+func (s Handler) BriSignData(args *blsbftv2.RemoteSignArgs, sig *[]byte) error {
+	if err := s.checkAuth(args); err != nil {
+		return err
+	}
+	if len(args.Data) == common.HashSize {
+		return errors.New("data of hash size is only signed for a block")
+	}
+	result, err := s.rpcServer.Config.MiningKey.BriSignData(args.Data)
+	if err != nil {
+		return err
+	}
+	*sig = result
+	return nil
+}
+
+// BriSignProposal - handler func which signs the producer signature of a proposed block
+func (s Handler) BriSignProposal(args *blsbftv2.RemoteSignArgs, sig *[]byte) error {
+	if err := s.checkBlock(args); err != nil {
+		return err
+	}
+	if err := s.rpcServer.protectionDB.CheckAndStoreProposal(args.Block); err != nil {
+		log.Println("BriSignProposal error", err)
+		return err
+	}
+	result, err := s.rpcServer.Config.MiningKey.BriSignProposal(args.Block)
+	if err != nil {
+		return err
+	}
+	*sig = result
+	return nil
+}
+
+// BLSSignVote - handler func which signs the BLS signature of a vote
+func (s Handler) BLSSignVote(args *blsbftv2.RemoteSignArgs, sig *[]byte) error {
+	if err := s.checkBlock(args); err != nil {
+		return err
+	}
+	if err := s.rpcServer.protectionDB.CheckAndStoreVote(args.Block); err != nil {
+		log.Println("BLSSignVote error", err)
+		return err
+	}
+	result, err := s.rpcServer.Config.MiningKey.BLSSignVote(args.Block, args.SelfIdx, args.Committee)
+	if err != nil {
+		return err
+	}
+	*sig = result
+	return nil
+}
+
+// BriSignVote - handler func which signs the bridge signature of a vote
+func (s Handler) BriSignVote(args *blsbftv2.RemoteSignArgs, sig *[]byte) error {
+	if err := s.checkBlock(args); err != nil {
+		return err
+	}
+	if err := s.rpcServer.protectionDB.CheckAndStoreVote(args.Block); err != nil {
+		log.Println("BriSignVote error", err)
+		return err
+	}
+	result, err := s.rpcServer.Config.MiningKey.BriSignVote(args.Block)
+	if err != nil {
+		return err
+	}
+	*sig = result
+	return nil
+}
+
+// BriSignVoteConfirmation - handler func which signs the confirmation of a vote, computed from the block hash and the vote signatures
+func (s Handler) BriSignVoteConfirmation(args *blsbftv2.RemoteSignArgs, sig *[]byte) error {
+	if err := s.checkBlock(args); err != nil {
+		return err
+	}
+	if err := s.rpcServer.protectionDB.CheckAndStoreVote(args.Block); err != nil {
+		log.Println("BriSignVoteConfirmation error", err)
+		return err
+	}
+	result, err := s.rpcServer.Config.MiningKey.BriSignVoteConfirmation(args.Block, args.BLS, args.BRI)
+	if err != nil {
+		return err
+	}
+	*sig = result
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+var (
+	signedProposalPrefix = "signed-proposal"
+	signedVotePrefix     = "signed-vote"
+)
+
+// ProtectionDB - history of the blocks signed by the remote signer, mirroring the rules of consensus v2:
+// one proposal per chain and propose timeslot, votes of a chain at the same height follow the voting rules
+// (see blsbftv2.SignedBlock.CanVoteAfter)
+type ProtectionDB struct {
+	db   incdb.Database
+	lock sync.Mutex
+}
+
+func NewProtectionDB(db incdb.Database) *ProtectionDB {
+	return &ProtectionDB{
+		db: db,
+	}
+}
+
+func getSignedProposalKey(block *blsbftv2.SignedBlock) []byte {
+	return []byte(fmt.Sprintf("%s-%d-%d", signedProposalPrefix, block.ChainID, block.ProposeTimeSlot))
+}
+
+func getSignedVoteKey(block *blsbftv2.SignedBlock) []byte {
+	return []byte(fmt.Sprintf("%s-%d-%d", signedVotePrefix, block.ChainID, block.Height))
+}
+
+// CheckAndStoreProposal returns an error if a different block of the chain was proposed at the same propose timeslot,
+// otherwise stores the proposal
+func (protectionDB *ProtectionDB) CheckAndStoreProposal(block *blsbftv2.SignedBlock) error {
+	if block.BlockHash == "" {
+		return errors.New("hash of the signed block is empty")
+	}
+	protectionDB.lock.Lock()
+	defer protectionDB.lock.Unlock()
+	key := getSignedProposalKey(block)
+	has, err := protectionDB.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if has {
+		proposedBlockHash, err := protectionDB.db.Get(key)
+		if err != nil {
+			return err
+		}
+		if string(proposedBlockHash) != block.BlockHash {
+			return fmt.Errorf("refuse to propose block %+v, block %+v already proposed at timeslot %+v of chain %+v", block.BlockHash, string(proposedBlockHash), block.ProposeTimeSlot, block.ChainID)
+		}
+		return nil
+	}
+	return protectionDB.db.Put(key, []byte(block.BlockHash))
+}
+
+// CheckAndStoreVote returns an error if the vote breaks the voting rules after the last block voted at the same height,
+// otherwise stores the block as the last voted block, signing again for the same block is allowed (BLS, bridge, confirmation)
+func (protectionDB *ProtectionDB) CheckAndStoreVote(block *blsbftv2.SignedBlock) error {
+	if block.BlockHash == "" {
+		return errors.New("hash of the signed block is empty")
+	}
+	protectionDB.lock.Lock()
+	defer protectionDB.lock.Unlock()
+	key := getSignedVoteKey(block)
+	has, err := protectionDB.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if has {
+		votedBlockBytes, err := protectionDB.db.Get(key)
+		if err != nil {
+			return err
+		}
+		votedBlock := &blsbftv2.SignedBlock{}
+		if err := json.Unmarshal(votedBlockBytes, votedBlock); err != nil {
+			return err
+		}
+		if err := block.CanVoteAfter(votedBlock); err != nil {
+			return fmt.Errorf("refuse to vote block %+v at height %+v of chain %+v after block %+v: %v", block.BlockHash, block.Height, block.ChainID, votedBlock.BlockHash, err)
+		}
+		if block.BlockHash == votedBlock.BlockHash {
+			return nil
+		}
+	}
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return protectionDB.db.Put(key, blockBytes)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/rpc"
+	"os"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+)
+
+// RpcServer serves signatures of a mining key to incognito nodes,
+// with double sign protection (see ProtectionDB)
+type RpcServer struct {
+	server       *rpc.Server
+	protectionDB *ProtectionDB
+	Config       RpcServerConfig // config for RPC server
+}
+
+type RpcServerConfig struct {
+	Network      string // unix or tcp
+	Address      string // socket path or host:port
+	AuthToken    string // secret shared with the node, required on tcp
+	MiningKey    *blsbftv2.MiningKey
+	ProtectionDB *ProtectionDB
+}
+
+func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
+	rpcServer.Config = *config
+	rpcServer.protectionDB = config.ProtectionDB
+	rpcServer.server = rpc.NewServer()
+}
+
+// Start - create handler and add into rpc server
+// Listen and serve rpc server with config address
+func (rpcServer *RpcServer) Start() error {
+	if rpcServer.Config.Network == "tcp" && rpcServer.Config.AuthToken == "" {
+		return errors.New("auth token is required to listen on tcp")
+	}
+	handler := &Handler{rpcServer}
+	if err := rpcServer.server.RegisterName(blsbftv2.RemoteSignerServiceName, handler); err != nil {
+		return err
+	}
+	if rpcServer.Config.Network == "unix" {
+		// remove socket file left by a previous run
+		if info, err := os.Stat(rpcServer.Config.Address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(rpcServer.Config.Address)
+		}
+	}
+	l, err := net.Listen(rpcServer.Config.Network, rpcServer.Config.Address)
+	if err != nil {
+		log.Println("listen error:", err)
+		return err
+	}
+	rpcServer.server.Accept(l)
+	l.Close()
+	return nil
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProtectionDB(t *testing.T, dir string) *ProtectionDB {
+	db, err := incdb.Open("leveldb", filepath.Join(dir, "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewProtectionDB(db)
+}
+
+func TestProtectionDB_CheckAndStoreProposal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	protectionDB := newTestProtectionDB(t, dir)
+
+	block := &blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: "block-1"}
+	assert.NoError(t, protectionDB.CheckAndStoreProposal(block))
+	assert.NoError(t, protectionDB.CheckAndStoreProposal(block))
+	// different block at the same propose timeslot
+	assert.Error(t, protectionDB.CheckAndStoreProposal(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: "block-2"}))
+	// re-proposed block in a later timeslot, same timeslot of another chain
+	assert.NoError(t, protectionDB.CheckAndStoreProposal(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 101, BlockHash: "block-2"}))
+	assert.NoError(t, protectionDB.CheckAndStoreProposal(&blsbftv2.SignedBlock{ChainID: -1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: "block-3"}))
+	assert.Error(t, protectionDB.CheckAndStoreProposal(&blsbftv2.SignedBlock{ChainID: 1, Height: 11, ProduceTimeSlot: 102, ProposeTimeSlot: 102}))
+}
+
+func TestProtectionDB_CheckAndStoreVote(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	protectionDB := newTestProtectionDB(t, dir)
+
+	block := &blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 101, BlockHash: "block-1"}
+	assert.NoError(t, protectionDB.CheckAndStoreVote(block))
+	// BLS, bridge signatures and confirmation of the same vote
+	assert.NoError(t, protectionDB.CheckAndStoreVote(block))
+	// block produced in the same timeslot, proposed in the same or an earlier timeslot
+	assert.Error(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 101, BlockHash: "block-2"}))
+	assert.Error(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: "block-2"}))
+	// block produced in a later timeslot
+	assert.Error(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 102, ProposeTimeSlot: 102, BlockHash: "block-2"}))
+	// same produce timeslot re-proposed in a later timeslot
+	assert.NoError(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 103, BlockHash: "block-2"}))
+	assert.Error(t, protectionDB.CheckAndStoreVote(block))
+	// block produced in an earlier timeslot
+	assert.NoError(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 10, ProduceTimeSlot: 99, ProposeTimeSlot: 104, BlockHash: "block-3"}))
+	// other chain and other height
+	assert.NoError(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: -1, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 101, BlockHash: "block-4"}))
+	assert.NoError(t, protectionDB.CheckAndStoreVote(&blsbftv2.SignedBlock{ChainID: 1, Height: 11, ProduceTimeSlot: 105, ProposeTimeSlot: 105, BlockHash: "block-5"}))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(base58.Base58Check{}.Encode(common.HashB([]byte("seed")), common.Base58Version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	socketPath := filepath.Join(dir, "remotesigner.sock")
+	rpcServer := &RpcServer{}
+	rpcServer.Init(&RpcServerConfig{
+		Network:      "unix",
+		Address:      socketPath,
+		AuthToken:    "secret",
+		MiningKey:    miningKey,
+		ProtectionDB: newTestProtectionDB(t, dir),
+	})
+	go rpcServer.Start()
+
+	var signer *blsbftv2.RemoteSigner
+	for i := 0; i < 50; i++ {
+		if signer, err = blsbftv2.NewRemoteSigner("unix:"+socketPath, "secret"); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, miningKey.GetPublicKeyBase58(), signer.GetPublicKeyBase58())
+
+	_, err = blsbftv2.NewRemoteSigner("unix:"+socketPath, "wrong")
+	assert.Error(t, err)
+	_, err = blsbftv2.NewRemoteSigner("tcp:127.0.0.1:9000", "")
+	assert.Error(t, err)
+	_, err = blsbftv2.NewRemoteSigner(socketPath, "secret")
+	assert.Error(t, err)
+
+	// signed bytes are computed by the signer from the block
+	blockHash := common.HashH([]byte("block-1"))
+	block := &blsbftv2.SignedBlock{ChainID: 0, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: blockHash.String()}
+	sig, err := signer.BriSignProposal(block)
+	assert.NoError(t, err)
+	isValid, err := bridgesig.Verify(miningKey.PubKey[common.BridgeConsensus], blockHash.GetBytes(), sig)
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+
+	bls, err := signer.BLSSignVote(block, 0, []blsmultisig.PublicKey{miningKey.PubKey[common.BlsConsensus]})
+	assert.NoError(t, err)
+	confirmation, err := signer.BriSignVoteConfirmation(block, bls, nil)
+	assert.NoError(t, err)
+	vote := &blsbftv2.BFTVote{BlockHash: block.BlockHash, BLS: bls, Confirmation: confirmation}
+	isValid, err = bridgesig.Verify(miningKey.PubKey[common.BridgeConsensus], block.GetVoteConfirmationData(vote.BLS, vote.BRI), vote.Confirmation)
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+
+	otherBlockHash := common.HashH([]byte("block-2"))
+	otherBlock := &blsbftv2.SignedBlock{ChainID: 0, Height: 10, ProduceTimeSlot: 100, ProposeTimeSlot: 100, BlockHash: otherBlockHash.String()}
+	_, err = signer.BriSignProposal(otherBlock)
+	assert.Error(t, err)
+	_, err = signer.BriSignVote(otherBlock)
+	assert.Error(t, err)
+	// block hash can not be signed as data not bound to a block
+	_, err = signer.BriSignData(otherBlockHash.GetBytes())
+	assert.Error(t, err)
+	_, err = signer.BriSignData([]byte("peer-id"))
+	assert.NoError(t, err)
+}
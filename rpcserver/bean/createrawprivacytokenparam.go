@@ -8,17 +8,19 @@ import (
 )
 
 type CreateRawPrivacyTokenTxParam struct {
-	SenderKeySet         *incognitokey.KeySet
-	ShardIDSender        byte
-	PaymentInfos         []*privacy.PaymentInfo
-	EstimateFeeCoinPerKb int64
-	HasPrivacyCoin       bool
-	TokenParamsRaw       map[string]interface{}
-	TokenParams          map[string]interface{}
-	HasPrivacyToken      bool
-	Info                 []byte
-	IsGetPTokenFee       bool
-	UnitPTokenFee        int64
+	SenderKeySet          *incognitokey.KeySet
+	ShardIDSender         byte
+	PaymentInfos          []*privacy.PaymentInfo
+	EstimateFeeCoinPerKb  int64
+	HasPrivacyCoin        bool
+	TokenParamsRaw        map[string]interface{}
+	TokenParams           map[string]interface{}
+	HasPrivacyToken       bool
+	Info                  []byte
+	IsGetPTokenFee        bool
+	UnitPTokenFee         int64
+	CoinSelectionStrategy string
+	AutoDefragment        bool
 }
 
 func NewCreateRawPrivacyTokenTxParam(params interface{}) (*CreateRawPrivacyTokenTxParam, error) {
@@ -72,6 +74,9 @@ func NewCreateRawPrivacyTokenTxParam(params interface{}) (*CreateRawPrivacyToken
 		TokenParamsRaw:       tokenParamsRaw,
 		IsGetPTokenFee:       isGetPTokenFee,
 		UnitPTokenFee:        unitPTokenFee,
+		// coin selection params are read from token params by NewCreateRawTxParam
+		CoinSelectionStrategy: txparam.CoinSelectionStrategy,
+		AutoDefragment:        txparam.AutoDefragment,
 	}, nil
 }
 
@@ -126,5 +131,8 @@ func NewCreateRawPrivacyTokenTxParamV2(params interface{}) (*CreateRawPrivacyTok
 		TokenParamsRaw:       tokenParamsRaw,
 		IsGetPTokenFee:       isGetPTokenFee,
 		UnitPTokenFee:        unitPTokenFee,
+		// coin selection params are read from token params by NewCreateRawTxParam
+		CoinSelectionStrategy: txparam.CoinSelectionStrategy,
+		AutoDefragment:        txparam.AutoDefragment,
 	}, nil
 }
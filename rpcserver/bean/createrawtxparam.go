@@ -1,6 +1,7 @@
 package bean
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -11,12 +12,67 @@ import (
 )
 
 type CreateRawTxParam struct {
-	SenderKeySet         *incognitokey.KeySet
-	ShardIDSender        byte
-	PaymentInfos         []*privacy.PaymentInfo
-	EstimateFeeCoinPerKb int64
-	HasPrivacyCoin       bool
-	Info                 []byte
+	SenderKeySet          *incognitokey.KeySet
+	ShardIDSender         byte
+	PaymentInfos          []*privacy.PaymentInfo
+	EstimateFeeCoinPerKb  int64
+	HasPrivacyCoin        bool
+	Info                  []byte
+	CoinSelectionStrategy string
+	AutoDefragment        bool
+}
+
+// GetCoinSelectionParams returns coin selection strategy and auto defragment flag from map param of request
+func GetCoinSelectionParams(paramsRaw map[string]interface{}) (string, bool, error) {
+	coinSelectionStrategy := ""
+	if strategyParam, ok := paramsRaw["CoinSelectionStrategy"]; ok {
+		coinSelectionStrategy, ok = strategyParam.(string)
+		if !ok {
+			return "", false, errors.New("coin selection strategy is invalid")
+		}
+	}
+	autoDefragment := false
+	if autoDefragmentParam, ok := paramsRaw["AutoDefragment"]; ok {
+		autoDefragment, ok = autoDefragmentParam.(bool)
+		if !ok {
+			return "", false, errors.New("auto defragment flag is invalid")
+		}
+	}
+	return coinSelectionStrategy, autoDefragment, nil
+}
+
+// SetMemosOfPaymentInfos encrypts memos by transmission keys of receivers and puts them into info of output coins
+// memosParam is a map of payment address and memo, only receiver can read its memo by its readonly key
+func SetMemosOfPaymentInfos(paymentInfos []*privacy.PaymentInfo, memosParam interface{}) error {
+	memos, ok := memosParam.(map[string]interface{})
+	if !ok {
+		return errors.New("memos param is invalid")
+	}
+	for paymentAddressStr, memoParam := range memos {
+		memo, ok := memoParam.(string)
+		if !ok {
+			return fmt.Errorf("memo of %+v is invalid", paymentAddressStr)
+		}
+		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		if err != nil {
+			return err
+		}
+		isFound := false
+		for _, paymentInfo := range paymentInfos {
+			if !bytes.Equal(paymentInfo.PaymentAddress.Bytes(), keyWalletReceiver.KeySet.PaymentAddress.Bytes()) {
+				continue
+			}
+			paymentInfo.Message, err = privacy.EncryptMemo([]byte(memo), paymentInfo.PaymentAddress.Tk)
+			if err != nil {
+				return err
+			}
+			isFound = true
+		}
+		if !isFound {
+			return fmt.Errorf("memo receiver %+v is not in list receivers", paymentAddressStr)
+		}
+	}
+	return nil
 }
 
 func GetKeySetFromPrivateKeyParams(privateKeyWalletStr string) (*incognitokey.KeySet, byte, error) {
@@ -106,7 +162,23 @@ func NewCreateRawTxParam(params interface{}) (*CreateRawTxParam, error) {
 	hasPrivacyCoin := int(hasPrivacyCoinParam) > 0
 
 	// param #5: meta data (optional)
-	// don't do anything
+	// only coin selection params and memos are read here, meta data is built by handler of each request
+	coinSelectionStrategy := ""
+	autoDefragment := false
+	if len(arrayParams) > 4 {
+		if paramsRaw, ok := arrayParams[4].(map[string]interface{}); ok {
+			coinSelectionStrategy, autoDefragment, err = GetCoinSelectionParams(paramsRaw)
+			if err != nil {
+				return nil, err
+			}
+			if memosParam, ok := paramsRaw["Memos"]; ok {
+				err = SetMemosOfPaymentInfos(paymentInfos, memosParam)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 
 	// param#6: info (optional)
 	info := []byte{}
@@ -121,12 +193,14 @@ func NewCreateRawTxParam(params interface{}) (*CreateRawTxParam, error) {
 	}
 
 	return &CreateRawTxParam{
-		SenderKeySet:         senderKeySet,
-		ShardIDSender:        shardIDSender,
-		PaymentInfos:         paymentInfos,
-		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
-		HasPrivacyCoin:       hasPrivacyCoin,
-		Info:                 info,
+		SenderKeySet:          senderKeySet,
+		ShardIDSender:         shardIDSender,
+		PaymentInfos:          paymentInfos,
+		EstimateFeeCoinPerKb:  int64(estimateFeeCoinPerKb),
+		HasPrivacyCoin:        hasPrivacyCoin,
+		Info:                  info,
+		CoinSelectionStrategy: coinSelectionStrategy,
+		AutoDefragment:        autoDefragment,
 	}, nil
 }
 
@@ -194,7 +268,23 @@ func NewCreateRawTxParamV2(params interface{}) (*CreateRawTxParam, error) {
 	hasPrivacyCoin := int(hasPrivacyCoinParam) > 0
 
 	// param #5: meta data (optional)
-	// don't do anything
+	// only coin selection params and memos are read here, meta data is built by handler of each request
+	coinSelectionStrategy := ""
+	autoDefragment := false
+	if len(arrayParams) > 4 {
+		if paramsRaw, ok := arrayParams[4].(map[string]interface{}); ok {
+			coinSelectionStrategy, autoDefragment, err = GetCoinSelectionParams(paramsRaw)
+			if err != nil {
+				return nil, err
+			}
+			if memosParam, ok := paramsRaw["Memos"]; ok {
+				err = SetMemosOfPaymentInfos(paymentInfos, memosParam)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 
 	// param#6: info (optional)
 	info := []byte{}
@@ -210,11 +300,13 @@ func NewCreateRawTxParamV2(params interface{}) (*CreateRawTxParam, error) {
 	}
 
 	return &CreateRawTxParam{
-		SenderKeySet:         senderKeySet,
-		ShardIDSender:        shardIDSender,
-		PaymentInfos:         paymentInfos,
-		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
-		HasPrivacyCoin:       hasPrivacyCoin,
-		Info:                 info,
+		SenderKeySet:          senderKeySet,
+		ShardIDSender:         shardIDSender,
+		PaymentInfos:          paymentInfos,
+		EstimateFeeCoinPerKb:  int64(estimateFeeCoinPerKb),
+		HasPrivacyCoin:        hasPrivacyCoin,
+		Info:                  info,
+		CoinSelectionStrategy: coinSelectionStrategy,
+		AutoDefragment:        autoDefragment,
 	}, nil
 }
@@ -0,0 +1,221 @@
+package bean
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// CreateUnsignedTxParam contains params to build a tx which is signed offline
+// Sender key set only has payment address and readonly key
+type CreateUnsignedTxParam struct {
+	SenderKeySet         *incognitokey.KeySet
+	ShardIDSender        byte
+	PaymentInfos         []*privacy.PaymentInfo
+	EstimateFeeCoinPerKb int64
+	HasPrivacyCoin       bool
+	TokenParam           *CreateUnsignedTokenParam
+	Info                 []byte
+	SpentSNDs            map[string]struct{}
+}
+
+// CreateUnsignedTokenParam contains params to transfer privacy token in unsigned tx
+// fee of unsigned privacy token tx is always paid by PRV
+type CreateUnsignedTokenParam struct {
+	TokenID         *common.Hash
+	TokenName       string
+	TokenSymbol     string
+	TokenReceivers  []*privacy.PaymentInfo
+	TokenAmount     uint64
+	HasPrivacyToken bool
+}
+
+func GetKeySetFromPaymentAddressAndReadonlyKeyParams(paymentAddressStr string, readonlyKeyStr string) (*incognitokey.KeySet, byte, error) {
+	paymentAddressKey, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, byte(0), err
+	}
+	if len(paymentAddressKey.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, byte(0), errors.New("payment address is invalid")
+	}
+	readonlyKey, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, byte(0), err
+	}
+	if len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, byte(0), errors.New("readonly key is invalid")
+	}
+	if !bytes.Equal(paymentAddressKey.KeySet.PaymentAddress.Pk, readonlyKey.KeySet.ReadonlyKey.Pk) {
+		return nil, byte(0), errors.New("readonly key does not match payment address")
+	}
+
+	keySet := &incognitokey.KeySet{
+		PaymentAddress: paymentAddressKey.KeySet.PaymentAddress,
+		ReadonlyKey:    readonlyKey.KeySet.ReadonlyKey,
+	}
+	lastByte := keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(lastByte)
+
+	return keySet, shardID, nil
+}
+
+func NewCreateUnsignedTxParam(params interface{}) (*CreateUnsignedTxParam, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, errors.New("not enough param")
+	}
+
+	// param #1: payment address of sender
+	paymentAddressParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, errors.New("sender payment address is invalid")
+	}
+
+	// param #2: readonly key of sender
+	readonlyKeyParam, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, errors.New("sender readonly key is invalid")
+	}
+	senderKeySet, shardIDSender, err := GetKeySetFromPaymentAddressAndReadonlyKeyParams(paymentAddressParam, readonlyKeyParam)
+	if err != nil {
+		return nil, err
+	}
+
+	// param #3: list receivers
+	receivers := make(map[string]interface{})
+	if arrayParams[2] != nil {
+		receivers, ok = arrayParams[2].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("receivers param is invalid")
+		}
+	}
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, amount := range receivers {
+		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyWalletReceiver.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("payment info %+v is invalid", paymentAddressStr)
+		}
+
+		amountParam, err := common.AssertAndConvertStrToNumber(amount)
+		if err != nil {
+			return nil, err
+		}
+
+		paymentInfo := &privacy.PaymentInfo{
+			Amount:         amountParam,
+			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+		}
+		paymentInfos = append(paymentInfos, paymentInfo)
+	}
+
+	// param #4: estimation fee nano P per kb
+	estimateFeeCoinPerKb, ok := arrayParams[3].(float64)
+	if !ok {
+		return nil, errors.New("estimate fee coin per kb is invalid")
+	}
+
+	// param #5: hasPrivacyCoin flag: 1 or -1
+	// default: -1 (has no privacy) (if missing this param)
+	hasPrivacyCoinParam := float64(-1)
+	if len(arrayParams) > 4 {
+		hasPrivacyCoinParam, ok = arrayParams[4].(float64)
+		if !ok {
+			return nil, errors.New("has privacy for tx is invalid")
+		}
+	}
+	hasPrivacyCoin := int(hasPrivacyCoinParam) > 0
+
+	// param #6: privacy token params (optional)
+	var tokenParam *CreateUnsignedTokenParam
+	if len(arrayParams) > 5 && arrayParams[5] != nil {
+		tokenParamsRaw, ok := arrayParams[5].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("token params is invalid")
+		}
+		tokenParam, err = newCreateUnsignedTokenParam(tokenParamsRaw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// param #7: info (optional)
+	info := []byte{}
+	if len(arrayParams) > 6 && arrayParams[6] != nil {
+		infoStr, ok := arrayParams[6].(string)
+		if !ok {
+			return nil, errors.New("info is invalid")
+		}
+		info = []byte(infoStr)
+	}
+
+	// param #8: snds of coins which were spent (optional)
+	// node can not compute serial numbers without private key, so caller excludes spent coins by their snds
+	spentSNDs := make(map[string]struct{})
+	if len(arrayParams) > 7 && arrayParams[7] != nil {
+		spentSNDsParam, ok := arrayParams[7].([]interface{})
+		if !ok {
+			return nil, errors.New("spent snds is invalid")
+		}
+		for _, item := range spentSNDsParam {
+			sndStr, ok := item.(string)
+			if !ok {
+				return nil, errors.New("spent snd is invalid")
+			}
+			sndBytes, _, err := base58.Base58Check{}.Decode(sndStr)
+			if err != nil || len(sndBytes) != common.HashSize {
+				return nil, fmt.Errorf("spent snd %+v is invalid", sndStr)
+			}
+			spentSNDs[string(sndBytes)] = struct{}{}
+		}
+	}
+
+	return &CreateUnsignedTxParam{
+		SenderKeySet:         senderKeySet,
+		ShardIDSender:        shardIDSender,
+		PaymentInfos:         paymentInfos,
+		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
+		HasPrivacyCoin:       hasPrivacyCoin,
+		TokenParam:           tokenParam,
+		Info:                 info,
+		SpentSNDs:            spentSNDs,
+	}, nil
+}
+
+func newCreateUnsignedTokenParam(tokenParamsRaw map[string]interface{}) (*CreateUnsignedTokenParam, error) {
+	tokenIDStr, ok := tokenParamsRaw["TokenID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Token ID, Params %+v ", tokenParamsRaw)
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, err
+	}
+	tokenName, _ := tokenParamsRaw["TokenName"].(string)
+	tokenSymbol, _ := tokenParamsRaw["TokenSymbol"].(string)
+	tokenReceivers, tokenAmount, err := transaction.CreateCustomTokenPrivacyReceiverArray(tokenParamsRaw["TokenReceivers"])
+	if err != nil {
+		return nil, err
+	}
+	hasPrivacyToken := true
+	if privacyParam, ok := tokenParamsRaw["Privacy"].(bool); ok {
+		hasPrivacyToken = privacyParam
+	}
+	return &CreateUnsignedTokenParam{
+		TokenID:         tokenID,
+		TokenName:       tokenName,
+		TokenSymbol:     tokenSymbol,
+		TokenReceivers:  tokenReceivers,
+		TokenAmount:     uint64(tokenAmount),
+		HasPrivacyToken: hasPrivacyToken,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package bean
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PayoutParam is a payment in payout batch, empty TokenID means PRV
+type PayoutParam struct {
+	PaymentAddress string
+	Amount         uint64
+	TokenID        string
+	Info           string
+}
+
+type CreatePayoutBatchParam struct {
+	SenderKeySet         *incognitokey.KeySet
+	ShardIDSender        byte
+	Payouts              []PayoutParam
+	EstimateFeeCoinPerKb int64
+	HasPrivacyCoin       bool
+	HasPrivacyToken      bool
+}
+
+// NewCreatePayoutBatchParam parses params:
+// param #1: private key of sender
+// param #2: payouts, a json array of {"PaymentAddress", "Amount", "TokenID", "Info"}
+// or a csv string with lines "payment address,amount,token id,info"
+// param #3: estimation fee nano P per kb
+// param #4: hasPrivacyCoin flag: 1 or -1 (optional)
+// param #5: hasPrivacyToken flag: 1 or -1 (optional)
+func NewCreatePayoutBatchParam(params interface{}) (*CreatePayoutBatchParam, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 3 {
+		return nil, errors.New("not enough param")
+	}
+
+	senderKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, errors.New("sender private key is invalid")
+	}
+	senderKeySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(senderKeyParam)
+	if err != nil {
+		return nil, err
+	}
+
+	var payouts []PayoutParam
+	switch payoutsParam := arrayParams[1].(type) {
+	case string:
+		payouts, err = parsePayoutsFromCSV(payoutsParam)
+	case []interface{}:
+		payouts, err = parsePayoutsFromJSON(payoutsParam)
+	default:
+		err = errors.New("payouts param is invalid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(payouts) == 0 {
+		return nil, errors.New("payouts param is empty")
+	}
+	for i, payout := range payouts {
+		err = validatePayoutParam(payout)
+		if err != nil {
+			return nil, fmt.Errorf("payout %d is invalid: %+v", i, err)
+		}
+		if payout.TokenID == common.PRVCoinID.String() {
+			payouts[i].TokenID = ""
+		}
+	}
+
+	estimateFeeCoinPerKb, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, errors.New("estimate fee coin per kb is invalid")
+	}
+
+	hasPrivacyCoin := false
+	if len(arrayParams) > 3 {
+		hasPrivacyCoinParam, ok := arrayParams[3].(float64)
+		if !ok {
+			return nil, errors.New("has privacy for tx is invalid")
+		}
+		hasPrivacyCoin = int(hasPrivacyCoinParam) > 0
+	}
+	hasPrivacyToken := true
+	if len(arrayParams) > 4 {
+		hasPrivacyTokenParam, ok := arrayParams[4].(float64)
+		if !ok {
+			return nil, errors.New("has privacy for token param is invalid")
+		}
+		hasPrivacyToken = int(hasPrivacyTokenParam) > 0
+	}
+
+	return &CreatePayoutBatchParam{
+		SenderKeySet:         senderKeySet,
+		ShardIDSender:        shardIDSender,
+		Payouts:              payouts,
+		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
+		HasPrivacyCoin:       hasPrivacyCoin,
+		HasPrivacyToken:      hasPrivacyToken,
+	}, nil
+}
+
+func parsePayoutsFromJSON(payoutsParam []interface{}) ([]PayoutParam, error) {
+	payouts := make([]PayoutParam, 0, len(payoutsParam))
+	for _, item := range payoutsParam {
+		payoutParam, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("payout param is invalid")
+		}
+		paymentAddress, ok := payoutParam["PaymentAddress"].(string)
+		if !ok {
+			return nil, errors.New("payment address of payout is invalid")
+		}
+		amount, err := common.AssertAndConvertStrToNumber(payoutParam["Amount"])
+		if err != nil {
+			return nil, err
+		}
+		tokenID, _ := payoutParam["TokenID"].(string)
+		info, _ := payoutParam["Info"].(string)
+		payouts = append(payouts, PayoutParam{
+			PaymentAddress: paymentAddress,
+			Amount:         amount,
+			TokenID:        tokenID,
+			Info:           info,
+		})
+	}
+	return payouts, nil
+}
+
+func parsePayoutsFromCSV(payoutsParam string) ([]PayoutParam, error) {
+	reader := csv.NewReader(strings.NewReader(payoutsParam))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	payouts := make([]PayoutParam, 0, len(records))
+	for _, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("payout record %+v is invalid", record)
+		}
+		amount, err := strconv.ParseUint(record[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("amount of payout record %+v is invalid", record)
+		}
+		payout := PayoutParam{
+			PaymentAddress: record[0],
+			Amount:         amount,
+		}
+		if len(record) > 2 {
+			payout.TokenID = record[2]
+		}
+		if len(record) > 3 {
+			payout.Info = record[3]
+		}
+		payouts = append(payouts, payout)
+	}
+	return payouts, nil
+}
+
+func validatePayoutParam(payout PayoutParam) error {
+	keyWallet, err := wallet.Base58CheckDeserialize(payout.PaymentAddress)
+	if err != nil {
+		return err
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return errors.New("payment address is invalid")
+	}
+	if payout.Amount == 0 {
+		return errors.New("amount is zero")
+	}
+	if payout.TokenID != "" {
+		_, err = common.Hash{}.NewHashFromStr(payout.TokenID)
+		if err != nil {
+			return err
+		}
+	}
+	if len(payout.Info) > privacy.MaxSizeInfoCoin {
+		return errors.New("info is too large")
+	}
+	return nil
+}
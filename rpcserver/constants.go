@@ -54,6 +54,7 @@ const (
 	listOutputCoins                            = "listoutputcoins"
 	createRawTransaction                       = "createtransaction"
 	sendRawTransaction                         = "sendtransaction"
+	createUnsignedTransaction                  = "createunsignedtransaction"
 	createAndSendTransaction                   = "createandsendtransaction"
 	createAndSendCustomTokenTransaction        = "createandsendcustomtokentransaction"
 	sendRawCustomTokenTransaction              = "sendrawcustomtokentransaction"
@@ -94,6 +95,15 @@ const (
 	listCommitmentIndices                      = "listcommitmentindices"
 	createAndSendStakingTransaction            = "createandsendstakingtransaction"
 	createAndSendStopAutoStakingTransaction    = "createandsendstopautostakingtransaction"
+	createRawDelegationTransaction             = "createrawdelegationtransaction"
+	createAndSendDelegationTransaction         = "createandsenddelegationtransaction"
+	createRawUnDelegationTransaction           = "createrawundelegationtransaction"
+	createAndSendUnDelegationTransaction       = "createandsendundelegationtransaction"
+	createRawSetCommissionRateTransaction      = "createrawsetcommissionratetransaction"
+	createAndSendSetCommissionRateTransaction  = "createandsendsetcommissionratetransaction"
+	getDelegations                             = "getdelegations"
+	createRawDoubleSignEvidenceTransaction     = "createrawdoublesignevidencetransaction"
+	createAndSendDoubleSignEvidenceTransaction = "createandsenddoublesignevidencetransaction"
 	decryptoutputcoinbykeyoftransaction        = "decryptoutputcoinbykeyoftransaction"
 
 	//===========For Testing and Benchmark==============
@@ -123,6 +133,31 @@ const (
 	getBalanceByPaymentAddress = "getbalancebypaymentaddress"
 	getReceivedByAccount       = "getreceivedbyaccount"
 	setTxFee                   = "settxfee"
+	importWatchOnlyAccount     = "importwatchonlyaccount"
+	removeWatchOnlyAccount     = "removewatchonlyaccount"
+	getWatchOnlyBalance        = "getwatchonlybalance"
+	getWatchOnlyTransactions   = "getwatchonlytransactions"
+
+	// payout batch
+	createAndSendPayoutBatch = "createandsendpayoutbatch"
+	retryPayoutBatch         = "retrypayoutbatch"
+	getPayoutBatch           = "getpayoutbatch"
+	listPayoutBatches        = "listpayoutbatches"
+
+	// coin scanner
+	registerCoinScannerKey   = "registercoinscannerkey"
+	unregisterCoinScannerKey = "unregistercoinscannerkey"
+	listCoinScannerKeys      = "listcoinscannerkeys"
+	getCoinScannerBalance    = "getcoinscannerbalance"
+	listCoinScannerCoins     = "listcoinscannercoins"
+
+	// portfolio
+	createPortfolio                   = "createportfolio"
+	removePortfolio                   = "removeportfolio"
+	listPortfolios                    = "listportfolios"
+	getPortfolioBalance               = "getportfoliobalance"
+	createAndSendPortfolioTransaction = "createandsendportfoliotransaction"
+	consolidatePortfolio              = "consolidateportfolio"
 
 	// walletsta
 	getPublicKeyFromPaymentAddress = "getpublickeyfrompaymentaddress"
@@ -170,6 +205,10 @@ const (
 	getIncognitoPublicKeyRole   = "getincognitopublickeyrole"
 	getMinerRewardFromMiningKey = "getminerrewardfromminingkey"
 
+	// mining keys hot-swap
+	setMiningDrainMode = "setminingdrainmode"
+	switchMiningKeys   = "switchminingkeys"
+
 	// slash
 	getProducersBlackList       = "getproducersblacklist"
 	getProducersBlackListDetail = "getproducersblacklistdetail"
@@ -196,6 +235,15 @@ const (
 	getPDEFeeWithdrawalStatus                  = "getpdefeewithdrawalstatus"
 	convertPDEPrices                           = "convertpdeprices"
 	extractPDEInstsFromBeaconBlock             = "extractpdeinstsfrombeaconblock"
+	createAndSendTxWithPRVLimitOrderReq        = "createandsendtxwithprvlimitorderreq"
+	createAndSendTxWithPTokenLimitOrderReq     = "createandsendtxwithptokenlimitorderreq"
+	createAndSendTxWithCancelLimitOrderReq     = "createandsendtxwithcancellimitorderreq"
+	getPDELimitOrders                          = "getpdelimitorders"
+	getPDELimitOrderStatus                     = "getpdelimitorderstatus"
+	getPDECancelLimitOrderStatus               = "getpdecancellimitorderstatus"
+	getPDETradeRouteQuote                      = "getpdetraderoutequote"
+	getPDETWAP                                 = "getpdetwap"
+	getPDELiquidityProviderStats               = "getpdeliquidityproviderstats"
 
 	// get burning address
 	getBurningAddress = "getburningaddress"
@@ -235,6 +283,8 @@ const (
 	getAmountTopUpWaitingPorting                  = "getamounttopupwaitingporting"
 	getPortalReqRedeemByTxIDStatus                = "getreqredeemstatusbytxid"
 	getReqRedeemFromLiquidationPoolByTxIDStatus   = "getreqredeemfromliquidationpoolbytxidstatus"
+	getPortalCustodianRisk                        = "getportalcustodianrisk"
+	getPortalExchangeRatesHistory                 = "getportalexchangerateshistory"
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
@@ -258,6 +308,9 @@ const (
 
 	// feature rewards
 	getRewardFeature = "getrewardfeature"
+
+	// validator performance
+	getValidatorPerformance = "getvalidatorperformance"
 )
 
 const (
@@ -279,4 +332,7 @@ const (
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeCoinScanner                         = "subcribecoinscanner"
+	subcribePortalCustodianRisk                 = "subcribeportalcustodianrisk"
+	subcribeValidatorPerformance                = "subcribevalidatorperformance"
 )
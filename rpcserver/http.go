@@ -45,6 +45,8 @@ type HttpServer struct {
 	walletService     *rpcservice.WalletService
 	portal            *rpcservice.PortalService
 	synkerService     *rpcservice.SynkerService
+	payoutService     *rpcservice.PayoutService
+	coinScanner       *rpcservice.CoinScannerService
 }
 
 func (httpServer *HttpServer) Init(config *RpcServerConfig) {
@@ -82,6 +84,12 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 		FeeEstimator: httpServer.config.FeeEstimator,
 		TxMemPool:    httpServer.config.TxMemPool,
 	}
+	httpServer.payoutService = &rpcservice.PayoutService{
+		BlockChain: httpServer.config.BlockChain,
+		TxService:  httpServer.txService,
+		TxMemPool:  httpServer.config.TxMemPool,
+		DB:         httpServer.config.Database[common.BeaconChainDataBaseID],
+	}
 	httpServer.walletService = &rpcservice.WalletService{
 		Wallet:     httpServer.config.Wallet,
 		BlockChain: httpServer.config.BlockChain,
@@ -93,6 +101,13 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 	httpServer.portal = &rpcservice.PortalService{
 		BlockChain: httpServer.config.BlockChain,
 	}
+	coinScanner, err := rpcservice.NewCoinScannerService(httpServer.config.BlockChain, httpServer.config.Database[common.BeaconChainDataBaseID],
+		httpServer.config.PubSubManager, httpServer.config.CoinScannerPassphrase)
+	if err != nil {
+		Logger.log.Errorf("Can not load coin scanner %+v", err)
+	} else {
+		httpServer.coinScanner = coinScanner
+	}
 }
 
 // Start is used by rpcserver.go to start the rpc listener.
@@ -123,6 +138,11 @@ func (httpServer *HttpServer) Start() error {
 			Logger.log.Infof("RPC Http listener done for %s", listen.Addr())
 		}(listen)
 	}
+	if httpServer.coinScanner != nil {
+		if err := httpServer.coinScanner.Start(); err != nil {
+			Logger.log.Errorf("Can not start coin scanner %+v", err)
+		}
+	}
 	atomic.StoreInt32(&httpServer.started, 1)
 	return nil
 }
@@ -140,6 +160,9 @@ func (httpServer *HttpServer) Stop() {
 	for _, listen := range httpServer.config.HttpListenters {
 		listen.Close()
 	}
+	if httpServer.coinScanner != nil && httpServer.started != 0 {
+		httpServer.coinScanner.Stop()
+	}
 	Logger.log.Warn("RPC server shutdown complete")
 	atomic.StoreInt32(&httpServer.started, 0)
 	atomic.StoreInt32(&httpServer.shutdown, 1)
@@ -0,0 +1,124 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// getCoinScanner returns coin scanner of node, which is only enabled when coinscannerpassphrase is set
+func (httpServer *HttpServer) getCoinScanner() (*rpcservice.CoinScannerService, *rpcservice.RPCError) {
+	if httpServer.coinScanner == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CoinScannerNotEnabledError, nil)
+	}
+	return httpServer.coinScanner, nil
+}
+
+// handleRegisterCoinScannerKey registers a key to coin scanner, which keeps balances of its coins in new blocks
+// param #1: readonly key, private keys are rejected so node never keeps a key which can spend coins
+// param #2: height of each shard to scan from (optional), default is the next block
+func (httpServer *HttpServer) handleRegisterCoinScannerKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	coinScanner, rpcErr := httpServer.getCoinScanner()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	keyStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key is invalid"))
+	}
+	fromHeight := uint64(0)
+	if len(arrayParams) > 1 {
+		fromHeightParam, ok := arrayParams[1].(float64)
+		if !ok || fromHeightParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("from height is invalid"))
+		}
+		fromHeight = uint64(fromHeightParam)
+	}
+	return coinScanner.RegisterKey(keyStr, fromHeight)
+}
+
+// handleUnregisterCoinScannerKey removes key of payment address and its coins from coin scanner
+// param #1: payment address
+func (httpServer *HttpServer) handleUnregisterCoinScannerKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	coinScanner, rpcErr := httpServer.getCoinScanner()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	if rpcErr := coinScanner.UnregisterKey(paymentAddress); rpcErr != nil {
+		return nil, rpcErr
+	}
+	return true, nil
+}
+
+func (httpServer *HttpServer) handleListCoinScannerKeys(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	coinScanner, rpcErr := httpServer.getCoinScanner()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return coinScanner.ListKeys(), nil
+}
+
+// handleGetCoinScannerBalance returns balances of unspent coins of key of payment address by token id
+// param #1: payment address
+func (httpServer *HttpServer) handleGetCoinScannerBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	coinScanner, rpcErr := httpServer.getCoinScanner()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	return coinScanner.GetBalances(paymentAddress)
+}
+
+// handleListCoinScannerCoins returns coins of key of payment address found by coin scanner
+// param #1: payment address
+// param #2: token id (optional), default is all tokens
+// param #3: unspent only flag (optional)
+func (httpServer *HttpServer) handleListCoinScannerCoins(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	coinScanner, rpcErr := httpServer.getCoinScanner()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	tokenID := ""
+	if len(arrayParams) > 1 {
+		tokenID, ok = arrayParams[1].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id is invalid"))
+		}
+	}
+	unspentOnly := false
+	if len(arrayParams) > 2 {
+		unspentOnly, ok = arrayParams[2].(bool)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("unspent only flag is invalid"))
+		}
+	}
+	return coinScanner.ListCoins(paymentAddress, tokenID, unspentOnly)
+}
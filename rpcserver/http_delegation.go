@@ -0,0 +1,157 @@
+package rpcserver
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// getDelegatorPaymentAddress returns the payment address of the private key param (arrayParams[0])
+func getDelegatorPaymentAddress(arrayParams []interface{}) (string, *rpcservice.RPCError) {
+	privateKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyParam)
+	if err != nil {
+		return "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New(fmt.Sprintf("Wrong privatekey %+v", err)))
+	}
+	keyWallet.KeySet.InitFromPrivateKeyByte(keyWallet.KeySet.PrivateKey)
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType), nil
+}
+
+// handleCreateRawDelegationTransaction - burns DelegationAmount PRV of the sender and delegates it to CommitteePublicKey
+func (httpServer *HttpServer) handleCreateRawDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	delegatorPaymentAddress, rpcErr := getDelegatorPaymentAddress(arrayParams)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	metaParam, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	delegationAmount, ok := metaParam["DelegationAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("delegation amount is invalid"))
+	}
+	if _, ok := metaParam["DelegatorPaymentAddress"]; !ok {
+		metaParam["DelegatorPaymentAddress"] = delegatorPaymentAddress
+	}
+
+	burningAddress := httpServer.blockService.GetBurningAddress(0)
+	arrayParams[1] = map[string]interface{}{burningAddress: delegationAmount}
+	arrayParams[4] = interface{}(metaParam)
+	return httpServer.createRawTxWithMetadata(
+		arrayParams,
+		closeChan,
+		metadata.NewDelegationMetadataFromRPC,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.createAndSendTxWithMetadata(
+		params,
+		closeChan,
+		(*HttpServer).handleCreateRawDelegationTransaction,
+		(*HttpServer).handleSendRawTransaction,
+	)
+}
+
+// handleCreateRawUnDelegationTransaction - requests to return the delegation DelegationTxID to its delegator
+func (httpServer *HttpServer) handleCreateRawUnDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	delegatorPaymentAddress, rpcErr := getDelegatorPaymentAddress(arrayParams)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	metaParam, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	metaParam["DelegatorPaymentAddress"] = delegatorPaymentAddress
+
+	burningAddress := httpServer.blockService.GetBurningAddress(0)
+	arrayParams[1] = map[string]interface{}{burningAddress: float64(0)}
+	arrayParams[4] = interface{}(metaParam)
+	return httpServer.createRawTxWithMetadata(
+		arrayParams,
+		closeChan,
+		metadata.NewUnDelegationMetadataFromRPC,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendUnDelegationTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.createAndSendTxWithMetadata(
+		params,
+		closeChan,
+		(*HttpServer).handleCreateRawUnDelegationTransaction,
+		(*HttpServer).handleSendRawTransaction,
+	)
+}
+
+// handleCreateRawSetCommissionRateTransaction - sets the commission rate (in basis points) of a validator,
+// the sender must be the funder of the validator's staking tx
+func (httpServer *HttpServer) handleCreateRawSetCommissionRateTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	burningAddress := httpServer.blockService.GetBurningAddress(0)
+	arrayParams[1] = map[string]interface{}{burningAddress: float64(0)}
+	return httpServer.createRawTxWithMetadata(
+		arrayParams,
+		closeChan,
+		metadata.NewSetCommissionRateMetadataFromRPC,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendSetCommissionRateTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.createAndSendTxWithMetadata(
+		params,
+		closeChan,
+		(*HttpServer).handleCreateRawSetCommissionRateTransaction,
+		(*HttpServer).handleSendRawTransaction,
+	)
+}
+
+// handleGetDelegations - Get the delegations and the commission rate of a validator (committee public key) in the current epoch
+func (httpServer *HttpServer) handleGetDelegations(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	committeePublicKey, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("committee public key is invalid"))
+	}
+
+	beaconBestState := httpServer.config.BlockChain.GetBeaconBestState()
+	stateDB := beaconBestState.GetBeaconConsensusStateDB()
+	commissionRate, _, err := statedb.GetValidatorCommission(stateDB, committeePublicKey, beaconBestState.Epoch)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	delegations, err := statedb.GetDelegationsByCommitteePublicKey(stateDB, committeePublicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return statedb.CommitteeDelegationInfo{
+		CommitteePublicKey: committeePublicKey,
+		CommissionRate:     commissionRate,
+		Delegations:        delegations,
+	}, nil
+}
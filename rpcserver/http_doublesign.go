@@ -0,0 +1,36 @@
+package rpcserver
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/pkg/errors"
+)
+
+// handleCreateRawDoubleSignEvidenceTransaction - submits the evidence of a committee public key signing
+// two different shard blocks of the same height and round
+func (httpServer *HttpServer) handleCreateRawDoubleSignEvidenceTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	if _, ok := arrayParams[4].(map[string]interface{}); !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	burningAddress := httpServer.blockService.GetBurningAddress(0)
+	arrayParams[1] = map[string]interface{}{burningAddress: float64(0)}
+	return httpServer.createRawTxWithMetadata(
+		arrayParams,
+		closeChan,
+		metadata.NewDoubleSignEvidenceMetadataFromRPC,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendDoubleSignEvidenceTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.createAndSendTxWithMetadata(
+		params,
+		closeChan,
+		(*HttpServer).handleCreateRawDoubleSignEvidenceTransaction,
+		(*HttpServer).handleSendRawTransaction,
+	)
+}
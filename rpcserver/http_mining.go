@@ -185,3 +185,48 @@ func (httpServer *HttpServer) handleGetMinerRewardFromMiningKey(params interface
 
 	return rewardAmountResult, nil
 }
+
+/*
+handleSetMiningDrainMode - RPC enables (or disables) drain mode, the node stops proposing and voting blocks
+before switching its mining keys
+*/
+func (httpServer *HttpServer) handleSetMiningDrainMode(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param empty"))
+	}
+
+	drainParam, ok := arrayParams[0].(bool)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Drain param is invalid"))
+	}
+	httpServer.config.ConsensusEngine.SetMiningDrainMode(drainParam)
+	return jsonresult.NewMiningKeysStatusResult(httpServer.config.ConsensusEngine), nil
+}
+
+/*
+handleSwitchMiningKeys - RPC replaces the mining keys of a drained node without restart,
+then publishes the node state with the new keys
+*/
+func (httpServer *HttpServer) handleSwitchMiningKeys(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param empty"))
+	}
+
+	miningKeysParam, ok := arrayParams[0].(string)
+	if !ok || miningKeysParam == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Mining keys param is invalid"))
+	}
+	if err := httpServer.config.ConsensusEngine.SwitchMiningKeys(miningKeysParam); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	layer, _, chainID := httpServer.config.ConsensusEngine.GetUserRole()
+	if chainID >= -1 {
+		if err := httpServer.config.Server.PublishNodeState(layer, chainID); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+	return jsonresult.NewMiningKeysStatusResult(httpServer.config.ConsensusEngine), nil
+}
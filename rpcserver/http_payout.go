@@ -0,0 +1,127 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleCreateAndSendPayoutBatch packs a list of payouts into as few txs as possible, sends them and tracks their status
+// param #1: private key of sender
+// param #2: payouts, a json array or a csv string
+// param #3: estimation fee nano P per kb
+// param #4: hasPrivacyCoin flag (optional)
+// param #5: hasPrivacyToken flag (optional)
+func (httpServer *HttpServer) handleCreateAndSendPayoutBatch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	param, err := bean.NewCreatePayoutBatchParam(params)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	batch, rpcErr := httpServer.payoutService.NewPayoutBatch(param)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	rpcErr = httpServer.sendPayoutTxs(batch, param.SenderKeySet, closeChan)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return batch, nil
+}
+
+// handleRetryPayoutBatch sends again txs of payout batch which were not sent or failed
+// param #1: private key of sender
+// param #2: batch id
+func (httpServer *HttpServer) handleRetryPayoutBatch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	privateKey, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	}
+	keySet, _, err := rpcservice.GetKeySetFromPrivateKeyParams(privateKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.InvalidSenderPrivateKeyError, err)
+	}
+	batchID, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("batch id is invalid"))
+	}
+	batch, rpcErr := httpServer.payoutService.GetPayoutBatch(batchID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if err := batch.CheckSender(keySet); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.InvalidSenderPrivateKeyError, err)
+	}
+	rpcErr = httpServer.sendPayoutTxs(batch, keySet, closeChan)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return batch, nil
+}
+
+// handleGetPayoutBatch returns payout batch with status of its txs
+// param #1: batch id
+func (httpServer *HttpServer) handleGetPayoutBatch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	batchID, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("batch id is invalid"))
+	}
+	return httpServer.payoutService.GetPayoutBatch(batchID)
+}
+
+func (httpServer *HttpServer) handleListPayoutBatches(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.payoutService.ListPayoutBatches()
+}
+
+// sendPayoutTxs builds and sends txs of batch which are pending or failed, result of each tx is recorded in batch
+func (httpServer *HttpServer) sendPayoutTxs(batch *rpcservice.PayoutBatch, keySet *incognitokey.KeySet, closeChan <-chan struct{}) *rpcservice.RPCError {
+	for _, txIndex := range batch.TxsToSend() {
+		select {
+		case <-closeChan:
+			return nil
+		default:
+		}
+		txID, err := httpServer.sendPayoutTx(batch, txIndex, keySet, closeChan)
+		if rpcErr := httpServer.payoutService.UpdatePayoutTx(batch, txIndex, txID, err); rpcErr != nil {
+			return rpcErr
+		}
+	}
+	return nil
+}
+
+func (httpServer *HttpServer) sendPayoutTx(batch *rpcservice.PayoutBatch, txIndex int, keySet *incognitokey.KeySet, closeChan <-chan struct{}) (string, error) {
+	tx, rpcErr := httpServer.payoutService.BuildPayoutTx(batch, txIndex, keySet)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return "", err
+	}
+	sendParams := []interface{}{base58.Base58Check{}.Encode(txBytes, common.ZeroByte)}
+	if batch.Txs[txIndex].TokenID == "" {
+		sendResult, rpcErr := httpServer.handleSendRawTransaction(sendParams, closeChan)
+		if rpcErr != nil {
+			return "", rpcErr
+		}
+		return sendResult.(jsonresult.CreateTransactionResult).TxID, nil
+	}
+	sendResult, rpcErr := httpServer.handleSendRawPrivacyCustomTokenTransaction(sendParams, closeChan)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	return sendResult.(jsonresult.CreateTransactionTokenResult).TxID, nil
+}
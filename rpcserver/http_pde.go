@@ -1177,6 +1177,14 @@ func (httpServer *HttpServer) handleCreateRawTxWithPRVCrossPoolTradeReq(params i
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
+	// UseBestRoute is optional, trades are routed via PRV pools by default
+	useBestRoute := false
+	if _, ok := data["UseBestRoute"]; ok {
+		useBestRoute, ok = data["UseBestRoute"].(bool)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("UseBestRoute is invalid"))
+		}
+	}
 	meta, _ := metadata.NewPDECrossPoolTradeRequest(
 		tokenIDToBuyStr,
 		tokenIDToSellStr,
@@ -1184,6 +1192,7 @@ func (httpServer *HttpServer) handleCreateRawTxWithPRVCrossPoolTradeReq(params i
 		minAcceptableAmount,
 		tradingFee,
 		traderAddressStr,
+		useBestRoute,
 		metadata.PDECrossPoolTradeRequestMeta,
 	)
 
@@ -1269,6 +1278,14 @@ func (httpServer *HttpServer) handleCreateRawTxWithPTokenCrossPoolTradeReq(param
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
+	// UseBestRoute is optional, trades are routed via PRV pools by default
+	useBestRoute := false
+	if _, ok := tokenParamsRaw["UseBestRoute"]; ok {
+		useBestRoute, ok = tokenParamsRaw["UseBestRoute"].(bool)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("UseBestRoute is invalid"))
+		}
+	}
 	meta, _ := metadata.NewPDECrossPoolTradeRequest(
 		tokenIDToBuyStr,
 		tokenIDToSellStr,
@@ -1276,6 +1293,7 @@ func (httpServer *HttpServer) handleCreateRawTxWithPTokenCrossPoolTradeReq(param
 		minAcceptableAmount,
 		tradingFee,
 		traderAddressStr,
+		useBestRoute,
 		metadata.PDECrossPoolTradeRequestMeta,
 	)
 
@@ -1471,3 +1489,646 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPDEFeeWithdrawalReq(param
 	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
 	return result, nil
 }
+
+func parsePDELimitOrderMeta(data map[string]interface{}) (*metadata.PDELimitOrderRequest, *rpcservice.RPCError) {
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	sellAmount, err := common.AssertAndConvertStrToNumber(data["SellAmount"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	minAcceptableAmount, err := common.AssertAndConvertStrToNumber(data["MinAcceptableAmount"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	tradingFee, err := common.AssertAndConvertStrToNumber(data["TradingFee"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	// ExpiryBeaconHeight is required, at most metadata.MaxPDELimitOrderLifetime beacon blocks ahead
+	expiryBeaconHeight, err := common.AssertAndConvertStrToNumber(data["ExpiryBeaconHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	meta, _ := metadata.NewPDELimitOrderRequest(
+		tokenIDToBuyStr,
+		tokenIDToSellStr,
+		sellAmount,
+		minAcceptableAmount,
+		tradingFee,
+		traderAddressStr,
+		expiryBeaconHeight,
+		metadata.PDELimitOrderRequestMeta,
+	)
+	return meta, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPRVLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDELimitOrderMeta(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParamV2(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPRVLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPRVLimitOrderReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithPTokenLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
+	}
+
+	if len(arrayParams) >= 7 {
+		hasPrivacyToken := int(arrayParams[6].(float64)) > 0
+		if hasPrivacyToken {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("The privacy mode must be disabled"))
+		}
+	}
+	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, rpcErr := parsePDELimitOrderMeta(tokenParamsRaw)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransactionV2(params, meta)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err2 := json.Marshal(customTokenTx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPTokenLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPTokenLimitOrderReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithCancelLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	orderIDStr, ok := data["OrderID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	orderID, err := common.Hash{}.NewHashFromStr(orderIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	traderAddressStr, ok := data["TraderAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, _ := metadata.NewPDECancelLimitOrderRequest(
+		*orderID,
+		traderAddressStr,
+		metadata.PDECancelLimitOrderRequestMeta,
+	)
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParamV2(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithCancelLimitOrderReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithCancelLimitOrderReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+// handleGetPDELimitOrders returns the resting limit orders of the order book at a beacon height,
+// optionally filtered by TraderAddressStr
+func (httpServer *HttpServer) handleGetPDELimitOrders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	beaconHeight, ok := data["BeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	}
+	traderAddressStr := ""
+	if _, ok := data["TraderAddressStr"]; ok {
+		traderAddressStr, ok = data["TraderAddressStr"].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TraderAddressStr is invalid"))
+		}
+	}
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	result := make(map[string]*rawdbv2.PDELimitOrder)
+	for key, order := range pdeState.PDELimitOrders {
+		if traderAddressStr != "" && order.TraderAddressStr != traderAddressStr {
+			continue
+		}
+		result[key] = order
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleGetPDELimitOrderStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	status, err := httpServer.blockService.GetPDEStatus(rawdbv2.PDELimitOrderStatusPrefix, txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return status, nil
+}
+
+func (httpServer *HttpServer) handleGetPDECancelLimitOrderStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	status, err := httpServer.blockService.GetPDEStatus(rawdbv2.PDECancelOrderStatusPrefix, txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return status, nil
+}
+
+// handleGetPDETradeRouteQuote quotes the best route of a trade through any pools,
+// the receiving amount of the default route via PRV pools is returned for comparison
+func (httpServer *HttpServer) handleGetPDETradeRouteQuote(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToSellStr is invalid"))
+	}
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToBuyStr is invalid"))
+	}
+	sellAmount, err := common.AssertAndConvertStrToNumber(data["SellAmount"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	tradingFee := uint64(0)
+	if _, ok := data["TradingFee"]; ok {
+		tradingFee, err = common.AssertAndConvertStrToNumber(data["TradingFee"])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+	}
+	maxHops := blockchain.MaxPDETradeRouteHops
+	if _, ok := data["MaxHops"]; ok {
+		maxHopsParam, ok := data["MaxHops"].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("MaxHops is invalid"))
+		}
+		maxHops = int(maxHopsParam)
+	}
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if _, ok := data["BeaconHeight"]; ok {
+		beaconHeightParam, ok := data["BeaconHeight"].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+		}
+		beaconHeight = uint64(beaconHeightParam)
+	}
+
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconHeight)
+	if err != nil || pdeState == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	route, err := blockchain.GetPDETradeRouteQuote(pdeState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, sellAmount, tradingFee, maxHops)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	type PDETradeRouteQuote struct {
+		*blockchain.PDETradeRoute
+		BeaconHeight              uint64
+		DefaultRouteReceiveAmount uint64
+	}
+	result := PDETradeRouteQuote{
+		PDETradeRoute:             route,
+		BeaconHeight:              beaconHeight,
+		DefaultRouteReceiveAmount: blockchain.GetPDEDefaultTradeReceiveAmount(pdeState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, sellAmount),
+	}
+	return result, nil
+}
+
+// handleGetPDETWAP returns the time weighted average price of a pool pair over beacon blocks (FromBeaconHeight, ToBeaconHeight],
+// ToBeaconHeight is the latest beacon height if it is not set
+func (httpServer *HttpServer) handleGetPDETWAP(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	token1IDStr, ok := data["Token1IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token1IDStr is invalid"))
+	}
+	token2IDStr, ok := data["Token2IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token2IDStr is invalid"))
+	}
+	fromBeaconHeight, ok := data["FromBeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromBeaconHeight is invalid"))
+	}
+	toBeaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if _, ok := data["ToBeaconHeight"]; ok {
+		toBeaconHeightParam, ok := data["ToBeaconHeight"].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToBeaconHeight is invalid"))
+		}
+		toBeaconHeight = uint64(toBeaconHeightParam)
+	}
+	if uint64(fromBeaconHeight) >= toBeaconHeight {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromBeaconHeight should be less than ToBeaconHeight"))
+	}
+
+	fromStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(uint64(fromBeaconHeight), httpServer.GetBeaconChainDatabase())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	toStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(toBeaconHeight, httpServer.GetBeaconChainDatabase())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	twap, err := blockchain.CalcPDETWAP(fromStateDB, uint64(fromBeaconHeight), toStateDB, toBeaconHeight, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return twap, nil
+}
+
+// maxPDELiquidityProviderStatsBlocks bounds the number of beacon blocks scanned for deposits and withdrawals
+const maxPDELiquidityProviderStatsBlocks = 5000
+
+func (httpServer *HttpServer) getPDEToken1PriceAtBeaconHeight(
+	beaconHeight uint64,
+	token1IDStr string,
+	token2IDStr string,
+) (float64, error) {
+	beaconFeatureStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(beaconHeight, httpServer.GetBeaconChainDatabase())
+	if err != nil {
+		return 0, err
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconHeight)
+	if err != nil {
+		return 0, err
+	}
+	return blockchain.GetPDEPoolPairToken1Price(pdeState, beaconHeight, token1IDStr, token2IDStr), nil
+}
+
+// collectPDELiquidityProviderEvents scans beacon blocks in [fromBeaconHeight, toBeaconHeight] for matched contributions
+// and accepted withdrawals of the contributor on the pool pair, amounts are taken from their status records
+func (httpServer *HttpServer) collectPDELiquidityProviderEvents(
+	fromBeaconHeight uint64,
+	toBeaconHeight uint64,
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+) ([]*blockchain.PDELiquidityProviderEvent, error) {
+	isPairToken := func(tokenIDStr string) bool {
+		return tokenIDStr == token1IDStr || tokenIDStr == token2IDStr
+	}
+	events := []*blockchain.PDELiquidityProviderEvent{}
+	contributionPairIDs := map[string]bool{}
+	withdrawalEvents := map[string]*blockchain.PDELiquidityProviderEvent{}
+	for height := fromBeaconHeight; height <= toBeaconHeight; height++ {
+		beaconBlocks, err := blockchain.FetchBeaconBlockFromHeight(httpServer.config.BlockChain, height, height)
+		if err != nil {
+			return nil, err
+		}
+		if len(beaconBlocks) == 0 {
+			continue
+		}
+		for _, inst := range beaconBlocks[0].Body.Instructions {
+			if len(inst) < 4 {
+				continue // Not error, just not PDE instruction
+			}
+			switch inst[0] {
+			case strconv.Itoa(metadata.PDEContributionMeta), strconv.Itoa(metadata.PDEPRVRequiredContributionRequestMeta):
+				var contributionPairID string
+				if inst[2] == common.PDEContributionMatchedChainStatus {
+					pdeContrib, err := parsePDEContributionInst(inst, height)
+					if err != nil || pdeContrib == nil ||
+						pdeContrib.ContributorAddressStr != contributorAddressStr || !isPairToken(pdeContrib.TokenIDStr) {
+						continue
+					}
+					contributionPairID = pdeContrib.PDEContributionPairID
+				} else if inst[2] == common.PDEContributionMatchedNReturnedChainStatus {
+					var matchedNReturnedContrib metadata.PDEMatchedNReturnedContribution
+					err := json.Unmarshal([]byte(inst[3]), &matchedNReturnedContrib)
+					if err != nil || matchedNReturnedContrib.ContributorAddressStr != contributorAddressStr ||
+						!isPairToken(matchedNReturnedContrib.TokenIDStr) {
+						continue
+					}
+					contributionPairID = matchedNReturnedContrib.PDEContributionPairID
+				}
+				if contributionPairID == "" || contributionPairIDs[contributionPairID] {
+					continue
+				}
+				contributionStatus, err := httpServer.blockService.GetPDEContributionStatus(rawdbv2.PDEContributionStatusPrefix, []byte(contributionPairID))
+				if err != nil {
+					return nil, err
+				}
+				if contributionStatus == nil ||
+					(contributionStatus.Status != common.PDEContributionAcceptedStatus && contributionStatus.Status != common.PDEContributionMatchedNReturnedStatus) ||
+					!isPairToken(contributionStatus.TokenID1Str) || !isPairToken(contributionStatus.TokenID2Str) {
+					continue
+				}
+				contributionPairIDs[contributionPairID] = true
+				event := &blockchain.PDELiquidityProviderEvent{
+					Type:         blockchain.PDELiquidityProviderDepositEvent,
+					RequestID:    contributionPairID,
+					BeaconHeight: height,
+				}
+				token1Amount := contributionStatus.Contributed1Amount - contributionStatus.Returned1Amount
+				token2Amount := contributionStatus.Contributed2Amount - contributionStatus.Returned2Amount
+				if contributionStatus.TokenID1Str == token1IDStr {
+					event.Token1Amount, event.Token2Amount = token1Amount, token2Amount
+				} else {
+					event.Token1Amount, event.Token2Amount = token2Amount, token1Amount
+				}
+				events = append(events, event)
+			case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+				pdeWithdrawal, err := parsePDEWithdrawalInst(inst, height)
+				if err != nil || pdeWithdrawal == nil || pdeWithdrawal.WithdrawerAddressStr != contributorAddressStr ||
+					!isPairToken(pdeWithdrawal.PairToken1IDStr) || !isPairToken(pdeWithdrawal.PairToken2IDStr) {
+					continue
+				}
+				event, found := withdrawalEvents[pdeWithdrawal.TxReqID.String()]
+				if !found {
+					status, err := httpServer.blockService.GetPDEStatus(rawdbv2.PDEWithdrawalStatusPrefix, pdeWithdrawal.TxReqID[:])
+					if err != nil {
+						return nil, err
+					}
+					if status != common.PDEWithdrawalAcceptedStatus {
+						continue
+					}
+					event = &blockchain.PDELiquidityProviderEvent{
+						Type:         blockchain.PDELiquidityProviderWithdrawalEvent,
+						RequestID:    pdeWithdrawal.TxReqID.String(),
+						BeaconHeight: height,
+					}
+					withdrawalEvents[pdeWithdrawal.TxReqID.String()] = event
+					events = append(events, event)
+				}
+				if pdeWithdrawal.WithdrawalTokenIDStr == token1IDStr {
+					event.Token1Amount += pdeWithdrawal.DeductingPoolValue
+				} else {
+					event.Token2Amount += pdeWithdrawal.DeductingPoolValue
+				}
+			}
+		}
+	}
+
+	token1Prices := map[uint64]float64{}
+	for _, event := range events {
+		token1Price, found := token1Prices[event.BeaconHeight]
+		if !found {
+			var err error
+			token1Price, err = httpServer.getPDEToken1PriceAtBeaconHeight(event.BeaconHeight, token1IDStr, token2IDStr)
+			if err != nil {
+				return nil, err
+			}
+			token1Prices[event.BeaconHeight] = token1Price
+		}
+		event.Token1Price = token1Price
+	}
+	return events, nil
+}
+
+func (httpServer *HttpServer) handleGetPDELiquidityProviderStats(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	contributorAddressStr, ok := data["ContributorAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ContributorAddressStr is invalid"))
+	}
+	token1IDStr, ok := data["Token1IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token1IDStr is invalid"))
+	}
+	token2IDStr, ok := data["Token2IDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token2IDStr is invalid"))
+	}
+	fromBeaconHeight, ok := data["FromBeaconHeight"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromBeaconHeight is invalid"))
+	}
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if _, ok := data["BeaconHeight"]; ok {
+		beaconHeightParam, ok := data["BeaconHeight"].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BeaconHeight is invalid"))
+		}
+		beaconHeight = uint64(beaconHeightParam)
+	}
+	if uint64(fromBeaconHeight) > beaconHeight {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromBeaconHeight should not be greater than BeaconHeight"))
+	}
+	if beaconHeight-uint64(fromBeaconHeight) >= maxPDELiquidityProviderStatsBlocks {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("could not scan more than %d beacon blocks", maxPDELiquidityProviderStatsBlocks))
+	}
+
+	beaconFeatureStateDB, err := httpServer.config.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(beaconHeight, httpServer.GetBeaconChainDatabase())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	events, err := httpServer.collectPDELiquidityProviderEvents(uint64(fromBeaconHeight), beaconHeight, contributorAddressStr, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	stats, err := blockchain.BuildPDELiquidityProviderStats(pdeState, beaconHeight, contributorAddressStr, token1IDStr, token2IDStr, events)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	return stats, nil
+}
@@ -254,6 +254,67 @@ func (httpServer *HttpServer) handleGetPortalState(params interface{}, closeChan
 	return result, nil
 }
 
+/*
+handleGetPortalCustodianRisk - RPC returns collateral health of custodians at the latest final exchange rates
+- Param #1: map with optional keys
+	+ CustodianAddress: only returns this custodian
+	+ WarningRatio: TP ratio below it is warned, default is MinPercentLockedCollateral
+	+ BeaconHeight: default is beacon best height
+*/
+func (httpServer *HttpServer) handleGetPortalCustodianRisk(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	data := make(map[string]interface{})
+	if len(arrayParams) > 0 {
+		var ok bool
+		data, ok = arrayParams[0].(map[string]interface{})
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+		}
+	}
+	custodianAddress := ""
+	if data["CustodianAddress"] != nil {
+		var ok bool
+		custodianAddress, ok = data["CustodianAddress"].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param CustodianAddress is invalid"))
+		}
+	}
+	warningRatio := uint64(0)
+	if data["WarningRatio"] != nil {
+		var err error
+		warningRatio, err = common.AssertAndConvertStrToNumber(data["WarningRatio"])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+	}
+
+	beaconBestState := httpServer.config.BlockChain.GetBeaconBestState()
+	beaconHeight := beaconBestState.BeaconHeight
+	stateDB := beaconBestState.GetBeaconFeatureStateDB()
+	if data["BeaconHeight"] != nil {
+		var err error
+		beaconHeight, err = common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		featureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(beaconBestState, beaconHeight)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+		}
+		stateDB, err = statedb.NewWithPrefixTrie(featureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.config.BlockChain.GetBeaconChainDatabase()))
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskError, err)
+		}
+	}
+
+	portalParam := httpServer.config.BlockChain.GetPortalParams(beaconHeight)
+	result, err := httpServer.portal.GetCustodianRiskDashboard(stateDB, beaconHeight, custodianAddress, warningRatio, portalParam)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskError, err)
+	}
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetPortalCustodianDepositStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	if len(arrayParams) < 1 {
@@ -155,6 +155,39 @@ func (httpServer *HttpServer) handleGetPortalFinalExchangeRates(params interface
 	return result, nil
 }
 
+/*
+handleGetPortalExchangeRatesHistory - RPC returns submitted and final exchange rates of exchange rates oracle per beacon block
+- Param #1: map with keys
+	+ FromBeaconHeight: first beacon height
+	+ ToBeaconHeight: last beacon height, at most MaxExchangeRatesHistoryBlocks blocks are returned
+*/
+func (httpServer *HttpServer) handleGetPortalExchangeRatesHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
+	}
+
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
+	}
+	fromHeight, err := common.AssertAndConvertStrToNumber(data["FromBeaconHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	toHeight, err := common.AssertAndConvertStrToNumber(data["ToBeaconHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	stateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	result, err := httpServer.portal.GetExchangeRatesHistory(stateDB, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetExchangeRatesHistoryError, err)
+	}
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleConvertExchangeRates(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 
@@ -0,0 +1,362 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// portfolioSpendParam contains params shared by rpcs which spend from accounts of a portfolio
+type portfolioSpendParam struct {
+	name       string
+	feePerKb   int64
+	hasPrivacy bool
+	tokenID    *common.Hash
+}
+
+// newPortfolioSpendParam reads portfolio name (#1), fee per kb (#3), privacy flag (#4), passphrase of local wallet (#5)
+// and token id (#6, optional, PRV if it is empty)
+func (httpServer *HttpServer) newPortfolioSpendParam(arrayParams []interface{}) (*portfolioSpendParam, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	param := &portfolioSpendParam{tokenID: &common.PRVCoinID}
+	var ok bool
+	param.name, ok = arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("portfolio name is invalid"))
+	}
+	feePerKb, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("fee per kb is invalid"))
+	}
+	param.feePerKb = int64(feePerKb)
+	hasPrivacy, ok := arrayParams[3].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("privacy flag is invalid"))
+	}
+	param.hasPrivacy = hasPrivacy > 0
+	passPhrase, ok := arrayParams[4].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+	if passPhrase != httpServer.config.Wallet.PassPhrase {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
+	}
+	if len(arrayParams) > 5 {
+		tokenIDStr, ok := arrayParams[5].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id is invalid"))
+		}
+		if tokenIDStr != "" {
+			tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+			if err != nil {
+				return nil, rpcservice.NewRPCError(rpcservice.TokenIsInvalidError, err)
+			}
+			param.tokenID = tokenID
+		}
+	}
+	return param, nil
+}
+
+// getPortfolioSenders returns spending accounts of portfolio with the PRV each of them keeps for fee
+func (httpServer *HttpServer) getPortfolioSenders(param *portfolioSpendParam) ([]*rpcservice.PortfolioSender, *rpcservice.RPCError) {
+	senders, rpcErr := httpServer.walletService.GetPortfolioSenders(param.name, param.tokenID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	for _, sender := range senders {
+		sender.FeeReserve, rpcErr = httpServer.txService.EstimateFeeReserve(param.feePerKb, sender.ShardID, param.hasPrivacy)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+	return senders, nil
+}
+
+// sendPortfolioTransaction creates and sends a tx which pays receivers from sender, it returns tx id
+func (httpServer *HttpServer) sendPortfolioTransaction(param *portfolioSpendParam, sender *rpcservice.PortfolioSender, receivers map[string]uint64, closeChan <-chan struct{}) (string, *rpcservice.RPCError) {
+	receiversParam := make(map[string]interface{})
+	tokenAmount := uint64(0)
+	for paymentAddress, amount := range receivers {
+		receiversParam[paymentAddress] = float64(amount)
+		tokenAmount += amount
+	}
+	privacyParam := float64(-1)
+	if param.hasPrivacy {
+		privacyParam = 1
+	}
+
+	if *param.tokenID == common.PRVCoinID {
+		result, rpcErr := httpServer.handleCreateAndSendTx([]interface{}{sender.PrivateKey, receiversParam, float64(param.feePerKb), privacyParam}, closeChan)
+		if rpcErr != nil {
+			return "", rpcErr
+		}
+		return result.(jsonresult.CreateTransactionResult).TxID, nil
+	}
+	tokenParams := map[string]interface{}{
+		"Privacy":        true,
+		"TokenID":        param.tokenID.String(),
+		"TokenName":      "",
+		"TokenSymbol":    "",
+		"TokenTxType":    float64(transaction.CustomTokenTransfer),
+		"TokenAmount":    float64(tokenAmount),
+		"TokenReceivers": receiversParam,
+		"TokenFee":       float64(0),
+	}
+	result, rpcErr := httpServer.handleCreateAndSendPrivacyCustomTokenTransaction([]interface{}{sender.PrivateKey, nil, float64(param.feePerKb), privacyParam, tokenParams}, closeChan)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	return result.(jsonresult.CreateTransactionTokenResult).TxID, nil
+}
+
+func portfolioSendError(err *rpcservice.RPCError, accountName string, sentTxs []jsonresult.PortfolioTransaction) *rpcservice.RPCError {
+	return rpcservice.NewRPCError(rpcservice.SendTxDataError, fmt.Errorf("send tx of account %s failed, sent txs %+v: %v", accountName, sentTxs, err))
+}
+
+/*
+handleCreatePortfolio - RPC creates a portfolio from accounts of local wallet, which are managed as one
+- Param #1: portfolio name
+- Param #2: account names
+- Param #3: shard ids, an account is created for each shard which has no account in portfolio
+- Param #4: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleCreatePortfolio(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 4 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+	name, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("portfolio name is invalid"))
+	}
+	accountNames := make([]string, 0)
+	for _, accountNameParam := range common.InterfaceSlice(arrayParams[1]) {
+		accountName, ok := accountNameParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("account name is invalid"))
+		}
+		accountNames = append(accountNames, accountName)
+	}
+	shardIDs := make([]byte, 0)
+	for _, shardIDParam := range common.InterfaceSlice(arrayParams[2]) {
+		shardID, ok := shardIDParam.(float64)
+		if !ok || shardID < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shard id is invalid"))
+		}
+		shardIDs = append(shardIDs, byte(shardID))
+	}
+	passPhrase, ok := arrayParams[3].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+	return httpServer.walletService.CreatePortfolio(name, accountNames, shardIDs, passPhrase)
+}
+
+/*
+handleRemovePortfolio - RPC removes a portfolio, its accounts are kept in local wallet
+- Param #1: portfolio name
+- Param #2: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleRemovePortfolio(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	name, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("portfolio name is invalid"))
+	}
+	passPhrase, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+	return httpServer.walletService.RemovePortfolio(name, passPhrase)
+}
+
+/*
+handleListPortfolios - RPC lists portfolios of local wallet with their accounts
+*/
+func (httpServer *HttpServer) handleListPortfolios(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.walletService.ListPortfolios()
+}
+
+/*
+handleGetPortfolioBalance - RPC returns balances of PRV and each privacy token aggregated over accounts of a portfolio
+- Param #1: portfolio name
+*/
+func (httpServer *HttpServer) handleGetPortfolioBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	name, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("portfolio name is invalid"))
+	}
+	return httpServer.walletService.GetPortfolioBalance(name)
+}
+
+/*
+handleCreateAndSendPortfolioTransaction - RPC pays receivers from accounts of a portfolio,
+each payment is sent from the account in receiver's shard if it has enough balance to avoid cross shard transfer,
+otherwise from the account with the largest balance. One tx is sent by each paying account
+- Param #1: portfolio name
+- Param #2: receivers, a map of payment address and amount
+- Param #3: estimation fee nano P per kb
+- Param #4: privacy flag (1 or -1)
+- Param #5: passPhrase of wallet
+- Param #6: token id (optional, PRV if it is empty)
+*/
+func (httpServer *HttpServer) handleCreateAndSendPortfolioTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	param, rpcErr := httpServer.newPortfolioSpendParam(arrayParams)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	receivers, ok := arrayParams[1].(map[string]interface{})
+	if !ok || len(receivers) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("receivers param is invalid"))
+	}
+	payments := make([]*rpcservice.PortfolioPayment, 0)
+	for paymentAddress, amountParam := range receivers {
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.InvalidReceiverPaymentAddressError, fmt.Errorf("payment address %s is invalid", paymentAddress))
+		}
+		amount, ok := amountParam.(float64)
+		if !ok || amount <= 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("amount of %s is invalid", paymentAddress))
+		}
+		pk := keyWallet.KeySet.PaymentAddress.Pk
+		payments = append(payments, &rpcservice.PortfolioPayment{
+			PaymentAddress: paymentAddress,
+			ShardID:        common.GetShardIDFromLastByte(pk[len(pk)-1]),
+			Amount:         uint64(amount),
+		})
+	}
+
+	senders, rpcErr := httpServer.getPortfolioSenders(param)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	routes, rpcErr := rpcservice.RoutePortfolioPayments(senders, payments, *param.tokenID == common.PRVCoinID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := make([]jsonresult.PortfolioTransaction, 0)
+	for _, sender := range senders {
+		senderPayments, ok := routes[sender.AccountName]
+		if !ok {
+			continue
+		}
+		item := jsonresult.PortfolioTransaction{
+			AccountName: sender.AccountName,
+			ShardID:     sender.ShardID,
+			Receivers:   make(map[string]uint64),
+		}
+		for _, payment := range senderPayments {
+			item.Receivers[payment.PaymentAddress] += payment.Amount
+		}
+		item.TxID, rpcErr = httpServer.sendPortfolioTransaction(param, sender, item.Receivers, closeChan)
+		if rpcErr != nil {
+			return nil, portfolioSendError(rpcErr, sender.AccountName, result)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+/*
+handleConsolidatePortfolio - RPC moves all balance of PRV or a privacy token of accounts of a portfolio
+into the account of the portfolio in target shard. If an account has more output coins than a tx can spend,
+the largest ones are moved, call it again to move the rest
+- Param #1: portfolio name
+- Param #2: target shard id
+- Param #3: estimation fee nano P per kb
+- Param #4: privacy flag (1 or -1)
+- Param #5: passPhrase of wallet
+- Param #6: token id (optional, PRV if it is empty)
+*/
+func (httpServer *HttpServer) handleConsolidatePortfolio(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	param, rpcErr := httpServer.newPortfolioSpendParam(arrayParams)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	targetShardIDParam, ok := arrayParams[1].(float64)
+	if !ok || targetShardIDParam < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("target shard id is invalid"))
+	}
+	targetShardID := byte(targetShardIDParam)
+
+	senders, rpcErr := httpServer.getPortfolioSenders(param)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var target *rpcservice.PortfolioSender
+	for _, sender := range senders {
+		if sender.ShardID == targetShardID {
+			target = sender
+			break
+		}
+	}
+	if target == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.PortfolioError, fmt.Errorf("portfolio has no account which can spend in shard %d", targetShardID))
+	}
+	targetAddress := wallet.KeyWallet{KeySet: *target.KeySet}
+	targetPaymentAddress := targetAddress.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	result := make([]jsonresult.PortfolioTransaction, 0)
+	for _, sender := range senders {
+		if sender.ShardID == targetShardID || sender.Balance == 0 {
+			continue
+		}
+		item := jsonresult.PortfolioTransaction{
+			AccountName: sender.AccountName,
+			ShardID:     sender.ShardID,
+			Receivers:   make(map[string]uint64),
+		}
+		if *param.tokenID != common.PRVCoinID {
+			item.Receivers[targetPaymentAddress] = sender.Balance
+			item.TxID, rpcErr = httpServer.sendPortfolioTransaction(param, sender, item.Receivers, closeChan)
+			if rpcErr != nil {
+				return nil, portfolioSendError(rpcErr, sender.AccountName, result)
+			}
+			result = append(result, item)
+			continue
+		}
+
+		// PRV is swept without change, the amount depends on the fee
+		tx, amount, rpcErr := httpServer.txService.BuildRawSweepTransaction(sender.KeySet, sender.ShardID, target.KeySet.PaymentAddress, param.feePerKb, param.hasPrivacy)
+		if rpcErr != nil {
+			return nil, portfolioSendError(rpcErr, sender.AccountName, result)
+		}
+		if tx == nil {
+			continue
+		}
+		txBytes, err := json.Marshal(tx)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
+		}
+		sendResult, rpcErr := httpServer.handleSendRawTransaction([]interface{}{base58.Base58Check{}.Encode(txBytes, common.ZeroByte)}, closeChan)
+		if rpcErr != nil {
+			return nil, portfolioSendError(rpcErr, sender.AccountName, result)
+		}
+		item.TxID = sendResult.(jsonresult.CreateTransactionResult).TxID
+		item.Receivers[targetPaymentAddress] = amount
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -34,6 +35,29 @@ func (httpServer *HttpServer) handleCreateRawTransaction(params interface{}, clo
 	return result, nil
 }
 
+// handleCreateUnsignedTransaction handles createunsignedtransaction commands.
+// It builds a PRV or privacy token transfer from sender's payment address and readonly key,
+// the result is signed offline by incognitoctl and broadcasted by sendtransaction
+// (or sendrawprivacycustomtokentransaction for privacy token transfer)
+func (httpServer *HttpServer) handleCreateUnsignedTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	unsignedTx, err := httpServer.txService.BuildUnsignedTransaction(createUnsignedTxParam, nil)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTxBytes, errMarshal := json.Marshal(unsignedTx)
+	if errMarshal != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, errMarshal)
+	}
+
+	result := jsonresult.NewCreateTransactionResult(nil, common.EmptyString, unsignedTxBytes, createUnsignedTxParam.ShardIDSender)
+	return result, nil
+}
+
 // handleSendTransaction implements the sendtransaction command.
 // Parameter #1—a serialized transaction to broadcast
 // Parameter #2–whether to allow high fees
@@ -71,6 +95,9 @@ func (httpServer *HttpServer) handleCreateAndSendTx(params interface{}, closeCha
 	var err error
 	data, err := httpServer.handleCreateRawTransaction(params, closeChan)
 	if err.(*rpcservice.RPCError) != nil {
+		if rpcservice.HasRPCErrorCode(err.(*rpcservice.RPCError), rpcservice.ExceedMaxInputCoinsError) {
+			return nil, httpServer.autoDefragmentAccount(params, nil, closeChan, err.(*rpcservice.RPCError))
+		}
 		return nil, rpcservice.NewRPCError(rpcservice.CreateTxDataError, err)
 	}
 	tx := data.(jsonresult.CreateTransactionResult)
@@ -151,6 +178,19 @@ func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, clo
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Tx hash is invalid"))
 	}
+
+	// param #2: readonly key of receiver (optional), memos of its output coins are decrypted
+	if len(arrayParams) > 1 && arrayParams[1] != nil {
+		readonlyKeyStr, ok := arrayParams[1].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("readonly key is invalid"))
+		}
+		readonlyKey, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+		if err != nil || len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("readonly key is invalid"))
+		}
+		return httpServer.txService.GetTransactionByHashForOwner(txHashStr, readonlyKey.KeySet.ReadonlyKey)
+	}
 	return httpServer.txService.GetTransactionByHash(txHashStr)
 }
 
@@ -580,6 +620,14 @@ func (httpServer *HttpServer) handleSendRawPrivacyCustomTokenTransaction(params
 func (httpServer *HttpServer) handleCreateAndSendPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	data, err := httpServer.handleCreateRawPrivacyCustomTokenTransaction(params, closeChan)
 	if err != nil {
+		if rpcservice.HasRPCErrorCode(err, rpcservice.ExceedMaxInputCoinsError) {
+			var tokenParamsRaw map[string]interface{}
+			if rpcservice.HasRPCErrorCode(err, rpcservice.BuildTokenParamError) {
+				// token coins exceed limit, PRV coins for fee are chosen after token coins
+				tokenParamsRaw, _ = common.InterfaceSlice(params)[4].(map[string]interface{})
+			}
+			return nil, httpServer.autoDefragmentAccount(params, tokenParamsRaw, closeChan, err)
+		}
 		return nil, err
 	}
 	tx := data.(jsonresult.CreateTransactionTokenResult)
@@ -862,3 +910,62 @@ func (httpServer *HttpServer) handleDecryptOutputCoinByKeyOfTransaction(params i
 
 	return result, err2
 }
+
+// autoDefragmentAccount sends a defragment tx when a payment needs more input coins than limit of a tx
+// and "AutoDefragment" is set in request, PRV coins are merged if tokenParamsRaw is nil, otherwise token coins are merged.
+// Payment is not sent, it can be retried after the defragment tx is confirmed
+func (httpServer *HttpServer) autoDefragmentAccount(params interface{}, tokenParamsRaw map[string]interface{}, closeChan <-chan struct{}, exceedErr *rpcservice.RPCError) *rpcservice.RPCError {
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+	if !createRawTxParam.AutoDefragment {
+		return exceedErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	hasPrivacyCoin := float64(-1)
+	if createRawTxParam.HasPrivacyCoin {
+		hasPrivacyCoin = 1
+	}
+
+	var defragmentResult interface{}
+	var err *rpcservice.RPCError
+	if tokenParamsRaw == nil {
+		totalAmount := uint64(0)
+		for _, paymentInfo := range createRawTxParam.PaymentInfos {
+			totalAmount += paymentInfo.Amount
+		}
+		// merge coins which are not larger than payment amount
+		defragmentParams := []interface{}{
+			arrayParams[0],
+			float64(totalAmount),
+			float64(createRawTxParam.EstimateFeeCoinPerKb),
+			hasPrivacyCoin,
+			float64(rpcservice.MaxInputCoinsPerTx(1, createRawTxParam.HasPrivacyCoin)),
+		}
+		defragmentResult, err = httpServer.handleDefragmentAccount(defragmentParams, closeChan)
+	} else {
+		defragmentTokenParamsRaw := make(map[string]interface{})
+		for key, value := range tokenParamsRaw {
+			defragmentTokenParamsRaw[key] = value
+		}
+		defragmentTokenParamsRaw["TokenTxType"] = float64(transaction.CustomTokenTransfer)
+		defragmentTokenParamsRaw["TokenAmount"] = float64(0)
+		defragmentTokenParamsRaw["TokenFee"] = float64(0)
+		defragmentTokenParamsRaw["TokenReceivers"] = map[string]interface{}{}
+		defragmentParams := []interface{}{
+			arrayParams[0],
+			nil,
+			float64(createRawTxParam.EstimateFeeCoinPerKb),
+			hasPrivacyCoin,
+			defragmentTokenParamsRaw,
+		}
+		defragmentResult, err = httpServer.handleDefragmentAccountToken(defragmentParams, closeChan)
+	}
+	if err != nil {
+		Logger.log.Errorf("Auto defragment account failed, err: %+v", err)
+		return exceedErr
+	}
+	return rpcservice.NewRPCError(rpcservice.ExceedMaxInputCoinsError,
+		fmt.Errorf("defragment tx %+v is sent, retry payment after it is confirmed", defragmentResult.(jsonresult.CreateTransactionResult).TxID))
+}
@@ -0,0 +1,50 @@
+package rpcserver
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/pkg/errors"
+)
+
+// handleGetValidatorPerformance - Get statistics of committee members of a chain (shard ID or -1 for beacon) in an epoch,
+// derived from blocks finalized by this node, optionally filtered by a committee public key
+func (httpServer *HttpServer) handleGetValidatorPerformance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	chainIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chain ID is invalid"))
+	}
+	epochParam, ok := arrayParams[1].(float64)
+	if !ok || epochParam < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("epoch is invalid"))
+	}
+	committeePublicKey := common.EmptyString
+	if len(arrayParams) > 2 {
+		committeePublicKey, ok = arrayParams[2].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("committee public key is invalid"))
+		}
+	}
+
+	epochPerformance, err := httpServer.config.BlockChain.GetValidatorPerformance(int(chainIDParam), uint64(epochParam))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := []jsonresult.ValidatorPerformance{}
+	for key, performance := range epochPerformance.Validators {
+		if committeePublicKey != common.EmptyString && key != committeePublicKey {
+			continue
+		}
+		result = append(result, jsonresult.NewValidatorPerformance(epochPerformance.ChainID, epochPerformance.Epoch, key, performance))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CommitteePublicKey < result[j].CommitteePublicKey
+	})
+	return result, nil
+}
@@ -156,6 +156,108 @@ func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan
 	return httpServer.walletService.RemoveAccount(privateKey, passPhrase)
 }
 
+/*
+handleImportWatchOnlyAccount - import a new watch-only account by payment address and readonly key
+- Param #1: payment address string
+- Param #2: readonly key string
+- Param #3: account name
+- Param #4: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleImportWatchOnlyAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 4 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("paymentAddress is invalid"))
+	}
+
+	readonlyKey, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("readonlyKey is invalid"))
+	}
+
+	accountName, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	passPhrase, ok := arrayParams[3].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	result, err := httpServer.walletService.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleRemoveWatchOnlyAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("paymentAddress is invalid"))
+	}
+
+	passPhrase, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	return httpServer.walletService.RemoveWatchOnlyAccount(paymentAddress, passPhrase)
+}
+
+/*
+handleGetWatchOnlyBalance - RPC returns PRV and privacy token totals received by a watch-only account,
+spent output coins can not be filtered without private key
+- Param #1: account name
+*/
+func (httpServer *HttpServer) handleGetWatchOnlyBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+
+	accountName, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	return httpServer.walletService.GetWatchOnlyBalance(accountName)
+}
+
+/*
+handleGetWatchOnlyTransactions - RPC returns incoming transactions of a watch-only account
+- Param #1: account name
+*/
+func (httpServer *HttpServer) handleGetWatchOnlyTransactions(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+
+	accountName, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	keySet, rpcErr := httpServer.walletService.GetWatchOnlyKeySet(accountName)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return httpServer.txService.GetTransactionByReceiver(*keySet)
+}
+
 // handleGetBalanceByPrivatekey -  return balance of private key
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// all component
@@ -0,0 +1,44 @@
+package jsonresult
+
+const (
+	CustodianRiskSafe     = "Safe"
+	CustodianRiskWarning  = "Warning"  // TP ratio is below warning ratio
+	CustodianRiskTP130    = "TP130"    // custodian is liquidated partly
+	CustodianRiskTP120    = "TP120"    // custodian is liquidated fully
+	CustodianRiskUnpriced = "Unpriced" // exchange rate of token is not found, so TP ratio is unknown
+)
+
+type PortalCustodianTokenRisk struct {
+	TokenID              string `json:"TokenID"`
+	LockedCollateral     uint64 `json:"LockedCollateral"`
+	HoldingPubToken      uint64 `json:"HoldingPubToken"`
+	HoldingPubTokenInPRV uint64 `json:"HoldingPubTokenInPRV"`
+	TPRatio              uint64 `json:"TPRatio"`
+	DistanceToTP130      int64  `json:"DistanceToTP130"` // TPRatio - TP130, in percent
+	DistanceToTP120      int64  `json:"DistanceToTP120"` // TPRatio - TP120, in percent
+	TopUpAmount          uint64 `json:"TopUpAmount"`     // PRV to deposit to get back to min percent of locked collateral
+	Status               string `json:"Status"`
+}
+
+type PortalCustodianRisk struct {
+	IncognitoAddress string                     `json:"IncognitoAddress"`
+	TotalCollateral  uint64                     `json:"TotalCollateral"`
+	FreeCollateral   uint64                     `json:"FreeCollateral"`
+	Tokens           []PortalCustodianTokenRisk `json:"Tokens"`
+}
+
+type PortalCustodianRiskDashboard struct {
+	BeaconHeight               uint64                `json:"BeaconHeight"`
+	WarningRatio               uint64                `json:"WarningRatio"`
+	MinPercentLockedCollateral uint64                `json:"MinPercentLockedCollateral"`
+	TP130                      uint64                `json:"TP130"`
+	TP120                      uint64                `json:"TP120"`
+	Custodians                 []PortalCustodianRisk `json:"Custodians"`
+}
+
+// PortalCustodianRiskAlert is notified when TP ratio of a custodian for a token crosses warning ratio
+type PortalCustodianRiskAlert struct {
+	BeaconHeight     uint64                   `json:"BeaconHeight"`
+	IncognitoAddress string                   `json:"IncognitoAddress"`
+	Token            PortalCustodianTokenRisk `json:"Token"`
+}
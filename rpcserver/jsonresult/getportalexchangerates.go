@@ -1,7 +1,8 @@
 package jsonresult
 
 type FinalExchangeRatesDetailResult struct {
-	Value uint64 `json:"Value"`
+	Value        uint64 `json:"Value"`
+	BeaconHeight uint64 `json:"BeaconHeight,omitempty"` // beacon height at which rate is updated by exchange rates oracle
 }
 
 type FinalExchangeRatesResult struct {
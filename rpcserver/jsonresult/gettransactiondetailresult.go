@@ -1,6 +1,7 @@
 package jsonresult
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/incognitochain/incognito-chain/common"
@@ -198,9 +199,49 @@ func (proofDetail *ProofDetail) ConvertFromProof(proof *zkp.PaymentProof) {
 	}
 }
 
+// SetMemos decrypts memos of output coins in proof which are sent to owner of viewingKey
+// Coins of privacy v2 are sent to one-time addresses, they are detected by decrypting them with viewingKey
+func (proofDetail *ProofDetail) SetMemos(proof *zkp.PaymentProof, viewingKey privacy.ViewingKey) {
+	if proof == nil {
+		return
+	}
+	for i, output := range proof.GetOutputCoins() {
+		if i >= len(proofDetail.OutputCoins) || output.CoinDetails == nil || output.CoinDetails.GetPublicKey() == nil {
+			continue
+		}
+		if privacy.IsOutputCoinV2(output.CoinDetails) {
+			// decryption sets value and randomness of coin, so a copy of the coin of tx is decrypted
+			coinDetails := *output.CoinDetails
+			_, _, err := privacy.DecryptConfidentialOutputCoin(&privacy.OutputCoin{CoinDetails: &coinDetails, CoinDetailsEncrypted: output.CoinDetailsEncrypted}, viewingKey)
+			if err != nil {
+				continue
+			}
+		} else if !bytes.Equal(output.CoinDetails.GetPublicKey().ToBytesS(), viewingKey.Pk) {
+			continue
+		}
+		// info of a confidential coin starts with its asset tag
+		memo, err := privacy.DecryptMemo(privacy.GetInfoOfConfidentialCoin(output.CoinDetails), viewingKey.Rk)
+		if err == nil {
+			proofDetail.OutputCoins[i].Memo = string(memo)
+		}
+	}
+}
+
+// SetMemos decrypts memos of output coins of tx which are sent to owner of viewingKey
+func (transactionDetail *TransactionDetail) SetMemos(tx metadata.Transaction, viewingKey privacy.ViewingKey) {
+	switch tempTx := tx.(type) {
+	case *transaction.Tx:
+		transactionDetail.ProofDetail.SetMemos(tempTx.Proof, viewingKey)
+	case *transaction.TxCustomTokenPrivacy:
+		transactionDetail.ProofDetail.SetMemos(tempTx.Proof, viewingKey)
+		transactionDetail.PrivacyCustomTokenProofDetail.SetMemos(tempTx.TxPrivacyTokenData.TxNormal.Proof, viewingKey)
+	}
+}
+
 type CoinDetail struct {
 	CoinDetails          Coin
 	CoinDetailsEncrypted string
+	Memo                 string `json:"Memo,omitempty"`
 }
 
 type Coin struct {
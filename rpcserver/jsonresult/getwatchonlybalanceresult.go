@@ -0,0 +1,10 @@
+package jsonresult
+
+// WatchOnlyBalance is the total received by a watch-only account in local wallet.
+// Readonly key can not derive serial numbers, so spent output coins are not filtered: amounts are not balances
+type WatchOnlyBalance struct {
+	AccountName                  string               `json:"AccountName"`
+	PaymentAddress               string               `json:"PaymentAddress"`
+	PRVTotalReceived             uint64               `json:"PRVTotalReceived"`
+	ListCustomTokenTotalReceived []CustomTokenBalance `json:"ListCustomTokenTotalReceived"`
+}
@@ -3,4 +3,7 @@ package jsonresult
 type ListAccounts struct {
 	WalletName string            `json:"WalletName"`
 	Accounts   map[string]uint64 `json:"Accounts"`
+	// WatchOnlyTotalReceived - account name -> total of PRV received by watch-only account,
+	// readonly key can not derive serial numbers so spent coins are not filtered, it is not a balance
+	WatchOnlyTotalReceived map[string]uint64 `json:"WatchOnlyTotalReceived"`
 }
@@ -23,6 +23,7 @@ type OutCoin struct {
 	Value                string `json:"Value"`
 	Info                 string `json:"Info"`
 	CoinDetailsEncrypted string `json:"CoinDetailsEncrypted"`
+	Memo                 string `json:"Memo,omitempty"`
 }
 
 func NewOutcoinFromInterface(data interface{}) (*OutCoin, error) {
@@ -67,3 +68,11 @@ func NewOutCoin(outCoin *privacy.OutputCoin) OutCoin {
 
 	return result
 }
+
+// SetMemo decrypts memo in info of outCoin by receiving key of its owner
+func (outCoin *OutCoin) SetMemo(info []byte, receivingKey []byte) {
+	memo, err := privacy.DecryptMemo(info, receivingKey)
+	if err == nil {
+		outCoin.Memo = string(memo)
+	}
+}
@@ -0,0 +1,21 @@
+package jsonresult
+
+type MiningKeysStatusResult struct {
+	MiningPublicKeys []string `json:"MiningPublicKeys"`
+	IsDraining       bool     `json:"IsDraining"`
+	ShardID          int      `json:"ShardID"`
+	Layer            string   `json:"Layer"`
+	Role             string   `json:"Role"`
+}
+
+func NewMiningKeysStatusResult(consensus interface {
+	GetUserRole() (string, string, int)
+	GetAllMiningPublicKeys() []string
+	IsMiningDrainMode() bool
+}) *MiningKeysStatusResult {
+	result := &MiningKeysStatusResult{}
+	result.MiningPublicKeys = consensus.GetAllMiningPublicKeys()
+	result.IsDraining = consensus.IsMiningDrainMode()
+	result.Layer, result.Role, result.ShardID = consensus.GetUserRole()
+	return result
+}
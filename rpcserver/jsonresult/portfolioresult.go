@@ -0,0 +1,35 @@
+package jsonresult
+
+// PortfolioAccount is an account of a portfolio in local wallet
+type PortfolioAccount struct {
+	AccountName    string `json:"AccountName"`
+	PaymentAddress string `json:"PaymentAddress"`
+	ShardID        byte   `json:"ShardID"`
+	IsWatchOnly    bool   `json:"IsWatchOnly"`
+}
+
+// Portfolio is a set of accounts of local wallet in several shards, which is managed as one
+type Portfolio struct {
+	PortfolioName string             `json:"PortfolioName"`
+	Accounts      []PortfolioAccount `json:"Accounts"`
+}
+
+// PortfolioTokenBalance is the balance of a token aggregated over accounts of a portfolio
+type PortfolioTokenBalance struct {
+	CustomTokenBalance
+	AccountBalances map[string]uint64 `json:"AccountBalances"` // account name -> balance
+}
+
+// PortfolioBalance is the balances of a portfolio, PRV is listed as a token with PRV token id
+type PortfolioBalance struct {
+	Portfolio
+	Balances []PortfolioTokenBalance `json:"Balances"`
+}
+
+// PortfolioTransaction is a tx sent by an account of a portfolio
+type PortfolioTransaction struct {
+	AccountName string            `json:"AccountName"`
+	ShardID     byte              `json:"ShardID"`
+	TxID        string            `json:"TxID"`
+	Receivers   map[string]uint64 `json:"Receivers"`
+}
@@ -0,0 +1,37 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+// ValidatorPerformance is the statistics of a committee member of a chain (shard ID or -1 for beacon) in an epoch
+type ValidatorPerformance struct {
+	ChainID            int     `json:"ChainID"`
+	Epoch              uint64  `json:"Epoch"`
+	CommitteePublicKey string  `json:"CommitteePublicKey"`
+	ProposedBlocks     uint64  `json:"ProposedBlocks"`
+	MissedProposals    uint64  `json:"MissedProposals"`
+	VotedBlocks        uint64  `json:"VotedBlocks"`
+	MissedVotes        uint64  `json:"MissedVotes"`
+	VoteRate           float64 `json:"VoteRate"`           // percent of the blocks validated by the committee member it voted for
+	AverageVoteLatency float64 `json:"AverageVoteLatency"` // in timeslots
+}
+
+func NewValidatorPerformance(chainID int, epoch uint64, committeePublicKey string, performance *blockchain.ValidatorPerformance) ValidatorPerformance {
+	result := ValidatorPerformance{
+		ChainID:            chainID,
+		Epoch:              epoch,
+		CommitteePublicKey: committeePublicKey,
+		ProposedBlocks:     performance.ProposedBlocks,
+		MissedProposals:    performance.MissedProposals,
+		VotedBlocks:        performance.VotedBlocks,
+		MissedVotes:        performance.MissedVotes,
+	}
+	if total := performance.VotedBlocks + performance.MissedVotes; total > 0 {
+		result.VoteRate = float64(performance.VotedBlocks) * 100 / float64(total)
+	}
+	if performance.VotedBlocks > 0 {
+		result.AverageVoteLatency = float64(performance.VoteLatency) / float64(performance.VotedBlocks)
+	}
+	return result
+}
@@ -68,6 +68,7 @@ var HttpHandler = map[string]httpHandler{
 	listOutputCoins:                         (*HttpServer).handleListOutputCoins,
 	createRawTransaction:                    (*HttpServer).handleCreateRawTransaction,
 	sendRawTransaction:                      (*HttpServer).handleSendRawTransaction,
+	createUnsignedTransaction:               (*HttpServer).handleCreateUnsignedTransaction,
 	createAndSendTransaction:                (*HttpServer).handleCreateAndSendTx,
 	getTransactionByHash:                    (*HttpServer).handleGetTransactionByHash,
 	gettransactionhashbyreceiver:            (*HttpServer).handleGetTransactionHashByReceiver,
@@ -82,6 +83,19 @@ var HttpHandler = map[string]httpHandler{
 	listCommitmentIndices:                   (*HttpServer).handleListCommitmentIndices,
 	decryptoutputcoinbykeyoftransaction:     (*HttpServer).handleDecryptOutputCoinByKeyOfTransaction,
 
+	// delegation
+	createRawDelegationTransaction:            (*HttpServer).handleCreateRawDelegationTransaction,
+	createAndSendDelegationTransaction:        (*HttpServer).handleCreateAndSendDelegationTransaction,
+	createRawUnDelegationTransaction:          (*HttpServer).handleCreateRawUnDelegationTransaction,
+	createAndSendUnDelegationTransaction:      (*HttpServer).handleCreateAndSendUnDelegationTransaction,
+	createRawSetCommissionRateTransaction:     (*HttpServer).handleCreateRawSetCommissionRateTransaction,
+	createAndSendSetCommissionRateTransaction: (*HttpServer).handleCreateAndSendSetCommissionRateTransaction,
+	getDelegations:                            (*HttpServer).handleGetDelegations,
+
+	// slashing
+	createRawDoubleSignEvidenceTransaction:     (*HttpServer).handleCreateRawDoubleSignEvidenceTransaction,
+	createAndSendDoubleSignEvidenceTransaction: (*HttpServer).handleCreateAndSendDoubleSignEvidenceTransaction,
+
 	//======Testing and Benchmark======
 	getAndSendTxsFromFile:   (*HttpServer).handleGetAndSendTxsFromFile,
 	getAndSendTxsFromFileV2: (*HttpServer).handleGetAndSendTxsFromFileV2,
@@ -179,6 +193,15 @@ var HttpHandler = map[string]httpHandler{
 	getPDEFeeWithdrawalStatus:                  (*HttpServer).handleGetPDEFeeWithdrawalStatus,
 	convertPDEPrices:                           (*HttpServer).handleConvertPDEPrices,
 	extractPDEInstsFromBeaconBlock:             (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
+	createAndSendTxWithPRVLimitOrderReq:        (*HttpServer).handleCreateAndSendTxWithPRVLimitOrderReq,
+	createAndSendTxWithPTokenLimitOrderReq:     (*HttpServer).handleCreateAndSendTxWithPTokenLimitOrderReq,
+	createAndSendTxWithCancelLimitOrderReq:     (*HttpServer).handleCreateAndSendTxWithCancelLimitOrderReq,
+	getPDELimitOrders:                          (*HttpServer).handleGetPDELimitOrders,
+	getPDELimitOrderStatus:                     (*HttpServer).handleGetPDELimitOrderStatus,
+	getPDECancelLimitOrderStatus:               (*HttpServer).handleGetPDECancelLimitOrderStatus,
+	getPDETradeRouteQuote:                      (*HttpServer).handleGetPDETradeRouteQuote,
+	getPDETWAP:                                 (*HttpServer).handleGetPDETWAP,
+	getPDELiquidityProviderStats:               (*HttpServer).handleGetPDELiquidityProviderStats,
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
@@ -217,6 +240,8 @@ var HttpHandler = map[string]httpHandler{
 	getAmountTopUpWaitingPorting:                  (*HttpServer).handleGetAmountTopUpWaitingPorting,
 	getPortalReqRedeemByTxIDStatus:                (*HttpServer).handleGetPortalReqRedeemByTxIDStatus,
 	getReqRedeemFromLiquidationPoolByTxIDStatus:   (*HttpServer).handleGetReqRedeemFromLiquidationPoolByTxIDStatus,
+	getPortalCustodianRisk:                        (*HttpServer).handleGetPortalCustodianRisk,
+	getPortalExchangeRatesHistory:                 (*HttpServer).handleGetPortalExchangeRatesHistory,
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
@@ -242,27 +267,53 @@ var HttpHandler = map[string]httpHandler{
 	// feature reward
 	getRewardFeature: (*HttpServer).handleGetRewardFeature,
 
+	// validator performance
+	getValidatorPerformance: (*HttpServer).handleGetValidatorPerformance,
+
 	// get committeeByHeight
 }
 
 // Commands that are available to a limited user
 var LimitedHttpHandler = map[string]httpHandler{
 	// local WALLET
-	listAccounts:                     (*HttpServer).handleListAccounts,
-	getAccount:                       (*HttpServer).handleGetAccount,
-	getAddressesByAccount:            (*HttpServer).handleGetAddressesByAccount,
-	getAccountAddress:                (*HttpServer).handleGetAccountAddress,
-	dumpPrivkey:                      (*HttpServer).handleDumpPrivkey,
-	importAccount:                    (*HttpServer).handleImportAccount,
-	removeAccount:                    (*HttpServer).handleRemoveAccount,
-	listUnspentOutputCoins:           (*HttpServer).handleListUnspentOutputCoins,
-	getBalance:                       (*HttpServer).handleGetBalance,
-	getBalanceByPrivatekey:           (*HttpServer).handleGetBalanceByPrivatekey,
-	getBalanceByPaymentAddress:       (*HttpServer).handleGetBalanceByPaymentAddress,
-	getReceivedByAccount:             (*HttpServer).handleGetReceivedByAccount,
-	setTxFee:                         (*HttpServer).handleSetTxFee,
-	convertNativeTokenToPrivacyToken: (*HttpServer).handleConvertNativeTokenToPrivacyToken,
-	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
+	listAccounts:                      (*HttpServer).handleListAccounts,
+	getAccount:                        (*HttpServer).handleGetAccount,
+	getAddressesByAccount:             (*HttpServer).handleGetAddressesByAccount,
+	getAccountAddress:                 (*HttpServer).handleGetAccountAddress,
+	dumpPrivkey:                       (*HttpServer).handleDumpPrivkey,
+	importAccount:                     (*HttpServer).handleImportAccount,
+	removeAccount:                     (*HttpServer).handleRemoveAccount,
+	listUnspentOutputCoins:            (*HttpServer).handleListUnspentOutputCoins,
+	getBalance:                        (*HttpServer).handleGetBalance,
+	getBalanceByPrivatekey:            (*HttpServer).handleGetBalanceByPrivatekey,
+	getBalanceByPaymentAddress:        (*HttpServer).handleGetBalanceByPaymentAddress,
+	getReceivedByAccount:              (*HttpServer).handleGetReceivedByAccount,
+	setTxFee:                          (*HttpServer).handleSetTxFee,
+	importWatchOnlyAccount:            (*HttpServer).handleImportWatchOnlyAccount,
+	removeWatchOnlyAccount:            (*HttpServer).handleRemoveWatchOnlyAccount,
+	getWatchOnlyBalance:               (*HttpServer).handleGetWatchOnlyBalance,
+	getWatchOnlyTransactions:          (*HttpServer).handleGetWatchOnlyTransactions,
+	createAndSendPayoutBatch:          (*HttpServer).handleCreateAndSendPayoutBatch,
+	retryPayoutBatch:                  (*HttpServer).handleRetryPayoutBatch,
+	getPayoutBatch:                    (*HttpServer).handleGetPayoutBatch,
+	listPayoutBatches:                 (*HttpServer).handleListPayoutBatches,
+	registerCoinScannerKey:            (*HttpServer).handleRegisterCoinScannerKey,
+	unregisterCoinScannerKey:          (*HttpServer).handleUnregisterCoinScannerKey,
+	listCoinScannerKeys:               (*HttpServer).handleListCoinScannerKeys,
+	getCoinScannerBalance:             (*HttpServer).handleGetCoinScannerBalance,
+	listCoinScannerCoins:              (*HttpServer).handleListCoinScannerCoins,
+	createPortfolio:                   (*HttpServer).handleCreatePortfolio,
+	removePortfolio:                   (*HttpServer).handleRemovePortfolio,
+	listPortfolios:                    (*HttpServer).handleListPortfolios,
+	getPortfolioBalance:               (*HttpServer).handleGetPortfolioBalance,
+	createAndSendPortfolioTransaction: (*HttpServer).handleCreateAndSendPortfolioTransaction,
+	consolidatePortfolio:              (*HttpServer).handleConsolidatePortfolio,
+	convertNativeTokenToPrivacyToken:  (*HttpServer).handleConvertNativeTokenToPrivacyToken,
+	convertPrivacyTokenToNativeToken:  (*HttpServer).handleConvertPrivacyTokenToNativeToken,
+
+	// mining keys hot-swap
+	setMiningDrainMode: (*HttpServer).handleSetMiningDrainMode,
+	switchMiningKeys:   (*HttpServer).handleSwitchMiningKeys,
 }
 
 var WsHandler = map[string]wsHandler{
@@ -283,4 +334,7 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeCoinScanner:                         (*WsServer).handleSubcribeCoinScanner,
+	subcribePortalCustodianRisk:                 (*WsServer).handleSubcribePortalCustodianRisk,
+	subcribeValidatorPerformance:                (*WsServer).handleSubcribeValidatorPerformance,
 }
@@ -84,6 +84,7 @@ type RpcServerConfig struct {
 		GetPublicKeyRole(publicKey string, keyType string) (int, int)
 		GetIncognitoPublicKeyRole(publicKey string) (int, bool, int)
 		GetMinerIncognitoPublickey(publicKey string, keyType string) []byte
+		PublishNodeState(userLayer string, shardID int) error
 	}
 	ConsensusEngine interface {
 		GetUserLayer() (string, int)
@@ -91,6 +92,9 @@ type RpcServerConfig struct {
 		GetCurrentMiningPublicKey() (publickey string, keyType string)
 		GetAllMiningPublicKeys() []string
 		ExtractBridgeValidationData(block common.BlockInterface) ([][]byte, []int, error)
+		SetMiningDrainMode(drain bool)
+		IsMiningDrainMode() bool
+		SwitchMiningKeys(keysString string) error
 	}
 	TxMemPool                   *mempool.TxPool
 	RPCMaxClients               int
@@ -110,6 +114,8 @@ type RpcServerConfig struct {
 	// IsMiningNode    bool   // flag mining node. True: mining, False: not mining
 	MiningKeys    string // encode of mining key
 	PubSubManager *pubsub.PubSubManager
+	// passphrase to encrypt keys registered to coin scanner, coin scanner is disabled if it is empty
+	CoinScannerPassphrase string
 }
 
 func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
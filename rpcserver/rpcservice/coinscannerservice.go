@@ -0,0 +1,582 @@
+package rpcservice
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Events of coin scanner which are published to pubsub.CoinScannerTopic
+const (
+	CoinScannerReceivedEvent = "received"
+	CoinScannerSpentEvent    = "spent"
+)
+
+// max number of blocks of a shard which are scanned before keys are persisted
+const coinScannerBlocksPerPass = 100
+
+// parameters of encryption of stored keys: a pbkdf2 key of passphrase and a salt encrypts keys with AES-GCM
+const (
+	coinScannerSaltSize      = 16
+	coinScannerKDFIterations = 100000
+)
+
+// ScannedCoin is an output coin of a registered key which is found by coin scanner
+type ScannedCoin struct {
+	TokenID     string
+	Version     int
+	PublicKey   string
+	Commitment  string
+	Value       uint64
+	Info        string
+	Memo        string
+	ShardID     byte
+	BlockHeight uint64
+	TxID        string
+	IsSpent     bool
+	SpentTxID   string
+}
+
+// CoinScannerEvent is published when coin scanner finds a coin of a registered key or finds that it is spent
+type CoinScannerEvent struct {
+	PublicKey string
+	Event     string
+	Coin      ScannedCoin
+}
+
+// CoinScannerKeyInfo is the status of a registered key, without its key material
+type CoinScannerKeyInfo struct {
+	PublicKey      string
+	ScannedHeights map[byte]uint64
+	NumCoins       int
+}
+
+// coinScannerKey is the state of a registered key, it is persisted encrypted by passphrase of coin scanner
+type coinScannerKey struct {
+	PublicKey      string
+	ReadonlyKey    string
+	ScannedHeights map[byte]uint64
+	Coins          []*ScannedCoin
+
+	keySet            *incognitokey.KeySet
+	coinsByCommitment map[string]*ScannedCoin
+}
+
+// CoinScannerService decrypts coins of registered keys in newly inserted shard blocks once, and keeps their balances
+// and spent status, so wallets do not need the node to decrypt every candidate coin on each poll.
+// Only readonly keys are registered, so node never keeps a key which can spend coins; they are stored in local database of node,
+// encrypted with AES-GCM by passphrase of coin scanner. Serial numbers of coins can not be computed from a readonly key,
+// so coins are only marked spent when they are spent without privacy, wallets check serial numbers of other coins themselves.
+type CoinScannerService struct {
+	BlockChain    *blockchain.BlockChain
+	DB            incdb.Database
+	PubSubManager *pubsub.PubSubManager
+	salt          []byte
+	aead          cipher.AEAD
+	keys          map[string]*coinScannerKey
+	mtx           sync.RWMutex
+	cScan         chan struct{}
+	cQuit         chan struct{}
+}
+
+// NewCoinScannerService loads keys registered to coin scanner, it returns nil if passphrase is empty, i.e. coin scanner is disabled
+func NewCoinScannerService(bc *blockchain.BlockChain, db incdb.Database, pubSubManager *pubsub.PubSubManager, passphrase string) (*CoinScannerService, error) {
+	if passphrase == "" {
+		return nil, nil
+	}
+	salt := make([]byte, coinScannerSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newCoinScannerAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	coinScannerService := &CoinScannerService{
+		BlockChain:    bc,
+		DB:            db,
+		PubSubManager: pubSubManager,
+		salt:          salt,
+		aead:          aead,
+		keys:          make(map[string]*coinScannerKey),
+		cScan:         make(chan struct{}, 1),
+		cQuit:         make(chan struct{}),
+	}
+	keysBytes, err := rawdbv2.GetAllCoinScannerKeys(db)
+	if err != nil {
+		return nil, err
+	}
+	// keys stored by previous runs of node are encrypted with their own salts, derive each of them once
+	aeads := map[string]cipher.AEAD{string(salt): aead}
+	for _, keyBytes := range keysBytes {
+		if len(keyBytes) < coinScannerSaltSize {
+			return nil, errors.New("stored coin scanner key is invalid")
+		}
+		keySalt := keyBytes[:coinScannerSaltSize]
+		keyAEAD, ok := aeads[string(keySalt)]
+		if !ok {
+			if keyAEAD, err = newCoinScannerAEAD(passphrase, keySalt); err != nil {
+				return nil, err
+			}
+			aeads[string(keySalt)] = keyAEAD
+		}
+		plaintext, err := openCoinScannerKey(keyAEAD, keyBytes[coinScannerSaltSize:])
+		if err != nil {
+			return nil, err
+		}
+		key := &coinScannerKey{}
+		if err := json.Unmarshal(plaintext, key); err != nil {
+			return nil, err
+		}
+		if err := key.init(); err != nil {
+			return nil, err
+		}
+		coinScannerService.keys[key.PublicKey] = key
+	}
+	return coinScannerService, nil
+}
+
+// newCoinScannerAEAD returns AES-GCM cipher of key which is derived from passphrase and salt
+func newCoinScannerAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, coinScannerKDFIterations, common.AESKeySize, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// openCoinScannerKey decrypts and authenticates a stored key, which is nonce followed by ciphertext
+func openCoinScannerKey(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("stored coin scanner key is invalid")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+// init restores key set and indices of coins of key, key must be a readonly key
+func (key *coinScannerKey) init() error {
+	keyWallet, err := wallet.Base58CheckDeserialize(key.ReadonlyKey)
+	if err != nil {
+		return err
+	}
+	if len(keyWallet.KeySet.PrivateKey) > 0 || len(keyWallet.KeySet.ReadonlyKey.Rk) == 0 {
+		return errors.New("key must be a readonly key")
+	}
+	keyWallet.KeySet.PaymentAddress.Pk = keyWallet.KeySet.ReadonlyKey.Pk
+	key.keySet = &keyWallet.KeySet
+	key.PublicKey = base58.Base58Check{}.Encode(key.keySet.PaymentAddress.Pk, common.ZeroByte)
+	if key.ScannedHeights == nil {
+		key.ScannedHeights = make(map[byte]uint64)
+	}
+	key.coinsByCommitment = make(map[string]*ScannedCoin)
+	for _, coin := range key.Coins {
+		key.coinsByCommitment[coin.Commitment] = coin
+	}
+	return nil
+}
+
+func (key *coinScannerKey) info() *CoinScannerKeyInfo {
+	scannedHeights := make(map[byte]uint64)
+	for shardID, height := range key.ScannedHeights {
+		scannedHeights[shardID] = height
+	}
+	return &CoinScannerKeyInfo{
+		PublicKey:      key.PublicKey,
+		ScannedHeights: scannedHeights,
+		NumCoins:       len(key.Coins),
+	}
+}
+
+func coinScannerKeyID(publicKey string) common.Hash {
+	return common.HashH([]byte(publicKey))
+}
+
+// GetCoinScannerPublicKey returns public key which identifies key of payment address in coin scanner
+func GetCoinScannerPublicKey(paymentAddress string) (string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return "", err
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return "", errors.New("payment address is invalid")
+	}
+	return base58.Base58Check{}.Encode(keyWallet.KeySet.PaymentAddress.Pk, common.ZeroByte), nil
+}
+
+// getKey returns registered key of payment address, caller must hold lock of coin scanner
+func (coinScannerService *CoinScannerService) getKey(paymentAddress string) (*coinScannerKey, *RPCError) {
+	publicKey, err := GetCoinScannerPublicKey(paymentAddress)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	key, ok := coinScannerService.keys[publicKey]
+	if !ok {
+		return nil, NewRPCError(GetCoinScannerKeyError, errors.New(paymentAddress))
+	}
+	return key, nil
+}
+
+func (coinScannerService *CoinScannerService) storeKey(key *coinScannerKey) error {
+	plaintext, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, coinScannerService.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := append(append([]byte{}, coinScannerService.salt...), nonce...)
+	data = coinScannerService.aead.Seal(data, nonce, plaintext, nil)
+	return rawdbv2.StoreCoinScannerKey(coinScannerService.DB, coinScannerKeyID(key.PublicKey), data)
+}
+
+// RegisterKey registers a readonly key, private keys are rejected, coins of key are scanned from fromHeight of each shard,
+// or from the next block if fromHeight is 0
+func (coinScannerService *CoinScannerService) RegisterKey(keyStr string, fromHeight uint64) (*CoinScannerKeyInfo, *RPCError) {
+	key := &coinScannerKey{ReadonlyKey: keyStr, ScannedHeights: make(map[byte]uint64)}
+	if err := key.init(); err != nil {
+		return nil, NewRPCError(RegisterCoinScannerKeyError, err)
+	}
+
+	for _, i := range coinScannerService.BlockChain.GetShardIDs() {
+		shardID := byte(i)
+		if fromHeight == 0 {
+			key.ScannedHeights[shardID] = coinScannerService.BlockChain.ShardChain[shardID].GetFinalViewHeight()
+		} else {
+			key.ScannedHeights[shardID] = fromHeight - 1
+		}
+	}
+
+	coinScannerService.mtx.Lock()
+	defer coinScannerService.mtx.Unlock()
+	if _, ok := coinScannerService.keys[key.PublicKey]; ok {
+		return nil, NewRPCError(RegisterCoinScannerKeyError, errors.New("key is already registered"))
+	}
+	if err := coinScannerService.storeKey(key); err != nil {
+		return nil, NewRPCError(RegisterCoinScannerKeyError, err)
+	}
+	coinScannerService.keys[key.PublicKey] = key
+	coinScannerService.requestScan()
+	return key.info(), nil
+}
+
+// UnregisterKey removes key of payment address and its coins from coin scanner
+func (coinScannerService *CoinScannerService) UnregisterKey(paymentAddress string) *RPCError {
+	coinScannerService.mtx.Lock()
+	defer coinScannerService.mtx.Unlock()
+	key, rpcErr := coinScannerService.getKey(paymentAddress)
+	if rpcErr != nil {
+		return rpcErr
+	}
+	if err := rawdbv2.DeleteCoinScannerKey(coinScannerService.DB, coinScannerKeyID(key.PublicKey)); err != nil {
+		return NewRPCError(RegisterCoinScannerKeyError, err)
+	}
+	delete(coinScannerService.keys, key.PublicKey)
+	return nil
+}
+
+// ListKeys returns status of all registered keys
+func (coinScannerService *CoinScannerService) ListKeys() []*CoinScannerKeyInfo {
+	coinScannerService.mtx.RLock()
+	defer coinScannerService.mtx.RUnlock()
+	result := make([]*CoinScannerKeyInfo, 0, len(coinScannerService.keys))
+	for _, key := range coinScannerService.keys {
+		result = append(result, key.info())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PublicKey < result[j].PublicKey
+	})
+	return result
+}
+
+// GetBalances returns balances of unspent coins of key of payment address by token id
+func (coinScannerService *CoinScannerService) GetBalances(paymentAddress string) (map[string]uint64, *RPCError) {
+	coinScannerService.mtx.RLock()
+	defer coinScannerService.mtx.RUnlock()
+	key, rpcErr := coinScannerService.getKey(paymentAddress)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	balances := make(map[string]uint64)
+	for _, coin := range key.Coins {
+		if !coin.IsSpent {
+			balances[coin.TokenID] += coin.Value
+		}
+	}
+	return balances, nil
+}
+
+// ListCoins returns coins of key of payment address with token id (all tokens if it is empty)
+func (coinScannerService *CoinScannerService) ListCoins(paymentAddress string, tokenID string, unspentOnly bool) ([]ScannedCoin, *RPCError) {
+	coinScannerService.mtx.RLock()
+	defer coinScannerService.mtx.RUnlock()
+	key, rpcErr := coinScannerService.getKey(paymentAddress)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	coins := make([]ScannedCoin, 0)
+	for _, coin := range key.Coins {
+		if (tokenID != "" && coin.TokenID != tokenID) || (unspentOnly && coin.IsSpent) {
+			continue
+		}
+		coins = append(coins, *coin)
+	}
+	return coins, nil
+}
+
+// Start scans blocks whenever a shard block is inserted until Stop is called
+func (coinScannerService *CoinScannerService) Start() error {
+	subID, subChan, err := coinScannerService.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer coinScannerService.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, subID)
+		// catch up with blocks which are inserted while node is stopped
+		coinScannerService.requestScan()
+		for {
+			select {
+			case msg := <-subChan:
+				shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				coinScannerService.scanShard(shardBlock.Header.ShardID)
+			case <-coinScannerService.cScan:
+				for _, i := range coinScannerService.BlockChain.GetShardIDs() {
+					coinScannerService.scanShard(byte(i))
+				}
+			case <-coinScannerService.cQuit:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (coinScannerService *CoinScannerService) Stop() {
+	close(coinScannerService.cQuit)
+}
+
+func (coinScannerService *CoinScannerService) requestScan() {
+	select {
+	case coinScannerService.cScan <- struct{}{}:
+	default:
+	}
+}
+
+// scanShard scans blocks of shard up to its final height for all keys, blocks which are not final may be reverted
+func (coinScannerService *CoinScannerService) scanShard(shardID byte) {
+	finalHeight := coinScannerService.BlockChain.ShardChain[shardID].GetFinalViewHeight()
+	for {
+		coinScannerService.mtx.RLock()
+		fromHeight := finalHeight
+		for _, key := range coinScannerService.keys {
+			if key.ScannedHeights[shardID] < fromHeight {
+				fromHeight = key.ScannedHeights[shardID]
+			}
+		}
+		coinScannerService.mtx.RUnlock()
+		if fromHeight >= finalHeight {
+			return
+		}
+		toHeight := fromHeight + coinScannerBlocksPerPass
+		if toHeight > finalHeight {
+			toHeight = finalHeight
+		}
+		for height := fromHeight + 1; height <= toHeight; height++ {
+			shardBlock, err := coinScannerService.BlockChain.GetShardBlockByHeightV1(height, shardID)
+			if err != nil {
+				Logger.log.Errorf("Coin scanner can not get block %d of shard %d: %+v", height, shardID, err)
+				return
+			}
+			coinScannerService.scanBlock(shardBlock)
+		}
+		coinScannerService.mtx.Lock()
+		for _, key := range coinScannerService.keys {
+			if err := coinScannerService.storeKey(key); err != nil {
+				Logger.log.Errorf("Coin scanner can not store key %s: %+v", key.PublicKey, err)
+			}
+		}
+		coinScannerService.mtx.Unlock()
+	}
+}
+
+// scanBlock scans block for keys which have not scanned it yet
+func (coinScannerService *CoinScannerService) scanBlock(shardBlock *blockchain.ShardBlock) {
+	coinScannerService.mtx.Lock()
+	defer coinScannerService.mtx.Unlock()
+	shardID := shardBlock.Header.ShardID
+	height := shardBlock.Header.Height
+	for _, key := range coinScannerService.keys {
+		if key.ScannedHeights[shardID] >= height {
+			continue
+		}
+		events := make([]*CoinScannerEvent, 0)
+		for _, tx := range shardBlock.Body.Transactions {
+			events = append(events, key.scanTx(tx, shardID, height)...)
+		}
+		// coins sent from other shards are stored in this shard only when their cross transactions are included
+		fromShardIDs := make([]int, 0, len(shardBlock.Body.CrossTransactions))
+		for fromShardID := range shardBlock.Body.CrossTransactions {
+			fromShardIDs = append(fromShardIDs, int(fromShardID))
+		}
+		sort.Ints(fromShardIDs)
+		for _, fromShardID := range fromShardIDs {
+			for _, crossTransaction := range shardBlock.Body.CrossTransactions[byte(fromShardID)] {
+				events = append(events, key.scanCrossTransaction(crossTransaction, shardID, height)...)
+			}
+		}
+		for _, event := range events {
+			go coinScannerService.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.CoinScannerTopic, event))
+		}
+		key.ScannedHeights[shardID] = height
+	}
+}
+
+// scanTx finds coins of key which are spent or received by tx
+func (key *coinScannerKey) scanTx(tx metadata.Transaction, shardID byte, height uint64) []*CoinScannerEvent {
+	events := make([]*CoinScannerEvent, 0)
+	txID := tx.Hash().String()
+	scanProof := func(proof *zkp.PaymentProof, tokenID common.Hash) {
+		if proof == nil {
+			return
+		}
+		for _, inputCoin := range proof.GetInputCoins() {
+			if coin := key.findInputCoin(inputCoin); coin != nil && !coin.IsSpent {
+				coin.IsSpent = true
+				coin.SpentTxID = txID
+				events = append(events, &CoinScannerEvent{PublicKey: key.PublicKey, Event: CoinScannerSpentEvent, Coin: *coin})
+			}
+		}
+		for _, outputCoin := range proof.GetOutputCoins() {
+			if event := key.receiveOutputCoin(outputCoin, tokenID, shardID, height, txID); event != nil {
+				events = append(events, event)
+			}
+		}
+	}
+	switch tx := tx.(type) {
+	case *transaction.Tx:
+		scanProof(tx.Proof, common.PRVCoinID)
+	case *transaction.TxCustomTokenPrivacy:
+		scanProof(tx.Tx.Proof, common.PRVCoinID)
+		scanProof(tx.TxPrivacyTokenData.TxNormal.Proof, tx.TxPrivacyTokenData.PropertyID)
+	}
+	return events
+}
+
+// scanCrossTransaction finds coins of key which are received from another shard by cross transaction,
+// tx id of these coins is unknown since cross transaction only keeps the hash of block of sending shard
+func (key *coinScannerKey) scanCrossTransaction(crossTransaction blockchain.CrossTransaction, shardID byte, height uint64) []*CoinScannerEvent {
+	events := make([]*CoinScannerEvent, 0)
+	for i := range crossTransaction.OutputCoin {
+		if event := key.receiveOutputCoin(&crossTransaction.OutputCoin[i], common.PRVCoinID, shardID, height, ""); event != nil {
+			events = append(events, event)
+		}
+	}
+	for _, tokenPrivacyData := range crossTransaction.TokenPrivacyData {
+		for i := range tokenPrivacyData.OutputCoin {
+			if event := key.receiveOutputCoin(&tokenPrivacyData.OutputCoin[i], tokenPrivacyData.PropertyID, shardID, height, ""); event != nil {
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// receiveOutputCoin adds output coin to coins of key if it belongs to key and it is stored in shard of block,
+// shard of coin is the shard of its public key, coins sent to other shards are received from their cross transactions
+func (key *coinScannerKey) receiveOutputCoin(outputCoin *privacy.OutputCoin, tokenID common.Hash, shardID byte, height uint64, txID string) *CoinScannerEvent {
+	coin := key.decryptOutputCoin(outputCoin, tokenID)
+	if coin == nil || key.coinsByCommitment[coin.Commitment] != nil {
+		return nil
+	}
+	coin.ShardID = common.GetShardIDFromLastByte(outputCoin.CoinDetails.GetPubKeyLastByte())
+	if coin.ShardID != shardID {
+		return nil
+	}
+	coin.BlockHeight = height
+	coin.TxID = txID
+	key.Coins = append(key.Coins, coin)
+	key.coinsByCommitment[coin.Commitment] = coin
+	return &CoinScannerEvent{PublicKey: key.PublicKey, Event: CoinScannerReceivedEvent, Coin: *coin}
+}
+
+// findInputCoin returns coin of key which is spent by input coin, it is only found when input coin reveals its public key
+// and commitment, i.e. when it is spent without privacy
+func (key *coinScannerKey) findInputCoin(inputCoin *privacy.InputCoin) *ScannedCoin {
+	if inputCoin.CoinDetails == nil || inputCoin.CoinDetails.GetCoinCommitment() == nil || inputCoin.CoinDetails.GetPublicKey() == nil {
+		return nil
+	}
+	coin, ok := key.coinsByCommitment[base58.Base58Check{}.Encode(inputCoin.CoinDetails.GetCoinCommitment().ToBytesS(), common.ZeroByte)]
+	publicKey := base58.Base58Check{}.Encode(inputCoin.CoinDetails.GetPublicKey().ToBytesS(), common.ZeroByte)
+	if !ok || coin.PublicKey != publicKey {
+		return nil
+	}
+	return coin
+}
+
+// decryptOutputCoin returns output coin if it belongs to key, coin of token is decrypted from a copy so block data is not changed
+func (key *coinScannerKey) decryptOutputCoin(outputCoin *privacy.OutputCoin, tokenID common.Hash) *ScannedCoin {
+	if outputCoin.CoinDetails == nil || outputCoin.CoinDetails.GetPublicKey() == nil || outputCoin.CoinDetails.GetCoinCommitment() == nil {
+		return nil
+	}
+	coinDetails := *outputCoin.CoinDetails
+	decryptedCoin := &privacy.OutputCoin{CoinDetails: &coinDetails, CoinDetailsEncrypted: outputCoin.CoinDetailsEncrypted}
+	version := 1
+	info := coinDetails.GetInfo()
+	if privacy.IsOutputCoinV2(outputCoin.CoinDetails) {
+		version = 2
+		_, asset, err := privacy.DecryptConfidentialOutputCoin(decryptedCoin, key.keySet.ReadonlyKey)
+		if err != nil {
+			return nil
+		}
+		if asset != nil {
+			tokenID = asset.TokenID
+			info = privacy.GetInfoOfConfidentialCoin(&coinDetails)
+		}
+	} else {
+		if !bytes.Equal(coinDetails.GetPublicKey().ToBytesS(), key.keySet.PaymentAddress.Pk) {
+			return nil
+		}
+		if decryptedCoin.CoinDetailsEncrypted != nil && !decryptedCoin.CoinDetailsEncrypted.IsNil() {
+			if err := decryptedCoin.Decrypt(key.keySet.ReadonlyKey); err != nil {
+				return nil
+			}
+		}
+	}
+	coin := &ScannedCoin{
+		TokenID:    tokenID.String(),
+		Version:    version,
+		PublicKey:  base58.Base58Check{}.Encode(coinDetails.GetPublicKey().ToBytesS(), common.ZeroByte),
+		Commitment: base58.Base58Check{}.Encode(coinDetails.GetCoinCommitment().ToBytesS(), common.ZeroByte),
+		Value:      coinDetails.GetValue(),
+		Info:       base58.Base58Check{}.Encode(info, common.ZeroByte),
+	}
+	if memo, err := privacy.DecryptMemo(info, key.keySet.ReadonlyKey.Rk); err == nil {
+		coin.Memo = string(memo)
+	}
+	return coin
+}
@@ -0,0 +1,112 @@
+package rpcservice
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCoinScannerTestKey(t *testing.T, seed byte) (*wallet.KeyWallet, *coinScannerKey) {
+	keyWallet, err := wallet.NewMasterKey([]byte{seed})
+	assert.Nil(t, err)
+	key := &coinScannerKey{ReadonlyKey: keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType)}
+	assert.Nil(t, key.init())
+	return keyWallet, key
+}
+
+func TestCoinScannerDecryptOutputCoin(t *testing.T) {
+	keyWallet, key := newCoinScannerTestKey(t, 1)
+	_, otherKey := newCoinScannerTestKey(t, 2)
+
+	// private keys are rejected
+	privateKey := &coinScannerKey{ReadonlyKey: keyWallet.Base58CheckSerialize(wallet.PriKeyType)}
+	assert.NotNil(t, privateKey.init())
+
+	paymentAddress := keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	publicKey, err := GetCoinScannerPublicKey(paymentAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, key.PublicKey, publicKey)
+
+	tokenID := common.Hash{3}
+	asset := &privacy.ConfidentialAsset{TokenID: tokenID, Blinder: privacy.RandomScalar()}
+	outputCoin, err := privacy.NewOutputCoinV2WithParams(keyWallet.KeySet.PaymentAddress, 100, nil, privacy.RandomScalar(), asset)
+	assert.Nil(t, err)
+
+	// confidential coin is decrypted with its token
+	coin := key.decryptOutputCoin(outputCoin, common.ConfidentialAssetID)
+	assert.NotNil(t, coin)
+	assert.Equal(t, 2, coin.Version)
+	assert.Equal(t, tokenID.String(), coin.TokenID)
+	assert.Equal(t, uint64(100), coin.Value)
+	assert.Nil(t, otherKey.decryptOutputCoin(outputCoin, common.ConfidentialAssetID))
+}
+
+func TestCoinScannerStoreKeys(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "coinscanner_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	coinScannerService, err := NewCoinScannerService(nil, db, nil, "")
+	assert.Nil(t, err)
+	assert.Nil(t, coinScannerService)
+
+	coinScannerService, err = NewCoinScannerService(nil, db, nil, "passphrase")
+	assert.Nil(t, err)
+	_, key := newCoinScannerTestKey(t, 1)
+	key.ScannedHeights[0] = 10
+	key.Coins = append(key.Coins, &ScannedCoin{Commitment: "commitment", Value: 5})
+	assert.Nil(t, coinScannerService.storeKey(key))
+
+	_, err = NewCoinScannerService(nil, db, nil, "wrong passphrase")
+	assert.NotNil(t, err)
+	coinScannerService, err = NewCoinScannerService(nil, db, nil, "passphrase")
+	assert.Nil(t, err)
+	storedKey, ok := coinScannerService.keys[key.PublicKey]
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), storedKey.ScannedHeights[0])
+	assert.Equal(t, uint64(5), storedKey.coinsByCommitment["commitment"].Value)
+
+	// stored keys are authenticated, a changed ciphertext is rejected
+	keysBytes, err := rawdbv2.GetAllCoinScannerKeys(db)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(keysBytes))
+	keysBytes[0][len(keysBytes[0])-1] ^= 1
+	assert.Nil(t, rawdbv2.StoreCoinScannerKey(db, coinScannerKeyID(key.PublicKey), keysBytes[0]))
+	_, err = NewCoinScannerService(nil, db, nil, "passphrase")
+	assert.NotNil(t, err)
+}
+
+func TestCoinScannerScanCrossTransaction(t *testing.T) {
+	keyWallet, key := newCoinScannerTestKey(t, 1)
+	outputCoin, err := privacy.NewOutputCoinV2WithParams(keyWallet.KeySet.PaymentAddress, 100, nil, privacy.RandomScalar(), nil)
+	assert.Nil(t, err)
+	crossTransaction := blockchain.CrossTransaction{OutputCoin: []privacy.OutputCoin{*outputCoin}}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	receiverShardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+
+	// coin is only received in the shard of its public key
+	otherShardID := (receiverShardID + 1) % byte(common.MaxShardNumber)
+	assert.Equal(t, 0, len(key.scanCrossTransaction(crossTransaction, otherShardID, 5)))
+	assert.Equal(t, 0, len(key.Coins))
+
+	events := key.scanCrossTransaction(crossTransaction, receiverShardID, 7)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, CoinScannerReceivedEvent, events[0].Event)
+	assert.Equal(t, receiverShardID, events[0].Coin.ShardID)
+	assert.Equal(t, uint64(7), events[0].Coin.BlockHeight)
+	assert.Equal(t, uint64(100), events[0].Coin.Value)
+	assert.Equal(t, 1, len(key.Coins))
+	assert.Equal(t, 0, len(key.scanCrossTransaction(crossTransaction, receiverShardID, 8)))
+}
@@ -0,0 +1,212 @@
+package rpcservice
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// Coin selection strategies which can be chosen per request by "CoinSelectionStrategy" param
+const (
+	DefaultCoinSelectionStrategy         = "default"
+	LargestFirstCoinSelectionStrategy    = "largestfirst"
+	MinimizeChangeCoinSelectionStrategy  = "minimizechange"
+	RandomCoinSelectionStrategy          = "random"
+	ConsolidateDustCoinSelectionStrategy = "consolidatedust"
+)
+
+// CoinSelector chooses coins in outCoins to spend amount
+// maxInputs is the max number of input coins of a tx, a selector may choose fewer coins but should not choose more
+// It returns chosen coins, remaining coins and total value of chosen coins
+type CoinSelector func(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error)
+
+var coinSelectors = map[string]CoinSelector{
+	DefaultCoinSelectionStrategy:         selectCoinsByDefault,
+	LargestFirstCoinSelectionStrategy:    selectCoinsLargestFirst,
+	MinimizeChangeCoinSelectionStrategy:  selectCoinsMinimizeChange,
+	RandomCoinSelectionStrategy:          selectCoinsRandomly,
+	ConsolidateDustCoinSelectionStrategy: selectCoinsConsolidateDust,
+}
+
+// RegisterCoinSelector adds a new coin selection strategy or replaces an existing one
+func RegisterCoinSelector(strategy string, selector CoinSelector) {
+	coinSelectors[strategy] = selector
+}
+
+// GetCoinSelector returns coin selector of strategy, empty strategy means default one
+func GetCoinSelector(strategy string) (CoinSelector, *RPCError) {
+	if strategy == "" {
+		strategy = DefaultCoinSelectionStrategy
+	}
+	selector, ok := coinSelectors[strategy]
+	if !ok {
+		return nil, NewRPCError(CoinSelectionStrategyError, fmt.Errorf("coin selection strategy %+v is not supported", strategy))
+	}
+	return selector, nil
+}
+
+// MaxInputCoinsPerTx returns the max number of input coins which keeps tx with numPayments outputs (and a change output)
+// under common.MaxTxSize
+func MaxInputCoinsPerTx(numPayments int, hasPrivacy bool) int {
+	maxInputs := 0
+	for numInputs := 1; numInputs <= 255; numInputs++ {
+		txSize := transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(numInputs, numPayments+1, hasPrivacy, nil, nil, 0))
+		if txSize > common.MaxTxSize {
+			break
+		}
+		maxInputs = numInputs
+	}
+	return maxInputs
+}
+
+func sumOutputCoinValues(outCoins []*privacy.OutputCoin) uint64 {
+	sum := uint64(0)
+	for _, outCoin := range outCoins {
+		sum += outCoin.CoinDetails.GetValue()
+	}
+	return sum
+}
+
+// takeCoinsInOrder chooses coins in order of sortedOutCoins until their total value reaches amount
+func takeCoinsInOrder(sortedOutCoins []*privacy.OutputCoin, amount uint64) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	resultOutputCoins := make([]*privacy.OutputCoin, 0)
+	remainOutputCoins := make([]*privacy.OutputCoin, 0)
+	totalResultOutputCoinAmount := uint64(0)
+	for _, outCoin := range sortedOutCoins {
+		if totalResultOutputCoinAmount < amount {
+			totalResultOutputCoinAmount += outCoin.CoinDetails.GetValue()
+			resultOutputCoins = append(resultOutputCoins, outCoin)
+		} else {
+			remainOutputCoins = append(remainOutputCoins, outCoin)
+		}
+	}
+	if totalResultOutputCoinAmount < amount {
+		return resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, errors.New("Not enough coin")
+	}
+	return resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, nil
+}
+
+func sortOutputCoinsByValue(outCoins []*privacy.OutputCoin, descending bool) []*privacy.OutputCoin {
+	sortedOutCoins := make([]*privacy.OutputCoin, len(outCoins))
+	copy(sortedOutCoins, outCoins)
+	sort.SliceStable(sortedOutCoins, func(i, j int) bool {
+		if descending {
+			return sortedOutCoins[i].CoinDetails.GetValue() > sortedOutCoins[j].CoinDetails.GetValue()
+		}
+		return sortedOutCoins[i].CoinDetails.GetValue() < sortedOutCoins[j].CoinDetails.GetValue()
+	})
+	return sortedOutCoins
+}
+
+// selectCoinsByDefault takes either the smallest coins or a single larger one
+func selectCoinsByDefault(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	return TxService{}.chooseBestOutCoinsToSpent(outCoins, amount)
+}
+
+// selectCoinsLargestFirst takes the largest coins first, so tx has as few inputs as possible
+func selectCoinsLargestFirst(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	return takeCoinsInOrder(sortOutputCoinsByValue(outCoins, true), amount)
+}
+
+// selectCoinsMinimizeChange chooses between the smallest coin which covers amount
+// and largest coins which do not exceed amount (topped up by the smallest coin covering the rest),
+// the one with less change is taken
+func selectCoinsMinimizeChange(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	if amount == 0 {
+		return []*privacy.OutputCoin{}, outCoins, 0, nil
+	}
+	ascendingOutCoins := sortOutputCoinsByValue(outCoins, false)
+
+	// candidate #1: the smallest single coin which covers amount
+	var singleCandidate []*privacy.OutputCoin
+	for _, outCoin := range ascendingOutCoins {
+		if outCoin.CoinDetails.GetValue() >= amount {
+			singleCandidate = []*privacy.OutputCoin{outCoin}
+			break
+		}
+	}
+
+	// candidate #2: largest coins which keep total under amount, then the smallest coin covering the rest
+	var subsetCandidate []*privacy.OutputCoin
+	chosen := make(map[*privacy.OutputCoin]bool)
+	total := uint64(0)
+	for i := len(ascendingOutCoins) - 1; i >= 0 && total < amount; i-- {
+		if maxInputs > 0 && len(subsetCandidate) >= maxInputs {
+			break
+		}
+		value := ascendingOutCoins[i].CoinDetails.GetValue()
+		if total+value <= amount {
+			total += value
+			chosen[ascendingOutCoins[i]] = true
+			subsetCandidate = append(subsetCandidate, ascendingOutCoins[i])
+		}
+	}
+	if total < amount {
+		for _, outCoin := range ascendingOutCoins {
+			if !chosen[outCoin] && total+outCoin.CoinDetails.GetValue() >= amount {
+				total += outCoin.CoinDetails.GetValue()
+				subsetCandidate = append(subsetCandidate, outCoin)
+				break
+			}
+		}
+	}
+	if total < amount {
+		subsetCandidate = nil
+	}
+
+	resultOutputCoins := subsetCandidate
+	if resultOutputCoins == nil || (singleCandidate != nil && sumOutputCoinValues(singleCandidate) <= total) {
+		resultOutputCoins = singleCandidate
+	}
+	if resultOutputCoins == nil {
+		// amount can not be covered without many small coins
+		return takeCoinsInOrder(sortOutputCoinsByValue(outCoins, true), amount)
+	}
+	chosen = make(map[*privacy.OutputCoin]bool)
+	for _, outCoin := range resultOutputCoins {
+		chosen[outCoin] = true
+	}
+	remainOutputCoins := make([]*privacy.OutputCoin, 0)
+	for _, outCoin := range outCoins {
+		if !chosen[outCoin] {
+			remainOutputCoins = append(remainOutputCoins, outCoin)
+		}
+	}
+	return resultOutputCoins, remainOutputCoins, sumOutputCoinValues(resultOutputCoins), nil
+}
+
+// selectCoinsRandomly takes coins in random order, so amount of payment can not be guessed from chosen coins
+func selectCoinsRandomly(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	shuffledOutCoins := make([]*privacy.OutputCoin, len(outCoins))
+	copy(shuffledOutCoins, outCoins)
+	for i := len(shuffledOutCoins) - 1; i > 0; i-- {
+		j, err := common.RandBigIntMaxRange(big.NewInt(int64(i + 1)))
+		if err != nil {
+			return nil, outCoins, 0, err
+		}
+		shuffledOutCoins[i], shuffledOutCoins[j.Int64()] = shuffledOutCoins[j.Int64()], shuffledOutCoins[i]
+	}
+	return takeCoinsInOrder(shuffledOutCoins, amount)
+}
+
+// selectCoinsConsolidateDust takes the smallest coins first and keeps adding small coins up to maxInputs,
+// so payment also merges dust coins of account
+func selectCoinsConsolidateDust(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, []*privacy.OutputCoin, uint64, error) {
+	ascendingOutCoins := sortOutputCoinsByValue(outCoins, false)
+	resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, err := takeCoinsInOrder(ascendingOutCoins, amount)
+	if err != nil || len(resultOutputCoins) >= maxInputs {
+		return resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, err
+	}
+	numDustCoins := maxInputs - len(resultOutputCoins)
+	if numDustCoins > len(remainOutputCoins) {
+		numDustCoins = len(remainOutputCoins)
+	}
+	resultOutputCoins = append(resultOutputCoins, remainOutputCoins[:numDustCoins]...)
+	totalResultOutputCoinAmount += sumOutputCoinValues(remainOutputCoins[:numDustCoins])
+	return resultOutputCoins, remainOutputCoins[numDustCoins:], totalResultOutputCoinAmount, nil
+}
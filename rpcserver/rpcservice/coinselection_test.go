@@ -0,0 +1,95 @@
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOutputCoins(values ...uint64) []*privacy.OutputCoin {
+	outCoins := make([]*privacy.OutputCoin, len(values))
+	for i, value := range values {
+		outCoins[i] = new(privacy.OutputCoin).Init()
+		outCoins[i].CoinDetails.SetValue(value)
+	}
+	return outCoins
+}
+
+func getOutputCoinValues(outCoins []*privacy.OutputCoin) []uint64 {
+	values := make([]uint64, len(outCoins))
+	for i, outCoin := range outCoins {
+		values[i] = outCoin.CoinDetails.GetValue()
+	}
+	return values
+}
+
+func TestGetCoinSelector(t *testing.T) {
+	for _, strategy := range []string{"", DefaultCoinSelectionStrategy, LargestFirstCoinSelectionStrategy,
+		MinimizeChangeCoinSelectionStrategy, RandomCoinSelectionStrategy, ConsolidateDustCoinSelectionStrategy} {
+		selector, err := GetCoinSelector(strategy)
+		assert.Nil(t, err)
+		assert.NotNil(t, selector)
+	}
+	_, err := GetCoinSelector("unknown")
+	assert.NotNil(t, err)
+	assert.Equal(t, GetErrorCode(CoinSelectionStrategyError), err.Code)
+}
+
+func TestSelectCoinsLargestFirst(t *testing.T) {
+	outCoins := newTestOutputCoins(5, 50, 20, 1, 30)
+	chosen, remain, total, err := selectCoinsLargestFirst(outCoins, 60, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{50, 30}, getOutputCoinValues(chosen))
+	assert.Equal(t, uint64(80), total)
+	assert.Len(t, remain, 3)
+
+	_, _, _, err = selectCoinsLargestFirst(outCoins, 1000, 10)
+	assert.NotNil(t, err)
+}
+
+func TestSelectCoinsMinimizeChange(t *testing.T) {
+	// exact combination of coins is better than a larger single coin
+	outCoins := newTestOutputCoins(100, 30, 20, 7)
+	chosen, remain, total, err := selectCoinsMinimizeChange(outCoins, 50, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(50), total)
+	assert.ElementsMatch(t, []uint64{30, 20}, getOutputCoinValues(chosen))
+	assert.ElementsMatch(t, []uint64{100, 7}, getOutputCoinValues(remain))
+
+	// single coin with less change is taken
+	outCoins = newTestOutputCoins(52, 40, 40)
+	chosen, _, total, err = selectCoinsMinimizeChange(outCoins, 50, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(52), total)
+	assert.Equal(t, []uint64{52}, getOutputCoinValues(chosen))
+
+	_, _, _, err = selectCoinsMinimizeChange(outCoins, 1000, 10)
+	assert.NotNil(t, err)
+}
+
+func TestSelectCoinsRandomly(t *testing.T) {
+	outCoins := newTestOutputCoins(5, 50, 20, 1, 30)
+	chosen, remain, total, err := selectCoinsRandomly(outCoins, 40, 10)
+	assert.Nil(t, err)
+	assert.True(t, total >= 40)
+	assert.Equal(t, total, sumOutputCoinValues(chosen))
+	assert.Equal(t, len(outCoins), len(chosen)+len(remain))
+}
+
+func TestSelectCoinsConsolidateDust(t *testing.T) {
+	outCoins := newTestOutputCoins(1000, 1, 2, 3, 4, 5)
+	chosen, remain, total, err := selectCoinsConsolidateDust(outCoins, 2, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, getOutputCoinValues(chosen))
+	assert.Equal(t, uint64(10), total)
+	assert.ElementsMatch(t, []uint64{5, 1000}, getOutputCoinValues(remain))
+}
+
+func TestMaxInputCoinsPerTx(t *testing.T) {
+	maxInputsNoPrivacy := MaxInputCoinsPerTx(1, false)
+	maxInputsPrivacy := MaxInputCoinsPerTx(1, true)
+	assert.True(t, maxInputsPrivacy > 0)
+	assert.True(t, maxInputsPrivacy <= maxInputsNoPrivacy)
+	assert.True(t, maxInputsNoPrivacy <= 255)
+}
@@ -58,6 +58,15 @@ const (
 	BuildPrivacyTokenParamError
 	GetListPrivacyCustomTokenBalanceError
 	GetPrivacyTokenError
+	CoinSelectionStrategyError
+	ExceedMaxInputCoinsError
+	CreatePayoutBatchError
+	GetPayoutBatchError
+	CoinScannerNotEnabledError
+	RegisterCoinScannerKeyError
+	GetCoinScannerKeyError
+	PortfolioError
+	PortfolioInsufficientBalanceError
 	// reject tx
 	RejectInvalidTxFeeError
 	RejectInvalidTxSizeError
@@ -99,6 +108,8 @@ const (
 	GetCustodianTopupStatusError
 	GetCustodianTopupWaitingPortingStatusError
 	GetAmountTopUpWaitingPortingError
+	GetCustodianRiskError
+	GetExchangeRatesHistoryError
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError
@@ -159,6 +170,15 @@ var ErrCodeMessage = map[int]struct {
 	GetKeySetFromPrivateKeyError:          {-1019, "Get KeySet From Private Key Error"},
 	GetListPrivacyCustomTokenBalanceError: {-1020, "Get List Privacy Custom Token Balance Error"},
 	GetPrivacyTokenError:                  {-1021, "Get Privacy Token Error"},
+	CoinSelectionStrategyError:            {-1022, "Invalid coin selection strategy"},
+	ExceedMaxInputCoinsError:              {-1023, "Number of input coins exceeds limit of tx, account needs to be defragmented"},
+	CreatePayoutBatchError:                {-1024, "Create payout batch error"},
+	GetPayoutBatchError:                   {-1025, "Get payout batch error"},
+	CoinScannerNotEnabledError:            {-1026, "Coin scanner is not enabled, set coinscannerpassphrase to enable it"},
+	RegisterCoinScannerKeyError:           {-1027, "Register key to coin scanner error"},
+	GetCoinScannerKeyError:                {-1028, "Key is not registered to coin scanner"},
+	PortfolioError:                        {-1029, "Portfolio of local wallet error"},
+	PortfolioInsufficientBalanceError:     {-1030, "No account of portfolio has enough balance to pay"},
 	// for block -2xxx
 	GetShardBlockByHeightError:  {-2000, "Get shard block by height error"},
 	GetShardBlockByHashError:    {-2001, "Get shard block by hash error"},
@@ -225,6 +245,8 @@ var ErrCodeMessage = map[int]struct {
 	GetCustodianTopupWaitingPortingStatusError:         {-9016, "Get custodian top up for waiting porting status error"},
 	GetAmountTopUpWaitingPortingError:                  {-9017, "Get amount top up for waiting porting error"},
 	GetReqRedeemFromLiquidationPoolStatusError:         {-9018, "Get redeem request form liquidation pool status error"},
+	GetCustodianRiskError:                              {-9019, "Get custodian risk error"},
+	GetExchangeRatesHistoryError:                       {-9020, "Get exchange rates history error"},
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError: {-10001, "Get relaying bnb header by block height error"},
@@ -293,6 +315,21 @@ func NewRPCError(key int, err error, param ...interface{}) *RPCError {
 	return e
 }
 
+// HasRPCErrorCode returns true if err or any rpc error wrapped in it has code of key
+func HasRPCErrorCode(err *RPCError, key int) bool {
+	for err != nil {
+		if err.Code == GetErrorCode(key) {
+			return true
+		}
+		innerErr, ok := errors.Cause(err.err).(*RPCError)
+		if !ok {
+			return false
+		}
+		err = innerErr
+	}
+	return false
+}
+
 // internalRPCError is a convenience function to convert an internal error to
 // an RPC error with the appropriate Code set.  It also logs the error to the
 // RPC server subsystem since internal errors really should not occur.  The
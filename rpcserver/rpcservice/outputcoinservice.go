@@ -59,7 +59,9 @@ func (coinService CoinService) ListUnspentOutputCoinsByKey(listKeyParams []inter
 			if outCoin.CoinDetails.GetValue() == 0 {
 				continue
 			}
-			item = append(item, jsonresult.NewOutCoin(outCoin))
+			outCoinResult := jsonresult.NewOutCoin(outCoin)
+			outCoinResult.SetMemo(outCoin.CoinDetails.GetInfo(), keyWallet.KeySet.ReadonlyKey.Rk)
+			item = append(item, outCoinResult)
 		}
 		result.Outputs[privateKeyStr] = item
 	}
@@ -116,7 +118,12 @@ func (coinService CoinService) ListOutputCoinsByKey(listKeyParams []interface{},
 		item := make([]jsonresult.OutCoin, 0)
 
 		for _, outCoin := range outputCoins {
-			item = append(item, jsonresult.NewOutCoin(outCoin))
+			outCoinResult := jsonresult.NewOutCoin(outCoin)
+			// memo is only shown to owner of coin
+			if len(keySet.ReadonlyKey.Rk) > 0 {
+				outCoinResult.SetMemo(outCoin.CoinDetails.GetInfo(), keySet.ReadonlyKey.Rk)
+			}
+			item = append(item, outCoinResult)
 		}
 		if readonlyKey != nil && len(readonlyKey.KeySet.ReadonlyKey.Rk) > 0 {
 			result.Outputs[readonlyKeyStr] = item
@@ -0,0 +1,371 @@
+package rpcservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/mempool"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Status of a tx in payout batch
+const (
+	PayoutTxPending   = "pending"
+	PayoutTxSent      = "sent"
+	PayoutTxConfirmed = "confirmed"
+	PayoutTxFailed    = "failed"
+)
+
+const (
+	// input coins which are reserved when packing payouts into a tx
+	payoutReservedInputCoins = 8
+	// Tx.Init accepts at most 254 payment infos, one of them is change of sender
+	maxPayoutsPerTx = 253
+)
+
+// PayoutTx is a tx which pays a group of payouts with the same token
+type PayoutTx struct {
+	TokenID       string
+	PayoutIndices []int
+	TxID          string
+	Status        string
+	Attempts      int
+	Error         string
+}
+
+// PayoutBatch is a list of payouts which are packed into txs, it is persisted to track status of its txs
+type PayoutBatch struct {
+	BatchID              string
+	SenderPaymentAddress string
+	ShardID              byte
+	CreatedTime          int64
+	EstimateFeeCoinPerKb int64
+	HasPrivacyCoin       bool
+	HasPrivacyToken      bool
+	Payouts              []bean.PayoutParam
+	Txs                  []*PayoutTx
+}
+
+// PayoutService builds txs of payout batches and keeps their status in local database of node
+type PayoutService struct {
+	BlockChain *blockchain.BlockChain
+	TxService  *TxService
+	TxMemPool  *mempool.TxPool
+	DB         incdb.Database
+	mtx        sync.Mutex
+}
+
+func getPaymentAddressStr(keySet *incognitokey.KeySet) string {
+	keyWallet := &wallet.KeyWallet{KeySet: incognitokey.KeySet{PaymentAddress: keySet.PaymentAddress}}
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+// estimatePayoutTxSize returns size in kb of a tx paying numPayouts payouts with total infoSize bytes of info
+func estimatePayoutTxSize(tokenID string, numPayouts int, infoSize int, hasPrivacyCoin bool) uint64 {
+	var txSize uint64
+	if tokenID == "" {
+		txSize = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(payoutReservedInputCoins, numPayouts+1, hasPrivacyCoin, nil, nil, 0))
+	} else {
+		tokenParams := &transaction.CustomTokenPrivacyParamTx{
+			PropertyID: tokenID,
+			TokenInput: make([]*privacy.InputCoin, payoutReservedInputCoins),
+			Receiver:   make([]*privacy.PaymentInfo, numPayouts+1),
+		}
+		txSize = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(payoutReservedInputCoins, 1, hasPrivacyCoin, nil, tokenParams, 0))
+	}
+	return txSize + uint64((infoSize+1023)/1024)
+}
+
+// packPayouts groups payouts by token and splits each group into as few txs as size limit of tx allows
+func packPayouts(payouts []bean.PayoutParam, hasPrivacyCoin bool) []*PayoutTx {
+	indicesByToken := make(map[string][]int)
+	tokenIDs := make([]string, 0)
+	for i, payout := range payouts {
+		if _, ok := indicesByToken[payout.TokenID]; !ok {
+			tokenIDs = append(tokenIDs, payout.TokenID)
+		}
+		indicesByToken[payout.TokenID] = append(indicesByToken[payout.TokenID], i)
+	}
+	sort.Strings(tokenIDs)
+
+	payoutTxs := make([]*PayoutTx, 0)
+	for _, tokenID := range tokenIDs {
+		var current *PayoutTx
+		infoSize := 0
+		for _, index := range indicesByToken[tokenID] {
+			payoutInfoSize := len(payouts[index].Info)
+			if current != nil {
+				numPayouts := len(current.PayoutIndices) + 1
+				if numPayouts > maxPayoutsPerTx || estimatePayoutTxSize(tokenID, numPayouts, infoSize+payoutInfoSize, hasPrivacyCoin) > common.MaxTxSize {
+					current = nil
+				}
+			}
+			if current == nil {
+				current = &PayoutTx{
+					TokenID: tokenID,
+					Status:  PayoutTxPending,
+				}
+				infoSize = 0
+				payoutTxs = append(payoutTxs, current)
+			}
+			current.PayoutIndices = append(current.PayoutIndices, index)
+			infoSize += payoutInfoSize
+		}
+	}
+	return payoutTxs
+}
+
+// NewPayoutBatch packs payouts of param into txs and stores the batch
+func (payoutService *PayoutService) NewPayoutBatch(param *bean.CreatePayoutBatchParam) (*PayoutBatch, *RPCError) {
+	batch := &PayoutBatch{
+		SenderPaymentAddress: getPaymentAddressStr(param.SenderKeySet),
+		ShardID:              param.ShardIDSender,
+		CreatedTime:          time.Now().Unix(),
+		EstimateFeeCoinPerKb: param.EstimateFeeCoinPerKb,
+		HasPrivacyCoin:       param.HasPrivacyCoin,
+		HasPrivacyToken:      param.HasPrivacyToken,
+		Payouts:              param.Payouts,
+		Txs:                  packPayouts(param.Payouts, param.HasPrivacyCoin),
+	}
+	batchBytes, err := json.Marshal(batch)
+	if err != nil {
+		return nil, NewRPCError(CreatePayoutBatchError, err)
+	}
+	batch.BatchID = common.HashH(batchBytes).String()
+
+	payoutService.mtx.Lock()
+	defer payoutService.mtx.Unlock()
+	if err := payoutService.storePayoutBatch(batch); err != nil {
+		return nil, NewRPCError(CreatePayoutBatchError, err)
+	}
+	return batch, nil
+}
+
+func (payoutService *PayoutService) storePayoutBatch(batch *PayoutBatch) error {
+	batchID, err := common.Hash{}.NewHashFromStr(batch.BatchID)
+	if err != nil {
+		return err
+	}
+	batchBytes, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	return rawdbv2.StorePayoutBatch(payoutService.DB, *batchID, batchBytes)
+}
+
+func (payoutService *PayoutService) getPayoutBatch(batchIDStr string) (*PayoutBatch, error) {
+	batchID, err := common.Hash{}.NewHashFromStr(batchIDStr)
+	if err != nil {
+		return nil, err
+	}
+	batchBytes, err := rawdbv2.GetPayoutBatch(payoutService.DB, *batchID)
+	if err != nil {
+		return nil, err
+	}
+	batch := &PayoutBatch{}
+	if err := json.Unmarshal(batchBytes, batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// BuildPayoutTx builds tx at txIndex of batch, which is signed by private key of keySet
+func (payoutService *PayoutService) BuildPayoutTx(batch *PayoutBatch, txIndex int, keySet *incognitokey.KeySet) (metadata.Transaction, *RPCError) {
+	if txIndex < 0 || txIndex >= len(batch.Txs) {
+		return nil, NewRPCError(GetPayoutBatchError, fmt.Errorf("tx index %d of payout batch is invalid", txIndex))
+	}
+	payoutTx := batch.Txs[txIndex]
+	paymentInfos := make([]*privacy.PaymentInfo, 0, len(payoutTx.PayoutIndices))
+	totalAmount := uint64(0)
+	for _, index := range payoutTx.PayoutIndices {
+		payout := batch.Payouts[index]
+		keyWallet, err := wallet.Base58CheckDeserialize(payout.PaymentAddress)
+		if err != nil {
+			return nil, NewRPCError(InvalidReceiverPaymentAddressError, err)
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			Amount:         payout.Amount,
+			Message:        []byte(payout.Info),
+		})
+		totalAmount += payout.Amount
+	}
+	transactionStateDB := payoutService.BlockChain.GetBestStateShard(batch.ShardID).GetCopiedTransactionStateDB()
+
+	if payoutTx.TokenID == "" {
+		inputCoins, realFee, rpcErr := payoutService.TxService.chooseOutsCoinByKeyset(paymentInfos, batch.EstimateFeeCoinPerKb, 0,
+			keySet, batch.ShardID, batch.HasPrivacyCoin, nil, nil, false, 0, "")
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		tx := &transaction.Tx{}
+		err := tx.Init(transaction.NewTxPrivacyInitParams(&keySet.PrivateKey, paymentInfos, inputCoins, realFee,
+			batch.HasPrivacyCoin, transactionStateDB, nil, nil, nil))
+		if err != nil {
+			return nil, NewRPCError(CreateTxDataError, err)
+		}
+		return tx, nil
+	}
+
+	tokenID, err := common.Hash{}.NewHashFromStr(payoutTx.TokenID)
+	if err != nil {
+		return nil, NewRPCError(TokenIsInvalidError, err)
+	}
+	outputTokens, err := payoutService.BlockChain.GetListOutputCoinsByKeyset(keySet, batch.ShardID, tokenID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	outputTokens, err = payoutService.TxService.filterMemPoolOutcoinsToSpent(outputTokens)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	candidateOutputTokens, _, _, err := payoutService.TxService.chooseBestOutCoinsToSpent(outputTokens, totalAmount)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:  payoutTx.TokenID,
+		TokenTxType: transaction.CustomTokenTransfer,
+		Amount:      totalAmount,
+		Receiver:    paymentInfos,
+		TokenInput:  transaction.ConvertOutputCoinToInputCoin(candidateOutputTokens),
+	}
+	// fee of payout token tx is paid by PRV
+	inputCoins, realFeePRV, rpcErr := payoutService.TxService.chooseOutsCoinByKeyset([]*privacy.PaymentInfo{}, batch.EstimateFeeCoinPerKb, 0,
+		keySet, batch.ShardID, batch.HasPrivacyCoin, nil, tokenParams, false, 0, "")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	hasPrivacyCoin := batch.HasPrivacyCoin
+	if realFeePRV == 0 {
+		hasPrivacyCoin = false
+	}
+	tx := &transaction.TxCustomTokenPrivacy{}
+	err = tx.Init(transaction.NewTxPrivacyTokenInitParams(&keySet.PrivateKey, []*privacy.PaymentInfo{}, inputCoins, realFeePRV,
+		tokenParams, transactionStateDB, nil, hasPrivacyCoin, batch.HasPrivacyToken, batch.ShardID, nil,
+		payoutService.BlockChain.BeaconChain.GetFinalViewState().GetBeaconFeatureStateDB()))
+	if err != nil {
+		return nil, NewRPCError(CreateTxDataError, err)
+	}
+	return tx, nil
+}
+
+// UpdatePayoutTx records result of an attempt to send tx at txIndex of batch
+func (payoutService *PayoutService) UpdatePayoutTx(batch *PayoutBatch, txIndex int, txID string, sendErr error) *RPCError {
+	payoutService.mtx.Lock()
+	defer payoutService.mtx.Unlock()
+	payoutTx := batch.Txs[txIndex]
+	payoutTx.Attempts++
+	if sendErr != nil {
+		payoutTx.Status = PayoutTxFailed
+		payoutTx.Error = sendErr.Error()
+	} else {
+		payoutTx.TxID = txID
+		payoutTx.Status = PayoutTxSent
+		payoutTx.Error = ""
+	}
+	if err := payoutService.storePayoutBatch(batch); err != nil {
+		return NewRPCError(CreatePayoutBatchError, err)
+	}
+	return nil
+}
+
+// refreshPayoutBatch updates status of sent txs of batch by looking for them in blocks and mempool
+func (payoutService *PayoutService) refreshPayoutBatch(batch *PayoutBatch) error {
+	isChanged := false
+	for _, payoutTx := range batch.Txs {
+		if payoutTx.Status != PayoutTxSent {
+			continue
+		}
+		txHash, err := common.Hash{}.NewHashFromStr(payoutTx.TxID)
+		if err != nil {
+			return err
+		}
+		if _, _, _, _, _, err := payoutService.BlockChain.GetTransactionByHash(*txHash); err == nil {
+			payoutTx.Status = PayoutTxConfirmed
+			isChanged = true
+			continue
+		}
+		if payoutService.TxMemPool.HaveTransaction(txHash) {
+			continue
+		}
+		payoutTx.Status = PayoutTxFailed
+		payoutTx.Error = "tx is neither in mempool nor in block"
+		isChanged = true
+	}
+	if isChanged {
+		return payoutService.storePayoutBatch(batch)
+	}
+	return nil
+}
+
+// GetPayoutBatch returns batch with up-to-date status of its txs
+func (payoutService *PayoutService) GetPayoutBatch(batchID string) (*PayoutBatch, *RPCError) {
+	payoutService.mtx.Lock()
+	defer payoutService.mtx.Unlock()
+	batch, err := payoutService.getPayoutBatch(batchID)
+	if err != nil {
+		return nil, NewRPCError(GetPayoutBatchError, err)
+	}
+	if err := payoutService.refreshPayoutBatch(batch); err != nil {
+		return nil, NewRPCError(GetPayoutBatchError, err)
+	}
+	return batch, nil
+}
+
+// ListPayoutBatches returns all batches stored by node, the newest first
+func (payoutService *PayoutService) ListPayoutBatches() ([]*PayoutBatch, *RPCError) {
+	payoutService.mtx.Lock()
+	defer payoutService.mtx.Unlock()
+	batchesBytes, err := rawdbv2.GetAllPayoutBatches(payoutService.DB)
+	if err != nil {
+		return nil, NewRPCError(GetPayoutBatchError, err)
+	}
+	batches := make([]*PayoutBatch, 0, len(batchesBytes))
+	for _, batchBytes := range batchesBytes {
+		batch := &PayoutBatch{}
+		if err := json.Unmarshal(batchBytes, batch); err != nil {
+			return nil, NewRPCError(GetPayoutBatchError, err)
+		}
+		if err := payoutService.refreshPayoutBatch(batch); err != nil {
+			return nil, NewRPCError(GetPayoutBatchError, err)
+		}
+		batches = append(batches, batch)
+	}
+	sort.SliceStable(batches, func(i, j int) bool {
+		return batches[i].CreatedTime > batches[j].CreatedTime
+	})
+	return batches, nil
+}
+
+// TxsToSend returns indices of txs of batch which are not sent yet or failed
+func (batch *PayoutBatch) TxsToSend() []int {
+	indices := make([]int, 0)
+	for i, payoutTx := range batch.Txs {
+		if payoutTx.Status == PayoutTxPending || payoutTx.Status == PayoutTxFailed {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// CheckSender makes sure keySet is the sender of batch before its txs are retried
+func (batch *PayoutBatch) CheckSender(keySet *incognitokey.KeySet) error {
+	if getPaymentAddressStr(keySet) != batch.SenderPaymentAddress {
+		return errors.New("private key is not the sender of payout batch")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackPayouts(t *testing.T) {
+	tokenID := common.Hash{1}.String()
+	payouts := make([]bean.PayoutParam, 0)
+	for i := 0; i < 600; i++ {
+		payouts = append(payouts, bean.PayoutParam{Amount: 1})
+	}
+	payouts = append(payouts, bean.PayoutParam{Amount: 1, TokenID: tokenID})
+
+	payoutTxs := packPayouts(payouts, false)
+	numPRVPayouts := 0
+	numTokenPayouts := 0
+	for _, payoutTx := range payoutTxs {
+		assert.Equal(t, PayoutTxPending, payoutTx.Status)
+		assert.True(t, len(payoutTx.PayoutIndices) <= maxPayoutsPerTx)
+		assert.True(t, estimatePayoutTxSize(payoutTx.TokenID, len(payoutTx.PayoutIndices), 0, false) <= common.MaxTxSize)
+		for _, index := range payoutTx.PayoutIndices {
+			assert.Equal(t, payouts[index].TokenID, payoutTx.TokenID)
+		}
+		if payoutTx.TokenID == "" {
+			numPRVPayouts += len(payoutTx.PayoutIndices)
+		} else {
+			numTokenPayouts += len(payoutTx.PayoutIndices)
+		}
+	}
+	assert.Equal(t, 600, numPRVPayouts)
+	assert.Equal(t, 1, numTokenPayouts)
+	// payouts of a token are only split when a tx is full
+	assert.Equal(t, maxPayoutsPerTx, len(payoutTxs[0].PayoutIndices))
+}
+
+func TestPayoutBatchTxsToSend(t *testing.T) {
+	batch := &PayoutBatch{
+		Txs: []*PayoutTx{
+			{Status: PayoutTxPending},
+			{Status: PayoutTxSent},
+			{Status: PayoutTxConfirmed},
+			{Status: PayoutTxFailed},
+		},
+	}
+	assert.Equal(t, []int{0, 3}, batch.TxsToSend())
+}
@@ -2,12 +2,19 @@ package rpcservice
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 )
 
+// MaxExchangeRatesHistoryBlocks is the maximum number of beacon blocks of exchange rates history in one request
+const MaxExchangeRatesHistoryBlocks = 1000
+
 type PortalService struct {
 	BlockChain *blockchain.BlockChain
 }
@@ -90,7 +97,8 @@ func (portal *PortalService) GetFinalExchangeRates(stateDB *statedb.StateDB, bea
 
 	for pTokenId, rates := range finalExchangeRates.Rates() {
 		item[pTokenId] = jsonresult.FinalExchangeRatesDetailResult{
-			Value: rates.Amount,
+			Value:        rates.Amount,
+			BeaconHeight: rates.BeaconHeight,
 		}
 	}
 
@@ -101,6 +109,33 @@ func (portal *PortalService) GetFinalExchangeRates(stateDB *statedb.StateDB, bea
 	return result, nil
 }
 
+// GetExchangeRatesHistory returns submitted and final exchange rates of beacon blocks from fromHeight to toHeight,
+// blocks without submissions are skipped
+func (portal *PortalService) GetExchangeRatesHistory(stateDB *statedb.StateDB, fromHeight uint64, toHeight uint64) ([]*metadata.ExchangeRatesHistory, error) {
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("FromBeaconHeight %v is greater than ToBeaconHeight %v", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight >= MaxExchangeRatesHistoryBlocks {
+		return nil, fmt.Errorf("Can not get history of more than %v blocks", MaxExchangeRatesHistoryBlocks)
+	}
+
+	result := make([]*metadata.ExchangeRatesHistory, 0)
+	for height := fromHeight; height <= toHeight; height++ {
+		data, err := statedb.GetPortalStateStatusMultiple(stateDB, statedb.PortalExchangeRatesHistoryStatusPrefix(), []byte(strconv.FormatUint(height, 10)))
+		if err != nil {
+			// no exchange rates are submitted in the block
+			continue
+		}
+		var history metadata.ExchangeRatesHistory
+		err = json.Unmarshal(data, &history)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &history)
+	}
+	return result, nil
+}
+
 func (portal *PortalService) ConvertExchangeRates(stateDB *statedb.StateDB, tokenID string, valuePToken uint64) (map[string]uint64, error) {
 	result := make(map[string]uint64)
 	finalExchangeRates, err := statedb.GetFinalExchangeRatesState(stateDB)
@@ -245,3 +280,110 @@ func (portal *PortalService) GetLiquidateExchangeRatesPool(
 	}
 	return result, nil
 }
+
+// GetCustodianRiskDashboard returns collateral health of custodians at final exchange rates of stateDB,
+// only custodian with custodianAddress is returned if it is not empty
+func (portal *PortalService) GetCustodianRiskDashboard(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	custodianAddress string,
+	warningRatio uint64,
+	portalParam blockchain.PortalParams) (*jsonresult.PortalCustodianRiskDashboard, error) {
+	currentPortalState, err := blockchain.InitCurrentPortalStateFromDB(stateDB)
+	if err != nil {
+		return nil, err
+	}
+	return calCustodianRiskDashboard(currentPortalState, beaconHeight, custodianAddress, warningRatio, portalParam)
+}
+
+func calCustodianRiskDashboard(
+	currentPortalState *blockchain.CurrentPortalState,
+	beaconHeight uint64,
+	custodianAddress string,
+	warningRatio uint64,
+	portalParam blockchain.PortalParams) (*jsonresult.PortalCustodianRiskDashboard, error) {
+	if warningRatio == 0 {
+		warningRatio = portalParam.MinPercentLockedCollateral
+	}
+	result := &jsonresult.PortalCustodianRiskDashboard{
+		BeaconHeight:               beaconHeight,
+		WarningRatio:               warningRatio,
+		MinPercentLockedCollateral: portalParam.MinPercentLockedCollateral,
+		TP130:                      portalParam.TP130,
+		TP120:                      portalParam.TP120,
+		Custodians:                 make([]jsonresult.PortalCustodianRisk, 0),
+	}
+
+	custodians := make([]*statedb.CustodianState, 0)
+	for _, custodian := range currentPortalState.CustodianPoolState {
+		if custodianAddress != "" && custodian.GetIncognitoAddress() != custodianAddress {
+			continue
+		}
+		custodians = append(custodians, custodian)
+	}
+	if custodianAddress != "" && len(custodians) == 0 {
+		return nil, fmt.Errorf("custodian %v is not found", custodianAddress)
+	}
+	sort.Slice(custodians, func(i, j int) bool {
+		return custodians[i].GetIncognitoAddress() < custodians[j].GetIncognitoAddress()
+	})
+
+	for _, custodian := range custodians {
+		tokenIDs := make([]string, 0)
+		for tokenID := range custodian.GetLockedAmountCollateral() {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+		for tokenID := range custodian.GetHoldingPublicTokens() {
+			if _, ok := custodian.GetLockedAmountCollateral()[tokenID]; !ok {
+				tokenIDs = append(tokenIDs, tokenID)
+			}
+		}
+		sort.Strings(tokenIDs)
+
+		custodianRisk := jsonresult.PortalCustodianRisk{
+			IncognitoAddress: custodian.GetIncognitoAddress(),
+			TotalCollateral:  custodian.GetTotalCollateral(),
+			FreeCollateral:   custodian.GetFreeCollateral(),
+			Tokens:           make([]jsonresult.PortalCustodianTokenRisk, 0),
+		}
+		for _, tokenID := range tokenIDs {
+			tpRatio, err := blockchain.CalCustodianTPRatio(currentPortalState, custodian, currentPortalState.FinalExchangeRatesState, tokenID, portalParam)
+			if err != nil {
+				// a token without exchange rate is reported as unpriced, so risks of other tokens are still reported
+				custodianRisk.Tokens = append(custodianRisk.Tokens, jsonresult.PortalCustodianTokenRisk{
+					TokenID:          tokenID,
+					LockedCollateral: custodian.GetLockedAmountCollateral()[tokenID],
+					HoldingPubToken:  custodian.GetHoldingPublicTokens()[tokenID],
+					Status:           jsonresult.CustodianRiskUnpriced,
+				})
+				continue
+			}
+			tokenRisk := jsonresult.PortalCustodianTokenRisk{
+				TokenID:              tokenID,
+				LockedCollateral:     tpRatio.LockedCollateral,
+				HoldingPubToken:      tpRatio.HoldPubTokenAmount,
+				HoldingPubTokenInPRV: tpRatio.HoldPubTokenInPRV,
+				TPRatio:              tpRatio.TPRatio,
+				Status:               jsonresult.CustodianRiskSafe,
+			}
+			if tpRatio.HoldPubTokenAmount > 0 {
+				tokenRisk.DistanceToTP130 = int64(tpRatio.TPRatio) - int64(portalParam.TP130)
+				tokenRisk.DistanceToTP120 = int64(tpRatio.TPRatio) - int64(portalParam.TP120)
+				tokenRisk.TopUpAmount, err = blockchain.CalAmountNeededDepositLiquidate(currentPortalState, custodian, currentPortalState.FinalExchangeRatesState, tokenID, portalParam)
+				if err != nil {
+					return nil, err
+				}
+				if tpRatio.IsTP120 {
+					tokenRisk.Status = jsonresult.CustodianRiskTP120
+				} else if tpRatio.IsTP130 {
+					tokenRisk.Status = jsonresult.CustodianRiskTP130
+				} else if tpRatio.TPRatio < warningRatio {
+					tokenRisk.Status = jsonresult.CustodianRiskWarning
+				}
+			}
+			custodianRisk.Tokens = append(custodianRisk.Tokens, tokenRisk)
+		}
+		result.Custodians = append(result.Custodians, custodianRisk)
+	}
+	return result, nil
+}
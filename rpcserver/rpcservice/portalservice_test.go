@@ -0,0 +1,71 @@
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalCustodianRiskDashboard(t *testing.T) {
+	portalParam := blockchain.PortalParams{MinPercentLockedCollateral: 150, TP120: 120, TP130: 130}
+	portalState := &blockchain.CurrentPortalState{
+		CustodianPoolState: map[string]*statedb.CustodianState{
+			"a": statedb.NewCustodianStateWithValue("custodianA", 200000, 40000,
+				map[string]uint64{common.PortalBTCIDStr: 10}, map[string]uint64{common.PortalBTCIDStr: 160000}, nil, nil),
+			"b": statedb.NewCustodianStateWithValue("custodianB", 1250, 0,
+				map[string]uint64{common.PortalBNBIDStr: 10}, map[string]uint64{common.PortalBNBIDStr: 1250}, nil, nil),
+			"c": statedb.NewCustodianStateWithValue("custodianC", 140000, 0,
+				map[string]uint64{common.PortalBTCIDStr: 10}, map[string]uint64{common.PortalBTCIDStr: 140000}, nil, nil),
+			"d": statedb.NewCustodianStateWithValue("custodianD", 1000, 0,
+				nil, map[string]uint64{common.PortalBNBIDStr: 1000}, nil, nil),
+		},
+		WaitingPortingRequests: map[string]*statedb.WaitingPortingRequest{},
+		WaitingRedeemRequests:  map[string]*statedb.RedeemRequest{},
+		MatchedRedeemRequests:  map[string]*statedb.RedeemRequest{},
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PortalBTCIDStr: {Amount: 10000},
+			common.PortalBNBIDStr: {Amount: 100},
+			common.PRVIDStr:       {Amount: 1},
+		}),
+	}
+
+	dashboard, err := calCustodianRiskDashboard(portalState, 10, "", 0, portalParam)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(150), dashboard.WarningRatio)
+	assert.Equal(t, 4, len(dashboard.Custodians))
+	expected := map[string]jsonresult.PortalCustodianTokenRisk{
+		"custodianA": {TokenID: common.PortalBTCIDStr, LockedCollateral: 160000, HoldingPubToken: 10, HoldingPubTokenInPRV: 100000,
+			TPRatio: 160, DistanceToTP130: 30, DistanceToTP120: 40, Status: jsonresult.CustodianRiskSafe},
+		"custodianB": {TokenID: common.PortalBNBIDStr, LockedCollateral: 1250, HoldingPubToken: 10, HoldingPubTokenInPRV: 1000,
+			TPRatio: 125, DistanceToTP130: -5, DistanceToTP120: 5, TopUpAmount: 250, Status: jsonresult.CustodianRiskTP130},
+		"custodianC": {TokenID: common.PortalBTCIDStr, LockedCollateral: 140000, HoldingPubToken: 10, HoldingPubTokenInPRV: 100000,
+			TPRatio: 140, DistanceToTP130: 10, DistanceToTP120: 20, TopUpAmount: 10000, Status: jsonresult.CustodianRiskWarning},
+		"custodianD": {TokenID: common.PortalBNBIDStr, LockedCollateral: 1000, Status: jsonresult.CustodianRiskSafe},
+	}
+	for _, custodian := range dashboard.Custodians {
+		assert.Equal(t, []jsonresult.PortalCustodianTokenRisk{expected[custodian.IncognitoAddress]}, custodian.Tokens)
+	}
+
+	// custodian C is safe with a lower warning ratio
+	dashboard, err = calCustodianRiskDashboard(portalState, 10, "custodianC", 135, portalParam)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(dashboard.Custodians))
+	assert.Equal(t, jsonresult.CustodianRiskSafe, dashboard.Custodians[0].Tokens[0].Status)
+
+	_, err = calCustodianRiskDashboard(portalState, 10, "custodianE", 0, portalParam)
+	assert.NotNil(t, err)
+
+	// a token without exchange rate is unpriced, other tokens are still reported
+	portalState.CustodianPoolState["e"] = statedb.NewCustodianStateWithValue("custodianE", 5000, 0,
+		map[string]uint64{"unpriced": 5}, map[string]uint64{"unpriced": 5000}, nil, nil)
+	dashboard, err = calCustodianRiskDashboard(portalState, 10, "", 0, portalParam)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(dashboard.Custodians))
+	assert.Equal(t, []jsonresult.PortalCustodianTokenRisk{{TokenID: "unpriced", LockedCollateral: 5000, HoldingPubToken: 5, Status: jsonresult.CustodianRiskUnpriced}},
+		dashboard.Custodians[4].Tokens)
+	assert.Equal(t, expected["custodianA"], dashboard.Custodians[0].Tokens[0])
+}
@@ -183,7 +183,13 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	isGetFeePToken bool,
 	unitFeePToken int64,
+	coinSelectionStrategy string,
 ) ([]*privacy.InputCoin, uint64, *RPCError) {
+	selectCoins, rpcErr := GetCoinSelector(coinSelectionStrategy)
+	if rpcErr != nil {
+		return nil, 0, rpcErr
+	}
+	maxInputs := MaxInputCoinsPerTx(len(paymentInfos), hasPrivacy)
 	// estimate fee according to 8 recent block
 	if numBlock == 0 {
 		numBlock = 1000
@@ -208,8 +214,8 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if len(outCoins) == 0 && totalAmmount > 0 {
 		return nil, 0, NewRPCError(GetOutputCoinError, errors.New("not enough output coin"))
 	}
-	// Use Knapsack to get candiate output coin
-	candidateOutputCoins, outCoins, candidateOutputCoinAmount, err := txService.chooseBestOutCoinsToSpent(outCoins, totalAmmount)
+	// Use coin selection strategy to get candiate output coin
+	candidateOutputCoins, outCoins, candidateOutputCoinAmount, err := selectCoins(outCoins, totalAmmount, maxInputs)
 	if err != nil {
 		return nil, 0, NewRPCError(GetOutputCoinError, err)
 	}
@@ -255,13 +261,16 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	// if not enough to pay fee
 	if needToPayFee > 0 {
 		if len(outCoins) > 0 {
-			candidateOutputCoinsForFee, _, _, err1 := txService.chooseBestOutCoinsToSpent(outCoins, uint64(needToPayFee))
+			candidateOutputCoinsForFee, _, _, err1 := selectCoins(outCoins, uint64(needToPayFee), maxInputs-len(candidateOutputCoins))
 			if err != nil {
 				return nil, 0, NewRPCError(GetOutputCoinError, err1)
 			}
 			candidateOutputCoins = append(candidateOutputCoins, candidateOutputCoinsForFee...)
 		}
 	}
+	if len(candidateOutputCoins) > maxInputs {
+		return nil, 0, NewRPCError(ExceedMaxInputCoinsError, fmt.Errorf("need %d input coins, max input coins of tx is %d", len(candidateOutputCoins), maxInputs))
+	}
 	// convert to inputcoins
 	inputCoins := transaction.ConvertOutputCoinToInputCoin(candidateOutputCoins)
 	return inputCoins, realFee, nil
@@ -362,7 +371,7 @@ func (txService TxService) BuildRawTransaction(params *bean.CreateRawTxParam, me
 	inputCoins, realFee, err1 := txService.chooseOutsCoinByKeyset(
 		params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
 		params.SenderKeySet, params.ShardIDSender, params.HasPrivacyCoin,
-		meta, nil, false, int64(0))
+		meta, nil, false, int64(0), params.CoinSelectionStrategy)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -402,6 +411,73 @@ func (txService TxService) CreateRawTransaction(params *bean.CreateRawTxParam, m
 	return tx.Hash(), txBytes, txShardID, nil
 }
 
+// EstimateFeeReserve returns fee of a small PRV transfer (one input coin, one payment and change) from shardID,
+// it is the PRV an account keeps to pay fee when payments are routed among accounts of a portfolio
+func (txService TxService) EstimateFeeReserve(feePerKb int64, shardID byte, hasPrivacy bool) (uint64, *RPCError) {
+	beaconState, err := txService.BlockChain.GetClonedBeaconBestState()
+	if err != nil {
+		return 0, NewRPCError(GetClonedBeaconBestStateError, err)
+	}
+	unitFee, err := txService.EstimateFeeWithEstimator(feePerKb, shardID, 0, nil, int64(beaconState.BeaconHeight))
+	if err != nil {
+		return 0, NewRPCError(RejectInvalidTxFeeError, err)
+	}
+	txSize := transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(1, 2, hasPrivacy, nil, nil, 0))
+	return unitFee * txSize, nil
+}
+
+// BuildRawSweepTransaction builds a tx which sends all PRV of sender to receiver except the fee, so no change is left.
+// If sender has more output coins than a tx can spend, the largest ones are sent.
+// It returns the tx and the amount receiver gets, or nil tx if balance of sender does not cover the fee
+func (txService TxService) BuildRawSweepTransaction(senderKeySet *incognitokey.KeySet, shardIDSender byte, receiver privacy.PaymentAddress, feePerKb int64, hasPrivacy bool) (*transaction.Tx, uint64, *RPCError) {
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(senderKeySet, shardIDSender, &common.PRVCoinID)
+	if err != nil {
+		return nil, 0, NewRPCError(GetOutputCoinError, err)
+	}
+	outCoins, err = txService.filterMemPoolOutcoinsToSpent(outCoins)
+	if err != nil {
+		return nil, 0, NewRPCError(GetOutputCoinError, err)
+	}
+	sort.Slice(outCoins, func(i, j int) bool {
+		return outCoins[i].CoinDetails.GetValue() > outCoins[j].CoinDetails.GetValue()
+	})
+	if maxInputs := MaxInputCoinsPerTx(1, hasPrivacy); len(outCoins) > maxInputs {
+		outCoins = outCoins[:maxInputs]
+	}
+	amount := sumOutputCoinValues(outCoins)
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiver, Amount: amount}}
+	beaconState, err := txService.BlockChain.GetClonedBeaconBestState()
+	if err != nil {
+		return nil, 0, NewRPCError(GetClonedBeaconBestStateError, err)
+	}
+	realFee, _, _, err := txService.EstimateFee(feePerKb, false, outCoins, paymentInfos, shardIDSender, 0, hasPrivacy, nil, nil, int64(beaconState.BeaconHeight))
+	if err != nil {
+		return nil, 0, NewRPCError(RejectInvalidTxFeeError, err)
+	}
+	if amount <= realFee {
+		return nil, 0, nil
+	}
+	paymentInfos[0].Amount = amount - realFee
+
+	tx := transaction.Tx{}
+	err = tx.Init(
+		transaction.NewTxPrivacyInitParams(
+			&senderKeySet.PrivateKey,
+			paymentInfos,
+			transaction.ConvertOutputCoinToInputCoin(outCoins),
+			realFee,
+			hasPrivacy,
+			txService.BlockChain.GetBestStateShard(shardIDSender).GetCopiedTransactionStateDB(),
+			nil,
+			nil,
+			nil,
+		))
+	if err != nil {
+		return nil, 0, NewRPCError(CreateTxDataError, err)
+	}
+	return &tx, paymentInfos[0].Amount, nil
+}
+
 func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message, *common.Hash, byte, *RPCError) {
 	// Decode base58check data of tx
 	rawTxBytes, _, err := base58.Base58Check{}.Decode(txB58Check)
@@ -549,6 +625,12 @@ func (txService TxService) BuildPrivacyCustomTokenParam(tokenParamsRaw map[strin
 	if err1 != nil {
 		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, err1)
 	}
+	if tokenMemosParam, ok := tokenParamsRaw["TokenMemos"]; ok {
+		err1 = bean.SetMemosOfPaymentInfos(tokenParams.Receiver, tokenMemosParam)
+		if err1 != nil {
+			return nil, nil, nil, NewRPCError(RPCInvalidParamsError, err1)
+		}
+	}
 	voutsAmount += int64(tokenFee)
 	// get list custom token
 	switch tokenParams.TokenTxType {
@@ -585,10 +667,19 @@ func (txService TxService) BuildPrivacyCustomTokenParam(tokenParamsRaw map[strin
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
-			candidateOutputTokens, _, _, err := txService.chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount))
+			coinSelectionStrategy, _ := tokenParamsRaw["CoinSelectionStrategy"].(string)
+			selectCoins, rpcErr := GetCoinSelector(coinSelectionStrategy)
+			if rpcErr != nil {
+				return nil, nil, nil, rpcErr
+			}
+			maxInputs := MaxInputCoinsPerTx(len(tokenParams.Receiver), true)
+			candidateOutputTokens, _, _, err := selectCoins(outputTokens, uint64(voutsAmount), maxInputs)
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
+			if len(candidateOutputTokens) > maxInputs {
+				return nil, nil, nil, NewRPCError(ExceedMaxInputCoinsError, fmt.Errorf("need %d input token coins, max input coins of tx is %d", len(candidateOutputTokens), maxInputs))
+			}
 			intputToken := transaction.ConvertOutputCoinToInputCoin(candidateOutputTokens)
 			tokenParams.TokenInput = intputToken
 		}
@@ -681,6 +772,12 @@ func (txService TxService) BuildPrivacyCustomTokenParamV2(tokenParamsRaw map[str
 	if err1 != nil {
 		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, err1)
 	}
+	if tokenMemosParam, ok := tokenParamsRaw["TokenMemos"]; ok {
+		err1 = bean.SetMemosOfPaymentInfos(tokenParams.Receiver, tokenMemosParam)
+		if err1 != nil {
+			return nil, nil, nil, NewRPCError(RPCInvalidParamsError, err1)
+		}
+	}
 	voutsAmount += int64(tokenFee)
 	// get list custom token
 	switch tokenParams.TokenTxType {
@@ -717,10 +814,19 @@ func (txService TxService) BuildPrivacyCustomTokenParamV2(tokenParamsRaw map[str
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
-			candidateOutputTokens, _, _, err := txService.chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount))
+			coinSelectionStrategy, _ := tokenParamsRaw["CoinSelectionStrategy"].(string)
+			selectCoins, rpcErr := GetCoinSelector(coinSelectionStrategy)
+			if rpcErr != nil {
+				return nil, nil, nil, rpcErr
+			}
+			maxInputs := MaxInputCoinsPerTx(len(tokenParams.Receiver), true)
+			candidateOutputTokens, _, _, err := selectCoins(outputTokens, uint64(voutsAmount), maxInputs)
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
+			if len(candidateOutputTokens) > maxInputs {
+				return nil, nil, nil, NewRPCError(ExceedMaxInputCoinsError, fmt.Errorf("need %d input token coins, max input coins of tx is %d", len(candidateOutputTokens), maxInputs))
+			}
 			intputToken := transaction.ConvertOutputCoinToInputCoin(candidateOutputTokens)
 			tokenParams.TokenInput = intputToken
 		}
@@ -765,7 +871,7 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransaction(params interfac
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, txParam.CoinSelectionStrategy)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -817,7 +923,7 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransactionV2(params interf
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, txParam.CoinSelectionStrategy)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -865,9 +971,24 @@ func (txService TxService) GetTransactionHashByReceiver(paymentAddressParam stri
 }
 
 func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.TransactionDetail, *RPCError) {
+	result, _, err := txService.getTransactionByHash(txHashStr)
+	return result, err
+}
+
+// GetTransactionByHashForOwner returns tx detail with memos of output coins which are sent to owner of readonly key
+func (txService TxService) GetTransactionByHashForOwner(txHashStr string, readonlyKey privacy.ViewingKey) (*jsonresult.TransactionDetail, *RPCError) {
+	result, tx, err := txService.getTransactionByHash(txHashStr)
+	if err != nil {
+		return nil, err
+	}
+	result.SetMemos(tx, readonlyKey)
+	return result, nil
+}
+
+func (txService TxService) getTransactionByHash(txHashStr string) (*jsonresult.TransactionDetail, metadata.Transaction, *RPCError) {
 	txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
 	if err != nil {
-		return nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
+		return nil, nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
 	}
 	Logger.log.Infof("Get Transaction By Hash %+v", *txHash)
 
@@ -876,24 +997,24 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 		// maybe tx is still in tx mempool -> check mempool
 		tx, errM := txService.TxMemPool.GetTx(txHash)
 		if errM != nil {
-			return nil, NewRPCError(TxNotExistedInMemAndBLockError, errors.New("Tx is not existed in block or mempool"))
+			return nil, nil, NewRPCError(TxNotExistedInMemAndBLockError, errors.New("Tx is not existed in block or mempool"))
 		}
 		shardIDTemp := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 		result, errM := jsonresult.NewTransactionDetail(tx, nil, 0, 0, shardIDTemp)
 		if errM != nil {
-			return nil, NewRPCError(UnexpectedError, errM)
+			return nil, nil, NewRPCError(UnexpectedError, errM)
 		}
 		result.IsInMempool = true
-		return result, nil
+		return result, tx, nil
 	}
 
 	result, err := jsonresult.NewTransactionDetail(tx, &blockHash, blockHeight, index, shardID)
 	if err != nil {
-		return nil, NewRPCError(UnexpectedError, err)
+		return nil, nil, NewRPCError(UnexpectedError, err)
 	}
 	result.IsInBlock = true
 	Logger.log.Debugf("handleGetTransactionByHash result: %+v", result)
-	return result, nil
+	return result, tx, nil
 }
 
 func (txService TxService) ListPrivacyCustomToken() (map[common.Hash]*statedb.TokenState, error) {
@@ -1720,7 +1841,7 @@ func (txService TxService) BuildRawDefragmentPrivacyCustomTokenTransaction(param
 	realFeePRV := uint64(0)
 	inputCoins, realFeePRV, err = txService.chooseOutsCoinByKeyset(txParam.PaymentInfos,
 		txParam.EstimateFeeCoinPerKb, 0, txParam.SenderKeySet,
-		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee)
+		txParam.ShardIDSender, txParam.HasPrivacyCoin, nil, tokenParams, txParam.IsGetPTokenFee, txParam.UnitPTokenFee, "")
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -1865,3 +1986,167 @@ func (txService TxService) BuildDefragmentPrivacyCustomTokenParam(tokenParamsRaw
 	}
 	return tokenParams, nil, nil, nil
 }
+
+// getUnspentOutputCoinsBySpentSNDs returns output coins of key set which are not in spentSNDs
+// readonly key set can not derive serial numbers, so spent coins are excluded by their snds
+func (txService TxService) getUnspentOutputCoinsBySpentSNDs(keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash, spentSNDs map[string]struct{}) ([]*privacy.OutputCoin, *RPCError) {
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardID, tokenID)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	unspentOutCoins := make([]*privacy.OutputCoin, 0)
+	for _, outCoin := range outCoins {
+		if _, ok := spentSNDs[string(outCoin.CoinDetails.GetSNDerivator().ToBytesS())]; !ok {
+			unspentOutCoins = append(unspentOutCoins, outCoin)
+		}
+	}
+	return unspentOutCoins, nil
+}
+
+// buildUnsignedTxComponent chooses random commitments and output snds for spending outCoins
+func (txService TxService) buildUnsignedTxComponent(
+	shardID byte, tokenID *common.Hash, hasPrivacy bool, fee uint64,
+	paymentInfos []*privacy.PaymentInfo, outCoins []*privacy.OutputCoin,
+	stateDB *statedb.StateDB,
+) (transaction.UnsignedTxComponent, *RPCError) {
+	inputCoins := transaction.ConvertOutputCoinToInputCoin(outCoins)
+	sumInputValue := uint64(0)
+	for _, inputCoin := range inputCoins {
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, paymentInfo := range paymentInfos {
+		sumOutputValue += paymentInfo.Amount
+	}
+	if sumInputValue < sumOutputValue+fee {
+		return transaction.UnsignedTxComponent{}, NewRPCError(GetOutputCoinError, errors.New("not enough output coin"))
+	}
+	numOutputs := len(paymentInfos)
+	if sumInputValue > sumOutputValue+fee {
+		numOutputs++
+	}
+
+	commitmentIndices, myCommitmentIndices, commitments := []uint64{}, []uint64{}, [][]byte{}
+	if hasPrivacy && len(inputCoins) > 0 {
+		commitmentIndices, myCommitmentIndices, commitments = txService.BlockChain.RandomCommitmentsProcess(inputCoins, 0, shardID, tokenID)
+		if len(commitmentIndices) != len(inputCoins)*privacy.CommitmentRingSize {
+			return transaction.UnsignedTxComponent{}, NewRPCError(UnexpectedError, errors.New("can not random commitments"))
+		}
+	}
+
+	sndOutputs := make([]*privacy.Scalar, numOutputs)
+	for i := range sndOutputs {
+		for {
+			sndOut := privacy.RandomScalar()
+			ok, err := transaction.CheckSNDerivatorExistence(tokenID, sndOut, stateDB)
+			if err != nil {
+				return transaction.UnsignedTxComponent{}, NewRPCError(UnexpectedError, err)
+			}
+			if !ok {
+				sndOutputs[i] = sndOut
+				break
+			}
+		}
+	}
+
+	return transaction.NewUnsignedTxComponent(hasPrivacy, fee, paymentInfos, inputCoins,
+		commitmentIndices, commitments, myCommitmentIndices, sndOutputs), nil
+}
+
+// BuildUnsignedTransaction builds tx package for PRV or privacy token transfer which is proved and signed offline
+// Sender is given by payment address and readonly key, so private key is never sent to node
+// Fee of privacy token transfer is paid by PRV
+func (txService TxService) BuildUnsignedTransaction(params *bean.CreateUnsignedTxParam, meta metadata.Metadata) (*transaction.UnsignedTx, *RPCError) {
+	shardID := params.ShardIDSender
+	stateDB := txService.BlockChain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
+	unsignedTx := &transaction.UnsignedTx{
+		Type:     common.TxNormalType,
+		SenderPk: params.SenderKeySet.PaymentAddress.Pk,
+		LockTime: time.Now().Unix(),
+		Info:     params.Info,
+		Metadata: meta,
+	}
+
+	// choose privacy token coins to spend
+	var tokenParamsForFee *transaction.CustomTokenPrivacyParamTx
+	if params.TokenParam != nil {
+		outTokens, rpcErr := txService.getUnspentOutputCoinsBySpentSNDs(params.SenderKeySet, shardID, params.TokenParam.TokenID, params.SpentSNDs)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		candidateOutputTokens, _, _, err := txService.chooseBestOutCoinsToSpent(outTokens, params.TokenParam.TokenAmount)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+		tokenComponent, rpcErr := txService.buildUnsignedTxComponent(shardID, params.TokenParam.TokenID, params.TokenParam.HasPrivacyToken, 0,
+			params.TokenParam.TokenReceivers, candidateOutputTokens, stateDB)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		unsignedTx.Type = common.TxCustomTokenPrivacyType
+		unsignedTx.TokenData = &transaction.UnsignedTokenData{
+			PropertyID:          params.TokenParam.TokenID.String(),
+			PropertyName:        params.TokenParam.TokenName,
+			PropertySymbol:      params.TokenParam.TokenSymbol,
+			UnsignedTxComponent: tokenComponent,
+		}
+		tokenParamsForFee = &transaction.CustomTokenPrivacyParamTx{
+			PropertyID:     params.TokenParam.TokenID.String(),
+			PropertyName:   params.TokenParam.TokenName,
+			PropertySymbol: params.TokenParam.TokenSymbol,
+			TokenTxType:    transaction.CustomTokenTransfer,
+			Amount:         params.TokenParam.TokenAmount,
+			Receiver:       params.TokenParam.TokenReceivers,
+			TokenInput:     transaction.ConvertOutputCoinToInputCoin(candidateOutputTokens),
+		}
+	}
+
+	// choose PRV coins to spend for payments and fee
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	outCoins, rpcErr := txService.getUnspentOutputCoinsBySpentSNDs(params.SenderKeySet, shardID, prvCoinID, params.SpentSNDs)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	totalAmount := uint64(0)
+	for _, paymentInfo := range params.PaymentInfos {
+		totalAmount += paymentInfo.Amount
+	}
+	candidateOutputCoins := make([]*privacy.OutputCoin, 0)
+	candidateOutputCoinAmount := uint64(0)
+	if totalAmount > 0 {
+		var err error
+		candidateOutputCoins, outCoins, candidateOutputCoinAmount, err = txService.chooseBestOutCoinsToSpent(outCoins, totalAmount)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+	}
+	paymentInfosForFee := append([]*privacy.PaymentInfo{}, params.PaymentInfos...)
+	if candidateOutputCoinAmount > totalAmount {
+		paymentInfosForFee = append(paymentInfosForFee, &privacy.PaymentInfo{
+			PaymentAddress: params.SenderKeySet.PaymentAddress,
+			Amount:         candidateOutputCoinAmount - totalAmount,
+		})
+	}
+	beaconHeight := txService.BlockChain.GetBeaconBestState().BeaconHeight
+	realFee, _, _, err := txService.EstimateFee(params.EstimateFeeCoinPerKb, false, candidateOutputCoins,
+		paymentInfosForFee, shardID, 0, params.HasPrivacyCoin, meta, tokenParamsForFee, int64(beaconHeight))
+	if err != nil {
+		return nil, NewRPCError(RejectInvalidTxFeeError, err)
+	}
+	if totalAmount+realFee > candidateOutputCoinAmount {
+		candidateOutputCoinsForFee, _, _, err := txService.chooseBestOutCoinsToSpent(outCoins, totalAmount+realFee-candidateOutputCoinAmount)
+		if err != nil {
+			return nil, NewRPCError(GetOutputCoinError, err)
+		}
+		candidateOutputCoins = append(candidateOutputCoins, candidateOutputCoinsForFee...)
+	}
+	hasPrivacyCoin := params.HasPrivacyCoin && len(candidateOutputCoins) > 0
+	prvComponent, rpcErr := txService.buildUnsignedTxComponent(shardID, prvCoinID, hasPrivacyCoin, realFee,
+		params.PaymentInfos, candidateOutputCoins, stateDB)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	unsignedTx.PRV = prvComponent
+	return unsignedTx, nil
+}
@@ -3,11 +3,14 @@ package rpcservice
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
 	"math/rand"
+	"sort"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
@@ -19,8 +22,9 @@ type WalletService struct {
 
 func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPCError) {
 	result := jsonresult.ListAccounts{
-		Accounts:   make(map[string]uint64),
-		WalletName: walletService.Wallet.Name,
+		Accounts:               make(map[string]uint64),
+		WalletName:             walletService.Wallet.Name,
+		WatchOnlyTotalReceived: make(map[string]uint64),
 	}
 	accounts := walletService.Wallet.ListAccounts()
 	for accountName, account := range accounts {
@@ -39,6 +43,10 @@ func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPC
 		for _, out := range outCoins {
 			amount += out.CoinDetails.GetValue()
 		}
+		if account.IsWatchOnly {
+			result.WatchOnlyTotalReceived[accountName] = amount
+			continue
+		}
 		result.Accounts[accountName] = amount
 	}
 
@@ -99,6 +107,126 @@ func (walletService *WalletService) ImportAccount(privateKey string, accountName
 	return result, nil
 }
 
+func (walletService *WalletService) ImportWatchOnlyAccount(paymentAddress string, readonlyKey string, accountName string, passPhrase string) (wallet.KeySerializedData, error) {
+	account, err := walletService.Wallet.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return wallet.KeySerializedData{}, err
+	}
+	result := wallet.KeySerializedData{
+		PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+		Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+		ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+	}
+
+	return result, nil
+}
+
+func (walletService *WalletService) RemoveWatchOnlyAccount(paymentAddress string, passPhrase string) (bool, *RPCError) {
+	err := walletService.Wallet.RemoveWatchOnlyAccount(paymentAddress, passPhrase)
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	return true, nil
+}
+
+// GetWatchOnlyKeySet returns key set (payment address and readonly key) of watch-only account which has accountName
+func (walletService WalletService) GetWatchOnlyKeySet(accountName string) (*incognitokey.KeySet, *RPCError) {
+	for _, account := range walletService.Wallet.MasterAccount.Child {
+		if account.Name == accountName && account.IsWatchOnly {
+			keySet := account.Key.KeySet
+			return &keySet, nil
+		}
+	}
+	return nil, NewRPCError(RPCInvalidParamsError, errors.New("watch-only account is not found"))
+}
+
+// GetWatchOnlyBalance returns PRV and privacy token totals received by watch-only account which has accountName,
+// spent output coins are not filtered (see jsonresult.WatchOnlyBalance)
+func (walletService WalletService) GetWatchOnlyBalance(accountName string) (jsonresult.WatchOnlyBalance, *RPCError) {
+	keySet, rpcErr := walletService.GetWatchOnlyKeySet(accountName)
+	if rpcErr != nil {
+		return jsonresult.WatchOnlyBalance{}, rpcErr
+	}
+	lastByte := keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(lastByte)
+	keyWallet := &wallet.KeyWallet{KeySet: *keySet}
+	result := jsonresult.WatchOnlyBalance{
+		AccountName:                  accountName,
+		PaymentAddress:               keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+		ListCustomTokenTotalReceived: []jsonresult.CustomTokenBalance{},
+	}
+
+	prvTotalReceived, tokenTotalsReceived, rpcErr := walletService.getBalances(keySet, shardID)
+	if rpcErr != nil {
+		return jsonresult.WatchOnlyBalance{}, rpcErr
+	}
+	result.PRVTotalReceived = prvTotalReceived
+	result.ListCustomTokenTotalReceived = tokenTotalsReceived
+	return result, nil
+}
+
+// getBalances returns PRV balance and non-zero privacy token balances of keySet,
+// for a keySet without private key (watch-only) spent output coins are not filtered
+func (walletService WalletService) getBalances(keySet *incognitokey.KeySet, shardID byte) (uint64, []jsonresult.CustomTokenBalance, *RPCError) {
+	tokenStates, err := walletService.BlockChain.ListAllPrivacyCustomTokenAndPRV()
+	if err != nil {
+		return 0, nil, NewRPCError(GetListPrivacyCustomTokenBalanceError, err)
+	}
+	_, allBridgeTokens, err := walletService.BlockChain.GetAllBridgeTokens()
+	if err != nil {
+		return 0, nil, NewRPCError(GetListPrivacyCustomTokenBalanceError, err)
+	}
+	isBridgeToken := make(map[common.Hash]bool)
+	for _, bridgeToken := range allBridgeTokens {
+		isBridgeToken[*bridgeToken.TokenID] = true
+		if _, ok := tokenStates[*bridgeToken.TokenID]; !ok {
+			tokenStates[*bridgeToken.TokenID] = nil
+		}
+	}
+
+	prvBalance := uint64(0)
+	tokenBalances := []jsonresult.CustomTokenBalance{}
+	for tokenID, tokenState := range tokenStates {
+		tokenIDTemp := tokenID
+		balance, rpcErr := walletService.getTokenBalance(keySet, shardID, &tokenIDTemp)
+		if rpcErr != nil {
+			return 0, nil, rpcErr
+		}
+		if tokenID == common.PRVCoinID {
+			prvBalance = balance
+			continue
+		}
+		if balance == 0 {
+			continue
+		}
+		item := jsonresult.CustomTokenBalance{
+			TokenID:       tokenID.String(),
+			TokenImage:    common.Render([]byte(tokenID.String())),
+			Amount:        balance,
+			IsPrivacy:     true,
+			IsBridgeToken: isBridgeToken[tokenID],
+		}
+		if tokenState != nil {
+			item.Name = tokenState.PropertyName()
+			item.Symbol = tokenState.PropertySymbol()
+		}
+		tokenBalances = append(tokenBalances, item)
+	}
+	return prvBalance, tokenBalances, nil
+}
+
+func (walletService WalletService) getTokenBalance(keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash) (uint64, *RPCError) {
+	outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardID, tokenID)
+	if err != nil {
+		return 0, NewRPCError(UnexpectedError, err)
+	}
+	balance := uint64(0)
+	for _, out := range outCoins {
+		balance += out.CoinDetails.GetValue()
+	}
+	return balance, nil
+}
+
 func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase string) (bool, *RPCError) {
 	err := walletService.Wallet.RemoveAccount(privateKey, passPhrase)
 	if err != nil {
@@ -171,8 +299,11 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 
 	balance := uint64(0)
 	if accountName == "*" {
-		// get balance for all accounts in wallet
+		// get balance for all accounts in wallet, except watch-only accounts which can not filter spent output coins
 		for _, account := range walletService.Wallet.MasterAccount.Child {
+			if account.IsWatchOnly {
+				continue
+			}
 			lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 			shardIDSender := common.GetShardIDFromLastByte(lastByte)
 			outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardIDSender, prvCoinID)
@@ -186,6 +317,9 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	} else {
 		for _, account := range walletService.Wallet.MasterAccount.Child {
 			if account.Name == accountName {
+				if account.IsWatchOnly {
+					return uint64(0), NewRPCError(RPCInvalidParamsError, errors.New("watch-only account has no balance, use getwatchonlybalance to get its total received"))
+				}
 				// get balance for accountName in wallet
 				lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 				shardIDSender := common.GetShardIDFromLastByte(lastByte)
@@ -228,3 +362,236 @@ func (walletService WalletService) GetReceivedByAccount(accountName string) (uin
 	}
 	return balance, nil
 }
+
+func newPortfolioResult(name string, accounts []wallet.AccountWallet) jsonresult.Portfolio {
+	result := jsonresult.Portfolio{
+		PortfolioName: name,
+		Accounts:      make([]jsonresult.PortfolioAccount, 0),
+	}
+	for _, account := range accounts {
+		accountTemp := account
+		result.Accounts = append(result.Accounts, jsonresult.PortfolioAccount{
+			AccountName:    account.Name,
+			PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+			ShardID:        wallet.GetShardIDOfAccount(&accountTemp),
+			IsWatchOnly:    account.IsWatchOnly,
+		})
+	}
+	return result
+}
+
+// CreatePortfolio creates portfolio from accounts of local wallet, a new account is created for each shard in shardIDs
+// which has no account in portfolio
+func (walletService *WalletService) CreatePortfolio(name string, accountNames []string, shardIDs []byte, passPhrase string) (jsonresult.Portfolio, *RPCError) {
+	activeShards := walletService.BlockChain.GetBeaconBestState().ActiveShards
+	for _, shardID := range shardIDs {
+		if int(shardID) >= activeShards {
+			return jsonresult.Portfolio{}, NewRPCError(RPCInvalidParamsError, fmt.Errorf("shard %d is not active", shardID))
+		}
+	}
+	accounts, err := walletService.Wallet.CreatePortfolio(name, accountNames, shardIDs, passPhrase)
+	if err != nil {
+		return jsonresult.Portfolio{}, NewRPCError(PortfolioError, err)
+	}
+	return newPortfolioResult(name, accounts), nil
+}
+
+func (walletService *WalletService) RemovePortfolio(name string, passPhrase string) (bool, *RPCError) {
+	err := walletService.Wallet.RemovePortfolio(name, passPhrase)
+	if err != nil {
+		return false, NewRPCError(PortfolioError, err)
+	}
+	return true, nil
+}
+
+func (walletService WalletService) ListPortfolios() ([]jsonresult.Portfolio, *RPCError) {
+	result := make([]jsonresult.Portfolio, 0)
+	for name := range walletService.Wallet.ListPortfolios() {
+		accounts, err := walletService.Wallet.GetPortfolioAccounts(name)
+		if err != nil {
+			return nil, NewRPCError(PortfolioError, err)
+		}
+		result = append(result, newPortfolioResult(name, accounts))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PortfolioName < result[j].PortfolioName
+	})
+	return result, nil
+}
+
+// GetPortfolioBalance returns balances of each token aggregated over accounts of portfolio,
+// balances of watch-only accounts are the total of received output coins
+func (walletService WalletService) GetPortfolioBalance(name string) (jsonresult.PortfolioBalance, *RPCError) {
+	accounts, err := walletService.Wallet.GetPortfolioAccounts(name)
+	if err != nil {
+		return jsonresult.PortfolioBalance{}, NewRPCError(PortfolioError, err)
+	}
+	result := jsonresult.PortfolioBalance{
+		Portfolio: newPortfolioResult(name, accounts),
+		Balances:  make([]jsonresult.PortfolioTokenBalance, 0),
+	}
+	balances := make(map[string]*jsonresult.PortfolioTokenBalance)
+	addBalance := func(accountName string, tokenBalance jsonresult.CustomTokenBalance) {
+		item, ok := balances[tokenBalance.TokenID]
+		if !ok {
+			item = &jsonresult.PortfolioTokenBalance{
+				CustomTokenBalance: tokenBalance,
+				AccountBalances:    make(map[string]uint64),
+			}
+			item.Amount = 0
+			balances[tokenBalance.TokenID] = item
+		}
+		item.Amount += tokenBalance.Amount
+		item.AccountBalances[accountName] = tokenBalance.Amount
+	}
+	for _, account := range accounts {
+		accountTemp := account
+		prvBalance, tokenBalances, rpcErr := walletService.getBalances(&accountTemp.Key.KeySet, wallet.GetShardIDOfAccount(&accountTemp))
+		if rpcErr != nil {
+			return jsonresult.PortfolioBalance{}, rpcErr
+		}
+		addBalance(account.Name, jsonresult.CustomTokenBalance{
+			Name:       common.PRVCoinName,
+			Symbol:     common.PRVCoinName,
+			Amount:     prvBalance,
+			TokenID:    common.PRVCoinID.String(),
+			TokenImage: common.Render([]byte(common.PRVCoinID.String())),
+			IsPrivacy:  true,
+		})
+		for _, tokenBalance := range tokenBalances {
+			addBalance(account.Name, tokenBalance)
+		}
+	}
+	for _, item := range balances {
+		result.Balances = append(result.Balances, *item)
+	}
+	sort.Slice(result.Balances, func(i, j int) bool {
+		// PRV first, then tokens by id
+		if result.Balances[i].TokenID == common.PRVCoinID.String() || result.Balances[j].TokenID == common.PRVCoinID.String() {
+			return result.Balances[i].TokenID == common.PRVCoinID.String()
+		}
+		return result.Balances[i].TokenID < result.Balances[j].TokenID
+	})
+	return result, nil
+}
+
+// PortfolioSender is a spending account of portfolio with its balances
+type PortfolioSender struct {
+	AccountName string
+	PrivateKey  string
+	KeySet      *incognitokey.KeySet
+	ShardID     byte
+	Balance     uint64 // balance of token to pay
+	PRVBalance  uint64 // balance to pay fee, it equals Balance for PRV payments
+	FeeReserve  uint64 // PRV kept to pay fee of the tx of sender
+}
+
+// PortfolioPayment is a payment to a receiver, which is routed to a sender of portfolio
+type PortfolioPayment struct {
+	PaymentAddress string
+	ShardID        byte
+	Amount         uint64
+}
+
+// GetPortfolioSenders returns accounts of portfolio which can spend, with their balances of tokenID and PRV
+func (walletService WalletService) GetPortfolioSenders(name string, tokenID *common.Hash) ([]*PortfolioSender, *RPCError) {
+	accounts, err := walletService.Wallet.GetPortfolioAccounts(name)
+	if err != nil {
+		return nil, NewRPCError(PortfolioError, err)
+	}
+	senders := make([]*PortfolioSender, 0)
+	for _, account := range accounts {
+		if account.IsWatchOnly {
+			continue
+		}
+		accountTemp := account
+		sender := &PortfolioSender{
+			AccountName: account.Name,
+			PrivateKey:  account.Key.Base58CheckSerialize(wallet.PriKeyType),
+			KeySet:      &accountTemp.Key.KeySet,
+			ShardID:     wallet.GetShardIDOfAccount(&accountTemp),
+		}
+		var rpcErr *RPCError
+		sender.PRVBalance, rpcErr = walletService.getTokenBalance(sender.KeySet, sender.ShardID, &common.PRVCoinID)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		sender.Balance = sender.PRVBalance
+		if *tokenID != common.PRVCoinID {
+			sender.Balance, rpcErr = walletService.getTokenBalance(sender.KeySet, sender.ShardID, tokenID)
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+		}
+		senders = append(senders, sender)
+	}
+	if len(senders) == 0 {
+		return nil, NewRPCError(PortfolioError, errors.New("portfolio has no account which can spend"))
+	}
+	return senders, nil
+}
+
+// RoutePortfolioPayments assigns each payment to a sender: the sender in receiver's shard if it can pay,
+// otherwise the sender with the largest balance, so that most payments are not cross shard.
+// A sender which pays keeps its fee reserve in PRV. It returns payments of each sender by account name
+func RoutePortfolioPayments(senders []*PortfolioSender, payments []*PortfolioPayment, isPRV bool) (map[string][]*PortfolioPayment, *RPCError) {
+	balances := make(map[string]uint64)
+	prvBalances := make(map[string]uint64)
+	for _, sender := range senders {
+		balances[sender.AccountName] = sender.Balance
+		prvBalances[sender.AccountName] = sender.PRVBalance
+	}
+	result := make(map[string][]*PortfolioPayment)
+	canPay := func(sender *PortfolioSender, amount uint64) bool {
+		feeReserve := uint64(0)
+		if _, ok := result[sender.AccountName]; !ok {
+			feeReserve = sender.FeeReserve
+		}
+		if isPRV {
+			return balances[sender.AccountName] >= amount+feeReserve
+		}
+		return balances[sender.AccountName] >= amount && prvBalances[sender.AccountName] >= feeReserve
+	}
+
+	// route large payments first, they are the hardest to fit
+	sortedPayments := append([]*PortfolioPayment{}, payments...)
+	sort.SliceStable(sortedPayments, func(i, j int) bool {
+		return sortedPayments[i].Amount > sortedPayments[j].Amount
+	})
+	for _, payment := range sortedPayments {
+		var chosen *PortfolioSender
+		for _, sender := range senders {
+			if sender.ShardID != payment.ShardID || !canPay(sender, payment.Amount) {
+				continue
+			}
+			if chosen == nil || balances[sender.AccountName] > balances[chosen.AccountName] {
+				chosen = sender
+			}
+		}
+		if chosen == nil {
+			for _, sender := range senders {
+				if !canPay(sender, payment.Amount) {
+					continue
+				}
+				if chosen == nil || balances[sender.AccountName] > balances[chosen.AccountName] {
+					chosen = sender
+				}
+			}
+		}
+		if chosen == nil {
+			return nil, NewRPCError(PortfolioInsufficientBalanceError, fmt.Errorf("can not pay %d to %s", payment.Amount, payment.PaymentAddress))
+		}
+		if _, ok := result[chosen.AccountName]; !ok {
+			prvBalances[chosen.AccountName] -= chosen.FeeReserve
+			if isPRV {
+				balances[chosen.AccountName] -= chosen.FeeReserve
+			}
+		}
+		balances[chosen.AccountName] -= payment.Amount
+		if isPRV {
+			prvBalances[chosen.AccountName] -= payment.Amount
+		}
+		result[chosen.AccountName] = append(result[chosen.AccountName], payment)
+	}
+	return result, nil
+}
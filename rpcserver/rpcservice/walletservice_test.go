@@ -0,0 +1,44 @@
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutePortfolioPayments(t *testing.T) {
+	senders := []*PortfolioSender{
+		{AccountName: "A", ShardID: 0, Balance: 1000, PRVBalance: 1000, FeeReserve: 10},
+		{AccountName: "B", ShardID: 1, Balance: 300, PRVBalance: 300, FeeReserve: 10},
+		{AccountName: "C", ShardID: 1, Balance: 500, PRVBalance: 500, FeeReserve: 10},
+	}
+	payments := []*PortfolioPayment{
+		{PaymentAddress: "r1", ShardID: 1, Amount: 400},
+		{PaymentAddress: "r2", ShardID: 1, Amount: 290},
+		{PaymentAddress: "r3", ShardID: 2, Amount: 100},
+	}
+	routes, rpcErr := RoutePortfolioPayments(senders, payments, true)
+	assert.Nil(t, rpcErr)
+	// r1 is paid in its shard by the richest sender, r2 still fits in shard 1 with fee of B,
+	// r3 has no sender in its shard so it is paid by the richest one
+	assert.Equal(t, []*PortfolioPayment{payments[0]}, routes["C"])
+	assert.Equal(t, []*PortfolioPayment{payments[1]}, routes["B"])
+	assert.Equal(t, []*PortfolioPayment{payments[2]}, routes["A"])
+
+	// fee reserve of B makes it unable to pay 295, so it goes to another shard
+	payments[1].Amount = 295
+	routes, rpcErr = RoutePortfolioPayments(senders, payments, true)
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, 0, len(routes["B"]))
+	assert.Equal(t, []*PortfolioPayment{payments[1], payments[2]}, routes["A"])
+
+	// tokens are paid from token balance, fee from PRV balance
+	senders[1].PRVBalance = 5
+	payments[1].Amount = 290
+	routes, rpcErr = RoutePortfolioPayments(senders, payments, false)
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, 0, len(routes["B"]))
+
+	_, rpcErr = RoutePortfolioPayments(senders, []*PortfolioPayment{{PaymentAddress: "r4", ShardID: 0, Amount: 1000}}, true)
+	assert.Equal(t, ErrCodeMessage[PortfolioInsufficientBalanceError].Code, rpcErr.Code)
+}
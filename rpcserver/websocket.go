@@ -26,6 +26,7 @@ type WsServer struct {
 	cRequestProcessShutdown chan struct{}
 
 	blockService *rpcservice.BlockService
+	portal       *rpcservice.PortalService
 }
 type RpcSubResult struct {
 	Result interface{}
@@ -54,6 +55,9 @@ func (wsServer *WsServer) Init(config *RpcServerConfig) {
 		DB:         wsServer.config.Database,
 		MemCache:   wsServer.config.MemCache,
 	}
+	wsServer.portal = &rpcservice.PortalService{
+		BlockChain: wsServer.config.BlockChain,
+	}
 }
 
 func NewSubscriptionManager(ws *websocket.Conn) *SubcriptionManager {
@@ -0,0 +1,66 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleSubcribeCoinScanner notifies coins of payment address which are received or spent, as found by coin scanner,
+// key of payment address must be registered to coin scanner by registercoinscannerkey
+func (wsServer *WsServer) handleSubcribeCoinScanner(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain ONE params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	publicKey, err := rpcservice.GetCoinScannerPublicKey(paymentAddress)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.CoinScannerTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Coin Scanner")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.CoinScannerTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				event, ok := msg.Value.(*rpcservice.CoinScannerEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *rpcservice.CoinScannerEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if event.PublicKey != publicKey {
+					continue
+				}
+				cResult <- RpcSubResult{Result: event, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Coin Scanner"}}
+				return
+			}
+		}
+	}
+}
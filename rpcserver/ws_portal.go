@@ -0,0 +1,103 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleSubcribePortalCustodianRisk notifies when TP ratio of a custodian for a token falls below warning ratio,
+// it is checked on every new beacon block and notified again only after the ratio gets back over warning ratio,
+// tokens without exchange rate are skipped
+// - Param #1: custodian address, empty string to watch all custodians
+// - Param #2: warning ratio (optional), default is MinPercentLockedCollateral
+func (wsServer *WsServer) handleSubcribePortalCustodianRisk(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 || len(arrayParams) > 2 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should contain 1 or 2 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	custodianAddress, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Custodian Address"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	warningRatio := uint64(0)
+	if len(arrayParams) > 1 {
+		warningRatioParam, ok := arrayParams[1].(float64)
+		if !ok || warningRatioParam < 0 {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Warning Ratio"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		warningRatio = uint64(warningRatioParam)
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Portal Custodian Risk", custodianAddress)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewBeaconBlockTopic, subId)
+		close(cResult)
+	}()
+	// custodian address + token id -> TP ratio is below warning ratio
+	warned := make(map[string]bool)
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				beaconBlock, ok := msg.Value.(*blockchain.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				// risk is computed from the state of the notified block, which may not be the best block by now
+				beaconHeight := beaconBlock.Header.Height
+				beaconView, err := wsServer.config.BlockChain.GetBeaconViewStateDataFromBlockHash(*beaconBlock.Hash())
+				if err != nil {
+					Logger.log.Errorf("Can not get beacon view at beacon height %+v, error %+v", beaconHeight, err)
+					continue
+				}
+				portalParam := wsServer.config.BlockChain.GetPortalParams(beaconHeight)
+				dashboard, err := wsServer.portal.GetCustodianRiskDashboard(beaconView.GetBeaconFeatureStateDB(), beaconHeight, custodianAddress, warningRatio, portalParam)
+				if err != nil {
+					Logger.log.Errorf("Can not get custodian risk at beacon height %+v, error %+v", beaconHeight, err)
+					continue
+				}
+				for _, custodian := range dashboard.Custodians {
+					for _, token := range custodian.Tokens {
+						// risk of an unpriced token is unknown, it is checked again when its exchange rate is found
+						if token.Status == jsonresult.CustodianRiskUnpriced {
+							continue
+						}
+						key := custodian.IncognitoAddress + token.TokenID
+						isWarning := token.Status != jsonresult.CustodianRiskSafe
+						if isWarning && !warned[key] {
+							cResult <- RpcSubResult{Result: jsonresult.PortalCustodianRiskAlert{
+								BeaconHeight:     beaconHeight,
+								IncognitoAddress: custodian.IncognitoAddress,
+								Token:            token,
+							}}
+						}
+						warned[key] = isWarning
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Portal Custodian Risk"}}
+				return
+			}
+		}
+	}
+}
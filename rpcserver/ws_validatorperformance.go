@@ -0,0 +1,62 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleSubcribeValidatorPerformance notifies the statistics of a committee member in the current epoch
+// whenever blocks of its chain are finalized
+func (wsServer *WsServer) handleSubcribeValidatorPerformance(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain ONE params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	committeePublicKey, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.ValidatorPerformanceTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Validator Performance")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.ValidatorPerformanceTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				epochPerformance, ok := msg.Value.(*blockchain.EpochValidatorPerformance)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.EpochValidatorPerformance, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				performance, ok := epochPerformance.Validators[committeePublicKey]
+				if !ok {
+					continue
+				}
+				cResult <- RpcSubResult{Result: jsonresult.NewValidatorPerformance(epochPerformance.ChainID, epochPerformance.Epoch, committeePublicKey, performance), Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Validator Performance"}}
+				return
+			}
+		}
+	}
+}
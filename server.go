@@ -78,6 +78,8 @@ type Server struct {
 	// userKeySet        *incognitokey.KeySet
 	miningKeys      string
 	privateKey      string
+	remoteSigner    string
+	remoteSignerTok string
 	wallet          *wallet.Wallet
 	consensusEngine *consensus.Engine
 	blockgen        *blockchain.BlockGenerator
@@ -225,7 +227,9 @@ func (serverObj *Server) NewServer(
 
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	serverObj.remoteSigner = cfg.RemoteSigner
+	serverObj.remoteSignerTok = cfg.RemoteSignerToken
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
 			panic("miningkeys can't be empty in this node mode")
 		}
@@ -540,6 +544,7 @@ func (serverObj *Server) NewServer(
 			ConsensusEngine:             serverObj.consensusEngine,
 			MemCache:                    serverObj.memCache,
 			Syncker:                     serverObj.syncker,
+			CoinScannerPassphrase:       cfg.CoinScannerPassphrase,
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
 		serverObj.rpcServer.Init(&rpcConfig)
@@ -729,6 +734,7 @@ func (serverObj Server) Start() {
 	//go serverObj.blockChain.Synker.Start()
 	go serverObj.syncker.Start()
 	go serverObj.blockgen.Start(serverObj.cQuit)
+	go serverObj.blockChain.IndexValidatorPerformance(serverObj.cQuit)
 
 	if serverObj.memPool != nil {
 		err := serverObj.memPool.LoadOrResetDatabaseMempool()
@@ -1292,7 +1298,7 @@ func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 }
 
 func (serverObj *Server) GetNodeRole() string {
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		return ""
 	}
 	if cfg.NodeMode == "relay" {
@@ -1787,7 +1793,7 @@ func (serverObj *Server) GetChainMiningStatus(chain int) string {
 	if chain >= common.MaxShardNumber || chain < -1 {
 		return notmining
 	}
-	if cfg.MiningKeys != "" || cfg.PrivateKey != "" {
+	if cfg.MiningKeys != "" || cfg.PrivateKey != "" || cfg.RemoteSigner != "" {
 		//Beacon: chain = -1
 		role, chainID := serverObj.GetUserMiningState()
 		layer := ""
@@ -1849,6 +1855,14 @@ func (serverObj *Server) GetPrivateKey() string {
 	return serverObj.privateKey
 }
 
+func (serverObj *Server) GetRemoteSigner() string {
+	return serverObj.remoteSigner
+}
+
+func (serverObj *Server) GetRemoteSignerToken() string {
+	return serverObj.remoteSignerTok
+}
+
 func (serverObj *Server) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	chainID := chain.GetShardID()
 	if chainID == -1 {
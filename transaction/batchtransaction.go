@@ -7,21 +7,33 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
 	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/oneoutofmany"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumberprivacy"
 )
 
 type batchTransaction struct {
-	txs []metadata.Transaction
+	txs        []metadata.Transaction
+	verifier   *TxVerifier
+	stateRoots []common.Hash
 }
 
 func NewBatchTransaction(txs []metadata.Transaction) *batchTransaction {
-	return &batchTransaction{txs: txs}
+	return &batchTransaction{txs: txs, verifier: DefaultTxVerifier}
 }
 
 func (b *batchTransaction) AddTxs(txs []metadata.Transaction) {
 	b.txs = append(b.txs, txs...)
 }
 
+// SetStateRoots sets roots of state dbs which txs are validated against,
+// txs which were already verified against the same roots (e.g. when they were accepted into mempool) are not verified again
+func (b *batchTransaction) SetStateRoots(stateRoots ...common.Hash) {
+	b.stateRoots = stateRoots
+}
+
 func (b *batchTransaction) Validate(transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB) (bool, error, int) {
 	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB)
 }
@@ -32,38 +44,126 @@ func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transactio
 	if err != nil {
 		return false, err, -1
 	}
-	bulletProofList := make([]*aggregaterange.AggregatedRangeProof, 0)
+	txIndices := make([]int, 0, len(txList))
 	for i, tx := range txList {
+		if b.verifier.IsVerified(tx, b.stateRoots...) {
+			continue
+		}
+		txIndices = append(txIndices, i)
+	}
+	if len(txIndices) < len(txList) {
+		Logger.log.Debugf("Skip verifying %d txs which were verified before", len(txList)-len(txIndices))
+	}
+
+	// state db is not safe for concurrent use, each worker reads its own copy
+	numWorkers := b.verifier.GetNumWorkers()
+	transactionStateDBs := make([]*statedb.StateDB, numWorkers)
+	bridgeStateDBs := make([]*statedb.StateDB, numWorkers)
+	for worker := 0; worker < numWorkers; worker++ {
+		if transactionStateDB != nil {
+			transactionStateDBs[worker] = transactionStateDB.Copy()
+		}
+		if bridgeStateDB != nil {
+			bridgeStateDBs[worker] = bridgeStateDB.Copy()
+		}
+	}
+	ok, err, k := b.verifier.Verify(len(txIndices), func(worker int, k int) (bool, error) {
+		tx := txList[txIndices[k]]
 		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 		hasPrivacy := tx.IsPrivacy()
-		ok, err := tx.ValidateTransaction(hasPrivacy, transactionStateDB, bridgeStateDB, shardID, prvCoinID, true, false)
+		ok, err := tx.ValidateTransaction(hasPrivacy, transactionStateDBs[worker], bridgeStateDBs[worker], shardID, prvCoinID, true, false)
 		if !ok {
-			return false, err, i
+			return false, err
 		}
 		if tx.GetMetadata() != nil {
 			if hasPrivacy {
-				return false, errors.New("Metadata can not exist in privacy tx"), i
+				return false, errors.New("Metadata can not exist in privacy tx")
 			}
 			validateMetadata := tx.GetMetadata().ValidateMetadataByItself()
 			if !validateMetadata {
-				return validateMetadata, NewTransactionErr(UnexpectedError, errors.New("Metadata is invalid")), i
+				return validateMetadata, NewTransactionErr(UnexpectedError, errors.New("Metadata is invalid"))
 			}
 		}
+		return true, nil
+	})
+	if !ok {
+		return false, err, txIndices[k]
+	}
 
-		if hasPrivacy {
-			if bulletProof := tx.GetProof().GetAggregatedRangeProof(); bulletProof != nil {
-				bulletProofList = append(bulletProofList, bulletProof)
-			}
+	proofs := newBatchProofs()
+	for _, i := range txIndices {
+		tx := txList[i]
+		if tx.IsPrivacy() {
+			proofs.add(tx.GetProof(), i)
 		}
+		// proof of privacy token is also verified in batch mode by ValidateTransaction
+		if tokenTx, ok := tx.(*TxCustomTokenPrivacy); ok && tokenTx.TxPrivacyTokenData.TxNormal.IsPrivacy() {
+			proofs.add(tokenTx.TxPrivacyTokenData.TxNormal.Proof, i)
+		}
+	}
+	return proofs.verify()
+}
+
+// batchProofs collects proofs of txs which are skipped by ValidateTransaction in batch mode,
+// each kind of proof is verified at once for all txs
+type batchProofs struct {
+	bulletProofs         []*aggregaterange.AggregatedRangeProof
+	bulletProofTxs       []int
+	oneOfManyProofs      []*oneoutofmany.OneOutOfManyProof
+	oneOfManyProofTxs    []int
+	serialNumberProofs   []*serialnumberprivacy.SNPrivacyProof
+	serialNumberProofTxs []int
+}
+
+func newBatchProofs() *batchProofs {
+	return &batchProofs{}
+}
+
+func (b *batchProofs) add(proof *zkp.PaymentProof, txIndex int) {
+	if proof == nil {
+		return
 	}
+	if bulletProof := proof.GetAggregatedRangeProof(); bulletProof != nil {
+		b.bulletProofs = append(b.bulletProofs, bulletProof)
+		b.bulletProofTxs = append(b.bulletProofTxs, txIndex)
+	}
+	for _, oneOfManyProof := range proof.GetOneOfManyProof() {
+		b.oneOfManyProofs = append(b.oneOfManyProofs, oneOfManyProof)
+		b.oneOfManyProofTxs = append(b.oneOfManyProofTxs, txIndex)
+	}
+	for _, serialNumberProof := range proof.GetSerialNumberProof() {
+		b.serialNumberProofs = append(b.serialNumberProofs, serialNumberProof)
+		b.serialNumberProofTxs = append(b.serialNumberProofTxs, txIndex)
+	}
+}
+
+// verify returns index of tx whose proof is invalid, -1 if it is unknown
+func (b *batchProofs) verify() (bool, error, int) {
 	//TODO: add go routine
-	ok, err, i := aggregaterange.VerifyBatchingAggregatedRangeProofs(bulletProofList)
+	ok, err, i := aggregaterange.VerifyBatchingAggregatedRangeProofs(b.bulletProofs)
 	if err != nil {
-		return false, NewTransactionErr(TxProofVerifyFailError, err), -1
+		return false, NewTransactionErr(TxProofVerifyFailError, err), getBatchTxIndex(b.bulletProofTxs, i)
 	}
 	if !ok {
 		Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", i)
 		return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", i)), -1
 	}
+	ok, err, i = oneoutofmany.VerifyBatch(b.oneOfManyProofs)
+	if !ok {
+		Logger.log.Errorf("FAILED VERIFICATION BATCH ONE OUT OF MANY PROOF %d", i)
+		return false, NewTransactionErr(TxProofVerifyFailError, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)), getBatchTxIndex(b.oneOfManyProofTxs, i)
+	}
+	ok, err, i = serialnumberprivacy.VerifyBatch(b.serialNumberProofs)
+	if !ok {
+		Logger.log.Errorf("FAILED VERIFICATION BATCH SERIAL NUMBER PROOF %d", i)
+		return false, NewTransactionErr(TxProofVerifyFailError, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, err)), getBatchTxIndex(b.serialNumberProofTxs, i)
+	}
 	return true, nil, -1
 }
+
+func getBatchTxIndex(txIndices []int, proofIndex int) int {
+	if proofIndex < 0 || proofIndex >= len(txIndices) {
+		return -1
+	}
+	return txIndices[proofIndex]
+}
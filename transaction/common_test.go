@@ -3,9 +3,9 @@ package transaction
 import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
-	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/stretchr/testify/assert"
@@ -22,7 +22,7 @@ func TestConvertOutputCoinToInputCoin(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 	tx := &Tx{}
-	err = tx.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
+	err = tx.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, stateDB, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -39,7 +39,7 @@ func TestEstimateTxSize(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 	tx := &Tx{}
-	err = tx.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
+	err = tx.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, stateDB, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -75,44 +75,46 @@ func TestRandomCommitmentsProcess(t *testing.T) {
 	_ = key.KeySet.InitFromPrivateKey(&key.KeySet.PrivateKey)
 	paymentAddress := key.KeySet.PaymentAddress
 	tx1 := &Tx{}
-	err := tx1.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
+	err := tx1.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, stateDB, nil)
 	if err != nil {
 		t.Error(err)
 	}
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx1.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	statedb.StoreCommitments(stateDB, common.Hash{}, paymentAddress.Pk, [][]byte{tx1.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 
 	in1 := ConvertOutputCoinToInputCoin(tx1.Proof.GetOutputCoins())
 
-	cmmIndexs, myIndexs, cmm := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in1, 0, db, 0, &common.Hash{}))
+	cmmIndexs, myIndexs, cmm := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in1, 0, stateDB, 0, &common.Hash{}))
 	assert.Equal(t, 8, len(cmmIndexs))
 	assert.Equal(t, 1, len(myIndexs))
 	assert.Equal(t, 8, len(cmm))
 
 	tx2 := &Tx{}
-	err = tx2.InitTxSalary(5, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
+	err = tx2.InitTxSalary(5, &paymentAddress, &key.KeySet.PrivateKey, stateDB, nil)
 	if err != nil {
 		t.Error(err)
 	}
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx2.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	statedb.StoreCommitments(stateDB, common.Hash{}, paymentAddress.Pk, [][]byte{tx2.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 	tx3 := &Tx{}
-	err = tx3.InitTxSalary(5, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx3.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	err = tx3.InitTxSalary(5, &paymentAddress, &key.KeySet.PrivateKey, stateDB, nil)
+	statedb.StoreCommitments(stateDB, common.Hash{}, paymentAddress.Pk, [][]byte{tx3.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 	in2 := ConvertOutputCoinToInputCoin(tx2.Proof.GetOutputCoins())
 	in := append(in1, in2...)
 
-	cmmIndexs, myIndexs, cmm = RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, db, 0, &common.Hash{}))
+	cmmIndexs, myIndexs, cmm = RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, stateDB, 0, &common.Hash{}))
 	assert.Equal(t, 16, len(cmmIndexs))
 	assert.Equal(t, 16, len(cmm))
 	assert.Equal(t, 2, len(myIndexs))
 
-	rawdb.CleanCommitments(db)
-	cmmIndexs1, myCommIndex1, cmm1 := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, db, 0, &common.Hash{}))
+	emptyStateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	assert.Equal(t, nil, err)
+	cmmIndexs1, myCommIndex1, cmm1 := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, emptyStateDB, 0, &common.Hash{}))
 	assert.Equal(t, 0, len(cmmIndexs1))
 	assert.Equal(t, 0, len(myCommIndex1))
 	assert.Equal(t, 0, len(cmm1))
 }
 
 var db incdb.Database
+var stateDB *statedb.StateDB
 var _ = func() (_ struct{}) {
 	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
 	if err != nil {
@@ -123,6 +125,10 @@ var _ = func() (_ struct{}) {
 	if err != nil {
 		log.Fatalf("could not open db path: %s, %+v", dbPath, err)
 	}
+	stateDB, err = statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		log.Fatalf("could not init state db: %+v", err)
+	}
 	incdb.Logger.Init(common.NewBackend(nil).Logger("db", true))
 	Logger.Init(common.NewBackend(nil).Logger("tx", true))
 	privacy.Logger.Init(common.NewBackend(nil).Logger("privacy", true))
@@ -136,13 +142,13 @@ func TestBuildCoinbaseTxByCoinID(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 
-	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, stateDB, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	//assert.Equal(t, uint64(10), tx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetValue())
 	assert.Equal(t, common.PRVCoinID.String(), tx.GetTokenID().String())
 
-	txCustomTokenPrivacy, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{2}, CustomTokenPrivacyType, "Custom Token", 0))
+	txCustomTokenPrivacy, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, stateDB, nil, common.Hash{2}, CustomTokenPrivacyType, "Custom Token", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	//assert.Equal(t, uint64(10), txCustomTokenPrivacy.(*TxCustomTokenPrivacy).TxPrivacyTokenData.TxNormal.Proof.GetOutputCoins()[0].CoinDetails.GetValue())
@@ -3,6 +3,7 @@ package transaction
 const (
 	// txVersion is the current latest supported transaction version.
 	txVersion                        = 1
+	txVersion2                       = 2          // version of privacy v2 txs, which are accepted from beacon height BeaconHeightBreakPointPrivacyV2
 	ValidateTimeForOneoutOfManyProof = 1574985600 // GMT: Friday, November 29, 2019 12:00:00 AM
 )
 
@@ -44,6 +44,13 @@ const (
 	BatchTxProofVerifyFailError
 	VerifyMinerCreatedTxBeforeGettingInBlockError
 	CommitOutputCoinError
+	UnsignedTxSenderMismatchError
+	UnsignedTxInvalidDataError
+	PrivacyV2NotActivatedError
+	InvalidPrivacyV2TxError
+	InvalidConfidentialAssetError
+	ThresholdTxInvalidError
+	UnsignedTxUnexpectedError
 
 	NormalTokenPRVJsonError
 	NormalTokenJsonError
@@ -114,6 +121,13 @@ var ErrCodeMessage = map[int]struct {
 	RejectTxMedataWithBlockChain:                  {-1039, "Reject invalid metadata with blockchain"},
 	BatchTxProofVerifyFailError:                   {-1040, "Can not verify proof of batch txs %s"},
 	VerifyOneOutOfManyProofFailedErr:              {-1041, "Verify one out of many proof failed"},
+	UnsignedTxSenderMismatchError:                 {-1042, "Private key does not match sender of unsigned tx"},
+	UnsignedTxInvalidDataError:                    {-1043, "Unsigned tx data is invalid"},
+	PrivacyV2NotActivatedError:                    {-1044, "Privacy v2 tx is not activated at beacon height %d"},
+	InvalidPrivacyV2TxError:                       {-1045, "Invalid privacy v2 tx"},
+	InvalidConfidentialAssetError:                 {-1046, "Invalid asset of confidential coins"},
+	ThresholdTxInvalidError:                       {-1047, "Threshold signed tx is invalid"},
+	UnsignedTxUnexpectedError:                     {-1048, "Unsigned tx is not the expected tx"},
 
 	// for PRV
 	InvalidSanityDataPRVError:  {-2000, "Invalid sanity data for PRV"},
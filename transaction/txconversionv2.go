@@ -0,0 +1,193 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+)
+
+// Conversion txs spend coins of v1 and create coins of privacy v2:
+//   - input coins are revealed as in txs without privacy, and the tx is signed by Schnorr signature of sender
+//   - output coins are coins of privacy v2, their values are hidden by a range proof
+//     and their randomness sum up to zero, so balance is checked against the revealed input values
+//
+// Coins of PRV are converted to coins of PRV, coins of a custom token are converted to confidential coins
+// whose asset tag is the unblinded asset tag of token, so the token of converted coins is only hidden after they are spent.
+type TxConversionV2InitParams struct {
+	senderSK    *privacy.PrivateKey
+	paymentInfo []*privacy.PaymentInfo
+	inputCoins  []*privacy.InputCoin
+	fee         uint64
+	tokenID     *common.Hash
+	info        []byte
+}
+
+func NewTxConversionV2InitParams(senderSK *privacy.PrivateKey,
+	paymentInfo []*privacy.PaymentInfo,
+	inputCoins []*privacy.InputCoin,
+	fee uint64,
+	tokenID *common.Hash,
+	info []byte) *TxConversionV2InitParams {
+	return &TxConversionV2InitParams{
+		senderSK:    senderSK,
+		paymentInfo: paymentInfo,
+		inputCoins:  inputCoins,
+		fee:         fee,
+		tokenID:     tokenID,
+		info:        info,
+	}
+}
+
+// InitConversionV2 creates a conversion tx, serial numbers of input coins must be set by caller
+func (tx *Tx) InitConversionV2(params *TxConversionV2InitParams) error {
+	Logger.log.Debugf("CREATING CONVERSION TX V2........\n")
+	tx.Version = txVersion2
+	if len(params.inputCoins) == 0 {
+		return NewTransactionErr(WrongInputError, errors.New("conversion tx must have input coins"))
+	}
+	if len(params.inputCoins) > 255 {
+		return NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(params.inputCoins)))
+	}
+	if len(params.paymentInfo) > 254 {
+		return NewTransactionErr(PaymentInfoIsVeryLargeError, nil, strconv.Itoa(len(params.paymentInfo)))
+	}
+	if len(params.info) > MaxSizeInfo {
+		return NewTransactionErr(ExceedSizeInfoTxError, nil)
+	}
+	if params.tokenID != nil && (params.tokenID.IsEqual(&common.PRVCoinID) || params.tokenID.IsEqual(&common.ConfidentialAssetID)) {
+		params.tokenID = nil
+	}
+	start := time.Now()
+	if tx.LockTime == 0 {
+		tx.LockTime = time.Now().Unix()
+	}
+
+	senderFullKey := incognitokey.KeySet{}
+	err := senderFullKey.InitFromPrivateKey(params.senderSK)
+	if err != nil {
+		return NewTransactionErr(PrivateKeySenderInvalidError, err)
+	}
+	pkLastByteSender := senderFullKey.PaymentAddress.Pk[len(senderFullKey.PaymentAddress.Pk)-1]
+
+	tx.Type = common.TxNormalType
+	tx.Info = params.info
+	tx.Fee = params.fee
+	tx.PubKeyLastByteSender = pkLastByteSender
+
+	sumInputValue := uint64(0)
+	for _, inputCoin := range params.inputCoins {
+		if inputCoin.CoinDetails.GetSerialNumber() == nil {
+			return NewTransactionErr(WrongInputError, errors.New("serial number of input coin is not set"))
+		}
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, p := range params.paymentInfo {
+		sumOutputValue += p.Amount
+	}
+	if sumInputValue < sumOutputValue+params.fee {
+		return NewTransactionErr(WrongInputError, fmt.Errorf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, params.fee))
+	}
+	paymentInfo := params.paymentInfo
+	if overBalance := sumInputValue - sumOutputValue - params.fee; overBalance > 0 {
+		paymentInfo = append(paymentInfo, &privacy.PaymentInfo{PaymentAddress: senderFullKey.PaymentAddress, Amount: overBalance})
+	}
+	if len(paymentInfo) == 0 {
+		return NewTransactionErr(WrongInputError, errors.New("conversion tx must have output coins"))
+	}
+
+	// converted coins of a custom token carry the unblinded asset tag of token
+	var outputAsset *privacy.ConfidentialAsset
+	if params.tokenID != nil {
+		outputAsset = &privacy.ConfidentialAsset{TokenID: *params.tokenID, Blinder: new(privacy.Scalar).FromUint64(0)}
+	}
+	outputCoins := make([]*privacy.OutputCoin, len(paymentInfo))
+	sumRandomness := new(privacy.Scalar).FromUint64(0)
+	for i, pInfo := range paymentInfo {
+		if len(pInfo.Message) > privacy.MaxSizeInfoCoin || (outputAsset != nil && len(pInfo.Message) > privacy.MaxSizeInfoConfidentialCoin) {
+			return NewTransactionErr(ExceedSizeInfoOutCoinError, nil)
+		}
+		if outputAsset != nil && common.GetShardIDFromLastByte(pInfo.PaymentAddress.Pk[len(pInfo.PaymentAddress.Pk)-1]) != common.GetShardIDFromLastByte(pkLastByteSender) {
+			return NewTransactionErr(InvalidConfidentialAssetError, errors.New("confidential coins can only be sent in shard of sender"))
+		}
+		// randomness of the last output makes randomness of all outputs sum up to zero
+		randomness := privacy.RandomScalar()
+		if i == len(paymentInfo)-1 {
+			randomness = new(privacy.Scalar).Sub(new(privacy.Scalar).FromUint64(0), sumRandomness)
+		}
+		sumRandomness.Add(sumRandomness, randomness)
+		outputCoins[i], err = privacy.NewOutputCoinV2WithParams(pInfo.PaymentAddress, pInfo.Amount, pInfo.Message, randomness, outputAsset)
+		if err != nil {
+			return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+		}
+	}
+
+	sk := new(privacy.Scalar).FromBytesS(*params.senderSK)
+	tx.Proof, err = zkp.ProveConversionV2(params.inputCoins, outputCoins, sk)
+	if err != nil {
+		return NewTransactionErr(WithnessProveError, err, false, "")
+	}
+	for _, outputCoin := range tx.Proof.GetOutputCoins() {
+		outputCoin.CoinDetails.SetValue(0)
+		outputCoin.CoinDetails.SetRandomness(nil)
+	}
+
+	tx.sigPrivKey = append(*params.senderSK, big.NewInt(0).Bytes()...)
+	err = tx.signTx()
+	if err != nil {
+		return NewTransactionErr(SignTxError, err)
+	}
+
+	Logger.log.Debugf("Successfully Creating conversion tx v2 %+v in %s time", *tx.Hash(), time.Since(start))
+	return nil
+}
+
+// validateConversionV2 verifies signature and conversion proof of a conversion tx of token
+func (tx *Tx) validateConversionV2(transactionStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
+	if tokenID.IsEqual(&common.ConfidentialAssetID) {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("confidential coins can not be converted"))
+	}
+	valid, err := tx.verifySigTx()
+	if !valid {
+		Logger.log.Errorf("FAILED VERIFICATION SIGNATURE with tx hash %s", tx.Hash().String())
+		return false, NewTransactionErr(VerifyTxSigFailError, err)
+	}
+
+	// converted coins must be coins of token
+	outputAssetTag, err := tx.Proof.GetOutputAssetTag()
+	if err != nil {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, err)
+	}
+	if tokenID.IsEqual(&common.PRVCoinID) {
+		if outputAssetTag != nil {
+			return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("converted coins of PRV must not have asset tag"))
+		}
+	} else if outputAssetTag == nil || !privacy.IsPointEqual(outputAssetTag, privacy.AssetTag(*tokenID)) {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, fmt.Errorf("converted coins must have asset tag of token %s", tokenID.String()))
+	}
+
+	for _, inputCoin := range tx.Proof.GetInputCoins() {
+		if inputCoin.CoinDetails.GetCoinCommitment() == nil {
+			return false, NewTransactionErr(InputCommitmentIsNotExistedError, nil)
+		}
+		ok, err := tx.CheckCMExistence(inputCoin.CoinDetails.GetCoinCommitment().ToBytesS(), transactionStateDB, shardID, tokenID)
+		if !ok || err != nil {
+			return false, NewTransactionErr(InputCommitmentIsNotExistedError, err)
+		}
+	}
+
+	valid, err = tx.Proof.VerifyConversionV2(tx.SigPubKey, tx.Fee, isBatch)
+	if !valid {
+		Logger.log.Error("FAILED VERIFICATION CONVERSION PROOF V2")
+		return false, NewTransactionErr(TxProofVerifyFailError, err, tx.Hash().String())
+	}
+	return true, nil
+}
@@ -470,6 +470,9 @@ func (tx *Tx) ValidateTransaction(hasPrivacy bool, transactionStateDB *statedb.S
 	if tx.GetType() == common.TxRewardType {
 		return tx.ValidateTxSalary(transactionStateDB)
 	}
+	if tx.Version == txVersion2 {
+		return tx.validateTransactionV2(transactionStateDB, shardID, tokenID, isBatch)
+	}
 
 	var valid bool
 	var err error
@@ -845,6 +848,9 @@ func (tx Tx) ValidateTxWithBlockChain(chainRetriever metadata.ChainRetriever, sh
 }
 
 func (tx Tx) validateNormalTxSanityData(bcr metadata.ChainRetriever, beaconHeight uint64) (bool, error) {
+	if tx.Version == txVersion2 {
+		return tx.validateNormalTxSanityDataV2(bcr, beaconHeight)
+	}
 	//check version
 	if tx.Version > txVersion {
 		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version is %d. Wrong version tx. Only support for version >= %d", tx.Version, txVersion))
@@ -866,6 +872,18 @@ func (tx Tx) validateNormalTxSanityData(bcr metadata.ChainRetriever, beaconHeigh
 	if err != nil || !validateSanityOfProof {
 		return false, err
 	}
+	// output coins of privacy v2 are told apart by their snDerivator in cross shard outputs, and confidential coins by the prefix
+	// of asset tag in their info, so privacy v1 txs can not create output coins with them after privacy v2 is activated
+	if tx.Proof != nil && beaconHeight >= bcr.GetBeaconHeightBreakPointPrivacyV2() {
+		for _, outputCoin := range tx.Proof.GetOutputCoins() {
+			if privacy.IsOutputCoinV2(outputCoin.CoinDetails) {
+				return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v1 tx can not create an output coin of privacy v2"))
+			}
+			if outputCoin.CoinDetails != nil && privacy.HasAssetTagPrefix(outputCoin.CoinDetails.GetInfo()) {
+				return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("info of output coin of privacy v1 tx can not start with prefix of asset tag"))
+			}
+		}
+	}
 
 	if len(tx.SigPubKey) != common.SigPubKeySize {
 		return false, NewTransactionErr(RejectTxPublickeySigSize, fmt.Errorf("wrong tx Sig PK size %d", len(tx.SigPubKey)))
@@ -1195,6 +1213,9 @@ func (tx Tx) GetProof() *zkp.PaymentProof {
 }
 
 func (tx Tx) IsPrivacy() bool {
+	if tx.Proof != nil && tx.Proof.IsPrivacyV2() {
+		return true
+	}
 	if tx.Proof == nil || len(tx.Proof.GetOneOfManyProof()) == 0 {
 		return false
 	}
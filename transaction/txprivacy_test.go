@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -13,6 +14,20 @@ import (
 	"time"
 )
 
+// testChainRetriever serves the breakpoints read by the sanity checks of txs,
+// any other call panics on the nil embedded retriever
+type testChainRetriever struct {
+	metadata.ChainRetriever
+}
+
+func (testChainRetriever) GetBeaconHeightBreakPointPrivacyV2() uint64 {
+	return 0
+}
+
+func (testChainRetriever) GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar {
+	return nil
+}
+
 func TestUnmarshalJSON(t *testing.T) {
 	key, err := wallet.Base58CheckDeserialize("112t8rnXCqbbNYBquntyd6EvDT4WiDDQw84ZSRDKmazkqrzi6w8rWyCVt7QEZgAiYAV4vhJiX7V9MCfuj4hGLoDN7wdU1LoWGEFpLs59X7K3")
 	assert.Equal(t, nil, err)
@@ -20,7 +35,7 @@ func TestUnmarshalJSON(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 	responseMeta, err := metadata.NewWithDrawRewardResponse(&metadata.WithDrawRewardRequest{}, &common.Hash{})
-	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, responseMeta, common.Hash{}, NormalCoinType, "PRV", 0))
+	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, stateDB, responseMeta, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	assert.Equal(t, uint64(10), tx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetValue())
@@ -61,14 +76,15 @@ func TestInitTx(t *testing.T) {
 
 		// coin base tx to mint PRV
 		mintedAmount := 1000
-		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, stateDB, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 
-		isValidSanity, err := coinBaseTx.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err := coinBaseTx.ValidateSanityData(testChainRetriever{}, nil, nil, 0)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValidSanity)
 
 		// store output coin's coin commitments in coin base tx
-		db.StoreCommitments(
+		statedb.StoreCommitments(
+			stateDB,
 			common.PRVCoinID,
 			senderPaymentAddress.Pk,
 			[][]byte{coinBaseTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()},
@@ -103,7 +119,7 @@ func TestInitTx(t *testing.T) {
 			NewTxPrivacyInitParams(
 				&senderKey.KeySet.PrivateKey,
 				[]*privacy.PaymentInfo{{PaymentAddress: receiverPaymentAddress.KeySet.PaymentAddress, Amount: uint64(transferAmount), Message: msgCipherText.Bytes()}},
-				coinBaseOutput, uint64(fee), hasPrivacy, db, nil, nil, []byte{},
+				coinBaseOutput, uint64(fee), hasPrivacy, stateDB, nil, nil, []byte{},
 			),
 		)
 		if err != nil {
@@ -147,11 +163,11 @@ func TestInitTx(t *testing.T) {
 		assert.Equal(t, 1, len(listInputSerialNumber))
 		assert.Equal(t, common.HashH(coinBaseOutput[0].CoinDetails.GetSerialNumber().ToBytesS()), listInputSerialNumber[0])
 
-		isValidSanity, err = tx1.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err = tx1.ValidateSanityData(testChainRetriever{}, nil, nil, 0)
 		assert.Equal(t, true, isValidSanity)
 		assert.Equal(t, nil, err)
 
-		isValid, err := tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err := tx1.ValidateTransaction(hasPrivacy, stateDB, nil, shardID, nil, false, true)
 
 		fmt.Printf("Error: %v\n", err)
 		assert.Equal(t, true, isValid)
@@ -174,13 +190,13 @@ func TestInitTx(t *testing.T) {
 		//err = tx1.ValidateTxWithCurrentMempool(nil)
 		//	assert.Equal(t, nil, err)
 
-		err = tx1.ValidateDoubleSpendWithBlockchain(nil, shardID, db, nil)
+		err = tx1.ValidateDoubleSpendWithBlockchain(shardID, stateDB, nil)
 		assert.Equal(t, nil, err)
 
-		err = tx1.ValidateTxWithBlockChain(nil, shardID, nil, nil, db)
+		err = tx1.ValidateTxWithBlockChain(nil, nil, nil, shardID, stateDB)
 		assert.Equal(t, nil, err)
 
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(hasPrivacy, stateDB, nil, testChainRetriever{}, shardID, true, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 
@@ -272,14 +288,15 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 
 		// create coin base tx to mint PRV
 		mintedAmount := 1000
-		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, stateDB, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 
-		isValidSanity, err := coinBaseTx.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err := coinBaseTx.ValidateSanityData(testChainRetriever{}, nil, nil, 0)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValidSanity)
 
 		// store output coin's coin commitments in coin base tx
-		db.StoreCommitments(
+		statedb.StoreCommitments(
+			stateDB,
 			common.PRVCoinID,
 			senderPaymentAddress.Pk,
 			[][]byte{coinBaseTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()},
@@ -305,33 +322,33 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 			NewTxPrivacyInitParams(
 				&senderKey.KeySet.PrivateKey,
 				[]*privacy.PaymentInfo{{PaymentAddress: receiverPaymentAddress, Amount: uint64(transferAmount)}},
-				coinBaseOutput, uint64(fee), hasPrivacy, db, nil, nil, []byte{},
+				coinBaseOutput, uint64(fee), hasPrivacy, stateDB, nil, nil, []byte{},
 			),
 		)
 		assert.Equal(t, nil, err)
 
-		isValidSanity, err = tx1.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err = tx1.ValidateSanityData(testChainRetriever{}, nil, nil, 0)
 		assert.Equal(t, true, isValidSanity)
 		assert.Equal(t, nil, err)
 		fmt.Println("Hello")
-		isValid, err := tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err := tx1.ValidateTransaction(hasPrivacy, stateDB, nil, shardID, nil, false, true)
 		assert.Equal(t, true, isValid)
 		assert.Equal(t, nil, err)
 		fmt.Println("Hello")
-		err = tx1.ValidateDoubleSpendWithBlockchain(nil, shardID, db, nil)
+		err = tx1.ValidateDoubleSpendWithBlockchain(shardID, stateDB, nil)
 		assert.Equal(t, nil, err)
 
-		err = tx1.ValidateTxWithBlockChain(nil, shardID, nil, nil, db)
+		err = tx1.ValidateTxWithBlockChain(nil, nil, nil, shardID, stateDB)
 		assert.Equal(t, nil, err)
 
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(hasPrivacy, stateDB, nil, testChainRetriever{}, shardID, true, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 
 		// modify Sig
 		tx1.Sig[len(tx1.Sig)-1] = tx1.Sig[len(tx1.Sig)-1] ^ tx1.Sig[0]
 		tx1.Sig[len(tx1.Sig)-2] = tx1.Sig[len(tx1.Sig)-2] ^ tx1.Sig[1]
-		isValid, err = tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err = tx1.ValidateTransaction(hasPrivacy, stateDB, nil, shardID, nil, false, true)
 		assert.Equal(t, false, isValid)
 		assert.NotEqual(t, nil, err)
 		tx1.Sig[len(tx1.Sig)-1] = tx1.Sig[len(tx1.Sig)-1] ^ tx1.Sig[0]
@@ -341,7 +358,7 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 		tx1.SigPubKey[len(tx1.SigPubKey)-1] = tx1.SigPubKey[len(tx1.SigPubKey)-1] ^ tx1.SigPubKey[0]
 		tx1.SigPubKey[len(tx1.SigPubKey)-2] = tx1.SigPubKey[len(tx1.SigPubKey)-2] ^ tx1.SigPubKey[1]
 
-		isValid, err = tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err = tx1.ValidateTransaction(hasPrivacy, stateDB, nil, shardID, nil, false, true)
 		assert.Equal(t, false, isValid)
 		assert.NotEqual(t, nil, err)
 
@@ -366,7 +383,7 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 		tx1.Proof.SetBytes(originProof)
 
 		// back to correct case
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(hasPrivacy, stateDB, nil, testChainRetriever{}, shardID, true, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 	}
@@ -384,10 +401,10 @@ func TestInitSalaryTx(t *testing.T) {
 	receiverAddr := senderPaymentAddress
 
 	tx := new(Tx)
-	err = tx.InitTxSalary(salary, &receiverAddr, &senderKey.KeySet.PrivateKey, db, nil)
+	err = tx.InitTxSalary(salary, &receiverAddr, &senderKey.KeySet.PrivateKey, stateDB, nil)
 	assert.Equal(t, nil, err)
 
-	isValid, err := tx.ValidateTxSalary(db)
+	isValid, err := tx.ValidateTxSalary(stateDB)
 	assert.Equal(t, nil, err)
 	assert.Equal(t, true, isValid)
 
@@ -445,6 +445,29 @@ func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateSanityData(chainRetriev
 		}
 	}
 
+	// fee of privacy token tx is paid in PRV v1, tokens of privacy v2 are transferred with confidential coins or converted to them
+	if txCustomTokenPrivacy.Tx.Version == txVersion2 {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("PRV of privacy token tx can not be a privacy v2 tx"))
+	}
+	txNormal := txCustomTokenPrivacy.TxPrivacyTokenData.TxNormal
+	isConfidentialAsset := txCustomTokenPrivacy.TxPrivacyTokenData.PropertyID.IsEqual(&common.ConfidentialAssetID)
+	if txNormal.Version == txVersion2 {
+		if txCustomTokenPrivacy.TxPrivacyTokenData.Type != CustomTokenTransfer || txCustomTokenPrivacy.TxPrivacyTokenData.Mintable {
+			return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v2 token tx must be a transfer tx"))
+		}
+		if txNormal.Proof == nil {
+			return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v2 token tx must have a payment proof v2"))
+		}
+		if txNormal.Proof.IsConversion() == isConfidentialAsset {
+			return false, NewTransactionErr(InvalidConfidentialAssetError, fmt.Errorf("invalid token id %s of privacy v2 token tx", txCustomTokenPrivacy.TxPrivacyTokenData.PropertyID.String()))
+		}
+		if isConfidentialAsset && txNormal.Fee > 0 {
+			return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("fee of confidential coins must be zero"))
+		}
+	} else if isConfidentialAsset {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("confidential coins can only be transferred by privacy v2 token tx"))
+	}
+
 	// validate sanity data for PRV
 	//result, err := txCustomTokenPrivacy.Tx.validateNormalTxSanityData()
 	result, err := txCustomTokenPrivacy.Tx.ValidateSanityData(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight)
@@ -0,0 +1,74 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// InitPrivacyV2 creates a privacy token tx whose token part is a privacy v2 tx, fee is paid in PRV as in Init:
+//   - with confidential inputs, confidential coins of token are transferred and PropertyID is ConfidentialAssetID,
+//     so neither the token nor the amount is revealed
+//   - without them, TokenInput of tokenParams are converted to confidential coins of token with a conversion tx
+func (txCustomTokenPrivacy *TxCustomTokenPrivacy) InitPrivacyV2(params *TxPrivacyTokenInitParams, confidentialInputs []*InputCoinV2) error {
+	if params.tokenParams == nil || params.tokenParams.TokenTxType != CustomTokenTransfer {
+		return NewTransactionErr(PrivacyTokenTxTypeNotHandleError, errors.New("privacy v2 token tx must be a transfer tx"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(params.tokenParams.PropertyID)
+	if err != nil {
+		return NewTransactionErr(TokenIDInvalidError, err, params.tokenParams.PropertyID)
+	}
+	if tokenID.IsEqual(&common.PRVCoinID) || tokenID.IsEqual(&common.ConfidentialAssetID) {
+		return NewTransactionErr(TokenIDInvalidError, errors.New("invalid token id of privacy v2 token tx"), tokenID.String())
+	}
+
+	normalTx := Tx{}
+	err = normalTx.Init(NewTxPrivacyInitParams(
+		params.senderKey,
+		params.paymentInfo,
+		params.inputCoin,
+		params.feeNativeCoin,
+		params.hasPrivacyCoin,
+		params.transactionStateDB,
+		nil,
+		params.metaData,
+		params.info))
+	if err != nil {
+		return NewTransactionErr(PrivacyTokenInitPRVError, err)
+	}
+	normalTx.Type = common.TxCustomTokenPrivacyType
+	txCustomTokenPrivacy.Tx = normalTx
+
+	temp := Tx{}
+	if len(confidentialInputs) > 0 {
+		txCustomTokenPrivacy.TxPrivacyTokenData = TxPrivacyTokenData{
+			Type:       CustomTokenTransfer,
+			PropertyID: common.ConfidentialAssetID,
+		}
+		err = temp.InitV2(NewTxPrivacyV2InitParams(params.senderKey,
+			params.tokenParams.Receiver,
+			confidentialInputs,
+			0,
+			params.transactionStateDB,
+			nil,
+			tokenID))
+	} else {
+		txCustomTokenPrivacy.TxPrivacyTokenData = TxPrivacyTokenData{
+			Type:           CustomTokenTransfer,
+			PropertyID:     *tokenID,
+			PropertyName:   params.tokenParams.PropertyName,
+			PropertySymbol: params.tokenParams.PropertySymbol,
+		}
+		err = temp.InitConversionV2(NewTxConversionV2InitParams(params.senderKey,
+			params.tokenParams.Receiver,
+			params.tokenParams.TokenInput,
+			params.tokenParams.Fee,
+			tokenID,
+			nil))
+	}
+	if err != nil {
+		return NewTransactionErr(PrivacyTokenInitTokenDataError, err)
+	}
+	txCustomTokenPrivacy.TxPrivacyTokenData.TxNormal = temp
+	return nil
+}
@@ -0,0 +1,419 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/mlsag"
+)
+
+// Privacy v2 txs are Tx with version txVersion2:
+//   - output coins are sent to one-time addresses, so receivers are not revealed
+//   - each input is spent by a MLSAG ring signature over CommitmentRingSize coins of privacy v2,
+//     its rows are one-time public keys and commitments of ring members minus the pseudo commitment of input
+//   - serial number of each input coin is key image of its one-time private key, so double spending is detected as in v1
+//
+// Signatures of all inputs are concatenated in Sig, SigPubKey is empty.
+// PRV is transferred by privacy v2 txs with coins of PRV, custom tokens are transferred with confidential coins:
+// their rings are picked from confidential coins of all tokens and have one more row,
+// which is asset tag of ring members minus asset tag of outputs, so the signature proves that the spent coin
+// has the same token as outputs without revealing the token (see privacy/assettag.go).
+// Coins of v1 become coins of privacy v2 by conversion txs (see txconversionv2.go).
+const (
+	numRingRowsV2             = 2
+	numRingRowsV2Confidential = 3
+)
+
+// InputCoinV2 is a coin of privacy v2 owned by sender, its value and randomness are decrypted by privacy.DecryptOutputCoinV2,
+// Asset is set for confidential coins (see privacy.DecryptConfidentialOutputCoin)
+type InputCoinV2 struct {
+	CoinDetails   *privacy.Coin
+	OneTimeSecret *privacy.Scalar
+	Asset         *privacy.ConfidentialAsset
+}
+
+type TxPrivacyV2InitParams struct {
+	senderSK    *privacy.PrivateKey
+	paymentInfo []*privacy.PaymentInfo
+	inputCoins  []*InputCoinV2
+	fee         uint64
+	stateDB     *statedb.StateDB
+	info        []byte
+	// token of confidential coins, nil for PRV
+	confidentialTokenID *common.Hash
+}
+
+func NewTxPrivacyV2InitParams(senderSK *privacy.PrivateKey,
+	paymentInfo []*privacy.PaymentInfo,
+	inputCoins []*InputCoinV2,
+	fee uint64,
+	stateDB *statedb.StateDB,
+	info []byte,
+	confidentialTokenID *common.Hash) *TxPrivacyV2InitParams {
+	return &TxPrivacyV2InitParams{
+		senderSK:            senderSK,
+		paymentInfo:         paymentInfo,
+		inputCoins:          inputCoins,
+		fee:                 fee,
+		stateDB:             stateDB,
+		info:                info,
+		confidentialTokenID: confidentialTokenID,
+	}
+}
+
+// InitV2 creates a privacy v2 tx which spends input coins of privacy v2
+func (tx *Tx) InitV2(params *TxPrivacyV2InitParams) error {
+	Logger.log.Debugf("CREATING TX V2........\n")
+	tx.Version = txVersion2
+	if len(params.inputCoins) == 0 {
+		return NewTransactionErr(WrongInputError, errors.New("privacy v2 tx must have input coins"))
+	}
+	if len(params.inputCoins) > 255 {
+		return NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(params.inputCoins)))
+	}
+	if len(params.paymentInfo) > 254 {
+		return NewTransactionErr(PaymentInfoIsVeryLargeError, nil, strconv.Itoa(len(params.paymentInfo)))
+	}
+	if len(params.info) > MaxSizeInfo {
+		return NewTransactionErr(ExceedSizeInfoTxError, nil)
+	}
+	isConfidential := params.confidentialTokenID != nil
+	if isConfidential && params.fee > 0 {
+		return NewTransactionErr(InvalidConfidentialAssetError, errors.New("fee of confidential coins must be zero"))
+	}
+	start := time.Now()
+	if tx.LockTime == 0 {
+		tx.LockTime = time.Now().Unix()
+	}
+
+	senderFullKey := incognitokey.KeySet{}
+	err := senderFullKey.InitFromPrivateKey(params.senderSK)
+	if err != nil {
+		return NewTransactionErr(PrivateKeySenderInvalidError, err)
+	}
+	pkLastByteSender := senderFullKey.PaymentAddress.Pk[len(senderFullKey.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
+	coinV2TokenID := privacy.GetCoinV2TokenID(common.PRVCoinID)
+	if isConfidential {
+		coinV2TokenID = privacy.GetCoinV2TokenID(common.ConfidentialAssetID)
+	}
+
+	tx.Type = common.TxNormalType
+	tx.Info = params.info
+	tx.Fee = params.fee
+	tx.PubKeyLastByteSender = pkLastByteSender
+
+	sumInputValue := uint64(0)
+	for _, inputCoin := range params.inputCoins {
+		if isConfidential && (inputCoin.Asset == nil || !inputCoin.Asset.TokenID.IsEqual(params.confidentialTokenID)) {
+			return NewTransactionErr(InvalidConfidentialAssetError, errors.New("input coin is not a confidential coin of token"))
+		}
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, p := range params.paymentInfo {
+		sumOutputValue += p.Amount
+	}
+	if sumInputValue < sumOutputValue+params.fee {
+		return NewTransactionErr(WrongInputError, fmt.Errorf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, params.fee))
+	}
+	paymentInfo := params.paymentInfo
+	if overBalance := sumInputValue - sumOutputValue - params.fee; overBalance > 0 {
+		paymentInfo = append(paymentInfo, &privacy.PaymentInfo{PaymentAddress: senderFullKey.PaymentAddress, Amount: overBalance})
+	}
+
+	// all outputs of confidential coins share one asset tag, which is blinded by a new blinder
+	var outputAsset *privacy.ConfidentialAsset
+	if isConfidential {
+		outputAsset = &privacy.ConfidentialAsset{TokenID: *params.confidentialTokenID, Blinder: privacy.RandomScalar()}
+	}
+	outputCoins := make([]*privacy.OutputCoin, len(paymentInfo))
+	for i, pInfo := range paymentInfo {
+		if len(pInfo.Message) > privacy.MaxSizeInfoCoin || (isConfidential && len(pInfo.Message) > privacy.MaxSizeInfoConfidentialCoin) {
+			return NewTransactionErr(ExceedSizeInfoOutCoinError, nil)
+		}
+		if isConfidential && common.GetShardIDFromLastByte(pInfo.PaymentAddress.Pk[len(pInfo.PaymentAddress.Pk)-1]) != shardID {
+			return NewTransactionErr(InvalidConfidentialAssetError, errors.New("confidential coins can only be sent in shard of sender"))
+		}
+		outputCoins[i], err = privacy.NewOutputCoinV2WithParams(pInfo.PaymentAddress, pInfo.Amount, pInfo.Message, nil, outputAsset)
+		if err != nil {
+			return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+		}
+	}
+
+	// pick ring members for each input, real coin is put at a random position of its ring
+	commitmentIndices := make([]uint64, 0, len(params.inputCoins)*privacy.CommitmentRingSize)
+	pis := make([]int, len(params.inputCoins))
+	inputCoins := make([]*privacy.InputCoin, len(params.inputCoins))
+	privateKeys := make([]*privacy.Scalar, len(params.inputCoins))
+	for i, inputCoin := range params.inputCoins {
+		indices, pi, err := randomRingIndicesV2(params.stateDB, coinV2TokenID, shardID, inputCoin.CoinDetails)
+		if err != nil {
+			return NewTransactionErr(RandomCommitmentError, err)
+		}
+		commitmentIndices = append(commitmentIndices, indices...)
+		pis[i] = pi
+
+		privateKeys[i] = privacy.GetOneTimePrivateKey(*params.senderSK, inputCoin.OneTimeSecret)
+		inputCoins[i] = new(privacy.InputCoin).Init()
+		inputCoins[i].CoinDetails.SetValue(inputCoin.CoinDetails.GetValue())
+		inputCoins[i].CoinDetails.SetRandomness(inputCoin.CoinDetails.GetRandomness())
+		inputCoins[i].CoinDetails.SetSerialNumber(privacy.GenerateKeyImage(privateKeys[i]))
+	}
+
+	var pseudoRands []*privacy.Scalar
+	tx.Proof, pseudoRands, err = zkp.ProveV2(inputCoins, outputCoins, commitmentIndices)
+	if err != nil {
+		return NewTransactionErr(WithnessProveError, err, true, "")
+	}
+
+	// hide information of output coins except one-time public key, commitment and encrypted details
+	for _, outputCoin := range tx.Proof.GetOutputCoins() {
+		outputCoin.CoinDetails.SetValue(0)
+		outputCoin.CoinDetails.SetRandomness(nil)
+	}
+	// hide information of input coins except key images
+	inputRands := make([]*privacy.Scalar, len(inputCoins))
+	for i, inputCoin := range tx.Proof.GetInputCoins() {
+		inputRands[i] = inputCoin.CoinDetails.GetRandomness()
+		inputCoin.CoinDetails.SetValue(0)
+		inputCoin.CoinDetails.SetRandomness(nil)
+	}
+
+	rings, err := getRingsV2(params.stateDB, tx.Proof, shardID, common.PRVCoinID)
+	if err != nil {
+		return NewTransactionErr(CanNotGetCommitmentFromIndexError, err, commitmentIndices, shardID)
+	}
+	message := tx.Hash()[:]
+	tx.Sig = []byte{}
+	for i := range rings {
+		// commitment of coin minus pseudo commitment is a commitment to zero with randomness r - r'
+		keys := []*privacy.Scalar{privateKeys[i], new(privacy.Scalar).Sub(inputRands[i], pseudoRands[i])}
+		if isConfidential {
+			// asset tag of coin minus asset tag of outputs is (a - a')*G_randomness
+			keys = append(keys, new(privacy.Scalar).Sub(params.inputCoins[i].Asset.Blinder, outputAsset.Blinder))
+		}
+		sig, err := mlsag.NewMlsag(keys, rings[i], pis[i]).Sign(message)
+		if err != nil {
+			return NewTransactionErr(SignTxError, err)
+		}
+		tx.Sig = append(tx.Sig, sig.Bytes()...)
+	}
+	tx.SigPubKey = []byte{}
+
+	Logger.log.Debugf("Successfully Creating tx v2 %+v in %s time", *tx.Hash(), time.Since(start))
+	return nil
+}
+
+// randomRingIndicesV2 returns indices of ring members in the list of v2 coins and position of the real coin in ring,
+// ring members are picked randomly so a ring may contain the same coin more than once when there are few v2 coins
+func randomRingIndicesV2(stateDB *statedb.StateDB, coinV2TokenID common.Hash, shardID byte, coin *privacy.Coin) ([]uint64, int, error) {
+	realIndex, err := statedb.GetCommitmentIndex(stateDB, coinV2TokenID, privacy.CoinV2Bytes(coin), shardID)
+	if err != nil {
+		return nil, 0, err
+	}
+	lenCoins, err := statedb.GetCommitmentLength(stateDB, coinV2TokenID, shardID)
+	if err != nil {
+		return nil, 0, err
+	}
+	pi := rand.Intn(privacy.CommitmentRingSize)
+	indices := make([]uint64, privacy.CommitmentRingSize)
+	for j := range indices {
+		if j == pi {
+			indices[j] = realIndex.Uint64()
+			continue
+		}
+		index, err := common.RandBigIntMaxRange(lenCoins)
+		if err != nil {
+			return nil, 0, err
+		}
+		indices[j] = index.Uint64()
+	}
+	return indices, pi, nil
+}
+
+// numRingRows returns number of rows of ring signatures of inputs of proof
+func numRingRows(proof *zkp.PaymentProof) int {
+	if assetTag, _ := proof.GetOutputAssetTag(); assetTag != nil {
+		return numRingRowsV2Confidential
+	}
+	return numRingRowsV2
+}
+
+// getRingsV2 builds ring of each input of proof from the list of v2 coins of token in stateDB,
+// rings of confidential coins are built from the list of confidential coins of all tokens
+func getRingsV2(stateDB *statedb.StateDB, proof *zkp.PaymentProof, shardID byte, tokenID common.Hash) ([]*mlsag.Ring, error) {
+	outputAssetTag, err := proof.GetOutputAssetTag()
+	if err != nil {
+		return nil, err
+	}
+	coinV2TokenID := privacy.GetCoinV2TokenID(tokenID)
+	if outputAssetTag != nil {
+		coinV2TokenID = privacy.GetCoinV2TokenID(common.ConfidentialAssetID)
+	}
+	pseudoCommitments := proof.GetCommitmentInputValue()
+	commitmentIndices := proof.GetCommitmentIndices()
+	if len(pseudoCommitments) != len(proof.GetInputCoins()) || len(commitmentIndices) != len(pseudoCommitments)*privacy.CommitmentRingSize {
+		return nil, errors.New("invalid length of commitment indices")
+	}
+	rings := make([]*mlsag.Ring, len(pseudoCommitments))
+	for i, pseudoCommitment := range pseudoCommitments {
+		keys := make([][]*privacy.Point, privacy.CommitmentRingSize)
+		for j := 0; j < privacy.CommitmentRingSize; j++ {
+			coinBytes, err := statedb.GetCommitmentByIndex(stateDB, coinV2TokenID, commitmentIndices[i*privacy.CommitmentRingSize+j], shardID)
+			if err != nil {
+				return nil, err
+			}
+			publicKey, commitment, assetTag, err := privacy.ParseCoinV2Bytes(coinBytes)
+			if err != nil {
+				return nil, err
+			}
+			keys[j] = []*privacy.Point{publicKey, new(privacy.Point).Sub(commitment, pseudoCommitment)}
+			if outputAssetTag != nil {
+				if assetTag == nil {
+					return nil, errors.New("ring member is not a confidential coin")
+				}
+				keys[j] = append(keys[j], new(privacy.Point).Sub(assetTag, outputAssetTag))
+			}
+		}
+		rings[i] = mlsag.NewRing(keys)
+	}
+	return rings, nil
+}
+
+// validateTransactionV2 verifies payment proof and ring signatures of a privacy v2 tx
+func (tx *Tx) validateTransactionV2(transactionStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isBatch bool) (bool, error) {
+	if tx.Proof == nil || !tx.Proof.IsPrivacyV2() {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v2 tx must have a payment proof v2"))
+	}
+	if tokenID == nil {
+		tokenID = &common.PRVCoinID
+	}
+	if tx.Proof.IsConversion() {
+		return tx.validateConversionV2(transactionStateDB, shardID, tokenID, isBatch)
+	}
+	// PRV is transferred with coins of PRV, custom tokens are only transferred with confidential coins
+	outputAssetTag, err := tx.Proof.GetOutputAssetTag()
+	if err != nil {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, err)
+	}
+	if (outputAssetTag != nil) != tokenID.IsEqual(&common.ConfidentialAssetID) || (outputAssetTag == nil && !tokenID.IsEqual(&common.PRVCoinID)) {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, fmt.Errorf("invalid asset of privacy v2 tx with token id %s", tokenID.String()))
+	}
+	valid, err := tx.Proof.VerifyV2(tx.Fee, isBatch)
+	if !valid {
+		Logger.log.Error("FAILED VERIFICATION PAYMENT PROOF V2")
+		return false, NewTransactionErr(TxProofVerifyFailError, err, tx.Hash().String())
+	}
+
+	rings, err := getRingsV2(transactionStateDB, tx.Proof, shardID, *tokenID)
+	if err != nil {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, err)
+	}
+	numRows := numRingRows(tx.Proof)
+	sigSize := mlsag.SigSize(privacy.CommitmentRingSize, numRows)
+	if len(tx.Sig) != len(rings)*sigSize {
+		return false, NewTransactionErr(InitTxSignatureFromBytesError, fmt.Errorf("wrong length of ring signatures %d", len(tx.Sig)))
+	}
+	message := tx.Hash()[:]
+	for i, ring := range rings {
+		sig := new(mlsag.MlsagSig)
+		err := sig.SetBytes(tx.Sig[i*sigSize:(i+1)*sigSize], numRows)
+		if err != nil {
+			return false, NewTransactionErr(InitTxSignatureFromBytesError, err)
+		}
+		if !privacy.IsPointEqual(sig.GetKeyImage(), tx.Proof.GetInputCoins()[i].CoinDetails.GetSerialNumber()) {
+			return false, NewTransactionErr(VerifyTxSigFailError, errors.New("key image of ring signature does not match serial number of input coin"))
+		}
+		valid, err := mlsag.Verify(sig, ring, message)
+		if !valid {
+			Logger.log.Errorf("FAILED VERIFICATION RING SIGNATURE OF INPUT %d", i)
+			return false, NewTransactionErr(VerifyTxSigFailError, err)
+		}
+	}
+	return true, nil
+}
+
+// validateNormalTxSanityDataV2 checks sanity of a privacy v2 tx, which is only accepted after its activation beacon height
+func (tx Tx) validateNormalTxSanityDataV2(bcr metadata.ChainRetriever, beaconHeight uint64) (bool, error) {
+	if beaconHeight < bcr.GetBeaconHeightBreakPointPrivacyV2() {
+		return false, NewTransactionErr(PrivacyV2NotActivatedError, nil, beaconHeight)
+	}
+	if tx.Type != common.TxNormalType {
+		return false, NewTransactionErr(RejectTxType, fmt.Errorf("wrong privacy v2 tx type with %s", tx.Type))
+	}
+	if tx.Metadata != nil {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v2 tx can not contain metadata"))
+	}
+	if int64(tx.LockTime) > time.Now().Unix() {
+		return false, NewTransactionErr(RejectInvalidLockTime, fmt.Errorf("wrong tx locktime %d", tx.LockTime))
+	}
+	if actualTxSize := tx.GetTxActualSize(); actualTxSize > common.MaxTxSize {
+		return false, NewTransactionErr(RejectTxSize, fmt.Errorf("tx size %d kB is too large", actualTxSize))
+	}
+	if len(tx.Info) > MaxSizeInfo {
+		return false, NewTransactionErr(RejectTxInfoSize, fmt.Errorf("wrong tx info length %d bytes, only support info with max length <= %d bytes", len(tx.Info), MaxSizeInfo))
+	}
+	if tx.Proof == nil || !tx.Proof.IsPrivacyV2() {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("privacy v2 tx must have a payment proof v2"))
+	}
+	if len(tx.Proof.GetInputCoins()) > 255 || len(tx.Proof.GetOutputCoins()) > 255 {
+		return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("input coins or output coins in tx are very large"))
+	}
+	if tx.Proof.IsConversion() {
+		if len(tx.SigPubKey) != common.SigPubKeySize {
+			return false, NewTransactionErr(RejectTxPublickeySigSize, fmt.Errorf("wrong tx Sig PK size %d", len(tx.SigPubKey)))
+		}
+	} else {
+		if len(tx.SigPubKey) != 0 {
+			return false, NewTransactionErr(RejectTxPublickeySigSize, fmt.Errorf("privacy v2 tx must not have sig pubkey"))
+		}
+		if len(tx.Sig) != len(tx.Proof.GetInputCoins())*mlsag.SigSize(privacy.CommitmentRingSize, numRingRows(tx.Proof)) {
+			return false, NewTransactionErr(InitTxSignatureFromBytesError, fmt.Errorf("wrong length of ring signatures %d", len(tx.Sig)))
+		}
+	}
+	serialNumbers := make(map[common.Hash]bool)
+	for _, inputCoin := range tx.Proof.GetInputCoins() {
+		if inputCoin.CoinDetails == nil || inputCoin.CoinDetails.GetSerialNumber() == nil {
+			return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("invalid serial number of input coin"))
+		}
+		if !tx.Proof.IsConversion() && !privacy.IsKeyImageValid(inputCoin.CoinDetails.GetSerialNumber()) {
+			return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("invalid key image of input coin"))
+		}
+		hashSN := common.HashH(inputCoin.CoinDetails.GetSerialNumber().ToBytesS())
+		if serialNumbers[hashSN] {
+			return false, NewTransactionErr(DoubleSpendError, errors.New("duplicate serial numbers in tx"))
+		}
+		serialNumbers[hashSN] = true
+	}
+	outputAssetTag, err := tx.Proof.GetOutputAssetTag()
+	if err != nil {
+		return false, NewTransactionErr(InvalidConfidentialAssetError, err)
+	}
+	for _, outputCoin := range tx.Proof.GetOutputCoins() {
+		if !privacy.IsOutputCoinV2(outputCoin.CoinDetails) || outputCoin.CoinDetailsEncrypted == nil || outputCoin.CoinDetailsEncrypted.IsNil() {
+			return false, NewTransactionErr(InvalidPrivacyV2TxError, errors.New("invalid output coin of privacy v2 tx"))
+		}
+		if len(outputCoin.CoinDetails.GetInfo()) > privacy.MaxSizeInfoCoin {
+			return false, NewTransactionErr(ExceedSizeInfoOutCoinError, nil)
+		}
+		// asset tag is read from info of coin, so info of coins which are not confidential can not look like one
+		if outputAssetTag == nil && privacy.HasAssetTagPrefix(outputCoin.CoinDetails.GetInfo()) {
+			return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("info of output coin which is not confidential can not start with prefix of asset tag"))
+		}
+		// confidential coins are never sent cross shard, so all confidential coins of a shard are in one anonymity set
+		if outputAssetTag != nil && common.GetShardIDFromLastByte(outputCoin.CoinDetails.GetPubKeyLastByte()) != common.GetShardIDFromLastByte(tx.PubKeyLastByteSender) {
+			return false, NewTransactionErr(InvalidConfidentialAssetError, errors.New("confidential coins can only be sent in shard of sender"))
+		}
+	}
+	return true, nil
+}
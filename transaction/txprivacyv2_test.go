@@ -0,0 +1,243 @@
+package transaction
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStateDBV2(t *testing.T) *statedb.StateDB {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_txprivacyv2_")
+	assert.Equal(t, nil, err)
+	diskDB, err := incdb.Open("leveldb", dbPath)
+	assert.Equal(t, nil, err)
+	stateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	assert.Equal(t, nil, err)
+	return stateDB
+}
+
+func TestInitAndValidateTxV2(t *testing.T) {
+	stateDB := newTestStateDBV2(t)
+	senderSK := privacy.GeneratePrivateKey([]byte{1})
+	sender := incognitokey.KeySet{}
+	assert.Equal(t, nil, sender.InitFromPrivateKey(&senderSK))
+	receiverSK := privacy.GeneratePrivateKey([]byte{2})
+	receiver := incognitokey.KeySet{}
+	assert.Equal(t, nil, receiver.InitFromPrivateKey(&receiverSK))
+	shardID := common.GetShardIDFromLastByte(sender.PaymentAddress.Pk[len(sender.PaymentAddress.Pk)-1])
+	coinV2TokenID := privacy.GetCoinV2TokenID(common.PRVCoinID)
+
+	// coins of sender and decoys in the list of v2 coins
+	values := []uint64{1000, 2500}
+	inputCoins := make([]*InputCoinV2, len(values))
+	for i, value := range values {
+		outputCoin, err := privacy.NewOutputCoinV2(sender.PaymentAddress, value, nil)
+		assert.Equal(t, nil, err)
+		decoy, err := privacy.NewOutputCoinV2(sender.PaymentAddress, value, nil)
+		assert.Equal(t, nil, err)
+		err = statedb.StoreCommitments(stateDB, coinV2TokenID, nil, [][]byte{privacy.CoinV2Bytes(outputCoin.CoinDetails), privacy.CoinV2Bytes(decoy.CoinDetails)}, shardID)
+		assert.Equal(t, nil, err)
+
+		outputCoin.CoinDetails.SetValue(0)
+		outputCoin.CoinDetails.SetRandomness(nil)
+		secret, err := privacy.DecryptOutputCoinV2(outputCoin, sender.ReadonlyKey)
+		assert.Equal(t, nil, err)
+		inputCoins[i] = &InputCoinV2{CoinDetails: outputCoin.CoinDetails, OneTimeSecret: secret}
+	}
+
+	paymentInfo := []*privacy.PaymentInfo{{PaymentAddress: receiver.PaymentAddress, Amount: 3000}}
+	tx := new(Tx)
+	err := tx.InitV2(NewTxPrivacyV2InitParams(&senderSK, paymentInfo, inputCoins, 100, stateDB, nil, nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, tx.IsPrivacy())
+	assert.Equal(t, 2, len(tx.Proof.GetOutputCoins()))
+
+	valid, err := tx.validateTransactionV2(stateDB, shardID, nil, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, valid)
+
+	// receiver can decrypt its coin, sender can not
+	_, err = privacy.DecryptOutputCoinV2(tx.Proof.GetOutputCoins()[0], receiver.ReadonlyKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(3000), tx.Proof.GetOutputCoins()[0].CoinDetails.GetValue())
+	_, err = privacy.DecryptOutputCoinV2(tx.Proof.GetOutputCoins()[0], sender.ReadonlyKey)
+	assert.NotEqual(t, nil, err)
+
+	// key image is bound to the one-time key, so spending the same coin again gives the same serial number
+	for i, inputCoin := range inputCoins {
+		keyImage := privacy.GenerateKeyImage(privacy.GetOneTimePrivateKey(senderSK, inputCoin.OneTimeSecret))
+		assert.Equal(t, true, privacy.IsPointEqual(keyImage, tx.Proof.GetInputCoins()[i].CoinDetails.GetSerialNumber()))
+	}
+
+	// changing fee breaks the balance and the ring signatures
+	tx.Fee = 99
+	valid, _ = tx.validateTransactionV2(stateDB, shardID, nil, false)
+	assert.Equal(t, false, valid)
+	tx.Fee = 100
+
+	// ring signatures are bound to key images of inputs
+	tx.Sig[privacy.Ed25519KeySize] ^= 1
+	valid, _ = tx.validateTransactionV2(stateDB, shardID, nil, false)
+	assert.Equal(t, false, valid)
+}
+
+func TestInitTxV2WithInsufficientInput(t *testing.T) {
+	stateDB := newTestStateDBV2(t)
+	senderSK := privacy.GeneratePrivateKey([]byte{3})
+	sender := incognitokey.KeySet{}
+	assert.Equal(t, nil, sender.InitFromPrivateKey(&senderSK))
+	outputCoin, err := privacy.NewOutputCoinV2(sender.PaymentAddress, 100, nil)
+	assert.Equal(t, nil, err)
+	secret, err := privacy.DecryptOutputCoinV2(outputCoin, sender.ReadonlyKey)
+	assert.Equal(t, nil, err)
+
+	paymentInfo := []*privacy.PaymentInfo{{PaymentAddress: sender.PaymentAddress, Amount: 100}}
+	tx := new(Tx)
+	err = tx.InitV2(NewTxPrivacyV2InitParams(&senderSK, paymentInfo, []*InputCoinV2{{CoinDetails: outputCoin.CoinDetails, OneTimeSecret: secret}}, 10, stateDB, nil, nil))
+	assert.NotEqual(t, nil, err)
+}
+
+// newTestInputCoinV1 stores a coin v1 of token for owner and returns it as an input coin with its serial number
+func newTestInputCoinV1(t *testing.T, stateDB *statedb.StateDB, ownerSK privacy.PrivateKey, owner incognitokey.KeySet, tokenID common.Hash, value uint64) *privacy.InputCoin {
+	publicKey, err := new(privacy.Point).FromBytesS(owner.PaymentAddress.Pk)
+	assert.Equal(t, nil, err)
+	inputCoin := new(privacy.InputCoin).Init()
+	inputCoin.CoinDetails.SetPublicKey(publicKey)
+	inputCoin.CoinDetails.SetValue(value)
+	inputCoin.CoinDetails.SetRandomness(privacy.RandomScalar())
+	inputCoin.CoinDetails.SetSNDerivator(privacy.RandomScalar())
+	assert.Equal(t, nil, inputCoin.CoinDetails.CommitAll())
+	inputCoin.CoinDetails.SetSerialNumber(new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], new(privacy.Scalar).FromBytesS(ownerSK), inputCoin.CoinDetails.GetSNDerivator()))
+	shardID := common.GetShardIDFromLastByte(owner.PaymentAddress.Pk[len(owner.PaymentAddress.Pk)-1])
+	err = statedb.StoreCommitments(stateDB, tokenID, owner.PaymentAddress.Pk, [][]byte{inputCoin.CoinDetails.GetCoinCommitment().ToBytesS()}, shardID)
+	assert.Equal(t, nil, err)
+	return inputCoin
+}
+
+func TestInitAndValidateConversionTxV2(t *testing.T) {
+	stateDB := newTestStateDBV2(t)
+	senderSK := privacy.GeneratePrivateKey([]byte{4})
+	sender := incognitokey.KeySet{}
+	assert.Equal(t, nil, sender.InitFromPrivateKey(&senderSK))
+	shardID := common.GetShardIDFromLastByte(sender.PaymentAddress.Pk[len(sender.PaymentAddress.Pk)-1])
+	tokenID := common.Hash{10}
+
+	for _, testTokenID := range []*common.Hash{nil, &tokenID} {
+		coinTokenID := common.PRVCoinID
+		if testTokenID != nil {
+			coinTokenID = *testTokenID
+		}
+		inputCoins := []*privacy.InputCoin{
+			newTestInputCoinV1(t, stateDB, senderSK, sender, coinTokenID, 700),
+			newTestInputCoinV1(t, stateDB, senderSK, sender, coinTokenID, 300),
+		}
+		paymentInfo := []*privacy.PaymentInfo{{PaymentAddress: sender.PaymentAddress, Amount: 600}}
+		tx := new(Tx)
+		err := tx.InitConversionV2(NewTxConversionV2InitParams(&senderSK, paymentInfo, inputCoins, 10, testTokenID, nil))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, tx.Proof.IsConversion())
+		assert.Equal(t, 2, len(tx.Proof.GetOutputCoins()))
+
+		// conversion proof survives serialization
+		proof := new(zkp.PaymentProof)
+		assert.Nil(t, proof.SetBytes(tx.Proof.Bytes()))
+		assert.Equal(t, true, proof.IsConversion())
+
+		valid, err := tx.validateTransactionV2(stateDB, shardID, testTokenID, false)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, valid)
+
+		// converted coins are coins of privacy v2 of sender, coins of token carry the unblinded asset tag of token
+		_, asset, err := privacy.DecryptConfidentialOutputCoin(tx.Proof.GetOutputCoins()[1], sender.ReadonlyKey)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uint64(390), tx.Proof.GetOutputCoins()[1].CoinDetails.GetValue())
+		outputAssetTag, err := tx.Proof.GetOutputAssetTag()
+		assert.Equal(t, nil, err)
+		if testTokenID == nil {
+			assert.Equal(t, true, asset == nil)
+			assert.Equal(t, true, outputAssetTag == nil)
+		} else {
+			assert.Equal(t, tokenID, asset.TokenID)
+			assert.Equal(t, true, privacy.IsPointEqual(privacy.AssetTag(tokenID), outputAssetTag))
+			// coins of token can not be claimed as coins of PRV
+			valid, _ = tx.validateTransactionV2(stateDB, shardID, nil, false)
+			assert.Equal(t, false, valid)
+		}
+
+		// changing fee breaks the balance and the signature
+		tx.Fee = 9
+		valid, _ = tx.validateTransactionV2(stateDB, shardID, testTokenID, false)
+		assert.Equal(t, false, valid)
+	}
+}
+
+func TestInitAndValidateConfidentialTxV2(t *testing.T) {
+	stateDB := newTestStateDBV2(t)
+	senderSK := privacy.GeneratePrivateKey([]byte{5})
+	sender := incognitokey.KeySet{}
+	assert.Equal(t, nil, sender.InitFromPrivateKey(&senderSK))
+	shardID := common.GetShardIDFromLastByte(sender.PaymentAddress.Pk[len(sender.PaymentAddress.Pk)-1])
+	receiver := incognitokey.KeySet{}
+	for seed := byte(6); ; seed++ {
+		receiverSK := privacy.GeneratePrivateKey([]byte{seed})
+		assert.Equal(t, nil, receiver.InitFromPrivateKey(&receiverSK))
+		if common.GetShardIDFromLastByte(receiver.PaymentAddress.Pk[len(receiver.PaymentAddress.Pk)-1]) == shardID {
+			break
+		}
+	}
+	confidentialTokenID := privacy.GetCoinV2TokenID(common.ConfidentialAssetID)
+	tokenID := common.Hash{11}
+	otherTokenID := common.Hash{12}
+
+	// confidential coins of sender, decoys are confidential coins of another token
+	inputCoins := make([]*InputCoinV2, 2)
+	for i := range inputCoins {
+		outputCoin, err := privacy.NewOutputCoinV2WithParams(sender.PaymentAddress, 500, nil, nil, &privacy.ConfidentialAsset{TokenID: tokenID, Blinder: privacy.RandomScalar()})
+		assert.Equal(t, nil, err)
+		decoy, err := privacy.NewOutputCoinV2WithParams(sender.PaymentAddress, 500, nil, nil, &privacy.ConfidentialAsset{TokenID: otherTokenID, Blinder: privacy.RandomScalar()})
+		assert.Equal(t, nil, err)
+		err = statedb.StoreCommitments(stateDB, confidentialTokenID, nil, [][]byte{privacy.CoinV2Bytes(outputCoin.CoinDetails), privacy.CoinV2Bytes(decoy.CoinDetails)}, shardID)
+		assert.Equal(t, nil, err)
+
+		outputCoin.CoinDetails.SetValue(0)
+		outputCoin.CoinDetails.SetRandomness(nil)
+		secret, asset, err := privacy.DecryptConfidentialOutputCoin(outputCoin, sender.ReadonlyKey)
+		assert.Equal(t, nil, err)
+		inputCoins[i] = &InputCoinV2{CoinDetails: outputCoin.CoinDetails, OneTimeSecret: secret, Asset: asset}
+	}
+
+	// spending confidential coins as coins of another token fails
+	paymentInfo := []*privacy.PaymentInfo{{PaymentAddress: receiver.PaymentAddress, Amount: 800}}
+	tx := new(Tx)
+	err := tx.InitV2(NewTxPrivacyV2InitParams(&senderSK, paymentInfo, inputCoins, 0, stateDB, nil, &otherTokenID))
+	assert.NotEqual(t, nil, err)
+
+	tx = new(Tx)
+	err = tx.InitV2(NewTxPrivacyV2InitParams(&senderSK, paymentInfo, inputCoins, 0, stateDB, nil, &tokenID))
+	assert.Equal(t, nil, err)
+	valid, err := tx.validateTransactionV2(stateDB, shardID, &common.ConfidentialAssetID, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, valid)
+
+	// confidential coins are not coins of PRV
+	valid, _ = tx.validateTransactionV2(stateDB, shardID, nil, false)
+	assert.Equal(t, false, valid)
+
+	// receiver learns token of its coin, which is hidden from others by the blinded asset tag
+	_, asset, err := privacy.DecryptConfidentialOutputCoin(tx.Proof.GetOutputCoins()[0], receiver.ReadonlyKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, tokenID, asset.TokenID)
+	assert.Equal(t, uint64(800), tx.Proof.GetOutputCoins()[0].CoinDetails.GetValue())
+	outputAssetTag, err := tx.Proof.GetOutputAssetTag()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, privacy.IsPointEqual(privacy.AssetTag(tokenID), outputAssetTag))
+}
@@ -0,0 +1,315 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumbernoprivacy"
+)
+
+// Txs of a threshold key are built from an unsigned tx without privacy by the parties of the key:
+// serial numbers of input coins and their proofs are computed by the parties, then a coordinator builds the tx
+// with BuildThresholdTx, each signer checks it with CheckThresholdTx and signs hashes of GetThresholdSigningHashes,
+// and the coordinator sets the aggregated signatures with SetThresholdSignatures.
+
+// GetThresholdInputCoins returns input coins of PRV and input coins of token (for privacy token tx) of unsigned tx,
+// their serial numbers are not set
+func (unsignedTx UnsignedTx) GetThresholdInputCoins() ([][]*privacy.InputCoin, error) {
+	components := unsignedTx.thresholdComponents()
+	inputCoins := make([][]*privacy.InputCoin, len(components))
+	for i, component := range components {
+		if component.HasPrivacy {
+			return nil, NewTransactionErr(ThresholdTxInvalidError, errors.New("threshold tx must not have privacy"))
+		}
+		var err error
+		inputCoins[i], err = component.decodeInputCoins()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return inputCoins, nil
+}
+
+func (unsignedTx UnsignedTx) thresholdComponents() []UnsignedTxComponent {
+	components := []UnsignedTxComponent{unsignedTx.PRV}
+	if unsignedTx.TokenData != nil {
+		components = append(components, unsignedTx.TokenData.UnsignedTxComponent)
+	}
+	return components
+}
+
+// BuildThresholdTx builds tx of unsigned tx with serial number proofs of input coins computed by parties of sender,
+// the tx is not signed
+func (unsignedTx UnsignedTx) BuildThresholdTx(senderAddress privacy.PaymentAddress, serialNumberProofs [][]*serialnumbernoprivacy.SNNoPrivacyProof) (metadata.Transaction, error) {
+	if !bytes.Equal(senderAddress.Pk, unsignedTx.SenderPk) {
+		return nil, NewTransactionErr(UnsignedTxSenderMismatchError, nil)
+	}
+	components := unsignedTx.thresholdComponents()
+	if len(serialNumberProofs) != len(components) {
+		return nil, NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("expect serial number proofs of %d components, got %d", len(components), len(serialNumberProofs)))
+	}
+
+	tx, err := components[0].buildThresholdTx(senderAddress, serialNumberProofs[0], unsignedTx.Metadata, unsignedTx.Info, unsignedTx.LockTime)
+	if err != nil {
+		return nil, err
+	}
+	if unsignedTx.TokenData == nil {
+		return tx, nil
+	}
+
+	propertyID, err := common.Hash{}.NewHashFromStr(unsignedTx.TokenData.PropertyID)
+	if err != nil {
+		return nil, NewTransactionErr(TokenIDInvalidError, err, unsignedTx.TokenData.PropertyID)
+	}
+	tokenTx, err := components[1].buildThresholdTx(senderAddress, serialNumberProofs[1], nil, unsignedTx.Info, unsignedTx.LockTime)
+	if err != nil {
+		return nil, err
+	}
+	tx.Type = common.TxCustomTokenPrivacyType
+	return &TxCustomTokenPrivacy{
+		Tx: *tx,
+		TxPrivacyTokenData: TxPrivacyTokenData{
+			Type:           CustomTokenTransfer,
+			PropertyName:   unsignedTx.TokenData.PropertyName,
+			PropertySymbol: unsignedTx.TokenData.PropertySymbol,
+			PropertyID:     *propertyID,
+			Mintable:       unsignedTx.TokenData.Mintable,
+			TxNormal:       *tokenTx,
+		},
+	}, nil
+}
+
+// buildThresholdTx builds tx without privacy of component as InitForASM does, SigPubKey is public key of sender
+func (component UnsignedTxComponent) buildThresholdTx(senderAddress privacy.PaymentAddress, serialNumberProofs []*serialnumbernoprivacy.SNNoPrivacyProof, meta metadata.Metadata, info []byte, lockTime int64) (*Tx, error) {
+	if component.HasPrivacy {
+		return nil, NewTransactionErr(ThresholdTxInvalidError, errors.New("threshold tx must not have privacy"))
+	}
+	inputCoins, err := component.decodeInputCoins()
+	if err != nil {
+		return nil, err
+	}
+	if len(serialNumberProofs) != len(inputCoins) {
+		return nil, NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("expect %d serial number proofs, got %d", len(inputCoins), len(serialNumberProofs)))
+	}
+	for i, inputCoin := range inputCoins {
+		if err := checkThresholdSerialNumberProof(inputCoin, serialNumberProofs[i], senderAddress.Pk); err != nil {
+			return nil, err
+		}
+		inputCoin.CoinDetails.SetSerialNumber(serialNumberProofs[i].GetOutput())
+	}
+	sndOutputs, err := component.decodeSNDOutputs(inputCoins)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{
+		Version:              txVersion,
+		Type:                 common.TxNormalType,
+		LockTime:             lockTime,
+		Fee:                  component.Fee,
+		Info:                 []byte{},
+		Metadata:             meta,
+		SigPubKey:            senderAddress.Pk,
+		PubKeyLastByteSender: senderAddress.Pk[len(senderAddress.Pk)-1],
+	}
+	if len(info) > 0 {
+		tx.Info = info
+	}
+	if len(inputCoins) == 0 && component.Fee == 0 {
+		return tx, nil
+	}
+
+	paymentInfos := component.PaymentInfos
+	if len(sndOutputs) > len(paymentInfos) {
+		sumInputValue := uint64(0)
+		for _, inputCoin := range inputCoins {
+			sumInputValue += inputCoin.CoinDetails.GetValue()
+		}
+		changeAmount := sumInputValue - component.Fee
+		for _, paymentInfo := range paymentInfos {
+			changeAmount -= paymentInfo.Amount
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{PaymentAddress: senderAddress, Amount: changeAmount})
+	}
+	outputCoins := make([]*privacy.OutputCoin, len(paymentInfos))
+	for i, paymentInfo := range paymentInfos {
+		if len(paymentInfo.Message) > privacy.MaxSizeInfoCoin {
+			return nil, NewTransactionErr(ExceedSizeInfoOutCoinError, nil)
+		}
+		publicKey, err := new(privacy.Point).FromBytesS(paymentInfo.PaymentAddress.Pk)
+		if err != nil {
+			return nil, NewTransactionErr(DecompressPaymentAddressError, err, paymentInfo.PaymentAddress)
+		}
+		outputCoins[i] = new(privacy.OutputCoin)
+		outputCoins[i].CoinDetails = new(privacy.Coin)
+		outputCoins[i].CoinDetails.SetValue(paymentInfo.Amount)
+		if len(paymentInfo.Message) > 0 {
+			outputCoins[i].CoinDetails.SetInfo(paymentInfo.Message)
+		}
+		outputCoins[i].CoinDetails.SetPublicKey(publicKey)
+		outputCoins[i].CoinDetails.SetSNDerivator(sndOutputs[i])
+		outputCoins[i].CoinDetails.SetRandomness(privacy.RandomScalar())
+		if err := outputCoins[i].CoinDetails.CommitAll(); err != nil {
+			return nil, NewTransactionErr(CommitOutputCoinError, err)
+		}
+	}
+
+	proof := new(zkp.PaymentProof)
+	proof.Init()
+	proof.SetInputCoins(inputCoins)
+	proof.SetOutputCoins(outputCoins)
+	proof.SetSerialNumberNoPrivacyProof(serialNumberProofs)
+	tx.Proof = proof
+	return tx, nil
+}
+
+// checkThresholdSerialNumberProof checks that proof is a valid serial number proof of input coin of sender
+func checkThresholdSerialNumberProof(inputCoin *privacy.InputCoin, proof *serialnumbernoprivacy.SNNoPrivacyProof, senderPk []byte) error {
+	if proof == nil || proof.GetVKey() == nil || proof.GetInput() == nil || proof.GetOutput() == nil {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("serial number proof is missing"))
+	}
+	if !bytes.Equal(proof.GetVKey().ToBytesS(), senderPk) || !bytes.Equal(inputCoin.CoinDetails.GetPublicKey().ToBytesS(), senderPk) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("input coin is not a coin of sender"))
+	}
+	if !privacy.IsScalarEqual(proof.GetInput(), inputCoin.CoinDetails.GetSNDerivator()) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("serial number proof is not a proof of input coin"))
+	}
+	if valid, err := proof.Verify(nil); !valid {
+		return NewTransactionErr(ThresholdTxInvalidError, err)
+	}
+	return nil
+}
+
+// CheckThresholdTx checks that tx built by coordinator spends input coins of unsigned tx and pays its payment infos,
+// so a signer does not sign a tx it does not agree with
+func (unsignedTx UnsignedTx) CheckThresholdTx(tx metadata.Transaction, senderAddress privacy.PaymentAddress) error {
+	var txs []*Tx
+	switch typedTx := tx.(type) {
+	case *Tx:
+		if unsignedTx.TokenData != nil {
+			return NewTransactionErr(ThresholdTxInvalidError, errors.New("expect privacy token tx"))
+		}
+		txs = []*Tx{typedTx}
+	case *TxCustomTokenPrivacy:
+		if unsignedTx.TokenData == nil || typedTx.TxPrivacyTokenData.PropertyID.String() != unsignedTx.TokenData.PropertyID {
+			return NewTransactionErr(ThresholdTxInvalidError, errors.New("token of tx does not match unsigned tx"))
+		}
+		txs = []*Tx{&typedTx.Tx, &typedTx.TxPrivacyTokenData.TxNormal}
+	default:
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("unexpected tx type"))
+	}
+	if !bytes.Equal(senderAddress.Pk, unsignedTx.SenderPk) {
+		return NewTransactionErr(UnsignedTxSenderMismatchError, nil)
+	}
+	if (unsignedTx.Metadata == nil) != (txs[0].Metadata == nil) ||
+		(unsignedTx.Metadata != nil && !unsignedTx.Metadata.Hash().IsEqual(txs[0].Metadata.Hash())) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("metadata of tx does not match unsigned tx"))
+	}
+	for i, component := range unsignedTx.thresholdComponents() {
+		if err := component.checkThresholdTx(txs[i], senderAddress, unsignedTx.LockTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (component UnsignedTxComponent) checkThresholdTx(tx *Tx, senderAddress privacy.PaymentAddress, lockTime int64) error {
+	if tx.LockTime != lockTime || tx.Fee != component.Fee || !bytes.Equal(tx.SigPubKey, senderAddress.Pk) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("tx does not match unsigned tx"))
+	}
+	inputCoins, err := component.decodeInputCoins()
+	if err != nil {
+		return err
+	}
+	if tx.Proof == nil {
+		if len(inputCoins) > 0 || component.Fee > 0 {
+			return NewTransactionErr(ThresholdTxInvalidError, errors.New("tx has no proof"))
+		}
+		return nil
+	}
+	txInputCoins := tx.Proof.GetInputCoins()
+	serialNumberProofs := tx.Proof.GetSerialNumberNoPrivacyProof()
+	if len(txInputCoins) != len(inputCoins) || len(serialNumberProofs) != len(inputCoins) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("input coins of tx do not match unsigned tx"))
+	}
+	for i, inputCoin := range inputCoins {
+		if !privacy.IsPointEqual(txInputCoins[i].CoinDetails.GetCoinCommitment(), inputCoin.CoinDetails.GetCoinCommitment()) {
+			return NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("input coin %d of tx does not match unsigned tx", i))
+		}
+		if err := checkThresholdSerialNumberProof(inputCoin, serialNumberProofs[i], senderAddress.Pk); err != nil {
+			return err
+		}
+	}
+
+	sndOutputs, err := component.decodeSNDOutputs(inputCoins)
+	if err != nil {
+		return err
+	}
+	outputCoins := tx.Proof.GetOutputCoins()
+	if len(outputCoins) != len(sndOutputs) {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("output coins of tx do not match unsigned tx"))
+	}
+	sumOutputValue := uint64(0)
+	for i, outputCoin := range outputCoins {
+		publicKey := senderAddress.Pk
+		if i < len(component.PaymentInfos) {
+			publicKey = component.PaymentInfos[i].PaymentAddress.Pk
+			if outputCoin.CoinDetails.GetValue() != component.PaymentInfos[i].Amount {
+				return NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("value of output coin %d does not match unsigned tx", i))
+			}
+		}
+		if !bytes.Equal(outputCoin.CoinDetails.GetPublicKey().ToBytesS(), publicKey) ||
+			!privacy.IsScalarEqual(outputCoin.CoinDetails.GetSNDerivator(), sndOutputs[i]) {
+			return NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("output coin %d does not match unsigned tx", i))
+		}
+		sumOutputValue += outputCoin.CoinDetails.GetValue()
+	}
+	sumInputValue := uint64(0)
+	for _, inputCoin := range inputCoins {
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	if sumInputValue != sumOutputValue+component.Fee {
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("values of output coins do not match unsigned tx"))
+	}
+	return nil
+}
+
+// GetThresholdSigningHashes returns hashes which are signed by parties of sender:
+// hash of tx, or hash of PRV tx and hash of token tx for privacy token tx
+func GetThresholdSigningHashes(tx metadata.Transaction) ([]*common.Hash, error) {
+	switch typedTx := tx.(type) {
+	case *Tx:
+		return []*common.Hash{typedTx.Hash()}, nil
+	case *TxCustomTokenPrivacy:
+		return []*common.Hash{typedTx.Tx.Hash(), typedTx.TxPrivacyTokenData.TxNormal.Hash()}, nil
+	}
+	return nil, NewTransactionErr(ThresholdTxInvalidError, errors.New("unexpected tx type"))
+}
+
+// SetThresholdSignatures sets signatures aggregated from signers, in order of GetThresholdSigningHashes
+func SetThresholdSignatures(tx metadata.Transaction, signatures []*privacy.SchnSignature) error {
+	var txs []*Tx
+	switch typedTx := tx.(type) {
+	case *Tx:
+		txs = []*Tx{typedTx}
+	case *TxCustomTokenPrivacy:
+		txs = []*Tx{&typedTx.Tx, &typedTx.TxPrivacyTokenData.TxNormal}
+	default:
+		return NewTransactionErr(ThresholdTxInvalidError, errors.New("unexpected tx type"))
+	}
+	if len(signatures) != len(txs) {
+		return NewTransactionErr(ThresholdTxInvalidError, fmt.Errorf("expect %d signatures, got %d", len(txs), len(signatures)))
+	}
+	for i, signature := range signatures {
+		txs[i].Sig = signature.Bytes()
+		if valid, err := txs[i].verifySigTx(); !valid {
+			return NewTransactionErr(VerifyTxSigFailError, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumbernoprivacy"
+	"github.com/stretchr/testify/assert"
+)
+
+// thresholdParties are shares of a 2-of-3 threshold key with its group commitments
+type thresholdParties struct {
+	threshold        int
+	shares           []*privacy.Scalar
+	groupCommitments []*privacy.Point
+}
+
+func newThresholdParties(t *testing.T, threshold int, numParties int) *thresholdParties {
+	parties := &thresholdParties{threshold: threshold, shares: make([]*privacy.Scalar, numParties)}
+	commitmentsList := make([][]*privacy.Point, 0)
+	for j := range parties.shares {
+		parties.shares[j] = new(privacy.Scalar).FromUint64(0)
+	}
+	for i := 0; i < numParties; i++ {
+		dealing, err := privacy.NewThresholdDealing(nil, threshold, numParties)
+		assert.Nil(t, err)
+		commitmentsList = append(commitmentsList, dealing.Commitments)
+		for j := range parties.shares {
+			parties.shares[j].Add(parties.shares[j], dealing.Shares[j])
+		}
+	}
+	var err error
+	parties.groupCommitments, err = privacy.SumThresholdCommitments(commitmentsList)
+	assert.Nil(t, err)
+	return parties
+}
+
+// serialNumber computes serial number of coin with snd by masked inversion among all parties
+func (parties *thresholdParties) serialNumber(t *testing.T, snd *privacy.Scalar) *privacy.Point {
+	numParties := len(parties.shares)
+	products := make(map[uint64]*privacy.Scalar)
+	rCommitments := make([][]*privacy.Point, 0)
+	rShares := make([]*privacy.Scalar, numParties)
+	zeroShares := make([]*privacy.Scalar, numParties)
+	for j := 0; j < numParties; j++ {
+		rShares[j] = new(privacy.Scalar).FromUint64(0)
+		zeroShares[j] = new(privacy.Scalar).FromUint64(0)
+	}
+	for i := 0; i < numParties; i++ {
+		rDealing, err := privacy.NewThresholdDealing(nil, parties.threshold, numParties)
+		assert.Nil(t, err)
+		zeroDealing, err := privacy.NewThresholdDealing(new(privacy.Scalar).FromUint64(0), 2*parties.threshold-1, numParties)
+		assert.Nil(t, err)
+		rCommitments = append(rCommitments, rDealing.Commitments)
+		for j := 0; j < numParties; j++ {
+			rShares[j].Add(rShares[j], rDealing.Shares[j])
+			zeroShares[j].Add(zeroShares[j], zeroDealing.Shares[j])
+		}
+	}
+	for j := 0; j < numParties; j++ {
+		products[uint64(j+1)] = privacy.ThresholdInverseProduct(rShares[j], parties.shares[j], snd, zeroShares[j])
+	}
+	groupRCommitments, err := privacy.SumThresholdCommitments(rCommitments)
+	assert.Nil(t, err)
+	serialNumber, err := privacy.CombineThresholdInverse(products, parties.threshold, groupRCommitments[0])
+	assert.Nil(t, err)
+	return serialNumber
+}
+
+// prove runs a threshold proof of knowledge of private key on bases with signers,
+// it returns group nonces and responses of signers to challenge computed from group nonces
+func (parties *thresholdParties) prove(t *testing.T, signers []uint64, msg []byte, bases []*privacy.Point, challenge func([]*privacy.Point) *privacy.Scalar) ([]*privacy.Point, *privacy.Scalar, []*privacy.Scalar) {
+	nonces := make(map[uint64]*privacy.ThresholdNonce)
+	commitments := make(map[uint64]*privacy.ThresholdNonceCommitment)
+	for _, index := range signers {
+		nonces[index], commitments[index] = privacy.NewThresholdNonce(bases)
+	}
+	groupNonces, bindingFactors, err := privacy.ThresholdGroupNonce(msg, len(bases), commitments)
+	assert.Nil(t, err)
+	c := challenge(groupNonces)
+	responses := make([]*privacy.Scalar, 0)
+	for _, index := range signers {
+		lagrange, err := privacy.ThresholdLagrangeCoefficient(index, signers)
+		assert.Nil(t, err)
+		responses = append(responses, nonces[index].Response(bindingFactors[index], c, lagrange, parties.shares[index-1]))
+	}
+	return groupNonces, c, responses
+}
+
+func TestThresholdTx(t *testing.T) {
+	parties := newThresholdParties(t, 2, 3)
+	signers := []uint64{1, 3}
+	groupPk := parties.groupCommitments[0]
+	senderAddress := privacy.PaymentAddress{
+		Pk: groupPk.ToBytesS(),
+		Tk: new(privacy.Point).ScalarMultBase(privacy.RandomScalar()).ToBytesS(),
+	}
+
+	inputCoins := make([]*privacy.InputCoin, 2)
+	for i := range inputCoins {
+		coin := new(privacy.Coin)
+		coin.SetPublicKey(groupPk)
+		coin.SetValue(1000)
+		coin.SetSNDerivator(privacy.RandomScalar())
+		coin.SetRandomness(privacy.RandomScalar())
+		assert.Nil(t, coin.CommitAll())
+		inputCoins[i] = &privacy.InputCoin{CoinDetails: coin}
+	}
+	receiver := privacy.GeneratePaymentAddress(privacy.GeneratePrivateKey([]byte{1}))
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiver, Amount: 1500}}
+	sndOutputs := []*privacy.Scalar{privacy.RandomScalar(), privacy.RandomScalar()}
+	unsignedTx := UnsignedTx{
+		Type:     common.TxNormalType,
+		SenderPk: senderAddress.Pk,
+		LockTime: 1,
+		PRV:      NewUnsignedTxComponent(false, 100, paymentInfos, inputCoins, nil, nil, nil, sndOutputs),
+	}
+
+	thresholdInputCoins, err := unsignedTx.GetThresholdInputCoins()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(thresholdInputCoins))
+	proofs := make([]*serialnumbernoprivacy.SNNoPrivacyProof, 0)
+	for _, inputCoin := range thresholdInputCoins[0] {
+		snd := inputCoin.CoinDetails.GetSNDerivator()
+		serialNumber := parties.serialNumber(t, snd)
+		groupNonces, _, responses := parties.prove(t, signers, serialNumber.ToBytesS(),
+			[]*privacy.Point{privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], serialNumber},
+			func(groupNonces []*privacy.Point) *privacy.Scalar {
+				return serialnumbernoprivacy.ThresholdChallenge(groupNonces[0], groupNonces[1])
+			})
+		proofs = append(proofs, serialnumbernoprivacy.NewThresholdSNNoPrivacyProof(serialNumber, groupPk, snd, groupNonces[0], groupNonces[1], responses))
+	}
+
+	tx, err := unsignedTx.BuildThresholdTx(senderAddress, [][]*serialnumbernoprivacy.SNNoPrivacyProof{proofs})
+	assert.Nil(t, err)
+	txBytes, err := json.Marshal(tx)
+	assert.Nil(t, err)
+	builtTx := new(Tx)
+	assert.Nil(t, json.Unmarshal(txBytes, builtTx))
+	assert.Nil(t, unsignedTx.CheckThresholdTx(builtTx, senderAddress))
+
+	hashes, err := GetThresholdSigningHashes(builtTx)
+	assert.Nil(t, err)
+	signatures := make([]*privacy.SchnSignature, len(hashes))
+	for i, hash := range hashes {
+		var e *privacy.Scalar
+		_, _, responses := parties.prove(t, signers, hash[:], []*privacy.Point{privacy.PedCom.G[privacy.PedersenPrivateKeyIndex]},
+			func(groupNonces []*privacy.Point) *privacy.Scalar {
+				e, err = privacy.ThresholdSchnorrChallenge(groupNonces[0], hash[:])
+				assert.Nil(t, err)
+				return new(privacy.Scalar).Sub(new(privacy.Scalar).FromUint64(0), e)
+			})
+		signatures[i] = privacy.NewThresholdSchnSignature(e, responses)
+	}
+	assert.Nil(t, SetThresholdSignatures(builtTx, signatures))
+	shardID := common.GetShardIDFromLastByte(builtTx.PubKeyLastByteSender)
+	valid, err := builtTx.Proof.Verify(false, builtTx.SigPubKey, builtTx.Fee, nil, shardID, &common.PRVCoinID, false)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, uint64(400), builtTx.Proof.GetOutputCoins()[1].CoinDetails.GetValue())
+
+	// signers do not sign a tx which pays others than unsigned tx
+	builtTx.Proof.GetOutputCoins()[0].CoinDetails.SetValue(1400)
+	assert.NotNil(t, unsignedTx.CheckThresholdTx(builtTx, senderAddress))
+	assert.NotNil(t, SetThresholdSignatures(builtTx, signatures[:0]))
+}
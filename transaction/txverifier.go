@@ -0,0 +1,133 @@
+package transaction
+
+import (
+	"encoding/json"
+	"runtime"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+const defaultVerifiedTxCacheSize = 10000
+
+// DefaultTxVerifier is shared by mempool and shard chain,
+// so txs verified when they are accepted into mempool are not fully verified again when the block including them arrives
+var DefaultTxVerifier = NewTxVerifier(runtime.NumCPU(), defaultVerifiedTxCacheSize)
+
+// TxVerifier verifies txs in parallel by a pool of workers,
+// and remembers txs which were verified against some state roots in a bounded cache
+type TxVerifier struct {
+	numWorkers  int
+	verifiedTxs *lru.Cache
+}
+
+func NewTxVerifier(numWorkers int, cacheSize int) *TxVerifier {
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+	verifiedTxs, _ := lru.New(cacheSize)
+	return &TxVerifier{
+		numWorkers:  numWorkers,
+		verifiedTxs: verifiedTxs,
+	}
+}
+
+func (v *TxVerifier) GetNumWorkers() int {
+	return v.numWorkers
+}
+
+// verifiedTxKey is hash of the fully serialized tx and all state roots which the verification of tx relies upon,
+// tx hash is not used since it does not cover the signature, the signing public key and the info of tx
+func verifiedTxKey(tx metadata.Transaction, stateRoots []common.Hash) (common.Hash, error) {
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txBytesHash := common.HashH(txBytes)
+	data := make([]byte, 0, common.HashSize*(len(stateRoots)+1))
+	data = append(data, txBytesHash[:]...)
+	for _, root := range stateRoots {
+		data = append(data, root[:]...)
+	}
+	return common.HashH(data), nil
+}
+
+// IsVerified returns true if the same tx was verified against the same state roots
+func (v *TxVerifier) IsVerified(tx metadata.Transaction, stateRoots ...common.Hash) bool {
+	if len(stateRoots) == 0 {
+		return false
+	}
+	key, err := verifiedTxKey(tx, stateRoots)
+	if err != nil {
+		return false
+	}
+	return v.verifiedTxs.Contains(key)
+}
+
+// MarkVerified records that tx was fully verified against state roots
+func (v *TxVerifier) MarkVerified(tx metadata.Transaction, stateRoots ...common.Hash) {
+	if len(stateRoots) == 0 {
+		return
+	}
+	key, err := verifiedTxKey(tx, stateRoots)
+	if err != nil {
+		return
+	}
+	v.verifiedTxs.Add(key, struct{}{})
+}
+
+// Verify runs verifyFunc for indices from 0 to numItems-1 by the pool of workers,
+// worker is the id of worker which runs the function, so each worker can keep its own resources (e.g. copied state db).
+// It returns index of the first invalid item, the remaining items are skipped as soon as an invalid item is found
+func (v *TxVerifier) Verify(numItems int, verifyFunc func(worker int, index int) (bool, error)) (bool, error, int) {
+	if numItems == 0 {
+		return true, nil, -1
+	}
+	numWorkers := v.numWorkers
+	if numWorkers > numItems {
+		numWorkers = numItems
+	}
+	var mtx sync.Mutex
+	failedIndex := -1
+	var failedErr error
+	isFailed := func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return failedIndex >= 0
+	}
+
+	jobs := make(chan int, numItems)
+	for i := 0; i < numItems; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < numWorkers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for index := range jobs {
+				if isFailed() {
+					return
+				}
+				ok, err := verifyFunc(worker, index)
+				if !ok {
+					mtx.Lock()
+					if failedIndex < 0 || index < failedIndex {
+						failedIndex = index
+						failedErr = err
+					}
+					mtx.Unlock()
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if failedIndex >= 0 {
+		return false, failedErr, failedIndex
+	}
+	return true, nil, -1
+}
@@ -0,0 +1,62 @@
+package transaction
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxVerifierCache(t *testing.T) {
+	verifier := NewTxVerifier(2, 2)
+	tx := &Tx{Version: 1, Type: common.TxNormalType, Fee: 10, SigPubKey: []byte{1}, Sig: []byte{1}}
+	root := common.HashH([]byte{2})
+	otherRoot := common.HashH([]byte{3})
+
+	assert.False(t, verifier.IsVerified(tx, root))
+	verifier.MarkVerified(tx, root)
+	assert.True(t, verifier.IsVerified(tx, root))
+	assert.False(t, verifier.IsVerified(tx, otherRoot))
+	assert.False(t, verifier.IsVerified(tx))
+
+	// a tx with the same hash but a different signature, signing public key or info is not verified
+	for _, mutatedTx := range []*Tx{
+		{Version: 1, Type: common.TxNormalType, Fee: 10, SigPubKey: []byte{1}, Sig: []byte{2}},
+		{Version: 1, Type: common.TxNormalType, Fee: 10, SigPubKey: []byte{2}, Sig: []byte{1}},
+		{Version: 1, Type: common.TxNormalType, Fee: 10, SigPubKey: []byte{1}, Sig: []byte{1}, Info: []byte{1}},
+	} {
+		assert.Equal(t, tx.Hash(), mutatedTx.Hash())
+		assert.False(t, verifier.IsVerified(mutatedTx, root))
+	}
+
+	// cache is bounded, the oldest tx is evicted
+	verifier.MarkVerified(&Tx{Version: 1, Fee: 4}, root)
+	verifier.MarkVerified(&Tx{Version: 1, Fee: 5}, root)
+	assert.False(t, verifier.IsVerified(tx, root))
+}
+
+func TestTxVerifierVerify(t *testing.T) {
+	verifier := NewTxVerifier(4, 10)
+	var count int32
+	ok, err, index := verifier.Verify(100, func(worker int, index int) (bool, error) {
+		assert.True(t, worker >= 0 && worker < 4)
+		atomic.AddInt32(&count, 1)
+		return true, nil
+	})
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, index)
+	assert.Equal(t, int32(100), count)
+
+	ok, err, index = verifier.Verify(100, func(worker int, index int) (bool, error) {
+		if index == 30 || index == 70 {
+			return false, errors.New("invalid")
+		}
+		return true, nil
+	})
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+	assert.True(t, index == 30 || index == 70)
+}
@@ -0,0 +1,315 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// UnsignedTxComponent contains data to prove and sign a transfer of one kind of coin (PRV or privacy token)
+// All data which needs the blockchain (input coins, random commitments, output snds) is chosen by a node,
+// so the component can be proved with sender's private key on a machine without blockchain
+type UnsignedTxComponent struct {
+	HasPrivacy          bool                   `json:"HasPrivacy"`
+	Fee                 uint64                 `json:"Fee"`
+	PaymentInfos        []*privacy.PaymentInfo `json:"PaymentInfos"`
+	InputCoins          [][]byte               `json:"InputCoins"` // bytes of decrypted input coins
+	CommitmentIndices   []uint64               `json:"CommitmentIndices"`
+	Commitments         [][]byte               `json:"Commitments"`
+	MyCommitmentIndices []uint64               `json:"MyCommitmentIndices"`
+	SNDOutputs          [][]byte               `json:"SNDOutputs"` // one snd for each payment info and change output
+}
+
+// UnsignedTokenData contains privacy token transfer data of unsigned tx
+type UnsignedTokenData struct {
+	PropertyID     string `json:"PropertyID"`
+	PropertyName   string `json:"PropertyName"`
+	PropertySymbol string `json:"PropertySymbol"`
+	Mintable       bool   `json:"Mintable"`
+	UnsignedTxComponent
+}
+
+// UnsignedTx is a transaction package built by a node without sender's private key
+// It is proved and signed offline by Sign, then the result is broadcasted by sendtransaction
+// (or sendrawprivacycustomtokentransaction for privacy token tx)
+type UnsignedTx struct {
+	Type      string              `json:"Type"`
+	SenderPk  []byte              `json:"SenderPk"`
+	LockTime  int64               `json:"LockTime"`
+	Info      []byte              `json:"Info"`
+	Metadata  metadata.Metadata   `json:"Metadata"`
+	PRV       UnsignedTxComponent `json:"PRV"`
+	TokenData *UnsignedTokenData  `json:"TokenData,omitempty"`
+}
+
+func (unsignedTx *UnsignedTx) UnmarshalJSON(data []byte) error {
+	type Alias UnsignedTx
+	temp := &struct {
+		Metadata *json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(unsignedTx),
+	}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return NewTransactionErr(UnsignedTxInvalidDataError, err)
+	}
+	if temp.Metadata == nil {
+		unsignedTx.Metadata = nil
+		return nil
+	}
+	meta, parseErr := metadata.ParseMetadata(temp.Metadata)
+	if parseErr != nil {
+		return parseErr
+	}
+	unsignedTx.Metadata = meta
+	return nil
+}
+
+// NewUnsignedTxComponent returns component with encoded input coins, random commitments and output snds
+func NewUnsignedTxComponent(
+	hasPrivacy bool,
+	fee uint64,
+	paymentInfos []*privacy.PaymentInfo,
+	inputCoins []*privacy.InputCoin,
+	commitmentIndices []uint64,
+	commitments [][]byte,
+	myCommitmentIndices []uint64,
+	sndOutputs []*privacy.Scalar) UnsignedTxComponent {
+	component := UnsignedTxComponent{
+		HasPrivacy:          hasPrivacy,
+		Fee:                 fee,
+		PaymentInfos:        paymentInfos,
+		InputCoins:          make([][]byte, len(inputCoins)),
+		CommitmentIndices:   commitmentIndices,
+		Commitments:         commitments,
+		MyCommitmentIndices: myCommitmentIndices,
+		SNDOutputs:          make([][]byte, len(sndOutputs)),
+	}
+	for i, inputCoin := range inputCoins {
+		component.InputCoins[i] = inputCoin.Bytes()
+	}
+	for i, snd := range sndOutputs {
+		component.SNDOutputs[i] = snd.ToBytesS()
+	}
+	return component
+}
+
+// parse decodes input coins and output snds of component
+// serial numbers of input coins are derived from senderSK, they are not known by the node which built the tx
+func (component UnsignedTxComponent) parse(senderSK *privacy.PrivateKey) ([]*privacy.InputCoin, []*privacy.Scalar, error) {
+	inputCoins, err := component.decodeInputCoins()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, inputCoin := range inputCoins {
+		inputCoin.CoinDetails.SetSerialNumber(
+			new(privacy.Point).Derive(
+				privacy.PedCom.G[privacy.PedersenPrivateKeyIndex],
+				new(privacy.Scalar).FromBytesS(*senderSK),
+				inputCoin.CoinDetails.GetSNDerivator()))
+	}
+	sndOutputs, err := component.decodeSNDOutputs(inputCoins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputCoins, sndOutputs, nil
+}
+
+// decodeInputCoins decodes input coins of component, their serial numbers are not set
+func (component UnsignedTxComponent) decodeInputCoins() ([]*privacy.InputCoin, error) {
+	inputCoins := make([]*privacy.InputCoin, len(component.InputCoins))
+	for i, inputCoinBytes := range component.InputCoins {
+		inputCoins[i] = new(privacy.InputCoin).Init()
+		err := inputCoins[i].SetBytes(inputCoinBytes)
+		if err != nil {
+			return nil, NewTransactionErr(UnsignedTxInvalidDataError, err)
+		}
+		if inputCoins[i].CoinDetails.GetSNDerivator() == nil {
+			return nil, NewTransactionErr(UnsignedTxInvalidDataError, fmt.Errorf("input coin %d has no snd", i))
+		}
+	}
+	return inputCoins, nil
+}
+
+// decodeSNDOutputs decodes output snds of component, there is one snd for each payment info and for change output
+func (component UnsignedTxComponent) decodeSNDOutputs(inputCoins []*privacy.InputCoin) ([]*privacy.Scalar, error) {
+	sumInputValue := uint64(0)
+	for _, inputCoin := range inputCoins {
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, paymentInfo := range component.PaymentInfos {
+		sumOutputValue += paymentInfo.Amount
+	}
+	if sumInputValue < sumOutputValue+component.Fee {
+		return nil, NewTransactionErr(UnsignedTxInvalidDataError, fmt.Errorf("input value %d less than output value %d and fee %d", sumInputValue, sumOutputValue, component.Fee))
+	}
+	numOutputs := len(component.PaymentInfos)
+	if sumInputValue > sumOutputValue+component.Fee {
+		numOutputs++
+	}
+	if len(component.SNDOutputs) != numOutputs {
+		return nil, NewTransactionErr(UnsignedTxInvalidDataError, fmt.Errorf("expect %d output snds, got %d", numOutputs, len(component.SNDOutputs)))
+	}
+	sndOutputs := make([]*privacy.Scalar, len(component.SNDOutputs))
+	for i, sndBytes := range component.SNDOutputs {
+		if len(sndBytes) != common.HashSize {
+			return nil, NewTransactionErr(UnsignedTxInvalidDataError, errors.New("invalid output snd"))
+		}
+		sndOutputs[i] = new(privacy.Scalar).FromBytesS(sndBytes)
+	}
+	return sndOutputs, nil
+}
+
+// UnsignedTxOutput is a payment of unsigned tx, TokenID is empty for a PRV payment
+type UnsignedTxOutput struct {
+	PaymentAddress privacy.PaymentAddress
+	Amount         uint64
+	TokenID        string
+}
+
+// Outputs lists payments of unsigned tx, PRV payments first, change outputs are not listed
+func (unsignedTx UnsignedTx) Outputs() []UnsignedTxOutput {
+	outputs := []UnsignedTxOutput{}
+	for _, paymentInfo := range unsignedTx.PRV.PaymentInfos {
+		outputs = append(outputs, UnsignedTxOutput{PaymentAddress: paymentInfo.PaymentAddress, Amount: paymentInfo.Amount})
+	}
+	if unsignedTx.TokenData != nil {
+		for _, paymentInfo := range unsignedTx.TokenData.PaymentInfos {
+			outputs = append(outputs, UnsignedTxOutput{PaymentAddress: paymentInfo.PaymentAddress, Amount: paymentInfo.Amount, TokenID: unsignedTx.TokenData.PropertyID})
+		}
+	}
+	return outputs
+}
+
+// CheckExpected returns error if payments of unsigned tx are not exactly expectedOutputs (in any order),
+// if the fee of PRV or of privacy token is greater than maxFee,
+// or if type of metadata is not expectedMetadataType (0 for a tx without metadata)
+// Sign does not check anything the node put into unsigned tx, so the sender checks it before signing
+func (unsignedTx UnsignedTx) CheckExpected(expectedOutputs []UnsignedTxOutput, maxFee uint64, expectedMetadataType int) error {
+	remainingOutputs := make([]*UnsignedTxOutput, len(expectedOutputs))
+	for i := range expectedOutputs {
+		remainingOutputs[i] = &expectedOutputs[i]
+	}
+	for _, output := range unsignedTx.Outputs() {
+		found := false
+		for i, expectedOutput := range remainingOutputs {
+			if expectedOutput != nil && expectedOutput.Amount == output.Amount && expectedOutput.TokenID == output.TokenID &&
+				bytes.Equal(expectedOutput.PaymentAddress.Bytes(), output.PaymentAddress.Bytes()) {
+				remainingOutputs[i] = nil
+				found = true
+				break
+			}
+		}
+		if !found {
+			return NewTransactionErr(UnsignedTxUnexpectedError, fmt.Errorf("unexpected payment of %d to %x", output.Amount, output.PaymentAddress.Pk))
+		}
+	}
+	for _, expectedOutput := range remainingOutputs {
+		if expectedOutput != nil {
+			return NewTransactionErr(UnsignedTxUnexpectedError, fmt.Errorf("missing payment of %d to %x", expectedOutput.Amount, expectedOutput.PaymentAddress.Pk))
+		}
+	}
+	if unsignedTx.PRV.Fee > maxFee {
+		return NewTransactionErr(UnsignedTxUnexpectedError, fmt.Errorf("fee %d is greater than %d", unsignedTx.PRV.Fee, maxFee))
+	}
+	if unsignedTx.TokenData != nil && unsignedTx.TokenData.Fee > maxFee {
+		return NewTransactionErr(UnsignedTxUnexpectedError, fmt.Errorf("token fee %d is greater than %d", unsignedTx.TokenData.Fee, maxFee))
+	}
+	metadataType := 0
+	if unsignedTx.Metadata != nil {
+		metadataType = unsignedTx.Metadata.GetType()
+	}
+	if metadataType != expectedMetadataType {
+		return NewTransactionErr(UnsignedTxUnexpectedError, fmt.Errorf("metadata type %d is not %d", metadataType, expectedMetadataType))
+	}
+	return nil
+}
+
+// Sign proves and signs unsigned tx with sender's private key
+// It returns *Tx for PRV tx or *TxCustomTokenPrivacy for privacy token tx,
+// payments, fees and metadata are signed as they are, they are checked by the sender, see CheckExpected
+func (unsignedTx UnsignedTx) Sign(senderSK *privacy.PrivateKey) (metadata.Transaction, error) {
+	senderKeySet := incognitokey.KeySet{}
+	err := senderKeySet.InitFromPrivateKey(senderSK)
+	if err != nil {
+		return nil, NewTransactionErr(PrivateKeySenderInvalidError, err)
+	}
+	if !bytes.Equal(senderKeySet.PaymentAddress.Pk, unsignedTx.SenderPk) {
+		return nil, NewTransactionErr(UnsignedTxSenderMismatchError, nil)
+	}
+
+	inputCoins, sndOutputs, err := unsignedTx.PRV.parse(senderSK)
+	if err != nil {
+		return nil, err
+	}
+
+	if unsignedTx.TokenData == nil {
+		tx := new(Tx)
+		err = tx.InitForASM(NewTxPrivacyInitParamsForASM(
+			senderSK,
+			unsignedTx.PRV.PaymentInfos,
+			inputCoins,
+			unsignedTx.PRV.Fee,
+			unsignedTx.PRV.HasPrivacy,
+			nil,
+			unsignedTx.Metadata,
+			unsignedTx.Info,
+			unsignedTx.PRV.CommitmentIndices,
+			unsignedTx.PRV.Commitments,
+			unsignedTx.PRV.MyCommitmentIndices,
+			sndOutputs,
+		), unsignedTx.LockTime)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+
+	tokenInputCoins, tokenSNDOutputs, err := unsignedTx.TokenData.parse(senderSK)
+	if err != nil {
+		return nil, err
+	}
+	tokenParams := &CustomTokenPrivacyParamTx{
+		PropertyID:     unsignedTx.TokenData.PropertyID,
+		PropertyName:   unsignedTx.TokenData.PropertyName,
+		PropertySymbol: unsignedTx.TokenData.PropertySymbol,
+		TokenTxType:    CustomTokenTransfer,
+		Mintable:       unsignedTx.TokenData.Mintable,
+		Receiver:       unsignedTx.TokenData.PaymentInfos,
+		TokenInput:     tokenInputCoins,
+		Fee:            unsignedTx.TokenData.Fee,
+	}
+	tx := new(TxCustomTokenPrivacy)
+	err = tx.InitForASM(NewTxPrivacyTokenInitParamsForASM(
+		senderSK,
+		unsignedTx.PRV.PaymentInfos,
+		inputCoins,
+		unsignedTx.PRV.Fee,
+		tokenParams,
+		unsignedTx.Metadata,
+		unsignedTx.PRV.HasPrivacy,
+		unsignedTx.TokenData.HasPrivacy,
+		common.GetShardIDFromLastByte(senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]),
+		unsignedTx.Info,
+		unsignedTx.PRV.CommitmentIndices,
+		unsignedTx.PRV.Commitments,
+		unsignedTx.PRV.MyCommitmentIndices,
+		sndOutputs,
+		unsignedTx.TokenData.CommitmentIndices,
+		unsignedTx.TokenData.Commitments,
+		unsignedTx.TokenData.MyCommitmentIndices,
+		tokenSNDOutputs,
+	), unsignedTx.LockTime)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,48 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsignedTxCheckExpected(t *testing.T) {
+	receiver1 := privacy.GeneratePaymentAddress(privacy.GeneratePrivateKey([]byte{1}))
+	receiver2 := privacy.GeneratePaymentAddress(privacy.GeneratePrivateKey([]byte{2}))
+	tokenID := common.HashH([]byte("token")).String()
+	unsignedTx := UnsignedTx{
+		PRV: UnsignedTxComponent{
+			Fee:          100,
+			PaymentInfos: []*privacy.PaymentInfo{{PaymentAddress: receiver1, Amount: 1000}},
+		},
+		TokenData: &UnsignedTokenData{
+			PropertyID: tokenID,
+			UnsignedTxComponent: UnsignedTxComponent{
+				PaymentInfos: []*privacy.PaymentInfo{{PaymentAddress: receiver2, Amount: 50}},
+			},
+		},
+	}
+	expectedOutputs := []UnsignedTxOutput{
+		{PaymentAddress: receiver2, Amount: 50, TokenID: tokenID},
+		{PaymentAddress: receiver1, Amount: 1000},
+	}
+	assert.Nil(t, unsignedTx.CheckExpected(expectedOutputs, 100, 0))
+
+	// fee over the limit
+	assert.NotNil(t, unsignedTx.CheckExpected(expectedOutputs, 99, 0))
+	// payment of another amount, to another receiver or of another token
+	assert.NotNil(t, unsignedTx.CheckExpected([]UnsignedTxOutput{expectedOutputs[0], {PaymentAddress: receiver1, Amount: 999}}, 100, 0))
+	assert.NotNil(t, unsignedTx.CheckExpected([]UnsignedTxOutput{expectedOutputs[0], {PaymentAddress: receiver2, Amount: 1000}}, 100, 0))
+	assert.NotNil(t, unsignedTx.CheckExpected([]UnsignedTxOutput{{PaymentAddress: receiver2, Amount: 50}, expectedOutputs[1]}, 100, 0))
+	// missing or extra payments
+	assert.NotNil(t, unsignedTx.CheckExpected(expectedOutputs[:1], 100, 0))
+	assert.NotNil(t, unsignedTx.CheckExpected(append(expectedOutputs, expectedOutputs[1]), 100, 0))
+
+	// metadata is signed only if its type is expected
+	unsignedTx.Metadata = &metadata.WithDrawRewardRequest{MetadataBase: *metadata.NewMetadataBase(metadata.WithDrawRewardRequestMeta)}
+	assert.NotNil(t, unsignedTx.CheckExpected(expectedOutputs, 100, 0))
+	assert.Nil(t, unsignedTx.CheckExpected(expectedOutputs, 100, metadata.WithDrawRewardRequestMeta))
+}
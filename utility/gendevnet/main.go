@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+/*
+gendevnet generates a local devnet: committee keys, network config (with genesis PRV allocations) and
+config files of all nodes. It must be run in the root folder of the repo, as the node does.
+Nodes are started by: ./incognito --configfile <out>/nodes/<node>.conf
+*/
+
+type node struct {
+	name       string
+	privateKey string
+}
+
+func main() {
+	numShards := flag.Int("shards", 2, "number of shards")
+	shardCommitteeSize := flag.Int("shardcommittee", 4, "committee size of each shard")
+	beaconCommitteeSize := flag.Int("beaconcommittee", 4, "committee size of beacon")
+	initPRV := flag.Uint64("initprv", 1000000*1e9, "initial nano PRV of each committee member")
+	bootnode := flag.String("bootnode", "127.0.0.1:9330", "address of discover peers server")
+	outDir := flag.String("out", "devnet", "output folder")
+	name := flag.String("name", "devnet", "network name")
+	flag.Parse()
+
+	if *numShards <= 0 || *numShards > common.MaxShardNumber {
+		log.Fatalf("number of shards must be in [1, %v]", common.MaxShardNumber)
+	}
+	if *shardCommitteeSize < blockchain.MinCommitteeSize || *beaconCommitteeSize < blockchain.MinCommitteeSize {
+		log.Fatalf("committee size must be at least %v", blockchain.MinCommitteeSize)
+	}
+	absOutDir, err := filepath.Abs(*outDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.MkdirAll(filepath.Join(absOutDir, "nodes"), 0755)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	keyList, err := generateKeyList(*numShards, *shardCommitteeSize, *beaconCommitteeSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	initTxs, err := generateInitTxs(keyList, *initPRV)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	netConfig, err := blockchain.NewNetworkConfig(blockchain.TestnetName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	netConfig.Name = *name
+	netConfig.Net = 0x64
+	netConfig.MaxShardCommitteeSize = *shardCommitteeSize
+	netConfig.MinShardCommitteeSize = *shardCommitteeSize
+	netConfig.MaxBeaconCommitteeSize = *beaconCommitteeSize
+	netConfig.MinBeaconCommitteeSize = *beaconCommitteeSize
+	netConfig.MinShardBlockInterval = blockchain.ConfigDuration(10 * time.Second)
+	netConfig.MaxShardBlockCreation = blockchain.ConfigDuration(6 * time.Second)
+	netConfig.MinBeaconBlockInterval = blockchain.ConfigDuration(10 * time.Second)
+	netConfig.MaxBeaconBlockCreation = blockchain.ConfigDuration(8 * time.Second)
+	netConfig.ActiveShards = *numShards
+	netConfig.Epoch = 100
+	netConfig.RandomTime = 50
+	netConfig.Genesis.BlockTime = time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	netConfig.Genesis.KeyListFile = "keylist.json"
+	netConfig.Genesis.InitialIncognito = initTxs
+	err = writeJSON(filepath.Join(absOutDir, "keylist.json"), keyList)
+	if err != nil {
+		log.Fatal(err)
+	}
+	netConfigFile := filepath.Join(absOutDir, "network.json")
+	err = writeJSON(netConfigFile, netConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	nodes := []node{}
+	for i, key := range keyList.Beacon {
+		nodes = append(nodes, node{name: fmt.Sprintf("beacon-%v", i), privateKey: key.PrivateKey})
+	}
+	for shardID := 0; shardID < *numShards; shardID++ {
+		for i, key := range keyList.Shard[shardID] {
+			nodes = append(nodes, node{name: fmt.Sprintf("shard%v-%v", shardID, i), privateKey: key.PrivateKey})
+		}
+	}
+	for i, n := range nodes {
+		err = writeNodeConfig(absOutDir, netConfigFile, *bootnode, n, i)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Printf("Generated devnet %v with %v shards and %v nodes in %v\n", *name, *numShards, len(nodes), absOutDir)
+}
+
+// generateKeyList derives committee keys from a random seed, shard keys are picked by shard of their payment address
+func generateKeyList(numShards int, shardCommitteeSize int, beaconCommitteeSize int) (*blockchain.KeyList, error) {
+	seed := make([]byte, 32)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := wallet.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	keyList := &blockchain.KeyList{
+		Shard:  make(map[int][]blockchain.AccountKey),
+		Beacon: []blockchain.AccountKey{},
+	}
+	isEnough := func() bool {
+		if len(keyList.Beacon) < beaconCommitteeSize {
+			return false
+		}
+		for shardID := 0; shardID < numShards; shardID++ {
+			if len(keyList.Shard[shardID]) < shardCommitteeSize {
+				return false
+			}
+		}
+		return true
+	}
+	for i := uint32(0); !isEnough(); i++ {
+		child, err := masterKey.NewChildKey(i)
+		if err != nil {
+			return nil, err
+		}
+		validatorKeyBytes := common.HashB(common.HashB(child.KeySet.PrivateKey))
+		committeeKey, err := incognitokey.NewCommitteeKeyFromSeed(validatorKeyBytes, child.KeySet.PaymentAddress.Pk)
+		if err != nil {
+			return nil, err
+		}
+		committeeKeyStrs, err := incognitokey.CommitteeKeyListToString([]incognitokey.CommitteePublicKey{committeeKey})
+		if err != nil {
+			return nil, err
+		}
+		accountKey := blockchain.AccountKey{
+			PrivateKey:         child.Base58CheckSerialize(wallet.PriKeyType),
+			PaymentAddress:     child.Base58CheckSerialize(wallet.PaymentAddressType),
+			CommitteePublicKey: committeeKeyStrs[0],
+		}
+
+		shardID := int(common.GetShardIDFromLastByte(child.KeySet.PaymentAddress.Pk[len(child.KeySet.PaymentAddress.Pk)-1]))
+		if shardID < numShards && len(keyList.Shard[shardID]) < shardCommitteeSize {
+			keyList.Shard[shardID] = append(keyList.Shard[shardID], accountKey)
+		} else if len(keyList.Beacon) < beaconCommitteeSize {
+			keyList.Beacon = append(keyList.Beacon, accountKey)
+		}
+	}
+	return keyList, nil
+}
+
+// generateInitTxs creates salary txs of initial PRV for all committee members
+func generateInitTxs(keyList *blockchain.KeyList, amount uint64) ([]string, error) {
+	dbPath, err := ioutil.TempDir("", "gendevnet")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, err
+	}
+
+	accountKeys := append([]blockchain.AccountKey{}, keyList.Beacon...)
+	for shardID := 0; shardID < len(keyList.Shard); shardID++ {
+		accountKeys = append(accountKeys, keyList.Shard[shardID]...)
+	}
+	initTxs := []string{}
+	for _, accountKey := range accountKeys {
+		keyWallet, err := wallet.Base58CheckDeserialize(accountKey.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		err = keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		initTx := transaction.Tx{}
+		err = initTx.InitTxSalary(amount, &keyWallet.KeySet.PaymentAddress, &keyWallet.KeySet.PrivateKey, stateDB, nil)
+		if err != nil {
+			return nil, err
+		}
+		initTxBytes, err := json.Marshal(initTx)
+		if err != nil {
+			return nil, err
+		}
+		initTxs = append(initTxs, string(initTxBytes))
+	}
+	return initTxs, nil
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// writeNodeConfig writes the config file of a node, ports of the i-th node are shifted by i
+func writeNodeConfig(outDir string, netConfigFile string, bootnode string, n node, i int) error {
+	options := []string{
+		"[Application Options]",
+		fmt.Sprintf("networkconfig=%v", netConfigFile),
+		fmt.Sprintf("discoverpeersaddress=%v", bootnode),
+		fmt.Sprintf("privatekey=%v", n.privateKey),
+		"nodemode=auto",
+		fmt.Sprintf("datadir=%v", filepath.Join(outDir, "data", n.name)),
+		fmt.Sprintf("logdir=%v", filepath.Join(outDir, "logs", n.name)),
+		fmt.Sprintf("listen=0.0.0.0:%v", 10000+i),
+		fmt.Sprintf("externaladdress=127.0.0.1:%v", 10000+i),
+		"norpcauth=1",
+		fmt.Sprintf("rpclisten=0.0.0.0:%v", 11000+i),
+		fmt.Sprintf("rpcwslisten=0.0.0.0:%v", 12000+i),
+	}
+	return ioutil.WriteFile(filepath.Join(outDir, "nodes", n.name+".conf"), []byte(strings.Join(options, "\n")+"\n"), 0644)
+}
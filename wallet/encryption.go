@@ -23,7 +23,7 @@ func deriveKey(passPhrase string, salt []byte) ([]byte, []byte) {
 // EncryptByPassPhrase receives passphrase and plaintext
 // it generates AES key from passPhrase and encrypt the plaintext
 // it returns encrypted plaintext (ciphertext) in string
-func encryptByPassPhrase(passphrase string, plaintext []byte) (string, error) {
+func EncryptByPassPhrase(passphrase string, plaintext []byte) (string, error) {
 	if len(plaintext) == 0 {
 		return common.EmptyString, NewWalletError(InvalidPlaintextErr, nil)
 	}
@@ -51,7 +51,7 @@ func encryptByPassPhrase(passphrase string, plaintext []byte) (string, error) {
 // DecryptByPassPhrase receives passPhrase and ciphertext (in hex encode to string)
 // it generates AES key from passPhrase and decrypt the ciphertext
 // and returns plain text in bytes array
-func decryptByPassPhrase(passPhrase string, cipherText string) ([]byte, error) {
+func DecryptByPassPhrase(passPhrase string, cipherText string) ([]byte, error) {
 	arr := strings.Split(cipherText, "-")
 
 	salt, err := hex.DecodeString(arr[0])
@@ -48,13 +48,13 @@ func TestEncryptionEncryptByPassPhrase(t *testing.T) {
 	passPhrase := "123"
 	plaintext := []byte{1, 2, 3, 4}
 
-	ciphertextStr, err := encryptByPassPhrase(passPhrase, plaintext)
+	ciphertextStr, err := EncryptByPassPhrase(passPhrase, plaintext)
 	fmt.Println("ciphertextStr : ", ciphertextStr)
 
 	assert.Equal(t, nil, err)
 	assert.Greater(t, len(ciphertextStr), 0)
 
-	plaintext2, err := decryptByPassPhrase(passPhrase, ciphertextStr)
+	plaintext2, err := DecryptByPassPhrase(passPhrase, ciphertextStr)
 	assert.Equal(t, plaintext, plaintext2)
 }
 
@@ -62,13 +62,13 @@ func TestEncryptionEncryptByPassPhraseWithEmptyPassPhrase(t *testing.T) {
 	passPhrase := ""
 	plaintext := []byte{1, 2, 3, 4}
 
-	ciphertextStr, err := encryptByPassPhrase(passPhrase, plaintext)
+	ciphertextStr, err := EncryptByPassPhrase(passPhrase, plaintext)
 	fmt.Println("ciphertextStr : ", ciphertextStr)
 
 	assert.Equal(t, nil, err)
 	assert.Greater(t, len(ciphertextStr), 0)
 
-	plaintext2, err := decryptByPassPhrase(passPhrase, ciphertextStr)
+	plaintext2, err := DecryptByPassPhrase(passPhrase, ciphertextStr)
 	assert.Equal(t, plaintext, plaintext2)
 }
 
@@ -76,7 +76,7 @@ func TestEncryptionEncryptByPassPhraseWithEmptyPlaintext(t *testing.T) {
 	passPhrase := "123"
 	plaintext := []byte{}
 
-	ciphertextStr, err := encryptByPassPhrase(passPhrase, plaintext)
+	ciphertextStr, err := EncryptByPassPhrase(passPhrase, plaintext)
 
 	assert.Equal(t, NewWalletError(InvalidPlaintextErr, nil), err)
 	assert.Equal(t, "", ciphertextStr)
@@ -89,10 +89,10 @@ func TestEncryptionEncryptByPassPhraseWithEmptyPlaintext(t *testing.T) {
 func TestEncryptionDecryptByPassPhraseWithUnmatchedPass(t *testing.T) {
 	passPhrase := "123"
 	plaintext := []byte{1, 2, 3, 4}
-	ciphertextStr, _ := encryptByPassPhrase(passPhrase, plaintext)
+	ciphertextStr, _ := EncryptByPassPhrase(passPhrase, plaintext)
 
 	passPhrase2 := "1234"
-	plaintext2, err := decryptByPassPhrase(passPhrase2, ciphertextStr)
+	plaintext2, err := DecryptByPassPhrase(passPhrase2, ciphertextStr)
 
 	assert.NotEqual(t, plaintext, plaintext2)
 	assert.Equal(t, nil, err)
@@ -102,7 +102,7 @@ func TestEncryptionDecryptByPassPhraseWithInvalidCipher(t *testing.T) {
 	passPhrase := "123"
 	ciphertextStr := "ciphertextabc"
 
-	_, err := decryptByPassPhrase(passPhrase, ciphertextStr)
+	_, err := DecryptByPassPhrase(passPhrase, ciphertextStr)
 
 	assert.NotEqual(t, nil, err)
 }
@@ -26,6 +26,10 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	WatchOnlyAccountErr
+	MismatchedReadonlyKeyErr
+	ExistedPortfolioErr
+	NotFoundPortfolioErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -34,25 +38,29 @@ var ErrCodeMessage = map[int]struct {
 }{
 	UnexpectedErr: {-1, "Unexpected error"},
 
-	InvalidChecksumErr:     {-1000, "Checksum does not match"},
-	WrongPassphraseErr:     {-1001, "Wrong passphrase"},
-	ExistedAccountErr:      {-1002, "Existed account"},
-	ExistedAccountNameErr:  {-1002, "Existed account name"},
-	EmptyWalletNameErr:     {-1003, "Wallet name is empty"},
-	NotFoundAccountErr:     {-1004, "Account wallet is not found"},
-	JsonMarshalErr:         {-1005, "Can not json marshal"},
-	JsonUnmarshalErr:       {-1006, "Can not json unmarshal"},
-	WriteFileErr:           {-1007, "Can not write file"},
-	ReadFileErr:            {-1008, "Can not read file"},
-	AESEncryptErr:          {-1009, "Can not AES encrypt data"},
-	AESDecryptErr:          {-1010, "Can not AES decrypt data"},
-	InvalidKeyTypeErr:      {-1011, "Serialized key type is invalid"},
-	InvalidPlaintextErr:    {-1012, "Plaintext is invalid"},
-	NewChildKeyError:       {-1013, "Can not create new child key"},
-	NewEntropyError:        {-1014, "Can not create entropy"},
-	NewMnemonicError:       {-1015, "Can not create mnemonic"},
-	MnemonicInvalidError:   {-1016, "Mnemonic is invalid"},
-	InvalidSeserializedKey: {-1016, "Serialized key is invalid"},
+	InvalidChecksumErr:       {-1000, "Checksum does not match"},
+	WrongPassphraseErr:       {-1001, "Wrong passphrase"},
+	ExistedAccountErr:        {-1002, "Existed account"},
+	ExistedAccountNameErr:    {-1002, "Existed account name"},
+	EmptyWalletNameErr:       {-1003, "Wallet name is empty"},
+	NotFoundAccountErr:       {-1004, "Account wallet is not found"},
+	JsonMarshalErr:           {-1005, "Can not json marshal"},
+	JsonUnmarshalErr:         {-1006, "Can not json unmarshal"},
+	WriteFileErr:             {-1007, "Can not write file"},
+	ReadFileErr:              {-1008, "Can not read file"},
+	AESEncryptErr:            {-1009, "Can not AES encrypt data"},
+	AESDecryptErr:            {-1010, "Can not AES decrypt data"},
+	InvalidKeyTypeErr:        {-1011, "Serialized key type is invalid"},
+	InvalidPlaintextErr:      {-1012, "Plaintext is invalid"},
+	NewChildKeyError:         {-1013, "Can not create new child key"},
+	NewEntropyError:          {-1014, "Can not create entropy"},
+	NewMnemonicError:         {-1015, "Can not create mnemonic"},
+	MnemonicInvalidError:     {-1016, "Mnemonic is invalid"},
+	InvalidSeserializedKey:   {-1016, "Serialized key is invalid"},
+	WatchOnlyAccountErr:      {-1017, "Account is watch-only and can not spend"},
+	MismatchedReadonlyKeyErr: {-1018, "Readonly key does not match payment address"},
+	ExistedPortfolioErr:      {-1019, "Existed portfolio"},
+	NotFoundPortfolioErr:     {-1020, "Portfolio is not found"},
 }
 
 type WalletError struct {
@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// A portfolio is a named set of wallet accounts which usually live in different shards,
+// its balances are aggregated and payments are routed from the account in receiver's shard
+// to avoid cross shard transfers
+
+// CreatePortfolio creates portfolio with name from accounts which have accountNames,
+// it also creates a new account for each shard in shardIDs which has no account in portfolio
+// It returns accounts of portfolio and errors (if any)
+func (wallet *Wallet) CreatePortfolio(name string, accountNames []string, shardIDs []byte, passPhrase string) ([]AccountWallet, error) {
+	if passPhrase != wallet.PassPhrase {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+	if name == "" {
+		return nil, NewWalletError(UnexpectedErr, fmt.Errorf("portfolio name is empty"))
+	}
+	if _, ok := wallet.Portfolios[name]; ok {
+		return nil, NewWalletError(ExistedPortfolioErr, nil)
+	}
+
+	accountsByShard := make(map[byte]bool)
+	names := make([]string, 0)
+	for _, accountName := range accountNames {
+		account, err := wallet.getAccountByName(accountName)
+		if err != nil {
+			return nil, err
+		}
+		if common.IndexOfStr(accountName, names) >= 0 {
+			continue
+		}
+		names = append(names, accountName)
+		accountsByShard[GetShardIDOfAccount(account)] = true
+	}
+	for _, shardID := range shardIDs {
+		if accountsByShard[shardID] {
+			continue
+		}
+		shardIDTemp := shardID
+		account, err := wallet.CreateNewAccount(fmt.Sprintf("%s shard %d", name, shardID), &shardIDTemp)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, account.Name)
+		accountsByShard[shardID] = true
+	}
+	if len(names) == 0 {
+		return nil, NewWalletError(NotFoundAccountErr, nil)
+	}
+
+	if wallet.Portfolios == nil {
+		wallet.Portfolios = make(map[string][]string)
+	}
+	wallet.Portfolios[name] = names
+	err := wallet.Save(wallet.PassPhrase)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.GetPortfolioAccounts(name)
+}
+
+// RemovePortfolio removes portfolio with name, its accounts are kept in wallet
+func (wallet *Wallet) RemovePortfolio(name string, passPhrase string) error {
+	if passPhrase != wallet.PassPhrase {
+		return NewWalletError(WrongPassphraseErr, nil)
+	}
+	if _, ok := wallet.Portfolios[name]; !ok {
+		return NewWalletError(NotFoundPortfolioErr, nil)
+	}
+	delete(wallet.Portfolios, name)
+	return wallet.Save(passPhrase)
+}
+
+// GetPortfolioAccounts returns accounts of portfolio with name,
+// accounts which were removed from wallet after the portfolio was created are skipped
+func (wallet *Wallet) GetPortfolioAccounts(name string) ([]AccountWallet, error) {
+	accountNames, ok := wallet.Portfolios[name]
+	if !ok {
+		return nil, NewWalletError(NotFoundPortfolioErr, nil)
+	}
+	result := make([]AccountWallet, 0)
+	for _, accountName := range accountNames {
+		account, err := wallet.getAccountByName(accountName)
+		if err != nil {
+			continue
+		}
+		result = append(result, *account)
+	}
+	return result, nil
+}
+
+// ListPortfolios returns a map with key is portfolio name and value is names of its accounts
+func (wallet *Wallet) ListPortfolios() map[string][]string {
+	result := make(map[string][]string)
+	for name, accountNames := range wallet.Portfolios {
+		result[name] = append([]string{}, accountNames...)
+	}
+	return result
+}
+
+func (wallet *Wallet) getAccountByName(accountName string) (*AccountWallet, error) {
+	for _, account := range wallet.MasterAccount.Child {
+		if account.Name == accountName {
+			accountTemp := account
+			return &accountTemp, nil
+		}
+	}
+	return nil, NewWalletError(NotFoundAccountErr, fmt.Errorf("account %s is not found", accountName))
+}
+
+// GetShardIDOfAccount returns shard of account, which is decided by last byte of its public key
+func GetShardIDOfAccount(account *AccountWallet) byte {
+	pk := account.Key.KeySet.PaymentAddress.Pk
+	return common.GetShardIDFromLastByte(pk[len(pk)-1])
+}
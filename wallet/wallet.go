@@ -7,14 +7,16 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"io/ioutil"
 )
 
 type AccountWallet struct {
-	Name       string
-	Key        KeyWallet
-	Child      []AccountWallet
-	IsImported bool
+	Name        string
+	Key         KeyWallet
+	Child       []AccountWallet
+	IsImported  bool
+	IsWatchOnly bool // account only holds payment address and readonly key, it can not spend
 }
 
 type Wallet struct {
@@ -24,6 +26,7 @@ type Wallet struct {
 	Mnemonic      string
 	MasterAccount AccountWallet
 	Name          string
+	Portfolios    map[string][]string // portfolio name -> names of accounts, see portfolio.go
 	config        *WalletConfig
 }
 
@@ -57,6 +60,7 @@ func (wallet *Wallet) Init(passPhrase string, numOfAccount uint32, name string)
 
 	mnemonicGen := MnemonicGenerator{}
 	wallet.Name = name
+	wallet.Portfolios = nil
 	wallet.Entropy, _ = mnemonicGen.newEntropy(128)
 	wallet.Mnemonic, _ = mnemonicGen.newMnemonic(wallet.Entropy)
 	wallet.Seed = mnemonicGen.NewSeed(wallet.Mnemonic, passPhrase)
@@ -172,6 +176,9 @@ func (wallet *Wallet) ExportAccount(childIndex uint32) string {
 	if int(childIndex) >= len(wallet.MasterAccount.Child) {
 		return ""
 	}
+	if wallet.MasterAccount.Child[childIndex].IsWatchOnly {
+		return ""
+	}
 	return wallet.MasterAccount.Child[childIndex].Key.Base58CheckSerialize(PriKeyType)
 }
 
@@ -180,7 +187,7 @@ func (wallet *Wallet) RemoveAccount(privateKeyStr string, passPhrase string) err
 		return NewWalletError(WrongPassphraseErr, nil)
 	}
 	for i, account := range wallet.MasterAccount.Child {
-		if account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
+		if !account.IsWatchOnly && account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
 			wallet.MasterAccount.Child = append(wallet.MasterAccount.Child[:i], wallet.MasterAccount.Child[i+1:]...)
 			err := wallet.Save(passPhrase)
 			if err != nil {
@@ -199,15 +206,6 @@ func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, pa
 		return nil, NewWalletError(WrongPassphraseErr, nil)
 	}
 
-	for _, account := range wallet.MasterAccount.Child {
-		if account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
-			return nil, NewWalletError(ExistedAccountErr, nil)
-		}
-		if account.Name == accountName {
-			return nil, NewWalletError(ExistedAccountNameErr, nil)
-		}
-	}
-
 	keyWallet, err := Base58CheckDeserialize(privateKeyStr)
 	if err != nil {
 		return nil, err
@@ -218,6 +216,16 @@ func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, pa
 		return nil, err
 	}
 
+	for _, account := range wallet.MasterAccount.Child {
+		// watch-only accounts have no private key, they are matched by payment address
+		if bytes.Equal(account.Key.KeySet.PaymentAddress.Pk, keyWallet.KeySet.PaymentAddress.Pk) {
+			return nil, NewWalletError(ExistedAccountErr, nil)
+		}
+		if account.Name == accountName {
+			return nil, NewWalletError(ExistedAccountNameErr, nil)
+		}
+	}
+
 	Logger.log.Debugf("Pub-key : %s", keyWallet.Base58CheckSerialize(PaymentAddressType))
 	Logger.log.Debugf("Readonly-key : %s", keyWallet.Base58CheckSerialize(ReadonlyKeyType))
 
@@ -235,6 +243,93 @@ func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, pa
 	return &account, nil
 }
 
+// ImportWatchOnlyAccount adds a watch-only account into wallet with paymentAddressStr and readonlyKeyStr
+// the account can decrypt and track its output coins but it has no private key, so it can not spend
+// It returns AccountWallet which is imported and errors (if any)
+func (wallet *Wallet) ImportWatchOnlyAccount(paymentAddressStr string, readonlyKeyStr string, accountName string, passPhrase string) (*AccountWallet, error) {
+	if passPhrase != wallet.PassPhrase {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+
+	paymentAddressKey, err := Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(paymentAddressKey.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	readonlyKey, err := Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	if !bytes.Equal(paymentAddressKey.KeySet.PaymentAddress.Pk, readonlyKey.KeySet.ReadonlyKey.Pk) {
+		return nil, NewWalletError(MismatchedReadonlyKeyErr, nil)
+	}
+
+	for _, account := range wallet.MasterAccount.Child {
+		if bytes.Equal(account.Key.KeySet.PaymentAddress.Pk, paymentAddressKey.KeySet.PaymentAddress.Pk) {
+			return nil, NewWalletError(ExistedAccountErr, nil)
+		}
+		if account.Name == accountName {
+			return nil, NewWalletError(ExistedAccountNameErr, nil)
+		}
+	}
+
+	keyWallet := KeyWallet{}
+	keyWallet.KeySet.PaymentAddress = paymentAddressKey.KeySet.PaymentAddress
+	keyWallet.KeySet.ReadonlyKey = readonlyKey.KeySet.ReadonlyKey
+
+	account := AccountWallet{
+		Key:         keyWallet,
+		Child:       make([]AccountWallet, 0),
+		IsImported:  true,
+		IsWatchOnly: true,
+		Name:        accountName,
+	}
+	wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
+	err = wallet.Save(wallet.PassPhrase)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// RemoveWatchOnlyAccount removes watch-only account which has paymentAddressStr out of wallet
+func (wallet *Wallet) RemoveWatchOnlyAccount(paymentAddressStr string, passPhrase string) error {
+	if passPhrase != wallet.PassPhrase {
+		return NewWalletError(WrongPassphraseErr, nil)
+	}
+	for i, account := range wallet.MasterAccount.Child {
+		if account.IsWatchOnly && account.Key.Base58CheckSerialize(PaymentAddressType) == paymentAddressStr {
+			wallet.MasterAccount.Child = append(wallet.MasterAccount.Child[:i], wallet.MasterAccount.Child[i+1:]...)
+			err := wallet.Save(passPhrase)
+			if err != nil {
+				Logger.log.Error(err)
+			}
+			return nil
+		}
+	}
+	return NewWalletError(NotFoundAccountErr, nil)
+}
+
+// GetSpendingKeySet returns full key set of account which has accountName
+// It returns error if account is not found or account is watch-only
+func (wallet *Wallet) GetSpendingKeySet(accountName string) (*incognitokey.KeySet, error) {
+	for _, account := range wallet.MasterAccount.Child {
+		if account.Name == accountName {
+			if account.IsWatchOnly || len(account.Key.KeySet.PrivateKey) == 0 {
+				return nil, NewWalletError(WatchOnlyAccountErr, nil)
+			}
+			keySet := account.Key.KeySet
+			return &keySet, nil
+		}
+	}
+	return nil, NewWalletError(NotFoundAccountErr, nil)
+}
+
 // Save saves encrypted wallet (using AES encryption scheme) in config data file of wallet
 // It returns error if any
 func (wallet *Wallet) Save(password string) error {
@@ -254,7 +349,7 @@ func (wallet *Wallet) Save(password string) error {
 	}
 
 	// encrypt data
-	cipherText, err := encryptByPassPhrase(password, data)
+	cipherText, err := EncryptByPassPhrase(password, data)
 	if err != nil {
 		Logger.log.Error(err)
 		return NewWalletError(UnexpectedErr, err)
@@ -277,7 +372,7 @@ func (wallet *Wallet) LoadWallet(password string) error {
 	if err != nil {
 		return NewWalletError(ReadFileErr, err)
 	}
-	bufBytes, err := decryptByPassPhrase(password, string(bytesData))
+	bufBytes, err := DecryptByPassPhrase(password, string(bytesData))
 	if err != nil {
 		return NewWalletError(AESDecryptErr, err)
 	}
@@ -296,6 +391,9 @@ func (wallet *Wallet) LoadWallet(password string) error {
 // If there is not any wallet account corresponding to paymentAddrSerialized, it returns empty KeySerializedData object
 func (wallet *Wallet) DumpPrivateKey(paymentAddrSerialized string) KeySerializedData {
 	for _, account := range wallet.MasterAccount.Child {
+		if account.IsWatchOnly {
+			continue
+		}
 		address := account.Key.Base58CheckSerialize(PaymentAddressType)
 		if address == paymentAddrSerialized {
 			key := KeySerializedData{
@@ -314,6 +412,13 @@ func (wallet *Wallet) DumpPrivateKey(paymentAddrSerialized string) KeySerialized
 func (wallet *Wallet) GetAddressByAccName(accountName string, shardID *byte) KeySerializedData {
 	for _, account := range wallet.MasterAccount.Child {
 		if account.Name == accountName {
+			if account.IsWatchOnly {
+				return KeySerializedData{
+					PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
+					Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+					ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
+				}
+			}
 			key := KeySerializedData{
 				PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
 				Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
@@ -345,7 +450,9 @@ func (wallet *Wallet) GetAddressesByAccName(accountName string) []KeySerializedD
 				PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
 				Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
 				ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
-				ValidatorKey:   base58.Base58Check{}.Encode(common.HashB(common.HashB(account.Key.KeySet.PrivateKey)), common.ZeroByte),
+			}
+			if !account.IsWatchOnly {
+				item.ValidatorKey = base58.Base58Check{}.Encode(common.HashB(common.HashB(account.Key.KeySet.PrivateKey)), common.ZeroByte)
 			}
 			result = append(result, item)
 		}
@@ -274,6 +274,19 @@ func TestWalletImportAccountWithExistedPrivKeyStr(t *testing.T) {
 	assert.Equal(t, NewWalletError(ExistedAccountErr, nil), err)
 }
 
+func TestWalletImportAccountWithExistedWatchOnlyAccount(t *testing.T) {
+	privateKeyStr := "112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ"
+	paymentAddressStr, readonlyKeyStr := getWatchOnlyKeysFromPrivateKey(privateKeyStr)
+	passPhrase := "123"
+
+	wallet.Init(passPhrase, 0, "Wallet")
+	_, err := wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Cold A", passPhrase)
+	assert.Equal(t, nil, err)
+
+	_, err = wallet.ImportAccount(privateKeyStr, "Acc A", passPhrase)
+	assert.Equal(t, NewWalletError(ExistedAccountErr, nil), err)
+}
+
 func TestWalletImportAccountWithExistedAccountName(t *testing.T) {
 	privateKeyStr := "112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ"
 	accountName := "Acc A"
@@ -299,6 +312,71 @@ func TestWalletImportAccountWithUnmatchedPassPhrase(t *testing.T) {
 	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
 }
 
+/*
+	Unit test for ImportWatchOnlyAccount function
+*/
+
+func getWatchOnlyKeysFromPrivateKey(privateKeyStr string) (string, string) {
+	keyWallet, _ := Base58CheckDeserialize(privateKeyStr)
+	keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	return keyWallet.Base58CheckSerialize(PaymentAddressType), keyWallet.Base58CheckSerialize(ReadonlyKeyType)
+}
+
+func TestWalletImportWatchOnlyAccount(t *testing.T) {
+	privateKeyStr := "112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ"
+	paymentAddressStr, readonlyKeyStr := getWatchOnlyKeysFromPrivateKey(privateKeyStr)
+	passPhrase := "123"
+
+	wallet.Init(passPhrase, 0, "Wallet")
+	numAccount := len(wallet.MasterAccount.Child)
+
+	newAccount, err := wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Cold A", passPhrase)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, numAccount+1, len(wallet.MasterAccount.Child))
+	assert.Equal(t, true, newAccount.IsImported)
+	assert.Equal(t, true, newAccount.IsWatchOnly)
+	assert.Equal(t, 0, len(newAccount.Key.KeySet.PrivateKey))
+	assert.Equal(t, paymentAddressStr, newAccount.Key.Base58CheckSerialize(PaymentAddressType))
+	assert.Equal(t, readonlyKeyStr, newAccount.Key.Base58CheckSerialize(ReadonlyKeyType))
+
+	// watch-only account can not spend
+	assert.Equal(t, "", wallet.ExportAccount(uint32(numAccount)))
+	assert.Equal(t, KeySerializedData{}, wallet.DumpPrivateKey(paymentAddressStr))
+	_, err = wallet.GetSpendingKeySet("Cold A")
+	assert.Equal(t, NewWalletError(WatchOnlyAccountErr, nil), err)
+	key := wallet.GetAddressByAccName("Cold A", nil)
+	assert.Equal(t, "", key.PrivateKey)
+	assert.Equal(t, paymentAddressStr, key.PaymentAddress)
+
+	// import the same payment address again
+	_, err = wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Cold B", passPhrase)
+	assert.Equal(t, NewWalletError(ExistedAccountErr, nil), err)
+
+	err = wallet.RemoveWatchOnlyAccount(paymentAddressStr, passPhrase)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, numAccount, len(wallet.MasterAccount.Child))
+}
+
+func TestWalletImportWatchOnlyAccountWithMismatchedReadonlyKey(t *testing.T) {
+	paymentAddressStr, _ := getWatchOnlyKeysFromPrivateKey("112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ")
+	_, readonlyKeyStr := getWatchOnlyKeysFromPrivateKey("112t8rnYJncU5TRMexdSX2X9a58c9dKPfzWMEaS7AXY3WniXbVUXvDVmZaKms2QEXtviEUKPdrqq3auNqZB8wQPtuXv8JfzprtMtgdGRiFij")
+	passPhrase := "123"
+
+	wallet.Init(passPhrase, 0, "Wallet")
+
+	_, err := wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Cold A", passPhrase)
+	assert.Equal(t, NewWalletError(MismatchedReadonlyKeyErr, nil), err)
+}
+
+func TestWalletImportWatchOnlyAccountWithUnmatchedPassPhrase(t *testing.T) {
+	paymentAddressStr, readonlyKeyStr := getWatchOnlyKeysFromPrivateKey("112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ")
+
+	wallet.Init("123", 0, "Wallet")
+
+	_, err := wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Cold A", "1234")
+	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
+}
+
 /*
 	Unit test for RemoveAccount function
 */
@@ -774,3 +852,37 @@ func TestWalletContainPubKeyWithNotExistedPubKey(t *testing.T) {
 	res := wallet.ContainPublicKey(randPubKey)
 	assert.Equal(t, false, res)
 }
+
+func TestWalletCreatePortfolio(t *testing.T) {
+	passPhrase := "123"
+	wallet.Init(passPhrase, 0, "Wallet")
+	shardID := byte(1)
+	account, err := wallet.CreateNewAccount("Acc A", &shardID)
+	assert.Equal(t, nil, err)
+
+	accounts, err := wallet.CreatePortfolio("Savings", []string{"Acc A"}, []byte{0, 1, 2}, passPhrase)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(accounts))
+	assert.Equal(t, account.Name, accounts[0].Name)
+	// account of shard 1 is reused, accounts of shard 0 and 2 are created
+	shardIDs := make(map[byte]bool)
+	for _, account := range accounts {
+		shardIDs[GetShardIDOfAccount(&account)] = true
+	}
+	assert.Equal(t, map[byte]bool{0: true, 1: true, 2: true}, shardIDs)
+	assert.Equal(t, []string{"Acc A", "Savings shard 0", "Savings shard 2"}, wallet.ListPortfolios()["Savings"])
+
+	_, err = wallet.CreatePortfolio("Savings", nil, []byte{3}, passPhrase)
+	assert.Equal(t, NewWalletError(ExistedPortfolioErr, nil), err)
+	_, err = wallet.CreatePortfolio("Spending", []string{"Acc B"}, nil, passPhrase)
+	assert.Equal(t, ErrCodeMessage[NotFoundAccountErr].code, err.(*WalletError).GetCode())
+	_, err = wallet.CreatePortfolio("Spending", nil, []byte{0}, "456")
+	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
+
+	err = wallet.RemovePortfolio("Savings", passPhrase)
+	assert.Equal(t, nil, err)
+	_, err = wallet.GetPortfolioAccounts("Savings")
+	assert.Equal(t, NewWalletError(NotFoundPortfolioErr, nil), err)
+	err = wallet.RemovePortfolio("Savings", passPhrase)
+	assert.Equal(t, NewWalletError(NotFoundPortfolioErr, nil), err)
+}